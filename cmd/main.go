@@ -2,18 +2,26 @@ package main
 
 import (
 	"context"
+	"io"
 	"log"
+	"os"
+	"os/signal"
 	"sync"
+	"syscall"
 	"time"
 
+	authUC "github.com/moura95/backend-challenge/internal/application/usecases/auth"
 	emailUC "github.com/moura95/backend-challenge/internal/application/usecases/email"
+	userUC "github.com/moura95/backend-challenge/internal/application/usecases/user"
 	"github.com/moura95/backend-challenge/internal/domain/email"
 	"github.com/moura95/backend-challenge/internal/infra/config"
 	"github.com/moura95/backend-challenge/internal/infra/database/postgres"
-	"github.com/moura95/backend-challenge/internal/infra/email/smtp"
+	"github.com/moura95/backend-challenge/internal/infra/email/provider"
 	"github.com/moura95/backend-challenge/internal/infra/http/gin"
 	"github.com/moura95/backend-challenge/internal/infra/messaging/rabbitmq"
 	"github.com/moura95/backend-challenge/internal/infra/repository/adapters"
+	"github.com/moura95/backend-challenge/internal/infra/security/crypto"
+	"github.com/moura95/backend-challenge/internal/infra/tracing"
 	"github.com/moura95/backend-challenge/internal/interfaces/http/handlers"
 	"go.uber.org/zap"
 
@@ -46,6 +54,9 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to load config: %v", err)
 	}
+	if err := loadConfig.Validate(); err != nil {
+		log.Fatalf("Invalid config: %v", err)
+	}
 
 	// Initialize logger
 	logger, err := zap.NewProduction()
@@ -55,6 +66,51 @@ func main() {
 	defer logger.Sync()
 	sugar := logger.Sugar()
 
+	// Initialize tracing (no-op unless OTEL_EXPORTER_OTLP_ENDPOINT is set)
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Config{
+		Endpoint:    loadConfig.OTelExporterEndpoint,
+		ServiceName: loadConfig.OTelServiceName,
+	})
+	if err != nil {
+		log.Fatalf("Failed to initialize tracing: %v", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			sugar.Warnf("Failed to flush traces: %v", err)
+		}
+	}()
+
+	// Configure password hashing cost
+	if err := crypto.SetBcryptCost(loadConfig.BcryptCost); err != nil {
+		sugar.Warnf("Invalid BCRYPT_COST, using default %d: %v", crypto.DefaultBcryptCost, err)
+	}
+
+	// Configure password complexity policy
+	if err := crypto.SetPasswordPolicy(crypto.PasswordPolicy{
+		MinLength:      loadConfig.PasswordMinLength,
+		RequireDigit:   loadConfig.PasswordRequireDigit,
+		RequireUpper:   loadConfig.PasswordRequireUpper,
+		RequireLower:   loadConfig.PasswordRequireLower,
+		RequireSpecial: loadConfig.PasswordRequireSpecial,
+	}); err != nil {
+		sugar.Warnf("Invalid PASSWORD_MIN_LENGTH, using default policy: %v", err)
+	}
+
+	// Configure delivery attempts per email type
+	if err := email.SetMaxAttempts(map[email.EmailType]int{
+		email.EmailTypeWelcome:       loadConfig.EmailMaxAttemptsWelcome,
+		email.EmailTypePasswordReset: loadConfig.EmailMaxAttemptsPasswordReset,
+		email.EmailTypeVerification:  loadConfig.EmailMaxAttemptsVerification,
+		email.EmailTypeNotification:  loadConfig.EmailMaxAttemptsNotification,
+	}); err != nil {
+		sugar.Warnf("Invalid EMAIL_MAX_ATTEMPTS_*, using default %d: %v", email.DefaultMaxAttempts, err)
+	}
+	if err := email.SetMaxBodySize(loadConfig.EmailMaxBodyBytes); err != nil {
+		sugar.Warnf("Invalid EMAIL_MAX_BODY_BYTES, using default %d: %v", email.DefaultMaxBodySize, err)
+	}
+
 	// Initialize database connection
 	conn, err := postgres.ConnectPostgres()
 	if err != nil {
@@ -67,6 +123,22 @@ func main() {
 	// Initialize repositories
 	repositories := adapters.NewRepositories(db)
 
+	// Promote bootstrap admin, if configured
+	if loadConfig.AdminEmail != "" {
+		seedAdmin(loadConfig.AdminEmail, repositories, sugar)
+	}
+
+	// Initialize email delivery (EMAIL_PROVIDER); closing it ends pooled
+	// SMTP connections on shutdown
+	emailService, err := provider.NewEmailService(loadConfig, sugar)
+	if err != nil {
+		log.Fatalf("Failed to create email service: %v", err)
+	}
+	if closer, ok := emailService.(io.Closer); ok {
+		defer closer.Close()
+	}
+	sugar.Infof("Email provider: %s", loadConfig.ResolvedEmailProvider())
+
 	// Initialize RabbitMQ connection
 	rabbitConn := setupRabbitMQ(loadConfig, sugar)
 	if rabbitConn != nil {
@@ -74,8 +146,8 @@ func main() {
 		sugar.Info("RabbitMQ connection established")
 	}
 
-	// Setup context for graceful shutdown
-	ctx, cancel := context.WithCancel(context.Background())
+	// Setup context for graceful shutdown: cancelled on SIGINT/SIGTERM
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer cancel()
 
 	var wg sync.WaitGroup
@@ -85,22 +157,51 @@ func main() {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			startEmailConsumer(ctx, loadConfig, repositories, rabbitConn, sugar)
+			startEmailConsumer(ctx, loadConfig, repositories, emailService, rabbitConn, sugar)
 		}()
 	}
 
+	// Start cleanup of expired revoked tokens and idempotency keys
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		startExpiredRecordsCleanup(ctx, repositories, sugar)
+	}()
+
 	// Log Swagger information
 	sugar.Info("🚀 Starting Backend Challenge API")
 	sugar.Info("📚 Swagger UI: http://localhost:8080/swagger/index.html")
 	sugar.Info("🔐 Use Bearer tokens for authentication")
 
 	// Run HTTP server
-	gin.RunGinServer(loadConfig, db, sugar, rabbitConn)
+	go gin.RunGinServer(loadConfig, db, sugar, rabbitConn)
+
+	// Wait for a shutdown signal, then let the consumer drain in-flight
+	// messages before the RabbitMQ connection is closed
+	<-ctx.Done()
+	sugar.Info("Shutting down, waiting for background workers")
+	wg.Wait()
+	sugar.Info("Shutdown complete")
 }
 
 func setupRabbitMQ(cfg config.Config, logger *zap.SugaredLogger) *rabbitmq.Connection {
 	connectionConfig := rabbitmq.ConnectionConfig{
-		URL: cfg.RabbitMQURL,
+		URL:            cfg.RabbitMQURL,
+		ConfirmTimeout: cfg.RabbitMQConfirmTimeout,
+		MessageTTL:     cfg.RabbitMQMessageTTL,
+		Exchange:       cfg.RabbitMQExchange,
+		DefaultRoute: rabbitmq.Route{
+			Queue:      cfg.EmailQueue,
+			RoutingKey: cfg.EmailRoutingKey,
+		},
+		Routes: map[email.EmailType]rabbitmq.Route{
+			email.EmailTypePasswordReset: {Queue: cfg.PasswordResetQueue},
+		},
+		DeadLetterExchange: cfg.RabbitMQDeadLetterExchange,
+		Prefetch:           cfg.RabbitMQPrefetch,
+		ConsumerWorkers:    cfg.EmailConsumerWorkers,
+		DrainTimeout:       cfg.EmailConsumerDrainTimeout,
+		Logger:             logger,
 	}
 
 	rabbitConn, err := rabbitmq.NewConnection(connectionConfig)
@@ -117,40 +218,28 @@ func startEmailConsumer(
 	ctx context.Context,
 	cfg config.Config,
 	repositories *adapters.Repositories,
+	emailService email.EmailService,
 	rabbit *rabbitmq.Connection,
 	logger *zap.SugaredLogger,
 ) {
-	// Setup SMTP service
-	smtpService := smtp.NewSMTPService(
-		email.SMTPConfig{
-			Host:     cfg.SMTPHost,
-			Port:     cfg.SMTPPort,
-			Username: "",
-			Password: "",
-			From:     cfg.SMTPFrom,
-		})
-
 	// Setup email processing use case
 	processEmailUC := emailUC.NewProcessEmailQueueUseCase(
 		repositories.Email,
-		smtpService,
+		emailService,
 	)
-	go func() {
-		for {
-			time.Sleep(1 * time.Minute)
-			processEmailUC.ProcessPendingEmails(ctx, 50)
-		}
-	}()
+	if cfg.EmailWorkers > 0 {
+		processEmailUC.WithConcurrency(cfg.EmailWorkers)
+	}
+	if cfg.EmailSendingTimeout > 0 {
+		processEmailUC.WithSendingTimeout(cfg.EmailSendingTimeout)
+	}
+	go processPendingEmailsPeriodically(ctx, processEmailUC, logger)
 
 	// Setup email consumer handler
 	emailHandler := handlers.NewEmailConsumerHandler(processEmailUC)
 
-	// Start consuming emails
-	err := rabbit.StartEmailConsumer(
-		ctx,
-		emailHandler.HandleEmailMessage,
-		"email_notifications",
-	)
+	// Start consuming emails from every configured queue
+	err := rabbit.StartEmailConsumers(ctx, emailHandler.HandleEmailMessage)
 
 	if err != nil {
 		logger.Errorf("Email consumer stopped with error: %v", err)
@@ -158,3 +247,70 @@ func startEmailConsumer(
 		logger.Info("Email consumer stopped gracefully")
 	}
 }
+
+// processPendingEmailsPeriodically reenvia os pendentes a cada minuto até o
+// contexto ser cancelado; um lote em andamento é interrompido no shutdown.
+func processPendingEmailsPeriodically(ctx context.Context, processEmailUC *emailUC.ProcessEmailQueueUseCase, logger *zap.SugaredLogger) {
+	ticker := time.NewTicker(1 * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("Pending emails processing stopped")
+			return
+		case <-ticker.C:
+			if _, err := processEmailUC.ProcessPendingEmails(ctx, emailUC.DefaultProcessBatchSize); err != nil {
+				logger.Errorf("Failed to process pending emails: %v", err)
+			}
+		}
+	}
+}
+
+func seedAdmin(adminEmail string, repositories *adapters.Repositories, logger *zap.SugaredLogger) {
+	promoteUC := userUC.NewPromoteUserUseCase(repositories.User)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := promoteUC.Execute(ctx, adminEmail); err != nil {
+		logger.Warnf("Failed to promote ADMIN_EMAIL %s: %v", adminEmail, err)
+		return
+	}
+
+	logger.Infof("User %s has admin role", adminEmail)
+}
+
+func startExpiredRecordsCleanup(
+	ctx context.Context,
+	repositories *adapters.Repositories,
+	logger *zap.SugaredLogger,
+) {
+	cleanupUC := authUC.NewCleanupRevokedTokensUseCase(repositories.Token)
+	cleanupKeysUC := authUC.NewCleanupIdempotencyKeysUseCase(repositories.Idempotency)
+
+	ticker := time.NewTicker(1 * time.Hour)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("Expired records cleanup stopped")
+			return
+		case <-ticker.C:
+			deleted, err := cleanupUC.Execute(ctx)
+			if err != nil {
+				logger.Errorf("Failed to cleanup revoked tokens: %v", err)
+			} else if deleted > 0 {
+				logger.Infof("Removed %d expired revoked tokens", deleted)
+			}
+
+			deletedKeys, err := cleanupKeysUC.Execute(ctx)
+			if err != nil {
+				logger.Errorf("Failed to cleanup idempotency keys: %v", err)
+			} else if deletedKeys > 0 {
+				logger.Infof("Removed %d expired idempotency keys", deletedKeys)
+			}
+		}
+	}
+}
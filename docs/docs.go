@@ -366,6 +366,9 @@ const docTemplate = `{
                 },
                 "password": {
                     "type": "string"
+                },
+                "remember_me": {
+                    "type": "boolean"
                 }
             }
         },
@@ -0,0 +1,97 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/moura95/backend-challenge/internal/domain/user"
+)
+
+// MaxBulkDeleteBatchSize caps how many users one bulk delete request can remove.
+const MaxBulkDeleteBatchSize = 100
+
+// ErrBulkDeleteBatchTooLarge is returned when the request exceeds MaxBulkDeleteBatchSize IDs.
+var ErrBulkDeleteBatchTooLarge = fmt.Errorf("bulk delete must have at most %d users", MaxBulkDeleteBatchSize)
+
+// Per-ID outcomes reported by BulkDeleteUsersUseCase.
+const (
+	BulkDeleteStatusDeleted  = "deleted"
+	BulkDeleteStatusNotFound = "not_found"
+)
+
+type BulkDeleteUsersRequest struct {
+	UserIDs []string `json:"user_ids"`
+}
+
+// BulkDeleteUserResult is the outcome of one requested ID, in the same order as the request.
+type BulkDeleteUserResult struct {
+	UserID string `json:"user_id"`
+	Status string `json:"status"`
+}
+
+type BulkDeleteUsersResponse struct {
+	Deleted  int                    `json:"deleted"`
+	NotFound int                    `json:"not_found"`
+	Results  []BulkDeleteUserResult `json:"results"`
+}
+
+// BulkDeleteUsersUseCase remove (soft delete) várias contas de uma vez, por
+// exemplo numa limpeza de spam.
+type BulkDeleteUsersUseCase struct {
+	userRepo user.Repository
+}
+
+func NewBulkDeleteUsersUseCase(userRepo user.Repository) *BulkDeleteUsersUseCase {
+	return &BulkDeleteUsersUseCase{
+		userRepo: userRepo,
+	}
+}
+
+// Execute valida todos os IDs antes de apagar qualquer conta; a remoção é
+// atômica e IDs inexistentes ou já removidos são reportados como not_found.
+func (uc *BulkDeleteUsersUseCase) Execute(ctx context.Context, req BulkDeleteUsersRequest) (*BulkDeleteUsersResponse, error) {
+	// 1. Validar tamanho do lote
+	if len(req.UserIDs) == 0 {
+		return nil, fmt.Errorf("usecase: bulk delete users failed: at least one user ID is required")
+	}
+	if len(req.UserIDs) > MaxBulkDeleteBatchSize {
+		return nil, fmt.Errorf("usecase: bulk delete users failed: %w", ErrBulkDeleteBatchTooLarge)
+	}
+
+	// 2. Validar todos os IDs antes de começar
+	ids := make([]uuid.UUID, len(req.UserIDs))
+	for i, rawID := range req.UserIDs {
+		parsedID, err := uuid.Parse(rawID)
+		if err != nil {
+			return nil, fmt.Errorf("usecase: bulk delete users failed: invalid user ID format: %q", rawID)
+		}
+		ids[i] = parsedID
+	}
+
+	// 3. Remover todos numa única operação
+	deletedIDs, err := uc.userRepo.DeleteMany(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("usecase: bulk delete users failed: %w", err)
+	}
+
+	deleted := make(map[uuid.UUID]bool, len(deletedIDs))
+	for _, id := range deletedIDs {
+		deleted[id] = true
+	}
+
+	// 4. Resultado por ID, na ordem do pedido
+	response := &BulkDeleteUsersResponse{Results: make([]BulkDeleteUserResult, 0, len(ids))}
+	for _, id := range ids {
+		result := BulkDeleteUserResult{UserID: id.String(), Status: BulkDeleteStatusDeleted}
+		if deleted[id] {
+			response.Deleted++
+		} else {
+			result.Status = BulkDeleteStatusNotFound
+			response.NotFound++
+		}
+		response.Results = append(response.Results, result)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,105 @@
+package admin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/moura95/backend-challenge/internal/domain/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// bulkDeleteUserRepository keeps users in memory, keyed by ID.
+type bulkDeleteUserRepository struct {
+	user.Repository
+	users map[uuid.UUID]*user.User
+	calls int
+}
+
+func (r *bulkDeleteUserRepository) DeleteMany(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	r.calls++
+	deleted := []uuid.UUID{}
+	for _, id := range ids {
+		if u, ok := r.users[id]; ok && u.DeletedAt == nil {
+			now := u.CreatedAt
+			u.DeletedAt = &now
+			deleted = append(deleted, id)
+		}
+	}
+	return deleted, nil
+}
+
+func TestBulkDeleteUsersUseCase_Execute(t *testing.T) {
+	setup := func(count int) (*BulkDeleteUsersUseCase, *bulkDeleteUserRepository, []uuid.UUID) {
+		repo := &bulkDeleteUserRepository{users: map[uuid.UUID]*user.User{}}
+		ids := make([]uuid.UUID, count)
+		for i := range ids {
+			ids[i] = uuid.New()
+			repo.users[ids[i]] = &user.User{ID: ids[i]}
+		}
+		return NewBulkDeleteUsersUseCase(repo), repo, ids
+	}
+
+	t.Run("deletes a subset and reports a result per ID", func(t *testing.T) {
+		uc, repo, ids := setup(4)
+		unknown := uuid.New()
+
+		response, err := uc.Execute(context.Background(), BulkDeleteUsersRequest{
+			UserIDs: []string{ids[0].String(), unknown.String(), ids[2].String()},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, response.Deleted)
+		assert.Equal(t, 1, response.NotFound)
+		assert.Equal(t, []BulkDeleteUserResult{
+			{UserID: ids[0].String(), Status: BulkDeleteStatusDeleted},
+			{UserID: unknown.String(), Status: BulkDeleteStatusNotFound},
+			{UserID: ids[2].String(), Status: BulkDeleteStatusDeleted},
+		}, response.Results)
+
+		assert.NotNil(t, repo.users[ids[0]].DeletedAt)
+		assert.NotNil(t, repo.users[ids[2]].DeletedAt)
+		// Os demais continuam intactos
+		assert.Nil(t, repo.users[ids[1]].DeletedAt)
+		assert.Nil(t, repo.users[ids[3]].DeletedAt)
+
+		// Já removidos aparecem como not_found numa segunda chamada
+		response, err = uc.Execute(context.Background(), BulkDeleteUsersRequest{UserIDs: []string{ids[0].String()}})
+		require.NoError(t, err)
+		assert.Equal(t, BulkDeleteStatusNotFound, response.Results[0].Status)
+	})
+
+	t.Run("rejects the whole batch when an ID is malformed", func(t *testing.T) {
+		uc, repo, ids := setup(2)
+
+		_, err := uc.Execute(context.Background(), BulkDeleteUsersRequest{
+			UserIDs: []string{ids[0].String(), "not-a-uuid", ids[1].String()},
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid user ID format")
+
+		assert.Equal(t, 0, repo.calls)
+		assert.Nil(t, repo.users[ids[0]].DeletedAt)
+	})
+
+	t.Run("rejects batches over the limit", func(t *testing.T) {
+		uc, repo, _ := setup(0)
+
+		userIDs := make([]string, MaxBulkDeleteBatchSize+1)
+		for i := range userIDs {
+			userIDs[i] = uuid.NewString()
+		}
+
+		_, err := uc.Execute(context.Background(), BulkDeleteUsersRequest{UserIDs: userIDs})
+		assert.ErrorIs(t, err, ErrBulkDeleteBatchTooLarge)
+		assert.Equal(t, 0, repo.calls)
+	})
+
+	t.Run("rejects an empty batch", func(t *testing.T) {
+		uc, _, _ := setup(0)
+
+		_, err := uc.Execute(context.Background(), BulkDeleteUsersRequest{})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,46 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/moura95/backend-challenge/internal/domain/email"
+	"github.com/moura95/backend-challenge/internal/domain/user"
+)
+
+type StatsResponse struct {
+	Users  user.SignupStats    `json:"users"`
+	Emails email.DeliveryStats `json:"emails"`
+}
+
+type GetStatsUseCase struct {
+	userRepo  user.Repository
+	emailRepo email.Repository
+}
+
+func NewGetStatsUseCase(userRepo user.Repository, emailRepo email.Repository) *GetStatsUseCase {
+	return &GetStatsUseCase{
+		userRepo:  userRepo,
+		emailRepo: emailRepo,
+	}
+}
+
+func (uc *GetStatsUseCase) Execute(ctx context.Context) (*StatsResponse, error) {
+	// 1. Contar usuários (janelas calculadas com o relógio do banco)
+	userStats, err := uc.userRepo.Stats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("usecase: get stats failed: %w", err)
+	}
+
+	// 2. Contar emails por status
+	emailStats, err := uc.emailRepo.Stats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("usecase: get stats failed: %w", err)
+	}
+
+	// 3. Montar resposta
+	return &StatsResponse{
+		Users:  *userStats,
+		Emails: *emailStats,
+	}, nil
+}
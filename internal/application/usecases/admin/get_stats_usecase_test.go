@@ -0,0 +1,203 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+
+	"github.com/moura95/backend-challenge/internal/domain/email"
+	"github.com/moura95/backend-challenge/internal/domain/user"
+	"github.com/moura95/backend-challenge/internal/infra/repository/adapters"
+)
+
+type statsTestServer struct {
+	container *postgres.PostgresContainer
+	db        *sqlx.DB
+	repos     *adapters.Repositories
+	cleanup   func()
+}
+
+func setupStatsTest(t *testing.T) *statsTestServer {
+	ctx := context.Background()
+
+	// Start PostgreSQL container
+	postgresContainer, err := postgres.RunContainer(ctx,
+		testcontainers.WithImage("postgres:15-alpine"),
+		postgres.WithDatabase("testdb"),
+		postgres.WithUsername("test"),
+		postgres.WithPassword("test"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(30*time.Second)),
+	)
+	require.NoError(t, err)
+
+	// Get connection string
+	connStr, err := postgresContainer.ConnectionString(ctx, "sslmode=disable")
+	require.NoError(t, err)
+
+	// Connect to database
+	db, err := sqlx.Connect("postgres", connStr)
+	require.NoError(t, err)
+
+	// Run migrations
+	err = runStatsMigrations(db)
+	require.NoError(t, err)
+
+	// Setup repositories
+	repos := adapters.NewRepositories(db)
+
+	cleanup := func() {
+		db.Close()
+		postgresContainer.Terminate(ctx)
+	}
+
+	return &statsTestServer{
+		container: postgresContainer,
+		db:        db,
+		repos:     repos,
+		cleanup:   cleanup,
+	}
+}
+
+func runStatsMigrations(db *sqlx.DB) error {
+	migrationSQL := `
+	CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+	
+	-- Users table
+	CREATE TABLE IF NOT EXISTS users (
+		uuid         UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+		name         VARCHAR(255) NOT NULL,
+		email        VARCHAR(100) NOT NULL UNIQUE,
+		password     TEXT NOT NULL,
+		verified_at  TIMESTAMPTZ,
+		role         VARCHAR(20) NOT NULL DEFAULT 'user',
+		deleted_at   TIMESTAMPTZ,
+		version      INTEGER NOT NULL DEFAULT 1,
+		status       VARCHAR(20) NOT NULL DEFAULT 'active',
+		token_version INTEGER NOT NULL DEFAULT 0,
+		last_login_at TIMESTAMPTZ,
+		created_at   TIMESTAMP NOT NULL DEFAULT NOW(),
+		updated_at   TIMESTAMP NOT NULL DEFAULT NOW()
+	);
+	
+	-- Emails table
+	CREATE TABLE IF NOT EXISTS emails (
+		uuid         UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+		to_email     VARCHAR(255) NOT NULL,
+		subject      VARCHAR(255) NOT NULL,
+		body         TEXT NOT NULL,
+		type         VARCHAR(50) NOT NULL,
+		status       VARCHAR(50) NOT NULL DEFAULT 'pending',
+		attempts     INTEGER NOT NULL DEFAULT 0,
+		max_attempts INTEGER NOT NULL DEFAULT 3,
+		error_msg    TEXT,
+		sent_at      TIMESTAMPTZ,
+		created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		plain_body   TEXT NOT NULL DEFAULT '',
+		next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		cc_emails    TEXT[] NOT NULL DEFAULT '{}',
+		bcc_emails   TEXT[] NOT NULL DEFAULT '{}',
+		dedup_key    VARCHAR(255),
+		scheduled_at TIMESTAMPTZ
+	);
+	
+	-- Email attachments table
+	CREATE TABLE IF NOT EXISTS email_attachments (
+		id           BIGSERIAL PRIMARY KEY,
+		email_uuid   UUID NOT NULL,
+		filename     VARCHAR(255) NOT NULL,
+		content_type VARCHAR(255) NOT NULL,
+		content      BYTEA NOT NULL,
+		created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		FOREIGN KEY (email_uuid) REFERENCES emails(uuid) ON DELETE CASCADE
+	);
+	`
+
+	_, err := db.Exec(migrationSQL)
+	return err
+}
+
+func seedUsers(t *testing.T, server *statsTestServer, prefix string, count int, age string) {
+	ctx := context.Background()
+
+	for i := 0; i < count; i++ {
+		address := fmt.Sprintf("%s%d@example.com", prefix, i)
+		testUser, err := user.NewUser("Stats User", address, "password123")
+		require.NoError(t, err)
+		require.NoError(t, server.repos.User.Create(ctx, testUser))
+
+		// Backdate using the DB clock, same one used by the stats query
+		_, err = server.db.Exec("UPDATE users SET created_at = NOW() - $2::interval WHERE email = $1", address, age)
+		require.NoError(t, err)
+	}
+}
+
+func seedEmails(t *testing.T, server *statsTestServer, status email.Status, count int) {
+	ctx := context.Background()
+
+	for i := 0; i < count; i++ {
+		emailEntity, err := email.NewNotificationEmail(fmt.Sprintf("%s%d@example.com", status, i), "Stats", "Body")
+		require.NoError(t, err)
+		require.NoError(t, server.repos.Email.Create(ctx, emailEntity))
+
+		_, err = server.db.Exec("UPDATE emails SET status = $2 WHERE uuid = $1", emailEntity.ID, string(status))
+		require.NoError(t, err)
+	}
+}
+
+func TestGetStatsUseCase_Execute(t *testing.T) {
+	server := setupStatsTest(t)
+	defer server.cleanup()
+
+	ctx := context.Background()
+	useCase := NewGetStatsUseCase(server.repos.User, server.repos.Email)
+
+	t.Run("should return zeros on empty database", func(t *testing.T) {
+		result, err := useCase.Execute(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, user.SignupStats{}, result.Users)
+		assert.Equal(t, email.DeliveryStats{}, result.Emails)
+	})
+
+	t.Run("should aggregate users by signup window and emails by status", func(t *testing.T) {
+		seedUsers(t, server, "recent", 2, "1 hour")
+		seedUsers(t, server, "week", 3, "3 days")
+		seedUsers(t, server, "month", 4, "20 days")
+		seedUsers(t, server, "old", 1, "90 days")
+		seedUsers(t, server, "deleted", 1, "1 hour")
+		_, err := server.db.Exec("UPDATE users SET deleted_at = NOW() WHERE email LIKE 'deleted%'")
+		require.NoError(t, err)
+
+		seedEmails(t, server, email.StatusPending, 2)
+		seedEmails(t, server, email.StatusSent, 5)
+		seedEmails(t, server, email.StatusFailed, 1)
+
+		result, err := useCase.Execute(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, user.SignupStats{
+			Total:          10,
+			CreatedLast24h: 2,
+			CreatedLast7d:  5,
+			CreatedLast30d: 9,
+		}, result.Users)
+		assert.Equal(t, email.DeliveryStats{
+			Pending: 2,
+			Sent:    5,
+			Failed:  1,
+		}, result.Emails)
+	})
+}
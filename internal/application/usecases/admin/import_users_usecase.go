@@ -0,0 +1,230 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/moura95/backend-challenge/internal/domain/email"
+	"github.com/moura95/backend-challenge/internal/domain/token"
+	"github.com/moura95/backend-challenge/internal/domain/user"
+	"github.com/moura95/backend-challenge/internal/infra/logging"
+	"github.com/moura95/backend-challenge/internal/infra/security/crypto"
+	"github.com/moura95/backend-challenge/internal/infra/tracing"
+)
+
+const (
+	// MaxImportBatchSize caps how many users one import request can create.
+	MaxImportBatchSize = 100
+
+	defaultPasswordSetupURL = "http://localhost:8080/reset-password"
+
+	// temporaryPasswordLength is the size of the random password given to
+	// imported users; they never see it and choose their own via the setup link.
+	temporaryPasswordLength = 32
+)
+
+// ErrImportBatchTooLarge is returned when the import exceeds MaxImportBatchSize rows.
+var ErrImportBatchTooLarge = fmt.Errorf("import batch must have at most %d users", MaxImportBatchSize)
+
+// Row outcomes reported by ImportUsersUseCase.
+const (
+	ImportStatusCreated = "created"
+	ImportStatusFailed  = "failed"
+)
+
+type ImportUserRecord struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// ImportUserResult is the outcome of one input row, in the same order as the request.
+type ImportUserResult struct {
+	Row    int    `json:"row"`
+	Email  string `json:"email"`
+	Status string `json:"status"`
+	UserID string `json:"user_id,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+type ImportUsersResponse struct {
+	Created int                `json:"created"`
+	Failed  int                `json:"failed"`
+	Results []ImportUserResult `json:"results"`
+}
+
+// ImportTxRunner executa fn numa única transação com repositórios ligados a
+// ela; a transação é confirmada somente se fn retornar nil.
+type ImportTxRunner func(ctx context.Context, fn func(userRepo user.Repository, emailRepo email.Repository, resetRepo token.PasswordResetRepository) error) error
+
+type ImportUsersUseCase struct {
+	userRepo      user.Repository
+	emailRepo     email.Repository
+	resetRepo     token.PasswordResetRepository
+	publisher     email.QueuePublisher
+	setupURL      string
+	tokenDuration time.Duration
+
+	// Quando configurado, cada linha (usuário, token e email) é gravada numa transação
+	runInTx ImportTxRunner
+}
+
+func NewImportUsersUseCase(
+	userRepo user.Repository,
+	emailRepo email.Repository,
+	resetRepo token.PasswordResetRepository,
+	publisher email.QueuePublisher,
+	setupURL string,
+) *ImportUsersUseCase {
+	if setupURL == "" {
+		setupURL = defaultPasswordSetupURL
+	}
+
+	return &ImportUsersUseCase{
+		userRepo:      userRepo,
+		emailRepo:     emailRepo,
+		resetRepo:     resetRepo,
+		publisher:     publisher,
+		setupURL:      setupURL,
+		tokenDuration: 72 * time.Hour,
+	}
+}
+
+// WithTransaction grava cada usuário importado com seu token e email atomicamente.
+func (uc *ImportUsersUseCase) WithTransaction(runner ImportTxRunner) *ImportUsersUseCase {
+	uc.runInTx = runner
+	return uc
+}
+
+// Execute cria cada linha de forma independente: uma linha inválida ou com
+// email repetido é reportada como falha sem desfazer as demais.
+func (uc *ImportUsersUseCase) Execute(ctx context.Context, records []ImportUserRecord) (*ImportUsersResponse, error) {
+	if len(records) == 0 {
+		return nil, fmt.Errorf("usecase: import users failed: at least one user is required")
+	}
+	if len(records) > MaxImportBatchSize {
+		return nil, fmt.Errorf("usecase: import users failed: %w", ErrImportBatchTooLarge)
+	}
+
+	response := &ImportUsersResponse{Results: make([]ImportUserResult, 0, len(records))}
+	for i, record := range records {
+		result := ImportUserResult{Row: i + 1, Email: user.NormalizeEmail(record.Email)}
+
+		createdUser, err := uc.importUser(ctx, record)
+		if err != nil {
+			result.Status = ImportStatusFailed
+			result.Error = err.Error()
+			response.Failed++
+		} else {
+			result.Status = ImportStatusCreated
+			result.UserID = createdUser.ID.String()
+			response.Created++
+		}
+
+		response.Results = append(response.Results, result)
+	}
+
+	return response, nil
+}
+
+func (uc *ImportUsersUseCase) importUser(ctx context.Context, record ImportUserRecord) (*user.User, error) {
+	// 1. Senha temporária aleatória: o usuário define a sua pelo link
+	temporaryPassword, err := crypto.GenerateTemporaryPassword(temporaryPasswordLength)
+	if err != nil {
+		return nil, err
+	}
+
+	newUser, err := user.NewUser(record.Name, record.Email, temporaryPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Persistir usuário, token de definição de senha e email juntos
+	var setupEmail *email.Email
+	err = uc.inTx(ctx, func(userRepo user.Repository, emailRepo email.Repository, resetRepo token.PasswordResetRepository) error {
+		exists, err := userRepo.EmailExists(ctx, newUser.Email)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return user.ErrEmailExists
+		}
+
+		if err := userRepo.Create(ctx, newUser); err != nil {
+			return err
+		}
+
+		setupToken, err := crypto.GenerateRandomString(64)
+		if err != nil {
+			return err
+		}
+		if err := resetRepo.Create(ctx, crypto.HashSHA256(setupToken), newUser.ID, time.Now().Add(uc.tokenDuration)); err != nil {
+			return err
+		}
+
+		created, err := email.NewPasswordSetupEmail(email.PasswordResetEmailData{
+			UserID:    newUser.ID.String(),
+			UserName:  newUser.Name,
+			UserEmail: newUser.Email,
+			ResetLink: fmt.Sprintf("%s?token=%s", uc.setupURL, setupToken),
+		})
+		if err != nil {
+			return err
+		}
+		if err := emailRepo.Create(ctx, created); err != nil {
+			return err
+		}
+
+		setupEmail = created
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Publicar após o commit (se falhar, o processamento de pendentes envia depois)
+	uc.publishSetupEmail(ctx, newUser, setupEmail)
+
+	return newUser, nil
+}
+
+// inTx usa o ImportTxRunner configurado ou, sem ele, os repositórios diretos.
+func (uc *ImportUsersUseCase) inTx(ctx context.Context, fn func(user.Repository, email.Repository, token.PasswordResetRepository) error) error {
+	if uc.runInTx == nil {
+		return fn(uc.userRepo, uc.emailRepo, uc.resetRepo)
+	}
+	return uc.runInTx(ctx, fn)
+}
+
+func (uc *ImportUsersUseCase) publishSetupEmail(ctx context.Context, newUser *user.User, setupEmail *email.Email) {
+	if uc.publisher == nil {
+		return
+	}
+
+	message := email.QueueMessage{
+		EmailID: setupEmail.ID,
+		Type:    setupEmail.Type,
+		Data: email.WelcomeEmailData{
+			UserID:    newUser.ID.String(),
+			UserName:  newUser.Name,
+			UserEmail: newUser.Email,
+		},
+		RequestID:    logging.RequestIDFromContext(ctx),
+		TraceContext: tracing.Inject(ctx),
+	}
+
+	err := uc.publisher.PublishEmailMessage(message)
+	if err == nil {
+		return
+	}
+	fmt.Printf("Warning: failed to publish password setup email: %v\n", err)
+
+	// Sem fila para a mensagem: registrar a falha no próprio email
+	if errors.Is(err, email.ErrMessageUnroutable) {
+		setupEmail.MarkAsFailed(err.Error())
+		if err := uc.emailRepo.Update(ctx, setupEmail); err != nil {
+			fmt.Printf("Warning: failed to mark unroutable email %s as failed: %v\n", setupEmail.ID, err)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moura95/backend-challenge/internal/domain/email"
+	"github.com/moura95/backend-challenge/internal/domain/token"
+	"github.com/moura95/backend-challenge/internal/domain/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// importUserRepository keeps users in memory, keyed by email.
+type importUserRepository struct {
+	user.Repository
+	users map[string]*user.User
+}
+
+func (r *importUserRepository) EmailExists(ctx context.Context, address string) (bool, error) {
+	_, ok := r.users[address]
+	return ok, nil
+}
+
+func (r *importUserRepository) Create(ctx context.Context, u *user.User) error {
+	if _, ok := r.users[u.Email]; ok {
+		return user.ErrEmailExists
+	}
+	r.users[u.Email] = u
+	return nil
+}
+
+type importEmailRepository struct {
+	email.Repository
+	emails  []*email.Email
+	updated []*email.Email
+}
+
+func (r *importEmailRepository) Create(ctx context.Context, e *email.Email) error {
+	r.emails = append(r.emails, e)
+	return nil
+}
+
+func (r *importEmailRepository) Update(ctx context.Context, e *email.Email) error {
+	r.updated = append(r.updated, e)
+	return nil
+}
+
+type importResetRepository struct {
+	token.PasswordResetRepository
+	tokens map[string]uuid.UUID
+}
+
+func (r *importResetRepository) Create(ctx context.Context, tokenHash string, userID uuid.UUID, expiresAt time.Time) error {
+	r.tokens[tokenHash] = userID
+	return nil
+}
+
+type importPublisher struct {
+	messages []email.QueueMessage
+	err      error
+}
+
+func (p *importPublisher) PublishEmailMessage(message email.QueueMessage) error {
+	p.messages = append(p.messages, message)
+	return p.err
+}
+
+func TestImportUsersUseCase_Execute(t *testing.T) {
+	setup := func(existing ...string) (*ImportUsersUseCase, *importUserRepository, *importEmailRepository, *importPublisher) {
+		userRepo := &importUserRepository{users: map[string]*user.User{}}
+		for _, address := range existing {
+			userRepo.users[address] = &user.User{ID: uuid.New(), Email: address}
+		}
+		emailRepo := &importEmailRepository{}
+		publisher := &importPublisher{}
+		uc := NewImportUsersUseCase(userRepo, emailRepo, &importResetRepository{tokens: map[string]uuid.UUID{}}, publisher, "https://app.example.com/setup")
+		return uc, userRepo, emailRepo, publisher
+	}
+
+	t.Run("reports a result per row for valid and duplicate emails", func(t *testing.T) {
+		uc, userRepo, emailRepo, publisher := setup("taken@example.com")
+
+		response, err := uc.Execute(context.Background(), []ImportUserRecord{
+			{Name: "Alice Smith", Email: "alice@example.com"},
+			{Name: "Taken User", Email: "taken@example.com"},
+			{Name: "Bob Jones", Email: "Bob@Example.com"},
+			{Name: "Alice Again", Email: "ALICE@example.com"},
+			{Name: "No Email", Email: "not-an-email"},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, response.Created)
+		assert.Equal(t, 3, response.Failed)
+		require.Len(t, response.Results, 5)
+
+		expected := []struct {
+			email  string
+			status string
+		}{
+			{"alice@example.com", ImportStatusCreated},
+			{"taken@example.com", ImportStatusFailed},
+			{"bob@example.com", ImportStatusCreated},
+			{"alice@example.com", ImportStatusFailed},
+			{"not-an-email", ImportStatusFailed},
+		}
+		for i, want := range expected {
+			result := response.Results[i]
+			assert.Equal(t, i+1, result.Row)
+			assert.Equal(t, want.email, result.Email)
+			assert.Equal(t, want.status, result.Status, want.email)
+			if want.status == ImportStatusCreated {
+				assert.NotEmpty(t, result.UserID)
+				assert.Empty(t, result.Error)
+			} else {
+				assert.Empty(t, result.UserID)
+				assert.NotEmpty(t, result.Error)
+			}
+		}
+		assert.Contains(t, response.Results[1].Error, user.ErrEmailExists.Error())
+		assert.Contains(t, response.Results[3].Error, user.ErrEmailExists.Error())
+
+		// Cada usuário criado recebe senha aleatória e um email de definição de senha
+		require.Len(t, emailRepo.emails, 2)
+		require.Len(t, publisher.messages, 2)
+		for _, setupEmail := range emailRepo.emails {
+			assert.Equal(t, email.EmailTypePasswordReset, setupEmail.Type)
+			assert.True(t, strings.Contains(setupEmail.Body, "https://app.example.com/setup?token="))
+		}
+		assert.NotEqual(t, userRepo.users["alice@example.com"].Password, userRepo.users["bob@example.com"].Password)
+	})
+
+	t.Run("marks the setup email failed when the message is unroutable", func(t *testing.T) {
+		uc, _, emailRepo, publisher := setup()
+		publisher.err = fmt.Errorf("rabbitmq: failed to publish to email queue: %w: no queue bound to exchange %q with routing key %q (312 NO_ROUTE)",
+			email.ErrMessageUnroutable, "emails", "missing.key")
+
+		response, err := uc.Execute(context.Background(), []ImportUserRecord{{Name: "Alice Smith", Email: "alice@example.com"}})
+		require.NoError(t, err)
+		assert.Equal(t, 1, response.Created)
+
+		require.Len(t, emailRepo.updated, 1)
+		failed := emailRepo.updated[0]
+		assert.Equal(t, emailRepo.emails[0].ID, failed.ID)
+		assert.Equal(t, 1, failed.Attempts)
+		assert.Contains(t, failed.ErrorMsg, "queue message is unroutable")
+		assert.Contains(t, failed.ErrorMsg, `routing key "missing.key"`)
+	})
+
+	t.Run("leaves other publish errors to the pending processor", func(t *testing.T) {
+		uc, _, emailRepo, publisher := setup()
+		publisher.err = assert.AnError
+
+		_, err := uc.Execute(context.Background(), []ImportUserRecord{{Name: "Alice Smith", Email: "alice@example.com"}})
+		require.NoError(t, err)
+
+		assert.Empty(t, emailRepo.updated)
+		assert.Equal(t, email.StatusPending, emailRepo.emails[0].Status)
+		assert.Zero(t, emailRepo.emails[0].Attempts)
+	})
+
+	t.Run("rejects batches over the limit", func(t *testing.T) {
+		uc, _, emailRepo, _ := setup()
+
+		records := make([]ImportUserRecord, MaxImportBatchSize+1)
+		for i := range records {
+			records[i] = ImportUserRecord{Name: "User", Email: uuid.NewString() + "@example.com"}
+		}
+
+		_, err := uc.Execute(context.Background(), records)
+		assert.ErrorIs(t, err, ErrImportBatchTooLarge)
+		assert.Empty(t, emailRepo.emails)
+	})
+
+	t.Run("rejects an empty batch", func(t *testing.T) {
+		uc, _, _, _ := setup()
+
+		_, err := uc.Execute(context.Background(), nil)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,50 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/moura95/backend-challenge/internal/domain/user"
+)
+
+const (
+	// DefaultLoginEventsLimit is used when the caller does not pass a limit.
+	DefaultLoginEventsLimit = 50
+	// MaxLoginEventsLimit caps how many events one request can return.
+	MaxLoginEventsLimit = 500
+)
+
+type ListLoginEventsUseCase struct {
+	loginEventRepo user.LoginEventRepository
+}
+
+func NewListLoginEventsUseCase(loginEventRepo user.LoginEventRepository) *ListLoginEventsUseCase {
+	return &ListLoginEventsUseCase{
+		loginEventRepo: loginEventRepo,
+	}
+}
+
+func (uc *ListLoginEventsUseCase) Execute(ctx context.Context, userID string, limit int) ([]*user.LoginEvent, error) {
+	// 1. Validar ID
+	parsedID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("usecase: list login events failed: invalid user ID format")
+	}
+
+	// 2. Aplicar limites
+	if limit <= 0 {
+		limit = DefaultLoginEventsLimit
+	}
+	if limit > MaxLoginEventsLimit {
+		limit = MaxLoginEventsLimit
+	}
+
+	// 3. Buscar eventos, mais recentes primeiro
+	events, err := uc.loginEventRepo.ListByUser(ctx, parsedID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("usecase: list login events failed: %w", err)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,67 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/moura95/backend-challenge/internal/domain/user"
+)
+
+// SuspendUserUseCase bloqueia o acesso da conta sem apagá-la: signin e
+// tokens já emitidos passam a ser recusados até a reativação.
+type SuspendUserUseCase struct {
+	userRepo user.Repository
+}
+
+func NewSuspendUserUseCase(userRepo user.Repository) *SuspendUserUseCase {
+	return &SuspendUserUseCase{
+		userRepo: userRepo,
+	}
+}
+
+func (uc *SuspendUserUseCase) Execute(ctx context.Context, userID string) (*user.User, error) {
+	return setUserStatus(ctx, uc.userRepo, userID, user.StatusSuspended, "suspend user")
+}
+
+// ReactivateUserUseCase devolve o acesso a uma conta suspensa.
+type ReactivateUserUseCase struct {
+	userRepo user.Repository
+}
+
+func NewReactivateUserUseCase(userRepo user.Repository) *ReactivateUserUseCase {
+	return &ReactivateUserUseCase{
+		userRepo: userRepo,
+	}
+}
+
+func (uc *ReactivateUserUseCase) Execute(ctx context.Context, userID string) (*user.User, error) {
+	return setUserStatus(ctx, uc.userRepo, userID, user.StatusActive, "reactivate user")
+}
+
+func setUserStatus(ctx context.Context, userRepo user.Repository, userID string, status user.Status, action string) (*user.User, error) {
+	// 1. Validar ID
+	parsedID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("usecase: %s failed: invalid user ID format", action)
+	}
+
+	// 2. Buscar usuário
+	foundUser, err := userRepo.GetByID(ctx, parsedID)
+	if err != nil {
+		return nil, fmt.Errorf("usecase: %s failed: %w", action, err)
+	}
+
+	// 3. Nada a fazer se já estiver no status pedido
+	if foundUser.Status == status {
+		return foundUser, nil
+	}
+
+	// 4. Atualizar status
+	if err := userRepo.UpdateStatus(ctx, foundUser.ID, status); err != nil {
+		return nil, fmt.Errorf("usecase: %s failed: %w", action, err)
+	}
+	foundUser.Status = status
+
+	return foundUser, nil
+}
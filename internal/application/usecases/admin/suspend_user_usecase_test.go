@@ -0,0 +1,114 @@
+package admin
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	authUC "github.com/moura95/backend-challenge/internal/application/usecases/auth"
+	"github.com/moura95/backend-challenge/internal/domain/user"
+	"github.com/moura95/backend-challenge/internal/infra/security/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// statusUserRepository keeps users in memory and stores status changes.
+type statusUserRepository struct {
+	user.Repository
+	users map[uuid.UUID]*user.User
+}
+
+func (r *statusUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*user.User, error) {
+	found, ok := r.users[id]
+	if !ok {
+		return nil, user.ErrUserNotFound
+	}
+	copied := *found
+	return &copied, nil
+}
+
+func (r *statusUserRepository) GetByEmail(ctx context.Context, address string) (*user.User, error) {
+	for _, found := range r.users {
+		if found.Email == address {
+			copied := *found
+			return &copied, nil
+		}
+	}
+	return nil, user.ErrUserNotFound
+}
+
+func (r *statusUserRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status user.Status) error {
+	found, ok := r.users[id]
+	if !ok {
+		return user.ErrUserNotFound
+	}
+	found.Status = status
+	return nil
+}
+
+func (r *statusUserRepository) UpdateLastLogin(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return nil
+}
+
+func TestSuspendAndReactivateUser(t *testing.T) {
+	ctx := context.Background()
+
+	existing, err := user.NewUser("John Doe", "suspend@example.com", "password123")
+	require.NoError(t, err)
+	repo := &statusUserRepository{users: map[uuid.UUID]*user.User{existing.ID: existing}}
+
+	tokenMaker, err := jwt.NewPasetoMaker("12345678901234567890123456789012")
+	require.NoError(t, err)
+	signIn := authUC.NewSignInUseCase(repo, tokenMaker)
+	credentials := authUC.SignInRequest{Email: "suspend@example.com", Password: "password123"}
+
+	suspendUC := NewSuspendUserUseCase(repo)
+	reactivateUC := NewReactivateUserUseCase(repo)
+
+	t.Run("new users are active and can sign in", func(t *testing.T) {
+		assert.Equal(t, user.StatusActive, existing.Status)
+
+		_, err := signIn.Execute(ctx, credentials)
+		assert.NoError(t, err)
+	})
+
+	t.Run("suspended user cannot sign in", func(t *testing.T) {
+		suspended, err := suspendUC.Execute(ctx, existing.ID.String())
+		require.NoError(t, err)
+		assert.Equal(t, user.StatusSuspended, suspended.Status)
+		assert.Equal(t, string(user.StatusSuspended), suspended.ToResponse().Status)
+
+		_, err = signIn.Execute(ctx, credentials)
+		assert.ErrorIs(t, err, user.ErrAccountSuspended)
+	})
+
+	t.Run("wrong password on a suspended account is still invalid credentials", func(t *testing.T) {
+		_, err := signIn.Execute(ctx, authUC.SignInRequest{Email: "suspend@example.com", Password: "wrongpassword"})
+		assert.ErrorIs(t, err, user.ErrInvalidCredentials)
+	})
+
+	t.Run("suspending twice is a no-op", func(t *testing.T) {
+		suspended, err := suspendUC.Execute(ctx, existing.ID.String())
+		require.NoError(t, err)
+		assert.Equal(t, user.StatusSuspended, suspended.Status)
+	})
+
+	t.Run("reactivation restores access", func(t *testing.T) {
+		reactivated, err := reactivateUC.Execute(ctx, existing.ID.String())
+		require.NoError(t, err)
+		assert.Equal(t, user.StatusActive, reactivated.Status)
+
+		response, err := signIn.Execute(ctx, credentials)
+		require.NoError(t, err)
+		assert.NotEmpty(t, response.Token)
+	})
+
+	t.Run("rejects invalid and unknown IDs", func(t *testing.T) {
+		_, err := suspendUC.Execute(ctx, "not-a-uuid")
+		assert.Error(t, err)
+
+		_, err = reactivateUC.Execute(ctx, uuid.NewString())
+		assert.ErrorIs(t, err, user.ErrUserNotFound)
+	})
+}
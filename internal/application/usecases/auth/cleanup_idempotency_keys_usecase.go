@@ -0,0 +1,28 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/moura95/backend-challenge/internal/domain/token"
+)
+
+type CleanupIdempotencyKeysUseCase struct {
+	idempotencyRepo token.IdempotencyRepository
+}
+
+func NewCleanupIdempotencyKeysUseCase(idempotencyRepo token.IdempotencyRepository) *CleanupIdempotencyKeysUseCase {
+	return &CleanupIdempotencyKeysUseCase{
+		idempotencyRepo: idempotencyRepo,
+	}
+}
+
+// Execute remove as chaves de idempotência de cadastro já expiradas.
+func (uc *CleanupIdempotencyKeysUseCase) Execute(ctx context.Context) (int64, error) {
+	deleted, err := uc.idempotencyRepo.DeleteExpired(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("usecase: cleanup idempotency keys failed: %w", err)
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,29 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/moura95/backend-challenge/internal/domain/token"
+)
+
+type CleanupRevokedTokensUseCase struct {
+	tokenRepo token.Repository
+}
+
+func NewCleanupRevokedTokensUseCase(tokenRepo token.Repository) *CleanupRevokedTokensUseCase {
+	return &CleanupRevokedTokensUseCase{
+		tokenRepo: tokenRepo,
+	}
+}
+
+// Execute remove da denylist os tokens que já expiraram, pois eles não
+// passariam mais na verificação de qualquer forma.
+func (uc *CleanupRevokedTokensUseCase) Execute(ctx context.Context) (int64, error) {
+	deleted, err := uc.tokenRepo.DeleteExpired(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("usecase: cleanup revoked tokens failed: %w", err)
+	}
+
+	return deleted, nil
+}
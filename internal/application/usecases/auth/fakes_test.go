@@ -0,0 +1,176 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/moura95/backend-challenge/internal/domain/email"
+	"github.com/moura95/backend-challenge/internal/domain/user"
+)
+
+// memoryUserRepository keeps users in memory for the tests that don't need
+// the shared database. Methods no test calls panic on the nil interface.
+type memoryUserRepository struct {
+	user.Repository
+	mu              sync.Mutex
+	users           map[uuid.UUID]*user.User
+	passwordUpdates int
+}
+
+func newMemoryUserRepository(users ...*user.User) *memoryUserRepository {
+	r := &memoryUserRepository{users: map[uuid.UUID]*user.User{}}
+	for _, u := range users {
+		r.users[u.ID] = u
+	}
+	return r
+}
+
+func (r *memoryUserRepository) EmailExists(ctx context.Context, address string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.byEmail(address) != nil, nil
+}
+
+func (r *memoryUserRepository) Create(ctx context.Context, u *user.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.users[u.ID] = u
+	return nil
+}
+
+func (r *memoryUserRepository) GetByEmail(ctx context.Context, address string) (*user.User, error) {
+	if found := r.find(address); found != nil {
+		return found, nil
+	}
+	return nil, user.ErrUserNotFound
+}
+
+func (r *memoryUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*user.User, error) {
+	if found := r.findByID(id); found != nil {
+		return found, nil
+	}
+	return nil, user.ErrUserNotFound
+}
+
+func (r *memoryUserRepository) MarkEmailVerified(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.users[id]
+	if !ok {
+		return user.ErrUserNotFound
+	}
+	stored.MarkAsVerified()
+	return nil
+}
+
+func (r *memoryUserRepository) UpdatePassword(ctx context.Context, u *user.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.users[u.ID]
+	if !ok {
+		return user.ErrUserNotFound
+	}
+	stored.Password = u.Password
+	r.passwordUpdates++
+	return nil
+}
+
+// UpdateLastLogin ignores times older than the stored one, like the users table.
+func (r *memoryUserRepository) UpdateLastLogin(ctx context.Context, id uuid.UUID, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.users[id]
+	if !ok {
+		return user.ErrUserNotFound
+	}
+	if stored.LastLoginAt == nil || stored.LastLoginAt.Before(at) {
+		stored.LastLoginAt = &at
+	}
+	return nil
+}
+
+// find returns a copy of the stored user with the email, or nil.
+func (r *memoryUserRepository) find(address string) *user.User {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	found := r.byEmail(address)
+	if found == nil {
+		return nil
+	}
+	stored := *found
+	return &stored
+}
+
+// findByID returns a copy of the stored user with the ID, or nil.
+func (r *memoryUserRepository) findByID(id uuid.UUID) *user.User {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	found, ok := r.users[id]
+	if !ok {
+		return nil
+	}
+	stored := *found
+	return &stored
+}
+
+func (r *memoryUserRepository) byEmail(address string) *user.User {
+	for _, u := range r.users {
+		if u.Email == address {
+			return u
+		}
+	}
+	return nil
+}
+
+// memoryEmailRepository records every email row the use cases write.
+type memoryEmailRepository struct {
+	email.Repository
+	emails []*email.Email
+}
+
+func (r *memoryEmailRepository) Create(ctx context.Context, e *email.Email) error {
+	r.emails = append(r.emails, e)
+	return nil
+}
+
+// memoryVerificationTokenRepository keeps email verification tokens in memory.
+type memoryVerificationTokenRepository struct {
+	owners    map[string]uuid.UUID
+	expiresAt map[string]time.Time
+	used      map[string]bool
+}
+
+func newMemoryVerificationTokenRepository() *memoryVerificationTokenRepository {
+	return &memoryVerificationTokenRepository{
+		owners:    map[string]uuid.UUID{},
+		expiresAt: map[string]time.Time{},
+		used:      map[string]bool{},
+	}
+}
+
+func (r *memoryVerificationTokenRepository) Create(ctx context.Context, tokenHash string, userID uuid.UUID, expiresAt time.Time) error {
+	r.owners[tokenHash] = userID
+	r.expiresAt[tokenHash] = expiresAt
+	return nil
+}
+
+func (r *memoryVerificationTokenRepository) Consume(ctx context.Context, tokenHash string) (uuid.UUID, error) {
+	userID, ok := r.owners[tokenHash]
+	if !ok || r.used[tokenHash] || !time.Now().Before(r.expiresAt[tokenHash]) {
+		return uuid.Nil, errors.New("repository: consume email verification token failed: token not found")
+	}
+	r.used[tokenHash] = true
+	return userID, nil
+}
+
+func (r *memoryVerificationTokenRepository) GetUserID(ctx context.Context, tokenHash string) (uuid.UUID, error) {
+	userID, ok := r.owners[tokenHash]
+	if !ok {
+		return uuid.Nil, errors.New("repository: get email verification token failed: token not found")
+	}
+	return userID, nil
+}
@@ -0,0 +1,75 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/moura95/backend-challenge/internal/domain/token"
+	"github.com/moura95/backend-challenge/internal/infra/security/jwt"
+)
+
+type LogoutRequest struct {
+	Token        string `json:"-"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+type LogoutUseCase struct {
+	tokenRepo  token.Repository
+	tokenMaker jwt.Maker
+}
+
+func NewLogoutUseCase(tokenRepo token.Repository, tokenMaker jwt.Maker) *LogoutUseCase {
+	return &LogoutUseCase{
+		tokenRepo:  tokenRepo,
+		tokenMaker: tokenMaker,
+	}
+}
+
+func (uc *LogoutUseCase) Execute(ctx context.Context, req LogoutRequest) error {
+	// 1. Validar entrada
+	if strings.TrimSpace(req.Token) == "" {
+		return fmt.Errorf("usecase: logout failed: token is required")
+	}
+
+	// 2. Revogar o access token atual
+	payload, err := uc.tokenMaker.VerifyToken(req.Token)
+	if err != nil {
+		return fmt.Errorf("usecase: logout failed: invalid token")
+	}
+
+	if err := uc.revoke(ctx, payload); err != nil {
+		return err
+	}
+
+	// 3. Revogar o refresh token, se informado e do mesmo usuário
+	if strings.TrimSpace(req.RefreshToken) == "" {
+		return nil
+	}
+
+	refreshPayload, err := uc.tokenMaker.VerifyToken(req.RefreshToken)
+	if err != nil || !refreshPayload.IsRefresh() || refreshPayload.UserUUID != payload.UserUUID {
+		return fmt.Errorf("usecase: logout failed: %w", token.ErrInvalidRefreshToken)
+	}
+
+	return uc.revoke(ctx, refreshPayload)
+}
+
+func (uc *LogoutUseCase) revoke(ctx context.Context, payload *jwt.Payload) error {
+	tokenID, err := uuid.Parse(payload.UUID)
+	if err != nil {
+		return fmt.Errorf("usecase: logout failed: invalid token")
+	}
+
+	userID, err := uuid.Parse(payload.UserUUID)
+	if err != nil {
+		return fmt.Errorf("usecase: logout failed: invalid token")
+	}
+
+	if err := uc.tokenRepo.Revoke(ctx, tokenID, userID, payload.ExpiredAt); err != nil {
+		return fmt.Errorf("usecase: logout failed: %w", err)
+	}
+
+	return nil
+}
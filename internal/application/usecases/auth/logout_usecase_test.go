@@ -0,0 +1,241 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+
+	"github.com/moura95/backend-challenge/internal/domain/user"
+	"github.com/moura95/backend-challenge/internal/infra/repository/adapters"
+	"github.com/moura95/backend-challenge/internal/infra/security/jwt"
+)
+
+type logoutTestServer struct {
+	container *postgres.PostgresContainer
+	db        *sqlx.DB
+	repos     *adapters.Repositories
+	cleanup   func()
+}
+
+func setupLogoutTest(t *testing.T) *logoutTestServer {
+	ctx := context.Background()
+
+	// Start PostgreSQL container
+	postgresContainer, err := postgres.RunContainer(ctx,
+		testcontainers.WithImage("postgres:15-alpine"),
+		postgres.WithDatabase("testdb"),
+		postgres.WithUsername("test"),
+		postgres.WithPassword("test"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(30*time.Second)),
+	)
+	require.NoError(t, err)
+
+	// Get connection string
+	connStr, err := postgresContainer.ConnectionString(ctx, "sslmode=disable")
+	require.NoError(t, err)
+
+	// Connect to database
+	db, err := sqlx.Connect("postgres", connStr)
+	require.NoError(t, err)
+
+	// Run migrations
+	err = runLogoutMigrations(db)
+	require.NoError(t, err)
+
+	// Setup repositories
+	repos := adapters.NewRepositories(db)
+
+	cleanup := func() {
+		db.Close()
+		postgresContainer.Terminate(ctx)
+	}
+
+	return &logoutTestServer{
+		container: postgresContainer,
+		db:        db,
+		repos:     repos,
+		cleanup:   cleanup,
+	}
+}
+
+func runLogoutMigrations(db *sqlx.DB) error {
+	migrationSQL := `
+	CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+	
+	-- Users table
+	CREATE TABLE IF NOT EXISTS users (
+		uuid         UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+		name         VARCHAR(255) NOT NULL,
+		email        VARCHAR(100) NOT NULL UNIQUE,
+		password     TEXT NOT NULL,
+		verified_at  TIMESTAMPTZ,
+		role         VARCHAR(20) NOT NULL DEFAULT 'user',
+		deleted_at   TIMESTAMPTZ,
+		version      INTEGER NOT NULL DEFAULT 1,
+		status       VARCHAR(20) NOT NULL DEFAULT 'active',
+		token_version INTEGER NOT NULL DEFAULT 0,
+		last_login_at TIMESTAMPTZ,
+		created_at   TIMESTAMP NOT NULL DEFAULT NOW(),
+		updated_at   TIMESTAMP NOT NULL DEFAULT NOW()
+	);
+	
+	-- Revoked tokens table
+	CREATE TABLE IF NOT EXISTS revoked_tokens (
+		token_uuid   UUID PRIMARY KEY,
+		user_uuid    UUID NOT NULL,
+		expires_at   TIMESTAMPTZ NOT NULL,
+		revoked_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	);
+	
+	-- Indexes
+	CREATE INDEX IF NOT EXISTS idx_users_email ON users(email);
+	`
+
+	_, err := db.Exec(migrationSQL)
+	return err
+}
+
+// Helper function to create a test user and return an access and refresh token pair
+func createUserAndTokens(t *testing.T, server *logoutTestServer, tokenMaker jwt.Maker, email, name string) (*user.User, string, string) {
+	ctx := context.Background()
+
+	testUser, err := user.NewUser(name, email, "password123")
+	require.NoError(t, err)
+
+	err = server.repos.User.Create(ctx, testUser)
+	require.NoError(t, err)
+
+	accessToken, _, err := tokenMaker.CreateToken(testUser.ID, 0, time.Hour)
+	require.NoError(t, err)
+
+	refreshToken, _, err := tokenMaker.CreateRefreshToken(testUser.ID, 0, 7*24*time.Hour)
+	require.NoError(t, err)
+
+	return testUser, accessToken, refreshToken
+}
+
+func TestLogoutUseCase_Execute(t *testing.T) {
+	server := setupLogoutTest(t)
+	defer server.cleanup()
+
+	ctx := context.Background()
+
+	// Setup token maker
+	tokenMaker, err := jwt.NewPasetoMaker("12345678901234567890123456789012")
+	require.NoError(t, err)
+
+	t.Run("should revoke access token so it cannot be reused", func(t *testing.T) {
+		_, accessToken, _ := createUserAndTokens(t, server, tokenMaker, "logout@example.com", "Logout User")
+
+		verifyUC := NewVerifyTokenUseCase(server.repos.User, server.repos.Token, tokenMaker)
+		_, err := verifyUC.Execute(ctx, accessToken)
+		require.NoError(t, err)
+
+		useCase := NewLogoutUseCase(server.repos.Token, tokenMaker)
+
+		// Execute
+		err = useCase.Execute(ctx, LogoutRequest{Token: accessToken})
+		require.NoError(t, err)
+
+		// Token is rejected afterwards
+		result, err := verifyUC.Execute(ctx, accessToken)
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "token revoked")
+	})
+
+	t.Run("should be idempotent", func(t *testing.T) {
+		_, accessToken, _ := createUserAndTokens(t, server, tokenMaker, "logout-twice@example.com", "Logout Twice")
+
+		useCase := NewLogoutUseCase(server.repos.Token, tokenMaker)
+
+		require.NoError(t, useCase.Execute(ctx, LogoutRequest{Token: accessToken}))
+		require.NoError(t, useCase.Execute(ctx, LogoutRequest{Token: accessToken}))
+	})
+
+	t.Run("should revoke refresh token when provided", func(t *testing.T) {
+		_, accessToken, refreshToken := createUserAndTokens(t, server, tokenMaker, "logout-refresh@example.com", "Logout Refresh")
+
+		useCase := NewLogoutUseCase(server.repos.Token, tokenMaker)
+
+		err := useCase.Execute(ctx, LogoutRequest{Token: accessToken, RefreshToken: refreshToken})
+		require.NoError(t, err)
+
+		refreshUC := NewRefreshTokenUseCase(server.repos.User, server.repos.Token, tokenMaker)
+		result, err := refreshUC.Execute(ctx, RefreshTokenRequest{RefreshToken: refreshToken})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "refresh token revoked")
+	})
+
+	t.Run("should reject refresh token from another user", func(t *testing.T) {
+		_, accessToken, _ := createUserAndTokens(t, server, tokenMaker, "logout-a@example.com", "User A")
+		_, _, otherRefresh := createUserAndTokens(t, server, tokenMaker, "logout-b@example.com", "User B")
+
+		useCase := NewLogoutUseCase(server.repos.Token, tokenMaker)
+
+		err := useCase.Execute(ctx, LogoutRequest{Token: accessToken, RefreshToken: otherRefresh})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid refresh token")
+	})
+
+	t.Run("should fail with empty token", func(t *testing.T) {
+		useCase := NewLogoutUseCase(server.repos.Token, tokenMaker)
+
+		err := useCase.Execute(ctx, LogoutRequest{Token: ""})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "token is required")
+	})
+
+	t.Run("should fail with invalid token", func(t *testing.T) {
+		useCase := NewLogoutUseCase(server.repos.Token, tokenMaker)
+
+		err := useCase.Execute(ctx, LogoutRequest{Token: "not.a.valid.token"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid token")
+	})
+}
+
+func TestCleanupRevokedTokensUseCase_Execute(t *testing.T) {
+	server := setupLogoutTest(t)
+	defer server.cleanup()
+
+	ctx := context.Background()
+
+	t.Run("should remove only expired revoked tokens", func(t *testing.T) {
+		userID := uuid.New()
+		expiredID := uuid.New()
+		activeID := uuid.New()
+
+		err := server.repos.Token.Revoke(ctx, expiredID, userID, time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+		err = server.repos.Token.Revoke(ctx, activeID, userID, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+
+		useCase := NewCleanupRevokedTokensUseCase(server.repos.Token)
+
+		deleted, err := useCase.Execute(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), deleted)
+
+		revoked, err := server.repos.Token.IsRevoked(ctx, expiredID)
+		require.NoError(t, err)
+		assert.False(t, revoked)
+
+		revoked, err = server.repos.Token.IsRevoked(ctx, activeID)
+		require.NoError(t, err)
+		assert.True(t, revoked)
+	})
+}
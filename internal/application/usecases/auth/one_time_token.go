@@ -0,0 +1,16 @@
+package auth
+
+import (
+	"github.com/moura95/backend-challenge/internal/infra/security/crypto"
+)
+
+// generateOneTimeToken gera o token enviado por email e o hash que é
+// persistido. O token em texto puro nunca é salvo no banco.
+func generateOneTimeToken() (string, string, error) {
+	plainToken, err := crypto.GenerateRandomString(64)
+	if err != nil {
+		return "", "", err
+	}
+
+	return plainToken, crypto.HashSHA256(plainToken), nil
+}
@@ -0,0 +1,265 @@
+package auth
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+
+	"github.com/moura95/backend-challenge/internal/domain/user"
+	"github.com/moura95/backend-challenge/internal/infra/repository/adapters"
+)
+
+type passwordResetTestServer struct {
+	container *postgres.PostgresContainer
+	db        *sqlx.DB
+	repos     *adapters.Repositories
+	cleanup   func()
+}
+
+func setupPasswordResetTest(t *testing.T) *passwordResetTestServer {
+	ctx := context.Background()
+
+	// Start PostgreSQL container
+	postgresContainer, err := postgres.RunContainer(ctx,
+		testcontainers.WithImage("postgres:15-alpine"),
+		postgres.WithDatabase("testdb"),
+		postgres.WithUsername("test"),
+		postgres.WithPassword("test"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(30*time.Second)),
+	)
+	require.NoError(t, err)
+
+	// Get connection string
+	connStr, err := postgresContainer.ConnectionString(ctx, "sslmode=disable")
+	require.NoError(t, err)
+
+	// Connect to database
+	db, err := sqlx.Connect("postgres", connStr)
+	require.NoError(t, err)
+
+	// Run migrations
+	err = runPasswordResetMigrations(db)
+	require.NoError(t, err)
+
+	// Setup repositories
+	repos := adapters.NewRepositories(db)
+
+	cleanup := func() {
+		db.Close()
+		postgresContainer.Terminate(ctx)
+	}
+
+	return &passwordResetTestServer{
+		container: postgresContainer,
+		db:        db,
+		repos:     repos,
+		cleanup:   cleanup,
+	}
+}
+
+func runPasswordResetMigrations(db *sqlx.DB) error {
+	migrationSQL := `
+	CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+	
+	-- Users table
+	CREATE TABLE IF NOT EXISTS users (
+		uuid         UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+		name         VARCHAR(255) NOT NULL,
+		email        VARCHAR(100) NOT NULL UNIQUE,
+		password     TEXT NOT NULL,
+		verified_at  TIMESTAMPTZ,
+		role         VARCHAR(20) NOT NULL DEFAULT 'user',
+		deleted_at   TIMESTAMPTZ,
+		version      INTEGER NOT NULL DEFAULT 1,
+		status       VARCHAR(20) NOT NULL DEFAULT 'active',
+		token_version INTEGER NOT NULL DEFAULT 0,
+		last_login_at TIMESTAMPTZ,
+		created_at   TIMESTAMP NOT NULL DEFAULT NOW(),
+		updated_at   TIMESTAMP NOT NULL DEFAULT NOW()
+	);
+	
+	-- Emails table
+	CREATE TABLE IF NOT EXISTS emails (
+		uuid         UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+		to_email     VARCHAR(255) NOT NULL,
+		subject      VARCHAR(255) NOT NULL,
+		body         TEXT NOT NULL,
+		type         VARCHAR(50) NOT NULL,
+		status       VARCHAR(50) NOT NULL DEFAULT 'pending',
+		attempts     INTEGER NOT NULL DEFAULT 0,
+		max_attempts INTEGER NOT NULL DEFAULT 3,
+		error_msg    TEXT,
+		sent_at      TIMESTAMPTZ,
+		created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		plain_body   TEXT NOT NULL DEFAULT '',
+		next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		cc_emails    TEXT[] NOT NULL DEFAULT '{}',
+		bcc_emails   TEXT[] NOT NULL DEFAULT '{}',
+		dedup_key    VARCHAR(255),
+		scheduled_at TIMESTAMPTZ
+	);
+	
+	-- Email attachments table
+	CREATE TABLE IF NOT EXISTS email_attachments (
+		id           BIGSERIAL PRIMARY KEY,
+		email_uuid   UUID NOT NULL,
+		filename     VARCHAR(255) NOT NULL,
+		content_type VARCHAR(255) NOT NULL,
+		content      BYTEA NOT NULL,
+		created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		FOREIGN KEY (email_uuid) REFERENCES emails(uuid) ON DELETE CASCADE
+	);
+	
+	-- Password reset tokens table
+	CREATE TABLE IF NOT EXISTS password_reset_tokens (
+		token_hash   TEXT PRIMARY KEY,
+		user_uuid    UUID NOT NULL REFERENCES users(uuid) ON DELETE CASCADE,
+		expires_at   TIMESTAMPTZ NOT NULL,
+		used_at      TIMESTAMPTZ,
+		created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	);
+	
+	-- Indexes
+	CREATE INDEX IF NOT EXISTS idx_users_email ON users(email);
+	CREATE INDEX IF NOT EXISTS idx_emails_status ON emails(status);
+	CREATE INDEX IF NOT EXISTS idx_emails_type ON emails(type);
+	`
+
+	_, err := db.Exec(migrationSQL)
+	return err
+}
+
+var resetTokenRegex = regexp.MustCompile(`token=([a-f0-9]+)`)
+
+// Helper function to read the reset token from the last password reset email
+func getResetTokenFromEmail(t *testing.T, server *passwordResetTestServer, to string) string {
+	var body string
+	err := server.db.Get(&body, `
+		SELECT body FROM emails
+		WHERE to_email = $1 AND type = 'password_reset'
+		ORDER BY created_at DESC
+		LIMIT 1`, to)
+	require.NoError(t, err)
+
+	matches := resetTokenRegex.FindStringSubmatch(body)
+	require.Len(t, matches, 2)
+
+	return matches[1]
+}
+
+func TestPasswordResetFlow(t *testing.T) {
+	server := setupPasswordResetTest(t)
+	defer server.cleanup()
+
+	ctx := context.Background()
+
+	requestUC := NewRequestPasswordResetUseCase(
+		server.repos.User,
+		server.repos.Email,
+		server.repos.PasswordReset,
+		nil,
+		"http://localhost:3000/reset-password",
+	)
+	resetUC := NewResetPasswordUseCase(server.repos.User, server.repos.PasswordReset)
+
+	createUser := func(email string) *user.User {
+		testUser, err := user.NewUser("Reset User", email, "oldpassword")
+		require.NoError(t, err)
+		require.NoError(t, server.repos.User.Create(ctx, testUser))
+		return testUser
+	}
+
+	t.Run("should reset password with emailed token", func(t *testing.T) {
+		createUser("reset@example.com")
+
+		// Request reset
+		err := requestUC.Execute(ctx, RequestPasswordResetRequest{Email: "reset@example.com"})
+		require.NoError(t, err)
+
+		resetToken := getResetTokenFromEmail(t, server, "reset@example.com")
+
+		// Confirm reset
+		err = resetUC.Execute(ctx, ResetPasswordRequest{Token: resetToken, NewPassword: "newpassword"})
+		require.NoError(t, err)
+
+		// New password works, old one does not
+		updatedUser, err := server.repos.User.GetByEmail(ctx, "reset@example.com")
+		require.NoError(t, err)
+		assert.NoError(t, updatedUser.CheckPassword("newpassword"))
+		assert.Error(t, updatedUser.CheckPassword("oldpassword"))
+	})
+
+	t.Run("should not allow reusing a reset token", func(t *testing.T) {
+		createUser("reuse@example.com")
+
+		err := requestUC.Execute(ctx, RequestPasswordResetRequest{Email: "reuse@example.com"})
+		require.NoError(t, err)
+
+		resetToken := getResetTokenFromEmail(t, server, "reuse@example.com")
+
+		err = resetUC.Execute(ctx, ResetPasswordRequest{Token: resetToken, NewPassword: "firstchange"})
+		require.NoError(t, err)
+
+		err = resetUC.Execute(ctx, ResetPasswordRequest{Token: resetToken, NewPassword: "secondchange"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid or expired reset token")
+	})
+
+	t.Run("should succeed silently for unknown email", func(t *testing.T) {
+		err := requestUC.Execute(ctx, RequestPasswordResetRequest{Email: "nobody@example.com"})
+		require.NoError(t, err)
+
+		var count int
+		err = server.db.Get(&count, "SELECT COUNT(*) FROM emails WHERE to_email = $1", "nobody@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+	})
+
+	t.Run("should reject unknown token", func(t *testing.T) {
+		err := resetUC.Execute(ctx, ResetPasswordRequest{Token: "deadbeef", NewPassword: "newpassword"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid or expired reset token")
+	})
+
+	t.Run("should reject weak password without consuming token", func(t *testing.T) {
+		createUser("weak@example.com")
+
+		err := requestUC.Execute(ctx, RequestPasswordResetRequest{Email: "weak@example.com"})
+		require.NoError(t, err)
+
+		resetToken := getResetTokenFromEmail(t, server, "weak@example.com")
+
+		err = resetUC.Execute(ctx, ResetPasswordRequest{Token: resetToken, NewPassword: "123"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "password must be at least 6 characters long")
+
+		// Token is still valid
+		err = resetUC.Execute(ctx, ResetPasswordRequest{Token: resetToken, NewPassword: "strongpassword"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("should reject expired token", func(t *testing.T) {
+		testUser := createUser("expired-reset@example.com")
+
+		// Insert an already expired token directly
+		err := server.repos.PasswordReset.Create(ctx, "expiredhash", testUser.ID, time.Now().Add(-time.Minute))
+		require.NoError(t, err)
+
+		_, err = server.repos.PasswordReset.Consume(ctx, "expiredhash")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "token not found")
+	})
+}
@@ -0,0 +1,131 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moura95/backend-challenge/internal/domain/token"
+	"github.com/moura95/backend-challenge/internal/domain/user"
+	"github.com/moura95/backend-challenge/internal/infra/security/jwt"
+)
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type RefreshTokenResponse struct {
+	User  *user.User `json:"user"`
+	Token string     `json:"token"`
+}
+
+type RefreshTokenUseCase struct {
+	userRepo      user.Repository
+	tokenRepo     token.Repository
+	tokenMaker    jwt.Maker
+	tokenDuration time.Duration
+}
+
+func NewRefreshTokenUseCase(userRepo user.Repository, tokenRepo token.Repository, tokenMaker jwt.Maker) *RefreshTokenUseCase {
+	return &RefreshTokenUseCase{
+		userRepo:      userRepo,
+		tokenRepo:     tokenRepo,
+		tokenMaker:    tokenMaker,
+		tokenDuration: 24 * time.Hour, // 24 hours
+	}
+}
+
+// WithTokenDuration define a validade do access token emitido.
+// Valores não positivos mantêm o padrão.
+func (uc *RefreshTokenUseCase) WithTokenDuration(duration time.Duration) *RefreshTokenUseCase {
+	if duration > 0 {
+		uc.tokenDuration = duration
+	}
+	return uc
+}
+
+func (uc *RefreshTokenUseCase) Execute(ctx context.Context, req RefreshTokenRequest) (*RefreshTokenResponse, error) {
+	// 1. Validar entrada
+	if strings.TrimSpace(req.RefreshToken) == "" {
+		return nil, fmt.Errorf("usecase: refresh token failed: refresh token is required")
+	}
+
+	// 2. Verificar e decodificar refresh token
+	payload, err := uc.tokenMaker.VerifyToken(req.RefreshToken)
+	if err != nil {
+		if errors.Is(err, jwt.ErrExpiredToken) {
+			return nil, fmt.Errorf("usecase: refresh token failed: %w", token.ErrRefreshTokenExpired)
+		}
+		return nil, fmt.Errorf("usecase: refresh token failed: %w", token.ErrInvalidRefreshToken)
+	}
+
+	// 3. Garantir que não é um access token
+	if !payload.IsRefresh() {
+		return nil, fmt.Errorf("usecase: refresh token failed: %w", token.ErrInvalidRefreshToken)
+	}
+
+	userID, err := uuid.Parse(payload.UserUUID)
+	if err != nil {
+		return nil, fmt.Errorf("usecase: refresh token failed: %w", token.ErrInvalidRefreshToken)
+	}
+
+	tokenID, err := uuid.Parse(payload.UUID)
+	if err != nil {
+		return nil, fmt.Errorf("usecase: refresh token failed: %w", token.ErrInvalidRefreshToken)
+	}
+
+	// 4. Verificar se o refresh token foi revogado (logout)
+	revoked, err := uc.tokenRepo.IsRevoked(ctx, tokenID)
+	if err != nil {
+		return nil, fmt.Errorf("usecase: refresh token failed: %w", err)
+	}
+	if revoked {
+		return nil, fmt.Errorf("usecase: refresh token failed: %w", token.ErrRefreshTokenRevoked)
+	}
+
+	// ...ou se a sessão do login foi revogada
+	var sessionOpts []jwt.PayloadOption
+	if payload.SessionID != "" {
+		sessionID, err := uuid.Parse(payload.SessionID)
+		if err != nil {
+			return nil, fmt.Errorf("usecase: refresh token failed: %w", token.ErrInvalidRefreshToken)
+		}
+		revoked, err := uc.tokenRepo.IsRevoked(ctx, sessionID)
+		if err != nil {
+			return nil, fmt.Errorf("usecase: refresh token failed: %w", err)
+		}
+		if revoked {
+			return nil, fmt.Errorf("usecase: refresh token failed: %w", token.ErrRefreshTokenRevoked)
+		}
+		sessionOpts = append(sessionOpts, jwt.WithSessionID(sessionID))
+	}
+
+	// 5. Confirmar que o usuário ainda existe e não está suspenso
+	foundUser, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("usecase: refresh token failed: %w", user.ErrUserNotFound)
+	}
+	if foundUser.IsSuspended() {
+		return nil, fmt.Errorf("usecase: refresh token failed: %w", user.ErrAccountSuspended)
+	}
+	// Sessões revogadas pelo usuário invalidam também os refresh tokens
+	if payload.TokenVersion != foundUser.TokenVersion {
+		return nil, fmt.Errorf("usecase: refresh token failed: %w", token.ErrRefreshTokenRevoked)
+	}
+
+	// 6. Gerar novo access token
+	token, _, err := uc.tokenMaker.CreateToken(foundUser.ID, foundUser.TokenVersion, uc.tokenDuration, sessionOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("usecase: refresh token failed: token generation error: %w", err)
+	}
+
+	response := &RefreshTokenResponse{
+		User:  foundUser,
+		Token: token,
+	}
+
+	return response, nil
+}
@@ -0,0 +1,244 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+
+	"github.com/moura95/backend-challenge/internal/domain/user"
+	"github.com/moura95/backend-challenge/internal/infra/repository/adapters"
+	"github.com/moura95/backend-challenge/internal/infra/security/jwt"
+)
+
+type refreshTokenTestServer struct {
+	container *postgres.PostgresContainer
+	db        *sqlx.DB
+	repos     *adapters.Repositories
+	cleanup   func()
+}
+
+func setupRefreshTokenTest(t *testing.T) *refreshTokenTestServer {
+	ctx := context.Background()
+
+	// Start PostgreSQL container
+	postgresContainer, err := postgres.RunContainer(ctx,
+		testcontainers.WithImage("postgres:15-alpine"),
+		postgres.WithDatabase("testdb"),
+		postgres.WithUsername("test"),
+		postgres.WithPassword("test"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(30*time.Second)),
+	)
+	require.NoError(t, err)
+
+	// Get connection string
+	connStr, err := postgresContainer.ConnectionString(ctx, "sslmode=disable")
+	require.NoError(t, err)
+
+	// Connect to database
+	db, err := sqlx.Connect("postgres", connStr)
+	require.NoError(t, err)
+
+	// Run migrations
+	err = runRefreshTokenMigrations(db)
+	require.NoError(t, err)
+
+	// Setup repositories
+	repos := adapters.NewRepositories(db)
+
+	cleanup := func() {
+		db.Close()
+		postgresContainer.Terminate(ctx)
+	}
+
+	return &refreshTokenTestServer{
+		container: postgresContainer,
+		db:        db,
+		repos:     repos,
+		cleanup:   cleanup,
+	}
+}
+
+func runRefreshTokenMigrations(db *sqlx.DB) error {
+	migrationSQL := `
+	CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+	
+	-- Users table
+	CREATE TABLE IF NOT EXISTS users (
+		uuid         UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+		name         VARCHAR(255) NOT NULL,
+		email        VARCHAR(100) NOT NULL UNIQUE,
+		password     TEXT NOT NULL,
+		verified_at  TIMESTAMPTZ,
+		role         VARCHAR(20) NOT NULL DEFAULT 'user',
+		deleted_at   TIMESTAMPTZ,
+		version      INTEGER NOT NULL DEFAULT 1,
+		status       VARCHAR(20) NOT NULL DEFAULT 'active',
+		token_version INTEGER NOT NULL DEFAULT 0,
+		last_login_at TIMESTAMPTZ,
+		created_at   TIMESTAMP NOT NULL DEFAULT NOW(),
+		updated_at   TIMESTAMP NOT NULL DEFAULT NOW()
+	);
+	
+	-- Revoked tokens table
+	CREATE TABLE IF NOT EXISTS revoked_tokens (
+		token_uuid   UUID PRIMARY KEY,
+		user_uuid    UUID NOT NULL,
+		expires_at   TIMESTAMPTZ NOT NULL,
+		revoked_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	);
+	
+	-- Indexes
+	CREATE INDEX IF NOT EXISTS idx_users_email ON users(email);
+	`
+
+	_, err := db.Exec(migrationSQL)
+	return err
+}
+
+// Helper function to create a test user and return a valid refresh token
+func createUserAndRefreshToken(t *testing.T, server *refreshTokenTestServer, tokenMaker jwt.Maker, email, name string) (*user.User, string) {
+	ctx := context.Background()
+
+	testUser, err := user.NewUser(name, email, "password123")
+	require.NoError(t, err)
+
+	err = server.repos.User.Create(ctx, testUser)
+	require.NoError(t, err)
+
+	refreshToken, _, err := tokenMaker.CreateRefreshToken(testUser.ID, 0, 7*24*time.Hour)
+	require.NoError(t, err)
+
+	return testUser, refreshToken
+}
+
+func TestRefreshTokenUseCase_Execute(t *testing.T) {
+	server := setupRefreshTokenTest(t)
+	defer server.cleanup()
+
+	ctx := context.Background()
+
+	// Setup token maker
+	tokenMaker, err := jwt.NewPasetoMaker("12345678901234567890123456789012")
+	require.NoError(t, err)
+
+	t.Run("should issue new access token with valid refresh token", func(t *testing.T) {
+		// Create test user and refresh token
+		testUser, refreshToken := createUserAndRefreshToken(t, server, tokenMaker, "refresh@example.com", "Refresh User")
+
+		// Create use case
+		useCase := NewRefreshTokenUseCase(server.repos.User, server.repos.Token, tokenMaker)
+
+		// Execute
+		result, err := useCase.Execute(ctx, RefreshTokenRequest{RefreshToken: refreshToken})
+
+		// Assert
+		require.NoError(t, err)
+		assert.NotNil(t, result)
+		assert.NotEmpty(t, result.Token)
+		assert.Equal(t, testUser.ID, result.User.ID)
+
+		// New token must be an access token
+		payload, err := tokenMaker.VerifyToken(result.Token)
+		require.NoError(t, err)
+		assert.Equal(t, jwt.TokenTypeAccess, payload.TokenType)
+		assert.Equal(t, testUser.ID.String(), payload.UserUUID)
+
+		// And it must be accepted by the verify token use case
+		verifyUC := NewVerifyTokenUseCase(server.repos.User, server.repos.Token, tokenMaker)
+		verifiedUser, err := verifyUC.Execute(ctx, result.Token)
+		require.NoError(t, err)
+		assert.Equal(t, testUser.ID, verifiedUser.ID)
+	})
+
+	t.Run("should fail with empty refresh token", func(t *testing.T) {
+		useCase := NewRefreshTokenUseCase(server.repos.User, server.repos.Token, tokenMaker)
+
+		result, err := useCase.Execute(ctx, RefreshTokenRequest{RefreshToken: ""})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "refresh token is required")
+	})
+
+	t.Run("should fail with expired refresh token", func(t *testing.T) {
+		testUser, _ := createUserAndRefreshToken(t, server, tokenMaker, "expired-refresh@example.com", "Expired User")
+
+		expiredToken, _, err := tokenMaker.CreateRefreshToken(testUser.ID, 0, -time.Hour)
+		require.NoError(t, err)
+
+		useCase := NewRefreshTokenUseCase(server.repos.User, server.repos.Token, tokenMaker)
+
+		result, err := useCase.Execute(ctx, RefreshTokenRequest{RefreshToken: expiredToken})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "refresh token has expired")
+	})
+
+	t.Run("should reject access token used as refresh token", func(t *testing.T) {
+		testUser, _ := createUserAndRefreshToken(t, server, tokenMaker, "access-as-refresh@example.com", "Access User")
+
+		accessToken, _, err := tokenMaker.CreateToken(testUser.ID, 0, time.Hour)
+		require.NoError(t, err)
+
+		useCase := NewRefreshTokenUseCase(server.repos.User, server.repos.Token, tokenMaker)
+
+		result, err := useCase.Execute(ctx, RefreshTokenRequest{RefreshToken: accessToken})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "invalid refresh token")
+	})
+
+	t.Run("should fail with malformed refresh token", func(t *testing.T) {
+		useCase := NewRefreshTokenUseCase(server.repos.User, server.repos.Token, tokenMaker)
+
+		result, err := useCase.Execute(ctx, RefreshTokenRequest{RefreshToken: "not.a.valid.token"})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "invalid refresh token")
+	})
+
+	t.Run("should fail when user no longer exists", func(t *testing.T) {
+		refreshToken, _, err := tokenMaker.CreateRefreshToken(uuid.New(), 0, time.Hour)
+		require.NoError(t, err)
+
+		useCase := NewRefreshTokenUseCase(server.repos.User, server.repos.Token, tokenMaker)
+
+		result, err := useCase.Execute(ctx, RefreshTokenRequest{RefreshToken: refreshToken})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "user not found")
+	})
+	t.Run("should reject revoked refresh token", func(t *testing.T) {
+		testUser, refreshToken := createUserAndRefreshToken(t, server, tokenMaker, "revoked-refresh@example.com", "Revoked User")
+
+		payload, err := tokenMaker.VerifyToken(refreshToken)
+		require.NoError(t, err)
+
+		err = server.repos.Token.Revoke(ctx, uuid.MustParse(payload.UUID), testUser.ID, payload.ExpiredAt)
+		require.NoError(t, err)
+
+		useCase := NewRefreshTokenUseCase(server.repos.User, server.repos.Token, tokenMaker)
+
+		result, err := useCase.Execute(ctx, RefreshTokenRequest{RefreshToken: refreshToken})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "refresh token revoked")
+	})
+}
@@ -0,0 +1,120 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/moura95/backend-challenge/internal/domain/email"
+	"github.com/moura95/backend-challenge/internal/domain/token"
+	"github.com/moura95/backend-challenge/internal/domain/user"
+	"github.com/moura95/backend-challenge/internal/infra/logging"
+	"github.com/moura95/backend-challenge/internal/infra/messaging/rabbitmq"
+	"github.com/moura95/backend-challenge/internal/infra/tracing"
+)
+
+const defaultPasswordResetURL = "http://localhost:8080/reset-password"
+
+type RequestPasswordResetRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type RequestPasswordResetUseCase struct {
+	userRepo      user.Repository
+	emailRepo     email.Repository
+	resetRepo     token.PasswordResetRepository
+	rabbit        *rabbitmq.Connection
+	resetURL      string
+	tokenDuration time.Duration
+}
+
+func NewRequestPasswordResetUseCase(
+	userRepo user.Repository,
+	emailRepo email.Repository,
+	resetRepo token.PasswordResetRepository,
+	rabbit *rabbitmq.Connection,
+	resetURL string,
+) *RequestPasswordResetUseCase {
+	if resetURL == "" {
+		resetURL = defaultPasswordResetURL
+	}
+
+	return &RequestPasswordResetUseCase{
+		userRepo:      userRepo,
+		emailRepo:     emailRepo,
+		resetRepo:     resetRepo,
+		rabbit:        rabbit,
+		resetURL:      resetURL,
+		tokenDuration: 1 * time.Hour,
+	}
+}
+
+// Execute não revela se o email existe: para emails desconhecidos retorna nil
+// sem enviar nada.
+func (uc *RequestPasswordResetUseCase) Execute(ctx context.Context, req RequestPasswordResetRequest) error {
+	// 1. Buscar usuário pelo email
+	foundUser, err := uc.userRepo.GetByEmail(ctx, user.NormalizeEmail(req.Email))
+	if err != nil {
+		if strings.Contains(err.Error(), "user not found") {
+			return nil
+		}
+		return fmt.Errorf("usecase: request password reset failed: %w", err)
+	}
+
+	// 2. Gerar token de uso único (apenas o hash é persistido)
+	resetToken, tokenHash, err := generateOneTimeToken()
+	if err != nil {
+		return fmt.Errorf("usecase: request password reset failed: %w", err)
+	}
+
+	err = uc.resetRepo.Create(ctx, tokenHash, foundUser.ID, time.Now().Add(uc.tokenDuration))
+	if err != nil {
+		return fmt.Errorf("usecase: request password reset failed: %w", err)
+	}
+
+	// 3. Criar e salvar email de reset
+	resetEmail, err := email.NewPasswordResetEmail(email.PasswordResetEmailData{
+		UserID:    foundUser.ID.String(),
+		UserName:  foundUser.Name,
+		UserEmail: foundUser.Email,
+		ResetLink: fmt.Sprintf("%s?token=%s", uc.resetURL, resetToken),
+	})
+	if err != nil {
+		return fmt.Errorf("usecase: request password reset failed: %w", err)
+	}
+
+	err = uc.emailRepo.Create(ctx, resetEmail)
+	if err != nil {
+		return fmt.Errorf("usecase: request password reset failed: %w", err)
+	}
+
+	// 4. Publicar na fila (se falhar, o processamento de pendentes envia depois)
+	uc.publishPasswordResetEmail(ctx, foundUser, resetEmail)
+
+	return nil
+}
+
+func (uc *RequestPasswordResetUseCase) publishPasswordResetEmail(ctx context.Context, user *user.User, resetEmail *email.Email) {
+	if uc.rabbit == nil || !uc.rabbit.IsConnected() {
+		fmt.Println("Warning: RabbitMQ not available, skipping password reset event")
+		return
+	}
+
+	message := email.QueueMessage{
+		EmailID: resetEmail.ID,
+		Type:    email.EmailTypePasswordReset,
+		PasswordReset: &email.PasswordResetMessageData{
+			UserID:    user.ID.String(),
+			UserEmail: user.Email,
+		},
+		RequestID:    logging.RequestIDFromContext(ctx),
+		TraceContext: tracing.Inject(ctx),
+	}
+
+	err := uc.rabbit.PublishEmailMessage(message)
+	if err != nil {
+		fmt.Printf("Warning: failed to publish password reset email: %v\n", err)
+		markUnroutableEmail(ctx, uc.emailRepo, resetEmail, err)
+	}
+}
@@ -0,0 +1,166 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/moura95/backend-challenge/internal/domain/email"
+	"github.com/moura95/backend-challenge/internal/domain/token"
+	"github.com/moura95/backend-challenge/internal/domain/user"
+	"github.com/moura95/backend-challenge/internal/infra/logging"
+	"github.com/moura95/backend-challenge/internal/infra/messaging/rabbitmq"
+	"github.com/moura95/backend-challenge/internal/infra/tracing"
+)
+
+// DefaultVerificationResendCooldown is how long a verification email to an
+// address blocks another resend to the same address.
+const DefaultVerificationResendCooldown = 2 * time.Minute
+
+type ResendVerificationRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ResendVerificationUseCase struct {
+	userRepo         user.Repository
+	emailRepo        email.Repository
+	verificationRepo token.EmailVerificationRepository
+	rabbit           *rabbitmq.Connection
+	verificationURL  string
+	tokenDuration    time.Duration
+	cooldown         time.Duration
+
+	// Quando configurado, token e email são gravados na mesma transação
+	runInTx TxRunner
+}
+
+func NewResendVerificationUseCase(
+	userRepo user.Repository,
+	emailRepo email.Repository,
+	verificationRepo token.EmailVerificationRepository,
+	rabbit *rabbitmq.Connection,
+	verificationURL string,
+) *ResendVerificationUseCase {
+	if verificationURL == "" {
+		verificationURL = defaultEmailVerificationURL
+	}
+
+	return &ResendVerificationUseCase{
+		userRepo:         userRepo,
+		emailRepo:        emailRepo,
+		verificationRepo: verificationRepo,
+		rabbit:           rabbit,
+		verificationURL:  verificationURL,
+		tokenDuration:    24 * time.Hour,
+		cooldown:         DefaultVerificationResendCooldown,
+	}
+}
+
+// WithCooldown define o intervalo mínimo entre reenvios para o mesmo email;
+// valores não positivos usam o padrão.
+func (uc *ResendVerificationUseCase) WithCooldown(cooldown time.Duration) *ResendVerificationUseCase {
+	if cooldown <= 0 {
+		cooldown = DefaultVerificationResendCooldown
+	}
+	uc.cooldown = cooldown
+	return uc
+}
+
+// WithTransaction grava o token e o email de verificação atomicamente.
+func (uc *ResendVerificationUseCase) WithTransaction(runner TxRunner) *ResendVerificationUseCase {
+	uc.runInTx = runner
+	return uc
+}
+
+// Execute não revela se o email existe: emails desconhecidos, contas já
+// verificadas e pedidos dentro do cooldown retornam nil sem enviar nada.
+func (uc *ResendVerificationUseCase) Execute(ctx context.Context, req ResendVerificationRequest) error {
+	// 1. Buscar usuário pelo email
+	foundUser, err := uc.userRepo.GetByEmail(ctx, user.NormalizeEmail(req.Email))
+	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			return nil
+		}
+		return fmt.Errorf("usecase: resend verification failed: %w", err)
+	}
+
+	// 2. Conta já verificada não recebe outro link
+	if foundUser.IsVerified() {
+		return nil
+	}
+
+	// 3. Gerar token e email; dentro do cooldown nada é gravado
+	var verificationEmail *email.Email
+	err = uc.inTx(ctx, func(_ user.Repository, emailRepo email.Repository, verificationRepo token.EmailVerificationRepository, _ token.IdempotencyRepository) error {
+		verificationToken, tokenHash, err := generateOneTimeToken()
+		if err != nil {
+			return err
+		}
+
+		created, err := email.NewVerificationEmail(email.VerificationEmailData{
+			UserID:           foundUser.ID.String(),
+			UserName:         foundUser.Name,
+			UserEmail:        foundUser.Email,
+			VerificationLink: fmt.Sprintf("%s?token=%s", uc.verificationURL, verificationToken),
+		})
+		if err != nil {
+			return err
+		}
+
+		saved, err := emailRepo.CreateIfNotExists(ctx, created, uc.cooldown)
+		if err != nil || !saved {
+			return err
+		}
+
+		if err := verificationRepo.Create(ctx, tokenHash, foundUser.ID, time.Now().Add(uc.tokenDuration)); err != nil {
+			return err
+		}
+
+		verificationEmail = created
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("usecase: resend verification failed: %w", err)
+	}
+
+	// 4. Publicar após o commit (se falhar, o processamento de pendentes envia depois)
+	if verificationEmail != nil {
+		uc.publishVerificationEmail(ctx, foundUser, verificationEmail)
+	}
+
+	return nil
+}
+
+// inTx usa o TxRunner configurado ou, sem ele, os repositórios diretos.
+func (uc *ResendVerificationUseCase) inTx(ctx context.Context, fn func(user.Repository, email.Repository, token.EmailVerificationRepository, token.IdempotencyRepository) error) error {
+	if uc.runInTx == nil {
+		return fn(uc.userRepo, uc.emailRepo, uc.verificationRepo, nil)
+	}
+	return uc.runInTx(ctx, fn)
+}
+
+func (uc *ResendVerificationUseCase) publishVerificationEmail(ctx context.Context, user *user.User, verificationEmail *email.Email) {
+	if uc.rabbit == nil || !uc.rabbit.IsConnected() {
+		fmt.Println("Warning: RabbitMQ not available, skipping verification email event")
+		return
+	}
+
+	message := email.QueueMessage{
+		EmailID: verificationEmail.ID,
+		Type:    verificationEmail.Type,
+		Data: email.WelcomeEmailData{
+			UserID:    user.ID.String(),
+			UserName:  user.Name,
+			UserEmail: user.Email,
+		},
+		RequestID:    logging.RequestIDFromContext(ctx),
+		TraceContext: tracing.Inject(ctx),
+	}
+
+	err := uc.rabbit.PublishEmailMessage(message)
+	if err != nil {
+		fmt.Printf("Warning: failed to publish verification email: %v\n", err)
+		markUnroutableEmail(ctx, uc.emailRepo, verificationEmail, err)
+	}
+}
@@ -0,0 +1,121 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/moura95/backend-challenge/internal/domain/email"
+	"github.com/moura95/backend-challenge/internal/domain/user"
+)
+
+// resendUserRepository serves users by email.
+type resendUserRepository struct {
+	user.Repository
+	users map[string]*user.User
+}
+
+func (r *resendUserRepository) GetByEmail(ctx context.Context, address string) (*user.User, error) {
+	found, ok := r.users[address]
+	if !ok {
+		return nil, user.ErrUserNotFound
+	}
+	return found, nil
+}
+
+// resendEmailRepository applies the dedup window like CreateIfNotExists.
+type resendEmailRepository struct {
+	email.Repository
+	emails []*email.Email
+	now    time.Time
+}
+
+func (r *resendEmailRepository) CreateIfNotExists(ctx context.Context, e *email.Email, window time.Duration) (bool, error) {
+	if e.DedupKey == "" {
+		e.DedupKey = email.DedupKey(e.Type, e.To)
+	}
+	for _, existing := range r.emails {
+		if existing.DedupKey == e.DedupKey && r.now.Sub(existing.CreatedAt) < window {
+			return false, nil
+		}
+	}
+
+	e.CreatedAt = r.now
+	r.emails = append(r.emails, e)
+	return true, nil
+}
+
+func TestResendVerificationUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T) (*ResendVerificationUseCase, *resendEmailRepository, *memoryVerificationTokenRepository) {
+		unverified, err := user.NewUser("Pending User", "pending@example.com", "password123")
+		require.NoError(t, err)
+		verified, err := user.NewUser("Verified User", "verified@example.com", "password123")
+		require.NoError(t, err)
+		verified.MarkAsVerified()
+
+		userRepo := &resendUserRepository{users: map[string]*user.User{
+			unverified.Email: unverified,
+			verified.Email:   verified,
+		}}
+		emailRepo := &resendEmailRepository{now: time.Now()}
+		tokenRepo := newMemoryVerificationTokenRepository()
+
+		uc := NewResendVerificationUseCase(userRepo, emailRepo, tokenRepo, nil, "https://app.example.com/verify").
+			WithCooldown(time.Minute)
+		return uc, emailRepo, tokenRepo
+	}
+
+	t.Run("should queue a new verification email for an unverified account", func(t *testing.T) {
+		uc, emailRepo, tokenRepo := setup(t)
+
+		require.NoError(t, uc.Execute(ctx, ResendVerificationRequest{Email: "Pending@Example.com"}))
+
+		require.Len(t, emailRepo.emails, 1)
+		sent := emailRepo.emails[0]
+		assert.Equal(t, email.EmailTypeVerification, sent.Type)
+		assert.Equal(t, "pending@example.com", sent.To)
+		assert.Contains(t, sent.Body, "https://app.example.com/verify?token=")
+		assert.Len(t, tokenRepo.owners, 1)
+	})
+
+	t.Run("should send nothing to an already verified account", func(t *testing.T) {
+		uc, emailRepo, tokenRepo := setup(t)
+
+		require.NoError(t, uc.Execute(ctx, ResendVerificationRequest{Email: "verified@example.com"}))
+
+		assert.Empty(t, emailRepo.emails)
+		assert.Empty(t, tokenRepo.owners)
+	})
+
+	t.Run("should send nothing to an unknown email", func(t *testing.T) {
+		uc, emailRepo, tokenRepo := setup(t)
+
+		require.NoError(t, uc.Execute(ctx, ResendVerificationRequest{Email: "nobody@example.com"}))
+
+		assert.Empty(t, emailRepo.emails)
+		assert.Empty(t, tokenRepo.owners)
+	})
+
+	t.Run("should silently ignore resends within the cooldown", func(t *testing.T) {
+		uc, emailRepo, tokenRepo := setup(t)
+		req := ResendVerificationRequest{Email: "pending@example.com"}
+
+		require.NoError(t, uc.Execute(ctx, req))
+		require.NoError(t, uc.Execute(ctx, req))
+
+		assert.Len(t, emailRepo.emails, 1)
+		assert.Len(t, tokenRepo.owners, 1)
+
+		// Passado o cooldown, um novo link é enviado
+		emailRepo.now = emailRepo.now.Add(time.Minute)
+		require.NoError(t, uc.Execute(ctx, req))
+
+		assert.Len(t, emailRepo.emails, 2)
+		assert.Len(t, tokenRepo.owners, 2)
+	})
+}
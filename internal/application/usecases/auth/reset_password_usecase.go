@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/moura95/backend-challenge/internal/domain/token"
+	"github.com/moura95/backend-challenge/internal/domain/user"
+	"github.com/moura95/backend-challenge/internal/infra/security/crypto"
+)
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required,min=6"`
+}
+
+type ResetPasswordUseCase struct {
+	userRepo  user.Repository
+	resetRepo token.PasswordResetRepository
+}
+
+func NewResetPasswordUseCase(userRepo user.Repository, resetRepo token.PasswordResetRepository) *ResetPasswordUseCase {
+	return &ResetPasswordUseCase{
+		userRepo:  userRepo,
+		resetRepo: resetRepo,
+	}
+}
+
+func (uc *ResetPasswordUseCase) Execute(ctx context.Context, req ResetPasswordRequest) error {
+	// 1. Validar entrada antes de consumir o token
+	if strings.TrimSpace(req.Token) == "" {
+		return fmt.Errorf("usecase: reset password failed: reset token is required")
+	}
+
+	validator := user.NewUserValidator()
+	if err := validator.ValidatePassword(req.NewPassword); err != nil {
+		return fmt.Errorf("usecase: reset password failed: %w", err)
+	}
+
+	// 2. Consumir token (uso único)
+	userID, err := uc.resetRepo.Consume(ctx, crypto.HashSHA256(req.Token))
+	if err != nil {
+		if strings.Contains(err.Error(), "token not found") {
+			return fmt.Errorf("usecase: reset password failed: invalid or expired reset token")
+		}
+		return fmt.Errorf("usecase: reset password failed: %w", err)
+	}
+
+	// 3. Buscar usuário
+	foundUser, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("usecase: reset password failed: %w", err)
+	}
+
+	// 4. Atualizar senha
+	if err := foundUser.ChangePassword(req.NewPassword); err != nil {
+		return fmt.Errorf("usecase: reset password failed: %w", err)
+	}
+
+	err = uc.userRepo.UpdatePassword(ctx, foundUser)
+	if err != nil {
+		return fmt.Errorf("usecase: reset password failed: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/moura95/backend-challenge/internal/domain/user"
+	"github.com/moura95/backend-challenge/internal/infra/security/jwt"
+)
+
+// lastLoginUserRepository serves a single user and stores last login updates
+// like the users table, ignoring times older than the stored one.
+type lastLoginUserRepository struct {
+	user.Repository
+	mu        sync.Mutex
+	user      *user.User
+	updateErr error
+	updates   int
+}
+
+func (r *lastLoginUserRepository) GetByEmail(ctx context.Context, email string) (*user.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if email != r.user.Email {
+		return nil, user.ErrUserNotFound
+	}
+	stored := *r.user
+	return &stored, nil
+}
+
+func (r *lastLoginUserRepository) UpdateLastLogin(ctx context.Context, id uuid.UUID, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.updates++
+	if r.updateErr != nil {
+		return r.updateErr
+	}
+	if id != r.user.ID {
+		return user.ErrUserNotFound
+	}
+	if r.user.LastLoginAt == nil || r.user.LastLoginAt.Before(at) {
+		r.user.LastLoginAt = &at
+	}
+	return nil
+}
+
+func (r *lastLoginUserRepository) stored() (*time.Time, int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.user.LastLoginAt, r.updates
+}
+
+func TestSignInUseCase_LastLogin(t *testing.T) {
+	tokenMaker, err := jwt.NewPasetoMaker("12345678901234567890123456789012")
+	require.NoError(t, err)
+
+	credentials := SignInRequest{Email: "last-login@example.com", Password: "password123"}
+	newRepo := func(t *testing.T) *lastLoginUserRepository {
+		existing, err := user.NewUser("John Doe", credentials.Email, credentials.Password)
+		require.NoError(t, err)
+		return &lastLoginUserRepository{user: existing}
+	}
+
+	t.Run("sign in populates last_login_at and a second sign in advances it", func(t *testing.T) {
+		repo := newRepo(t)
+		useCase := NewSignInUseCase(repo, tokenMaker)
+
+		stored, _ := repo.stored()
+		require.Nil(t, stored)
+
+		first, err := useCase.Execute(context.Background(), credentials)
+		require.NoError(t, err)
+		require.NotNil(t, first.User.LastLoginAt)
+		assert.Equal(t, first.User.LastLoginAt, first.User.ToResponse().LastLoginAt)
+
+		// A gravação é assíncrona
+		assert.Eventually(t, func() bool {
+			stored, _ := repo.stored()
+			return stored != nil && stored.Equal(*first.User.LastLoginAt)
+		}, time.Second, 5*time.Millisecond)
+
+		time.Sleep(time.Millisecond)
+
+		second, err := useCase.Execute(context.Background(), credentials)
+		require.NoError(t, err)
+		require.NotNil(t, second.User.LastLoginAt)
+		assert.True(t, second.User.LastLoginAt.After(*first.User.LastLoginAt))
+
+		assert.Eventually(t, func() bool {
+			stored, _ := repo.stored()
+			return stored != nil && stored.Equal(*second.User.LastLoginAt)
+		}, time.Second, 5*time.Millisecond)
+	})
+
+	t.Run("failed sign in leaves last_login_at untouched", func(t *testing.T) {
+		repo := newRepo(t)
+
+		_, err := NewSignInUseCase(repo, tokenMaker).Execute(context.Background(), SignInRequest{
+			Email:    credentials.Email,
+			Password: "wrong-password",
+		})
+		require.ErrorIs(t, err, user.ErrInvalidCredentials)
+
+		stored, updates := repo.stored()
+		assert.Nil(t, stored)
+		assert.Zero(t, updates)
+	})
+
+	t.Run("failing update does not fail the sign in", func(t *testing.T) {
+		repo := newRepo(t)
+		repo.updateErr = errors.New("database unavailable")
+
+		response, err := NewSignInUseCase(repo, tokenMaker).Execute(context.Background(), credentials)
+		require.NoError(t, err)
+		assert.NotEmpty(t, response.Token)
+
+		assert.Eventually(t, func() bool {
+			_, updates := repo.stored()
+			return updates == 1
+		}, time.Second, 5*time.Millisecond)
+	})
+}
@@ -0,0 +1,71 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/moura95/backend-challenge/internal/domain/user"
+	"github.com/moura95/backend-challenge/internal/infra/security/crypto"
+	"github.com/moura95/backend-challenge/internal/infra/security/jwt"
+)
+
+func TestSignInUseCase_PasswordRehash(t *testing.T) {
+	defer crypto.SetBcryptCost(crypto.DefaultBcryptCost)
+
+	tokenMaker, err := jwt.NewPasetoMaker("12345678901234567890123456789012")
+	require.NoError(t, err)
+
+	storedCost := func(t *testing.T, repo *memoryUserRepository, address string) int {
+		cost, err := bcrypt.Cost([]byte(repo.find(address).Password))
+		require.NoError(t, err)
+		return cost
+	}
+
+	t.Run("upgrades a hash below the configured cost", func(t *testing.T) {
+		require.NoError(t, crypto.SetBcryptCost(bcrypt.MinCost))
+		existing, err := user.NewUser("John Doe", "rehash@example.com", "password123")
+		require.NoError(t, err)
+		repo := newMemoryUserRepository(existing)
+		require.Equal(t, bcrypt.MinCost, storedCost(t, repo, existing.Email))
+
+		require.NoError(t, crypto.SetBcryptCost(bcrypt.MinCost+2))
+		_, err = NewSignInUseCase(repo, tokenMaker).Execute(context.Background(), SignInRequest{
+			Email:    "rehash@example.com",
+			Password: "password123",
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, repo.passwordUpdates)
+		assert.Equal(t, bcrypt.MinCost+2, storedCost(t, repo, existing.Email))
+		assert.NoError(t, crypto.CheckPassword("password123", repo.find(existing.Email).Password))
+
+		// Já no custo atual, o próximo login não reescreve o hash
+		_, err = NewSignInUseCase(repo, tokenMaker).Execute(context.Background(), SignInRequest{
+			Email:    "rehash@example.com",
+			Password: "password123",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, repo.passwordUpdates)
+	})
+
+	t.Run("leaves the hash alone on a wrong password", func(t *testing.T) {
+		require.NoError(t, crypto.SetBcryptCost(bcrypt.MinCost))
+		existing, err := user.NewUser("John Doe", "wrong@example.com", "password123")
+		require.NoError(t, err)
+		repo := newMemoryUserRepository(existing)
+
+		require.NoError(t, crypto.SetBcryptCost(bcrypt.MinCost+2))
+		_, err = NewSignInUseCase(repo, tokenMaker).Execute(context.Background(), SignInRequest{
+			Email:    "wrong@example.com",
+			Password: "wrongpassword",
+		})
+		assert.ErrorIs(t, err, user.ErrInvalidCredentials)
+
+		assert.Equal(t, 0, repo.passwordUpdates)
+		assert.Equal(t, bcrypt.MinCost, storedCost(t, repo, existing.Email))
+	})
+}
@@ -6,32 +6,99 @@ import (
 	"strings"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/moura95/backend-challenge/internal/domain/token"
 	"github.com/moura95/backend-challenge/internal/domain/user"
 	"github.com/moura95/backend-challenge/internal/infra/security/jwt"
+	"github.com/moura95/backend-challenge/internal/infra/security/ratelimit"
 )
 
+// lastLoginWriteTimeout limita a gravação do último login, que roda depois
+// da resposta e não herda o prazo da requisição.
+const lastLoginWriteTimeout = 5 * time.Second
+
 type SignInRequest struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
+	// Issues the access token with the longer remember-me lifetime
+	RememberMe bool `json:"remember_me"`
+	// Client metadata recorded by the login audit, filled in by the handler
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
 }
 
 type SignInResponse struct {
-	User  *user.User `json:"user"`
-	Token string     `json:"token"`
+	User         *user.User `json:"user"`
+	Token        string     `json:"token"`
+	RefreshToken string     `json:"refresh_token"`
+	// Expiração do access token, usada para o Max-Age do cookie
+	ExpiresAt time.Time `json:"expires_at"`
 }
 
 type SignInUseCase struct {
-	userRepo      user.Repository
-	tokenMaker    jwt.Maker
-	tokenDuration time.Duration
+	userRepo             user.Repository
+	tokenMaker           jwt.Maker
+	tokenDuration        time.Duration
+	rememberMeDuration   time.Duration
+	refreshTokenDuration time.Duration
+	requireVerifiedEmail bool
+	loginLimiter         ratelimit.LoginLimiter
+	loginEvents          user.LoginEventRepository
+	sessions             token.SessionRepository
 }
 
 func NewSignInUseCase(userRepo user.Repository, tokenMaker jwt.Maker) *SignInUseCase {
 	return &SignInUseCase{
-		userRepo:      userRepo,
-		tokenMaker:    tokenMaker,
-		tokenDuration: 24 * time.Hour, // 24 hours
+		userRepo:             userRepo,
+		tokenMaker:           tokenMaker,
+		tokenDuration:        24 * time.Hour,      // 24 hours
+		rememberMeDuration:   30 * 24 * time.Hour, // 30 days
+		refreshTokenDuration: 7 * 24 * time.Hour,  // 7 days
+	}
+}
+
+// RequireEmailVerification faz o login recusar usuários que ainda não
+// confirmaram o email.
+func (uc *SignInUseCase) RequireEmailVerification(required bool) *SignInUseCase {
+	uc.requireVerifiedEmail = required
+	return uc
+}
+
+// WithLoginLimiter bloqueia o login por email após falhas consecutivas.
+func (uc *SignInUseCase) WithLoginLimiter(limiter ratelimit.LoginLimiter) *SignInUseCase {
+	uc.loginLimiter = limiter
+	return uc
+}
+
+// WithLoginAudit grava cada tentativa de login, com sucesso ou não.
+func (uc *SignInUseCase) WithLoginAudit(repo user.LoginEventRepository) *SignInUseCase {
+	uc.loginEvents = repo
+	return uc
+}
+
+// WithSessions registra cada login como uma sessão que o usuário pode listar
+// e revogar.
+func (uc *SignInUseCase) WithSessions(repo token.SessionRepository) *SignInUseCase {
+	uc.sessions = repo
+	return uc
+}
+
+// WithTokenDuration define a validade do access token emitido.
+// Valores não positivos mantêm o padrão.
+func (uc *SignInUseCase) WithTokenDuration(duration time.Duration) *SignInUseCase {
+	if duration > 0 {
+		uc.tokenDuration = duration
 	}
+	return uc
+}
+
+// WithRememberMeTokenDuration define a validade do access token emitido
+// quando o login pede "lembrar de mim". Valores não positivos mantêm o padrão.
+func (uc *SignInUseCase) WithRememberMeTokenDuration(duration time.Duration) *SignInUseCase {
+	if duration > 0 {
+		uc.rememberMeDuration = duration
+	}
+	return uc
 }
 
 func (uc *SignInUseCase) Execute(ctx context.Context, req SignInRequest) (*SignInResponse, error) {
@@ -40,31 +107,201 @@ func (uc *SignInUseCase) Execute(ctx context.Context, req SignInRequest) (*SignI
 		return nil, fmt.Errorf("usecase: signin failed: %w", err)
 	}
 
-	// 2. Buscar usuário por email
+	// 2. Verificar bloqueio por tentativas
+	req.Email = user.NormalizeEmail(req.Email)
+	limiterKey := req.Email
+	if uc.loginLimiter != nil {
+		locked, _, err := uc.loginLimiter.IsLocked(ctx, limiterKey)
+		if err != nil {
+			return nil, fmt.Errorf("usecase: signin failed: %w", err)
+		}
+		if locked {
+			if err := uc.recordLogin(ctx, req, nil, false); err != nil {
+				return nil, err
+			}
+			return nil, fmt.Errorf("usecase: signin failed: %w", user.ErrTooManyAttempts)
+		}
+	}
+
+	// 3. Buscar usuário por email
 	foundUser, err := uc.userRepo.GetByEmail(ctx, req.Email)
 	if err != nil {
-		return nil, fmt.Errorf("usecase: signin failed: invalid credentials")
+		return nil, uc.invalidCredentials(ctx, req, nil)
 	}
 
 	err = foundUser.CheckPassword(req.Password)
 	if err != nil {
-		return nil, fmt.Errorf("usecase: signin failed: invalid credentials")
+		return nil, uc.invalidCredentials(ctx, req, &foundUser.ID)
+	}
+
+	if uc.loginLimiter != nil {
+		if err := uc.loginLimiter.Reset(ctx, limiterKey); err != nil {
+			return nil, fmt.Errorf("usecase: signin failed: %w", err)
+		}
+	}
+
+	// Hash gerado com custo menor que o configurado: aproveitar a senha em
+	// texto puro para atualizá-lo (falha aqui não impede o login)
+	uc.rehashPassword(ctx, foundUser, req.Password)
+
+	// 4. Contas suspensas não entram, mesmo com a senha correta
+	if foundUser.IsSuspended() {
+		if err := uc.recordLogin(ctx, req, &foundUser.ID, false); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("usecase: signin failed: %w", user.ErrAccountSuspended)
+	}
+
+	// 5. Verificar se o email foi confirmado (quando exigido)
+	if uc.requireVerifiedEmail && !foundUser.IsVerified() {
+		if err := uc.recordLogin(ctx, req, &foundUser.ID, false); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("usecase: signin failed: %w", user.ErrEmailNotVerified)
+	}
+
+	// 6. Gerar token de autenticação
+	tokenDuration := uc.tokenDuration
+	if req.RememberMe {
+		tokenDuration = uc.rememberMeDuration
+	}
+	token, payload, err := uc.tokenMaker.CreateToken(foundUser.ID, foundUser.TokenVersion, tokenDuration)
+	if err != nil {
+		return nil, fmt.Errorf("usecase: signin failed: token generation error: %w", err)
 	}
 
-	// 4. Gerar token de autenticação
-	token, _, err := uc.tokenMaker.CreateToken(foundUser.ID, uc.tokenDuration)
+	// 7. Gerar refresh token, ligado à sessão (o UUID do access token)
+	sessionID, err := uuid.Parse(payload.UUID)
 	if err != nil {
 		return nil, fmt.Errorf("usecase: signin failed: token generation error: %w", err)
 	}
+	refreshToken, refreshPayload, err := uc.tokenMaker.CreateRefreshToken(foundUser.ID, foundUser.TokenVersion, uc.refreshTokenDuration, jwt.WithSessionID(sessionID))
+	if err != nil {
+		return nil, fmt.Errorf("usecase: signin failed: refresh token generation error: %w", err)
+	}
+
+	// 8. Registrar a sessão
+	if err := uc.recordSession(ctx, req, foundUser.ID, sessionID, refreshPayload, payload); err != nil {
+		return nil, err
+	}
+
+	// 9. Registrar o login na auditoria
+	if err := uc.recordLogin(ctx, req, &foundUser.ID, true); err != nil {
+		return nil, err
+	}
+
+	// 10. Registrar o último login sem atrasar a resposta
+	uc.recordLastLogin(ctx, foundUser)
 
 	response := &SignInResponse{
-		User:  foundUser,
-		Token: token,
+		User:         foundUser,
+		Token:        token,
+		RefreshToken: refreshToken,
+		ExpiresAt:    payload.ExpiredAt,
 	}
 
 	return response, nil
 }
 
+// recordSession grava a sessão quando habilitado. Ela dura enquanto algum dos
+// tokens emitidos no login for válido.
+func (uc *SignInUseCase) recordSession(ctx context.Context, req SignInRequest, userID, sessionID uuid.UUID, refreshPayload, accessPayload jwt.Payload) error {
+	if uc.sessions == nil {
+		return nil
+	}
+
+	refreshTokenID, err := uuid.Parse(refreshPayload.UUID)
+	if err != nil {
+		return fmt.Errorf("usecase: signin failed: refresh token generation error: %w", err)
+	}
+
+	expiresAt := refreshPayload.ExpiredAt
+	if accessPayload.ExpiredAt.After(expiresAt) {
+		expiresAt = accessPayload.ExpiredAt
+	}
+
+	session := &token.Session{
+		ID:             sessionID,
+		UserID:         userID,
+		RefreshTokenID: refreshTokenID,
+		UserAgent:      req.UserAgent,
+		IPAddress:      req.IPAddress,
+		ExpiresAt:      expiresAt,
+	}
+	if err := uc.sessions.Create(ctx, session); err != nil {
+		return fmt.Errorf("usecase: signin failed: %w", err)
+	}
+
+	return nil
+}
+
+// recordLastLogin marca o login no usuário retornado e grava no banco em
+// segundo plano; uma falha só é logada, pois o login já foi concedido.
+func (uc *SignInUseCase) recordLastLogin(ctx context.Context, foundUser *user.User) {
+	now := time.Now()
+	foundUser.LastLoginAt = &now
+
+	userID := foundUser.ID
+	go func() {
+		writeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), lastLoginWriteTimeout)
+		defer cancel()
+
+		if err := uc.userRepo.UpdateLastLogin(writeCtx, userID, now); err != nil {
+			fmt.Printf("Warning: failed to record last login for user %s: %v\n", userID, err)
+		}
+	}()
+}
+
+func (uc *SignInUseCase) rehashPassword(ctx context.Context, foundUser *user.User, password string) {
+	if !foundUser.PasswordNeedsRehash() {
+		return
+	}
+
+	previous := foundUser.Password
+	if err := foundUser.RehashPassword(password); err != nil {
+		fmt.Printf("Warning: failed to rehash password for user %s: %v\n", foundUser.ID, err)
+		return
+	}
+	if err := uc.userRepo.UpdatePassword(ctx, foundUser); err != nil {
+		foundUser.Password = previous
+		fmt.Printf("Warning: failed to store rehashed password for user %s: %v\n", foundUser.ID, err)
+	}
+}
+
+func (uc *SignInUseCase) invalidCredentials(ctx context.Context, req SignInRequest, userID *uuid.UUID) error {
+	if uc.loginLimiter != nil {
+		if err := uc.loginLimiter.RegisterFailure(ctx, req.Email); err != nil {
+			return fmt.Errorf("usecase: signin failed: %w", err)
+		}
+	}
+	if err := uc.recordLogin(ctx, req, userID, false); err != nil {
+		return err
+	}
+
+	return fmt.Errorf("usecase: signin failed: %w", user.ErrInvalidCredentials)
+}
+
+// recordLogin grava a tentativa quando a auditoria está habilitada. userID
+// fica nil quando o email não corresponde a nenhuma conta.
+func (uc *SignInUseCase) recordLogin(ctx context.Context, req SignInRequest, userID *uuid.UUID, success bool) error {
+	if uc.loginEvents == nil {
+		return nil
+	}
+
+	event := &user.LoginEvent{
+		UserID:    userID,
+		Email:     req.Email,
+		IPAddress: req.IPAddress,
+		UserAgent: req.UserAgent,
+		Success:   success,
+	}
+	if err := uc.loginEvents.Create(ctx, event); err != nil {
+		return fmt.Errorf("usecase: signin failed: %w", err)
+	}
+
+	return nil
+}
+
 func (uc *SignInUseCase) validateSignInRequest(req SignInRequest) error {
 	if strings.TrimSpace(req.Email) == "" {
 		return fmt.Errorf("email is required")
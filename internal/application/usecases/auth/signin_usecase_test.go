@@ -16,6 +16,7 @@ import (
 	"github.com/moura95/backend-challenge/internal/domain/user"
 	"github.com/moura95/backend-challenge/internal/infra/repository/adapters"
 	"github.com/moura95/backend-challenge/internal/infra/security/jwt"
+	"github.com/moura95/backend-challenge/internal/infra/security/ratelimit"
 )
 
 type signInTestServer struct {
@@ -77,11 +78,31 @@ func runSignInMigrations(db *sqlx.DB) error {
 	CREATE TABLE IF NOT EXISTS users (
 		uuid         UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
 		name         VARCHAR(255) NOT NULL,
-		email        VARCHAR(100) NOT NULL UNIQUE,
+		email        VARCHAR(100) NOT NULL,
 		password     TEXT NOT NULL,
+		verified_at  TIMESTAMPTZ,
+		role         VARCHAR(20) NOT NULL DEFAULT 'user',
+		deleted_at   TIMESTAMPTZ,
+		version      INTEGER NOT NULL DEFAULT 1,
+		status       VARCHAR(20) NOT NULL DEFAULT 'active',
+		token_version INTEGER NOT NULL DEFAULT 0,
+		last_login_at TIMESTAMPTZ,
 		created_at   TIMESTAMP NOT NULL DEFAULT NOW(),
 		updated_at   TIMESTAMP NOT NULL DEFAULT NOW()
 	);
+	CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email_lower ON users (LOWER(email));
+	
+	-- Login events table
+	CREATE TABLE IF NOT EXISTS login_events (
+		id          BIGSERIAL PRIMARY KEY,
+		user_uuid   UUID,
+		email       VARCHAR(255) NOT NULL,
+		ip_address  VARCHAR(64) NOT NULL DEFAULT '',
+		user_agent  TEXT NOT NULL DEFAULT '',
+		success     BOOLEAN NOT NULL,
+		created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		FOREIGN KEY (user_uuid) REFERENCES users(uuid) ON DELETE CASCADE
+	);
 	
 	-- Indexes
 	CREATE INDEX IF NOT EXISTS idx_users_email ON users(email);
@@ -144,6 +165,100 @@ func TestSignInUseCase_Execute(t *testing.T) {
 		payload, err := tokenMaker.VerifyToken(result.Token)
 		require.NoError(t, err)
 		assert.Equal(t, testUser.ID.String(), payload.UserUUID)
+
+		// Verify refresh token is issued with refresh type
+		assert.NotEmpty(t, result.RefreshToken)
+		refreshPayload, err := tokenMaker.VerifyToken(result.RefreshToken)
+		require.NoError(t, err)
+		assert.Equal(t, testUser.ID.String(), refreshPayload.UserUUID)
+		assert.Equal(t, jwt.TokenTypeRefresh, refreshPayload.TokenType)
+		assert.True(t, refreshPayload.ExpiredAt.After(payload.ExpiredAt))
+	})
+
+	t.Run("should record last login and advance it on the next sign in", func(t *testing.T) {
+		testUser := createTestUser(t, server, "lastlogin@example.com", "password123", "Last Login")
+		useCase := NewSignInUseCase(server.repos.User, tokenMaker)
+		req := SignInRequest{Email: "lastlogin@example.com", Password: "password123"}
+
+		first, err := useCase.Execute(ctx, req)
+		require.NoError(t, err)
+		require.NotNil(t, first.User.LastLoginAt)
+
+		// A gravação roda em segundo plano
+		var stored *time.Time
+		require.Eventually(t, func() bool {
+			foundUser, err := server.repos.User.GetByID(ctx, testUser.ID)
+			if err != nil || foundUser.LastLoginAt == nil {
+				return false
+			}
+			stored = foundUser.LastLoginAt
+			return true
+		}, 5*time.Second, 20*time.Millisecond)
+		assert.WithinDuration(t, *first.User.LastLoginAt, *stored, time.Millisecond)
+
+		second, err := useCase.Execute(ctx, req)
+		require.NoError(t, err)
+		require.Eventually(t, func() bool {
+			foundUser, err := server.repos.User.GetByID(ctx, testUser.ID)
+			return err == nil && foundUser.LastLoginAt != nil && foundUser.LastLoginAt.After(*stored)
+		}, 5*time.Second, 20*time.Millisecond)
+		assert.True(t, second.User.LastLoginAt.After(*first.User.LastLoginAt))
+	})
+
+	t.Run("should issue access token with configured duration", func(t *testing.T) {
+		// Create test user in database
+		createTestUser(t, server, "shortttl@example.com", "password123", "Short TTL")
+
+		// Create use case with a short access token duration
+		useCase := NewSignInUseCase(server.repos.User, tokenMaker).
+			WithTokenDuration(5 * time.Minute)
+
+		// Execute
+		before := time.Now()
+		result, err := useCase.Execute(ctx, SignInRequest{
+			Email:    "shortttl@example.com",
+			Password: "password123",
+		})
+
+		// Assert
+		require.NoError(t, err)
+		payload, err := tokenMaker.VerifyToken(result.Token)
+		require.NoError(t, err)
+		assert.WithinDuration(t, before.Add(5*time.Minute), payload.ExpiredAt, 5*time.Second)
+	})
+
+	t.Run("should extend access token when remember me is set", func(t *testing.T) {
+		// Create test user in database
+		createTestUser(t, server, "rememberme@example.com", "password123", "Remember Me")
+
+		useCase := NewSignInUseCase(server.repos.User, tokenMaker).
+			WithTokenDuration(time.Hour).
+			WithRememberMeTokenDuration(14 * 24 * time.Hour)
+
+		// Execute without and with the flag
+		before := time.Now()
+		regular, err := useCase.Execute(ctx, SignInRequest{
+			Email:    "rememberme@example.com",
+			Password: "password123",
+		})
+		require.NoError(t, err)
+		remembered, err := useCase.Execute(ctx, SignInRequest{
+			Email:      "rememberme@example.com",
+			Password:   "password123",
+			RememberMe: true,
+		})
+		require.NoError(t, err)
+
+		// Assert
+		regularPayload, err := tokenMaker.VerifyToken(regular.Token)
+		require.NoError(t, err)
+		assert.WithinDuration(t, before.Add(time.Hour), regularPayload.ExpiredAt, 5*time.Second)
+
+		rememberedPayload, err := tokenMaker.VerifyToken(remembered.Token)
+		require.NoError(t, err)
+		assert.Equal(t, jwt.TokenTypeAccess, rememberedPayload.TokenType)
+		assert.WithinDuration(t, before.Add(14*24*time.Hour), rememberedPayload.ExpiredAt, 5*time.Second)
+		assert.True(t, rememberedPayload.ExpiredAt.After(regularPayload.ExpiredAt))
 	})
 
 	t.Run("should fail with invalid email", func(t *testing.T) {
@@ -292,20 +407,24 @@ func TestSignInUseCase_Execute(t *testing.T) {
 		// Create use case
 		useCase := NewSignInUseCase(server.repos.User, tokenMaker)
 
-		// Test data with same email but different case
-		req := SignInRequest{
-			Email:    "Mixed@Example.Com", // Exact same as stored
-			Password: "password123",
-		}
+		// Stored lowercased; any casing of the address signs in
+		assert.Equal(t, "mixed@example.com", testUser.Email)
 
-		// Execute
-		result, err := useCase.Execute(ctx, req)
+		for _, email := range []string{"Mixed@Example.Com", "mixed@example.com", "  MIXED@EXAMPLE.COM "} {
+			req := SignInRequest{
+				Email:    email,
+				Password: "password123",
+			}
 
-		// Assert
-		require.NoError(t, err)
-		assert.NotNil(t, result)
-		assert.Equal(t, testUser.ID, result.User.ID)
-		assert.NotEmpty(t, result.Token)
+			// Execute
+			result, err := useCase.Execute(ctx, req)
+
+			// Assert
+			require.NoError(t, err, email)
+			assert.NotNil(t, result)
+			assert.Equal(t, testUser.ID, result.User.ID)
+			assert.NotEmpty(t, result.Token)
+		}
 	})
 
 	t.Run("should generate different tokens for multiple sign-ins", func(t *testing.T) {
@@ -358,3 +477,161 @@ func TestSignInUseCase_Execute(t *testing.T) {
 		assert.NotEmpty(t, result.Token)
 	})
 }
+
+func TestSignInUseCase_LoginLockout(t *testing.T) {
+	server := setupSignInTest(t)
+	defer server.cleanup()
+
+	ctx := context.Background()
+
+	tokenMaker, err := jwt.NewPasetoMaker("12345678901234567890123456789012")
+	require.NoError(t, err)
+
+	t.Run("should block sixth attempt after five bad passwords until window elapses", func(t *testing.T) {
+		testUser := createTestUser(t, server, "lockout@example.com", "password123", "Lockout User")
+
+		window := 500 * time.Millisecond
+		useCase := NewSignInUseCase(server.repos.User, tokenMaker).
+			WithLoginLimiter(ratelimit.NewMemoryLoginLimiter(5, window))
+
+		// 5 bad passwords
+		for i := 0; i < 5; i++ {
+			result, err := useCase.Execute(ctx, SignInRequest{Email: "lockout@example.com", Password: "wrongpassword"})
+			assert.Nil(t, result)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "invalid credentials")
+		}
+
+		// 6th attempt is blocked even with the correct password
+		result, err := useCase.Execute(ctx, SignInRequest{Email: "lockout@example.com", Password: "password123"})
+		assert.Nil(t, result)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "too many attempts")
+
+		// After the window the correct password works again
+		time.Sleep(window + 50*time.Millisecond)
+
+		result, err = useCase.Execute(ctx, SignInRequest{Email: "lockout@example.com", Password: "password123"})
+		require.NoError(t, err)
+		assert.Equal(t, testUser.ID, result.User.ID)
+	})
+
+	t.Run("should reset counter after successful sign in", func(t *testing.T) {
+		createTestUser(t, server, "reset-counter@example.com", "password123", "Reset Counter")
+
+		useCase := NewSignInUseCase(server.repos.User, tokenMaker).
+			WithLoginLimiter(ratelimit.NewMemoryLoginLimiter(3, time.Minute))
+
+		for i := 0; i < 2; i++ {
+			_, err := useCase.Execute(ctx, SignInRequest{Email: "reset-counter@example.com", Password: "wrongpassword"})
+			require.Error(t, err)
+		}
+
+		_, err := useCase.Execute(ctx, SignInRequest{Email: "reset-counter@example.com", Password: "password123"})
+		require.NoError(t, err)
+
+		for i := 0; i < 2; i++ {
+			_, err := useCase.Execute(ctx, SignInRequest{Email: "reset-counter@example.com", Password: "wrongpassword"})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "invalid credentials")
+		}
+
+		_, err = useCase.Execute(ctx, SignInRequest{Email: "reset-counter@example.com", Password: "password123"})
+		require.NoError(t, err)
+	})
+
+	t.Run("should count failures for unknown emails", func(t *testing.T) {
+		useCase := NewSignInUseCase(server.repos.User, tokenMaker).
+			WithLoginLimiter(ratelimit.NewMemoryLoginLimiter(2, time.Minute))
+
+		for i := 0; i < 2; i++ {
+			_, err := useCase.Execute(ctx, SignInRequest{Email: "ghost@example.com", Password: "password123"})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "invalid credentials")
+		}
+
+		_, err := useCase.Execute(ctx, SignInRequest{Email: "GHOST@example.com", Password: "password123"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "too many attempts")
+	})
+}
+
+func TestSignInUseCase_LoginAudit(t *testing.T) {
+	server := setupSignInTest(t)
+	defer server.cleanup()
+
+	ctx := context.Background()
+
+	tokenMaker, err := jwt.NewPasetoMaker("12345678901234567890123456789012")
+	require.NoError(t, err)
+
+	useCase := NewSignInUseCase(server.repos.User, tokenMaker).
+		WithLoginAudit(server.repos.LoginEvent)
+
+	t.Run("should record failed and successful attempts", func(t *testing.T) {
+		testUser := createTestUser(t, server, "audit@example.com", "password123", "Audit User")
+
+		_, err := useCase.Execute(ctx, SignInRequest{
+			Email:     "audit@example.com",
+			Password:  "wrongpassword",
+			IPAddress: "203.0.113.7",
+			UserAgent: "audit-test/1.0",
+		})
+		require.Error(t, err)
+
+		_, err = useCase.Execute(ctx, SignInRequest{
+			Email:     "audit@example.com",
+			Password:  "password123",
+			IPAddress: "198.51.100.2",
+			UserAgent: "audit-test/2.0",
+		})
+		require.NoError(t, err)
+
+		events, err := server.repos.LoginEvent.ListByUser(ctx, testUser.ID, 10)
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+
+		// Most recent first
+		assert.True(t, events[0].Success)
+		assert.Equal(t, "198.51.100.2", events[0].IPAddress)
+		assert.Equal(t, "audit-test/2.0", events[0].UserAgent)
+
+		assert.False(t, events[1].Success)
+		assert.Equal(t, "203.0.113.7", events[1].IPAddress)
+		assert.Equal(t, "audit-test/1.0", events[1].UserAgent)
+
+		for _, event := range events {
+			require.NotNil(t, event.UserID)
+			assert.Equal(t, testUser.ID, *event.UserID)
+			assert.Equal(t, "audit@example.com", event.Email)
+			assert.False(t, event.CreatedAt.IsZero())
+		}
+	})
+
+	t.Run("should record unknown emails without a user", func(t *testing.T) {
+		_, err := useCase.Execute(ctx, SignInRequest{Email: "Nobody@Example.com", Password: "password123"})
+		require.Error(t, err)
+
+		var rows []struct {
+			UserUUID *string `db:"user_uuid"`
+			Success  bool    `db:"success"`
+		}
+		err = server.db.Select(&rows, "SELECT user_uuid, success FROM login_events WHERE email = $1", "nobody@example.com")
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+		assert.Nil(t, rows[0].UserUUID)
+		assert.False(t, rows[0].Success)
+	})
+
+	t.Run("should not record anything without audit", func(t *testing.T) {
+		createTestUser(t, server, "noaudit@example.com", "password123", "No Audit")
+
+		_, err := NewSignInUseCase(server.repos.User, tokenMaker).
+			Execute(ctx, SignInRequest{Email: "noaudit@example.com", Password: "password123"})
+		require.NoError(t, err)
+
+		var count int
+		require.NoError(t, server.db.Get(&count, "SELECT COUNT(*) FROM login_events WHERE email = $1", "noaudit@example.com"))
+		assert.Equal(t, 0, count)
+	})
+}
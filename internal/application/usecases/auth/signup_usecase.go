@@ -2,19 +2,33 @@ package auth
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/moura95/backend-challenge/internal/domain/email"
+	"github.com/moura95/backend-challenge/internal/domain/token"
 	"github.com/moura95/backend-challenge/internal/domain/user"
+	"github.com/moura95/backend-challenge/internal/infra/logging"
 	"github.com/moura95/backend-challenge/internal/infra/messaging/rabbitmq"
 	"github.com/moura95/backend-challenge/internal/infra/security/jwt"
+	"github.com/moura95/backend-challenge/internal/infra/tracing"
 )
 
+// ErrSignupsDisabled is returned while new signups are turned off
+// (SIGNUP_DISABLED), e.g. during an incident.
+var ErrSignupsDisabled = errors.New("signups temporarily disabled")
+
+// DefaultIdempotencyKeyTTL é usado quando IDEMPOTENCY_KEY_TTL não é definido
+const DefaultIdempotencyKeyTTL = 24 * time.Hour
+
 type SignUpRequest struct {
 	Name     string `json:"name" binding:"required,min=2,max=100"`
 	Email    string `json:"email" binding:"required,email"`
 	Password string `json:"password" binding:"required,min=6"`
+
+	// Vem do header Idempotency-Key: repetir a chave devolve o mesmo usuário
+	IdempotencyKey string `json:"-"`
 }
 
 type SignUpResponse struct {
@@ -22,12 +36,38 @@ type SignUpResponse struct {
 	Token string     `json:"token"`
 }
 
+// TxRunner executa fn numa única transação, entregando repositórios ligados
+// a ela. A transação é confirmada somente se fn retornar nil.
+type TxRunner func(ctx context.Context, fn func(userRepo user.Repository, emailRepo email.Repository, verificationRepo token.EmailVerificationRepository, idempotencyRepo token.IdempotencyRepository) error) error
+
 type SignUpUseCase struct {
 	userRepo      user.Repository
 	emailRepo     email.Repository
 	tokenMaker    jwt.Maker
 	rabbit        *rabbitmq.Connection
 	tokenDuration time.Duration
+
+	// Quando configurado, o cadastro envia um email de verificação
+	// no lugar do email de boas-vindas.
+	verificationRepo     token.EmailVerificationRepository
+	verificationURL      string
+	verificationDuration time.Duration
+
+	// Quando configurado, usuário e email são gravados na mesma transação
+	runInTx TxRunner
+
+	// Quando configurado, a chave de idempotência é gravada com o usuário
+	idempotencyRepo token.IdempotencyRepository
+	idempotencyTTL  time.Duration
+
+	// Template do email de boas-vindas; nil usa o padrão
+	welcomeTemplate *email.WelcomeTemplate
+
+	// Quando true, o cadastro não cria nem publica o email de boas-vindas
+	welcomeEmailDisabled bool
+
+	// Modo manutenção: todo cadastro é recusado antes de acessar o banco
+	signupsDisabled bool
 }
 
 func NewSignUpUseCase(
@@ -45,7 +85,85 @@ func NewSignUpUseCase(
 	}
 }
 
+func (uc *SignUpUseCase) WithEmailVerification(
+	verificationRepo token.EmailVerificationRepository,
+	verificationURL string,
+) *SignUpUseCase {
+	if verificationURL == "" {
+		verificationURL = defaultEmailVerificationURL
+	}
+
+	uc.verificationRepo = verificationRepo
+	uc.verificationURL = verificationURL
+	uc.verificationDuration = 24 * time.Hour
+	return uc
+}
+
+// WithTransaction grava o usuário e o email de cadastro atomicamente.
+func (uc *SignUpUseCase) WithTransaction(runner TxRunner) *SignUpUseCase {
+	uc.runInTx = runner
+	return uc
+}
+
+// WithIdempotency guarda cada Idempotency-Key por ttl; valores não
+// positivos usam o padrão.
+func (uc *SignUpUseCase) WithIdempotency(repo token.IdempotencyRepository, ttl time.Duration) *SignUpUseCase {
+	if ttl <= 0 {
+		ttl = DefaultIdempotencyKeyTTL
+	}
+
+	uc.idempotencyRepo = repo
+	uc.idempotencyTTL = ttl
+	return uc
+}
+
+// WithWelcomeTemplate troca o assunto e o corpo do email de boas-vindas.
+func (uc *SignUpUseCase) WithWelcomeTemplate(tmpl *email.WelcomeTemplate) *SignUpUseCase {
+	uc.welcomeTemplate = tmpl
+	return uc
+}
+
+// WithWelcomeEmail liga ou desliga o email de boas-vindas (ligado por padrão).
+// O email de verificação, quando configurado, continua sendo enviado.
+func (uc *SignUpUseCase) WithWelcomeEmail(enabled bool) *SignUpUseCase {
+	uc.welcomeEmailDisabled = !enabled
+	return uc
+}
+
+// WithSignupsDisabled recusa novos cadastros com ErrSignupsDisabled; login e
+// demais rotas de conta não são afetados.
+func (uc *SignUpUseCase) WithSignupsDisabled(disabled bool) *SignUpUseCase {
+	uc.signupsDisabled = disabled
+	return uc
+}
+
 func (uc *SignUpUseCase) Execute(ctx context.Context, req SignUpRequest) (*SignUpResponse, error) {
+	ctx, span := tracing.Start(ctx, "SignUpUseCase.Execute")
+	response, err := uc.execute(ctx, req)
+	tracing.End(span, err)
+	return response, err
+}
+
+func (uc *SignUpUseCase) execute(ctx context.Context, req SignUpRequest) (*SignUpResponse, error) {
+	// Cadastros desligados: nem o replay de idempotência consulta o banco
+	if uc.signupsDisabled {
+		return nil, fmt.Errorf("usecase: signup failed: %w", ErrSignupsDisabled)
+	}
+
+	req.Email = user.NormalizeEmail(req.Email)
+	useKey := req.IdempotencyKey != "" && uc.idempotencyRepo != nil
+
+	// 0. Chave já usada: devolve o usuário criado pela primeira requisição
+	if useKey {
+		response, err := uc.replay(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("usecase: signup failed: %w", err)
+		}
+		if response != nil {
+			return response, nil
+		}
+	}
+
 	// 1. Validar se email já existe
 	exists, err := uc.userRepo.EmailExists(ctx, req.Email)
 	if err != nil {
@@ -53,7 +171,7 @@ func (uc *SignUpUseCase) Execute(ctx context.Context, req SignUpRequest) (*SignU
 	}
 
 	if exists {
-		return nil, fmt.Errorf("usecase: signup failed: email already exists")
+		return nil, fmt.Errorf("usecase: signup failed: %w", user.ErrEmailExists)
 	}
 
 	// 2. Criar usuário
@@ -62,27 +180,60 @@ func (uc *SignUpUseCase) Execute(ctx context.Context, req SignUpRequest) (*SignU
 		return nil, fmt.Errorf("usecase: signup failed: %w", err)
 	}
 
-	// 3. Persistir usuário
-	err = uc.userRepo.Create(ctx, newUser)
+	// 3. Persistir usuário e email de boas-vindas (ou de verificação) juntos
+	var signUpEmail *email.Email
+	err = uc.inTx(ctx, func(userRepo user.Repository, emailRepo email.Repository, verificationRepo token.EmailVerificationRepository, idempotencyRepo token.IdempotencyRepository) error {
+		if err := userRepo.Create(ctx, newUser); err != nil {
+			return err
+		}
+
+		if useKey {
+			if err := idempotencyRepo.Create(ctx, req.IdempotencyKey, newUser.ID, time.Now().Add(uc.idempotencyTTL)); err != nil {
+				return err
+			}
+		}
+
+		if uc.skipSignUpEmail() {
+			return nil
+		}
+
+		// Token de verificação também dentro da transação
+		txUC := *uc
+		if uc.verificationRepo != nil {
+			txUC.verificationRepo = verificationRepo
+		}
+
+		created, err := txUC.createSignUpEmail(ctx, newUser)
+		if err != nil {
+			return fmt.Errorf("failed to create signup email: %w", err)
+		}
+
+		if err := emailRepo.Create(ctx, created); err != nil {
+			return fmt.Errorf("failed to save signup email: %w", err)
+		}
+		signUpEmail = created
+		return nil
+	})
 	if err != nil {
+		// Requisição concorrente com a mesma chave venceu: devolve o resultado dela
+		if useKey && (errors.Is(err, user.ErrEmailExists) || errors.Is(err, token.ErrIdempotencyKeyExists)) {
+			response, replayErr := uc.replay(ctx, req)
+			if replayErr != nil {
+				return nil, fmt.Errorf("usecase: signup failed: %w", replayErr)
+			}
+			if response != nil {
+				return response, nil
+			}
+		}
 		return nil, fmt.Errorf("usecase: signup failed: %w", err)
 	}
 
-	// 4. Criar e salvar email de boas-vindas
-	welcomeEmail, err := uc.createWelcomeEmail(newUser)
-	if err != nil {
-		fmt.Printf("Warning: failed to create welcome email: %v\n", err)
-	} else {
-		err = uc.emailRepo.Create(ctx, welcomeEmail)
-		if err != nil {
-			fmt.Printf("Warning: failed to save welcome email: %v\n", err)
-		} else {
-			// 5. Publicar evento com o ID correto do email
-			uc.publishSignUpEvents(ctx, newUser, welcomeEmail)
-		}
+	// 4. Publicar evento com o ID correto do email, após o commit
+	if signUpEmail != nil {
+		uc.publishSignUpEvents(ctx, newUser, signUpEmail)
 	}
 
-	// 6. Retornar resposta
+	// 5. Retornar resposta
 	response := &SignUpResponse{
 		User: newUser,
 	}
@@ -90,6 +241,71 @@ func (uc *SignUpUseCase) Execute(ctx context.Context, req SignUpRequest) (*SignU
 	return response, nil
 }
 
+// inTx usa o TxRunner configurado ou, sem ele, os repositórios diretos.
+func (uc *SignUpUseCase) inTx(ctx context.Context, fn func(user.Repository, email.Repository, token.EmailVerificationRepository, token.IdempotencyRepository) error) error {
+	if uc.runInTx == nil {
+		return fn(uc.userRepo, uc.emailRepo, uc.verificationRepo, uc.idempotencyRepo)
+	}
+	return uc.runInTx(ctx, fn)
+}
+
+// replay devolve a resposta original da chave, ou nil se ela não existe.
+// A chave só vale para o mesmo email.
+func (uc *SignUpUseCase) replay(ctx context.Context, req SignUpRequest) (*SignUpResponse, error) {
+	userID, err := uc.idempotencyRepo.Get(ctx, req.IdempotencyKey)
+	if errors.Is(err, token.ErrIdempotencyKeyNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	existingUser, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.NormalizeEmail(existingUser.Email) != req.Email {
+		return nil, token.ErrIdempotencyKeyReused
+	}
+
+	return &SignUpResponse{User: existingUser}, nil
+}
+
+// skipSignUpEmail indica que não há email de cadastro: boas-vindas desligado
+// e sem verificação de email.
+func (uc *SignUpUseCase) skipSignUpEmail() bool {
+	return uc.welcomeEmailDisabled && uc.verificationRepo == nil
+}
+
+func (uc *SignUpUseCase) createSignUpEmail(ctx context.Context, user *user.User) (*email.Email, error) {
+	if uc.verificationRepo == nil {
+		return uc.createWelcomeEmail(user)
+	}
+
+	return uc.createVerificationEmail(ctx, user)
+}
+
+func (uc *SignUpUseCase) createVerificationEmail(ctx context.Context, user *user.User) (*email.Email, error) {
+	verificationToken, tokenHash, err := generateOneTimeToken()
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.verificationRepo.Create(ctx, tokenHash, user.ID, time.Now().Add(uc.verificationDuration))
+	if err != nil {
+		return nil, err
+	}
+
+	verificationData := email.VerificationEmailData{
+		UserID:           user.ID.String(),
+		UserName:         user.Name,
+		UserEmail:        user.Email,
+		VerificationLink: fmt.Sprintf("%s?token=%s", uc.verificationURL, verificationToken),
+	}
+
+	return email.NewVerificationEmail(verificationData)
+}
+
 func (uc *SignUpUseCase) createWelcomeEmail(user *user.User) (*email.Email, error) {
 	welcomeData := email.WelcomeEmailData{
 		UserID:    user.ID.String(),
@@ -97,10 +313,10 @@ func (uc *SignUpUseCase) createWelcomeEmail(user *user.User) (*email.Email, erro
 		UserEmail: user.Email,
 	}
 
-	return email.NewWelcomeEmail(welcomeData)
+	return email.NewWelcomeEmailWithTemplate(welcomeData, uc.welcomeTemplate)
 }
 
-func (uc *SignUpUseCase) publishSignUpEvents(ctx context.Context, user *user.User, welcomeEmail *email.Email) {
+func (uc *SignUpUseCase) publishSignUpEvents(ctx context.Context, user *user.User, signUpEmail *email.Email) {
 	if uc.rabbit == nil || !uc.rabbit.IsConnected() {
 		fmt.Println("Warning: RabbitMQ not available, skipping events")
 		return
@@ -113,16 +329,33 @@ func (uc *SignUpUseCase) publishSignUpEvents(ctx context.Context, user *user.Use
 	}
 
 	message := email.QueueMessage{
-		EmailID: welcomeEmail.ID,
-		Type:    email.EmailTypeWelcome,
-		Data:    welcomeData,
+		EmailID:      signUpEmail.ID,
+		Type:         signUpEmail.Type,
+		Data:         welcomeData,
+		RequestID:    logging.RequestIDFromContext(ctx),
+		TraceContext: tracing.Inject(ctx),
 	}
 
-	err := uc.rabbit.PublishWelcomeEmailMessage(message)
+	err := uc.rabbit.PublishEmailMessage(message)
 	if err != nil {
-		fmt.Printf("Warning: failed to publish welcome email: %v\n", err)
+		fmt.Printf("Warning: failed to publish signup email: %v\n", err)
+		markUnroutableEmail(ctx, uc.emailRepo, signUpEmail, err)
 	} else {
 		fmt.Printf("Published signup events for user %s with email ID %s\n",
-			user.Email, welcomeEmail.ID.String())
+			user.Email, signUpEmail.ID.String())
+	}
+}
+
+// markUnroutableEmail records a failed attempt on an email whose queue
+// message the broker could not route to any queue; other publish errors are
+// left for the pending email processor.
+func markUnroutableEmail(ctx context.Context, emailRepo email.Repository, e *email.Email, publishErr error) {
+	if !errors.Is(publishErr, email.ErrMessageUnroutable) {
+		return
+	}
+
+	e.MarkAsFailed(publishErr.Error())
+	if err := emailRepo.Update(ctx, e); err != nil {
+		fmt.Printf("Warning: failed to mark unroutable email %s as failed: %v\n", e.ID, err)
 	}
 }
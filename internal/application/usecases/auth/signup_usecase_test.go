@@ -2,6 +2,7 @@ package auth
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -13,6 +14,9 @@ import (
 	"github.com/testcontainers/testcontainers-go/modules/postgres"
 	"github.com/testcontainers/testcontainers-go/wait"
 
+	"github.com/moura95/backend-challenge/internal/domain/email"
+	"github.com/moura95/backend-challenge/internal/domain/token"
+	"github.com/moura95/backend-challenge/internal/domain/user"
 	"github.com/moura95/backend-challenge/internal/infra/repository/adapters"
 	"github.com/moura95/backend-challenge/internal/infra/security/jwt"
 )
@@ -76,11 +80,19 @@ func runSignUpMigrations(db *sqlx.DB) error {
 	CREATE TABLE IF NOT EXISTS users (
 		uuid         UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
 		name         VARCHAR(255) NOT NULL,
-		email        VARCHAR(100) NOT NULL UNIQUE,
+		email        VARCHAR(100) NOT NULL,
 		password     TEXT NOT NULL,
+		verified_at  TIMESTAMPTZ,
+		role         VARCHAR(20) NOT NULL DEFAULT 'user',
+		deleted_at   TIMESTAMPTZ,
+		version      INTEGER NOT NULL DEFAULT 1,
+		status       VARCHAR(20) NOT NULL DEFAULT 'active',
+		token_version INTEGER NOT NULL DEFAULT 0,
+		last_login_at TIMESTAMPTZ,
 		created_at   TIMESTAMP NOT NULL DEFAULT NOW(),
 		updated_at   TIMESTAMP NOT NULL DEFAULT NOW()
 	);
+	CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email_lower ON users (LOWER(email));
 	
 	-- Emails table
 	CREATE TABLE IF NOT EXISTS emails (
@@ -95,7 +107,24 @@ func runSignUpMigrations(db *sqlx.DB) error {
 		error_msg    TEXT,
 		sent_at      TIMESTAMPTZ,
 		created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
-		updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		plain_body   TEXT NOT NULL DEFAULT '',
+		next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		cc_emails    TEXT[] NOT NULL DEFAULT '{}',
+		bcc_emails   TEXT[] NOT NULL DEFAULT '{}',
+		dedup_key    VARCHAR(255),
+		scheduled_at TIMESTAMPTZ
+	);
+	
+	-- Email attachments table
+	CREATE TABLE IF NOT EXISTS email_attachments (
+		id           BIGSERIAL PRIMARY KEY,
+		email_uuid   UUID NOT NULL,
+		filename     VARCHAR(255) NOT NULL,
+		content_type VARCHAR(255) NOT NULL,
+		content      BYTEA NOT NULL,
+		created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		FOREIGN KEY (email_uuid) REFERENCES emails(uuid) ON DELETE CASCADE
 	);
 	
 	-- Indexes
@@ -199,6 +228,44 @@ func TestSignUpUseCase_Execute(t *testing.T) {
 		assert.Equal(t, 1, userCount)
 	})
 
+	t.Run("should treat emails differing only in case as duplicates", func(t *testing.T) {
+		useCase := NewSignUpUseCase(
+			server.repos.User,
+			server.repos.Email,
+			tokenMaker,
+			nil,
+		)
+
+		result, err := useCase.Execute(ctx, SignUpRequest{
+			Name:     "Mixed Case",
+			Email:    "  Mixed@Example.Com ",
+			Password: "password123",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "mixed@example.com", result.User.Email)
+
+		result, err = useCase.Execute(ctx, SignUpRequest{
+			Name:     "Lower Case",
+			Email:    "mixed@example.com",
+			Password: "password456",
+		})
+		assert.ErrorIs(t, err, user.ErrEmailExists)
+		assert.Nil(t, result)
+
+		var userCount int
+		err = server.db.Get(&userCount, "SELECT COUNT(*) FROM users WHERE LOWER(email) = $1", "mixed@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, 1, userCount)
+	})
+
+	t.Run("should reject a differently cased duplicate at the database level", func(t *testing.T) {
+		_, err := server.db.Exec(`INSERT INTO users (name, email, password) VALUES ('Raw', 'raw@example.com', 'x')`)
+		require.NoError(t, err)
+
+		_, err = server.db.Exec(`INSERT INTO users (name, email, password) VALUES ('Raw', 'RAW@example.com', 'x')`)
+		assert.Error(t, err)
+	})
+
 	t.Run("should handle invalid email format", func(t *testing.T) {
 		// Create use case
 		useCase := NewSignUpUseCase(
@@ -292,6 +359,96 @@ func TestSignUpUseCase_Execute(t *testing.T) {
 		assert.Equal(t, 0, userCount)
 	})
 
+	t.Run("should roll back user when email insert fails", func(t *testing.T) {
+		// Email repository bound to the transaction that always fails on Create
+		useCase := NewSignUpUseCase(
+			server.repos.User,
+			server.repos.Email,
+			tokenMaker,
+			nil,
+		).WithTransaction(func(ctx context.Context, fn func(user.Repository, email.Repository, token.EmailVerificationRepository, token.IdempotencyRepository) error) error {
+			return server.repos.WithTx(ctx, func(tx *adapters.Repositories) error {
+				return fn(tx.User, failingEmailRepository{tx.Email}, tx.EmailVerification, tx.Idempotency)
+			})
+		})
+
+		req := SignUpRequest{
+			Name:     "Rollback User",
+			Email:    "rollback@example.com",
+			Password: "password123",
+		}
+
+		// Execute
+		result, err := useCase.Execute(ctx, req)
+
+		// Assert
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "failed to save signup email")
+
+		var userCount int
+		err = server.db.Get(&userCount, "SELECT COUNT(*) FROM users WHERE email = $1", "rollback@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, 0, userCount)
+	})
+
+	t.Run("should commit user and email together", func(t *testing.T) {
+		useCase := NewSignUpUseCase(
+			server.repos.User,
+			server.repos.Email,
+			tokenMaker,
+			nil,
+		).WithTransaction(func(ctx context.Context, fn func(user.Repository, email.Repository, token.EmailVerificationRepository, token.IdempotencyRepository) error) error {
+			return server.repos.WithTx(ctx, func(tx *adapters.Repositories) error {
+				return fn(tx.User, tx.Email, tx.EmailVerification, tx.Idempotency)
+			})
+		})
+
+		result, err := useCase.Execute(ctx, SignUpRequest{
+			Name:     "Committed User",
+			Email:    "committed@example.com",
+			Password: "password123",
+		})
+
+		require.NoError(t, err)
+		assert.NotNil(t, result)
+
+		var emailCount int
+		err = server.db.Get(&emailCount, "SELECT COUNT(*) FROM emails WHERE to_email = $1", "committed@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, 1, emailCount)
+	})
+
+	t.Run("should report every invalid field", func(t *testing.T) {
+		// Create use case
+		useCase := NewSignUpUseCase(
+			server.repos.User,
+			server.repos.Email,
+			tokenMaker,
+			nil,
+		)
+
+		// Test data missing name and with weak password
+		req := SignUpRequest{
+			Name:     "",
+			Email:    "multiinvalid@example.com",
+			Password: "123",
+		}
+
+		// Execute
+		result, err := useCase.Execute(ctx, req)
+
+		// Assert
+		require.Error(t, err)
+		assert.Nil(t, result)
+
+		var validationErr *user.ValidationError
+		require.True(t, errors.As(err, &validationErr))
+		assert.Len(t, validationErr.Fields, 2)
+		assert.Contains(t, validationErr.Fields[user.FieldName], "name must be at least 2 characters")
+		assert.Contains(t, validationErr.Fields[user.FieldPassword], "password must be at least 6 characters")
+	})
+
 	t.Run("should create multiple users with different emails", func(t *testing.T) {
 		// Create use case
 		useCase := NewSignUpUseCase(
@@ -362,3 +519,56 @@ func TestSignUpUseCase_Execute(t *testing.T) {
 		assert.Equal(t, 0, userCount)
 	})
 }
+
+func TestSignUpUseCase_WelcomeEmailDisabled(t *testing.T) {
+	server := setupSignUpTest(t)
+	defer server.cleanup()
+
+	ctx := context.Background()
+	tokenMaker, err := jwt.NewPasetoMaker("12345678901234567890123456789012")
+	require.NoError(t, err)
+
+	t.Run("should sign up without an email row when disabled", func(t *testing.T) {
+		useCase := NewSignUpUseCase(server.repos.User, server.repos.Email, tokenMaker, nil).
+			WithWelcomeEmail(false)
+
+		result, err := useCase.Execute(ctx, SignUpRequest{
+			Name:     "No Welcome",
+			Email:    "nowelcome@example.com",
+			Password: "password123",
+		})
+		require.NoError(t, err)
+		assert.NotEmpty(t, result.User.ID)
+
+		var userCount, emailCount int
+		require.NoError(t, server.db.Get(&userCount, "SELECT COUNT(*) FROM users WHERE email = $1", "nowelcome@example.com"))
+		require.NoError(t, server.db.Get(&emailCount, "SELECT COUNT(*) FROM emails WHERE to_email = $1", "nowelcome@example.com"))
+		assert.Equal(t, 1, userCount)
+		assert.Equal(t, 0, emailCount)
+	})
+
+	t.Run("should create the welcome email row when enabled", func(t *testing.T) {
+		useCase := NewSignUpUseCase(server.repos.User, server.repos.Email, tokenMaker, nil).
+			WithWelcomeEmail(true)
+
+		_, err := useCase.Execute(ctx, SignUpRequest{
+			Name:     "With Welcome",
+			Email:    "withwelcome@example.com",
+			Password: "password123",
+		})
+		require.NoError(t, err)
+
+		var emailCount int
+		require.NoError(t, server.db.Get(&emailCount, "SELECT COUNT(*) FROM emails WHERE to_email = $1 AND type = 'welcome'", "withwelcome@example.com"))
+		assert.Equal(t, 1, emailCount)
+	})
+}
+
+// failingEmailRepository wraps a repository and fails every Create
+type failingEmailRepository struct {
+	email.Repository
+}
+
+func (r failingEmailRepository) Create(ctx context.Context, emailEntity *email.Email) error {
+	return errors.New("email insert failed")
+}
@@ -0,0 +1,57 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/moura95/backend-challenge/internal/domain/email"
+	"github.com/moura95/backend-challenge/internal/infra/security/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignUpUseCase_WelcomeEmailToggle(t *testing.T) {
+	tokenMaker, err := jwt.NewPasetoMaker("12345678901234567890123456789012")
+	require.NoError(t, err)
+
+	setup := func() (*SignUpUseCase, *memoryUserRepository, *memoryEmailRepository) {
+		userRepo := newMemoryUserRepository()
+		emailRepo := &memoryEmailRepository{}
+		// Sem publisher: com o boas-vindas desligado nada precisa ser publicado
+		return NewSignUpUseCase(userRepo, emailRepo, tokenMaker, nil), userRepo, emailRepo
+	}
+	req := SignUpRequest{Name: "John Doe", Email: "welcome@example.com", Password: "password123"}
+
+	t.Run("creates the welcome email by default", func(t *testing.T) {
+		uc, userRepo, emailRepo := setup()
+
+		_, err := uc.Execute(context.Background(), req)
+
+		require.NoError(t, err)
+		assert.NotNil(t, userRepo.find("welcome@example.com"))
+		require.Len(t, emailRepo.emails, 1)
+		assert.Equal(t, email.EmailTypeWelcome, emailRepo.emails[0].Type)
+	})
+
+	t.Run("skips the welcome email when disabled", func(t *testing.T) {
+		uc, userRepo, emailRepo := setup()
+		uc.WithWelcomeEmail(false)
+
+		result, err := uc.Execute(context.Background(), req)
+
+		require.NoError(t, err)
+		assert.Equal(t, "welcome@example.com", result.User.Email)
+		assert.NotNil(t, userRepo.find("welcome@example.com"))
+		assert.Empty(t, emailRepo.emails)
+	})
+
+	t.Run("enabling explicitly keeps the default behavior", func(t *testing.T) {
+		uc, _, emailRepo := setup()
+		uc.WithWelcomeEmail(true)
+
+		_, err := uc.Execute(context.Background(), req)
+
+		require.NoError(t, err)
+		assert.Len(t, emailRepo.emails, 1)
+	})
+}
@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/moura95/backend-challenge/internal/domain/user"
+	"github.com/moura95/backend-challenge/internal/infra/security/crypto"
+)
+
+func TestVerifyEmailUseCase_Idempotent(t *testing.T) {
+	setup := func(t *testing.T, expiresIn time.Duration) (*VerifyEmailUseCase, *memoryUserRepository, *user.User, string) {
+		unverified, err := user.NewUser("Verify User", "verify@example.com", "password123")
+		require.NoError(t, err)
+
+		userRepo := newMemoryUserRepository(unverified)
+		tokenRepo := newMemoryVerificationTokenRepository()
+
+		rawToken := "verification-token"
+		require.NoError(t, tokenRepo.Create(context.Background(), crypto.HashSHA256(rawToken), unverified.ID, time.Now().Add(expiresIn)))
+
+		return NewVerifyEmailUseCase(userRepo, tokenRepo), userRepo, unverified, rawToken
+	}
+
+	t.Run("first verification marks the account as verified", func(t *testing.T) {
+		uc, userRepo, unverified, rawToken := setup(t, time.Hour)
+
+		result, err := uc.Execute(context.Background(), VerifyEmailRequest{Token: rawToken})
+		require.NoError(t, err)
+
+		assert.False(t, result.AlreadyVerified)
+		assert.True(t, userRepo.findByID(unverified.ID).IsVerified())
+	})
+
+	t.Run("repeating the same link succeeds as already verified", func(t *testing.T) {
+		uc, _, _, rawToken := setup(t, time.Hour)
+
+		_, err := uc.Execute(context.Background(), VerifyEmailRequest{Token: rawToken})
+		require.NoError(t, err)
+
+		result, err := uc.Execute(context.Background(), VerifyEmailRequest{Token: rawToken})
+		require.NoError(t, err)
+		assert.True(t, result.AlreadyVerified)
+	})
+
+	t.Run("unknown token is rejected", func(t *testing.T) {
+		uc, _, _, _ := setup(t, time.Hour)
+
+		_, err := uc.Execute(context.Background(), VerifyEmailRequest{Token: "unknown"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid or expired verification token")
+	})
+
+	t.Run("expired token of an unverified account is rejected", func(t *testing.T) {
+		uc, userRepo, unverified, rawToken := setup(t, -time.Minute)
+
+		_, err := uc.Execute(context.Background(), VerifyEmailRequest{Token: rawToken})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid or expired verification token")
+		assert.False(t, userRepo.findByID(unverified.ID).IsVerified())
+	})
+}
@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/moura95/backend-challenge/internal/domain/token"
+	"github.com/moura95/backend-challenge/internal/domain/user"
+	"github.com/moura95/backend-challenge/internal/infra/security/crypto"
+)
+
+const defaultEmailVerificationURL = "http://localhost:8080/verify-email"
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type VerifyEmailResponse struct {
+	// Set when the account had already been verified, e.g. an old link was opened again
+	AlreadyVerified bool `json:"already_verified"`
+}
+
+type VerifyEmailUseCase struct {
+	userRepo         user.Repository
+	verificationRepo token.EmailVerificationRepository
+}
+
+func NewVerifyEmailUseCase(userRepo user.Repository, verificationRepo token.EmailVerificationRepository) *VerifyEmailUseCase {
+	return &VerifyEmailUseCase{
+		userRepo:         userRepo,
+		verificationRepo: verificationRepo,
+	}
+}
+
+// Execute é idempotente: abrir de novo um link de uma conta já verificada
+// retorna sucesso com AlreadyVerified, enquanto tokens inválidos ou expirados
+// de contas não verificadas continuam sendo erro.
+func (uc *VerifyEmailUseCase) Execute(ctx context.Context, req VerifyEmailRequest) (*VerifyEmailResponse, error) {
+	// 1. Validar entrada
+	if strings.TrimSpace(req.Token) == "" {
+		return nil, fmt.Errorf("usecase: verify email failed: verification token is required")
+	}
+	tokenHash := crypto.HashSHA256(req.Token)
+
+	// 2. Consumir token (uso único)
+	userID, err := uc.verificationRepo.Consume(ctx, tokenHash)
+	if err != nil {
+		if !strings.Contains(err.Error(), "token not found") {
+			return nil, fmt.Errorf("usecase: verify email failed: %w", err)
+		}
+		// Token já usado ou expirado: sucesso se a conta dele já foi verificada
+		if uc.tokenOwnerVerified(ctx, tokenHash) {
+			return &VerifyEmailResponse{AlreadyVerified: true}, nil
+		}
+		return nil, fmt.Errorf("usecase: verify email failed: invalid or expired verification token")
+	}
+
+	// 3. Token válido de uma conta já verificada (ex.: email reenviado)
+	foundUser, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("usecase: verify email failed: %w", err)
+	}
+	if foundUser.IsVerified() {
+		return &VerifyEmailResponse{AlreadyVerified: true}, nil
+	}
+
+	// 4. Marcar email como verificado
+	err = uc.userRepo.MarkEmailVerified(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("usecase: verify email failed: %w", err)
+	}
+
+	return &VerifyEmailResponse{}, nil
+}
+
+func (uc *VerifyEmailUseCase) tokenOwnerVerified(ctx context.Context, tokenHash string) bool {
+	userID, err := uc.verificationRepo.GetUserID(ctx, tokenHash)
+	if err != nil {
+		return false
+	}
+
+	owner, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return false
+	}
+
+	return owner.IsVerified()
+}
@@ -0,0 +1,268 @@
+package auth
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+
+	"github.com/moura95/backend-challenge/internal/infra/repository/adapters"
+	"github.com/moura95/backend-challenge/internal/infra/security/jwt"
+)
+
+type verifyEmailTestServer struct {
+	container *postgres.PostgresContainer
+	db        *sqlx.DB
+	repos     *adapters.Repositories
+	cleanup   func()
+}
+
+func setupVerifyEmailTest(t *testing.T) *verifyEmailTestServer {
+	ctx := context.Background()
+
+	// Start PostgreSQL container
+	postgresContainer, err := postgres.RunContainer(ctx,
+		testcontainers.WithImage("postgres:15-alpine"),
+		postgres.WithDatabase("testdb"),
+		postgres.WithUsername("test"),
+		postgres.WithPassword("test"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(30*time.Second)),
+	)
+	require.NoError(t, err)
+
+	// Get connection string
+	connStr, err := postgresContainer.ConnectionString(ctx, "sslmode=disable")
+	require.NoError(t, err)
+
+	// Connect to database
+	db, err := sqlx.Connect("postgres", connStr)
+	require.NoError(t, err)
+
+	// Run migrations
+	err = runVerifyEmailMigrations(db)
+	require.NoError(t, err)
+
+	// Setup repositories
+	repos := adapters.NewRepositories(db)
+
+	cleanup := func() {
+		db.Close()
+		postgresContainer.Terminate(ctx)
+	}
+
+	return &verifyEmailTestServer{
+		container: postgresContainer,
+		db:        db,
+		repos:     repos,
+		cleanup:   cleanup,
+	}
+}
+
+func runVerifyEmailMigrations(db *sqlx.DB) error {
+	migrationSQL := `
+	CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+	
+	-- Users table
+	CREATE TABLE IF NOT EXISTS users (
+		uuid         UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+		name         VARCHAR(255) NOT NULL,
+		email        VARCHAR(100) NOT NULL UNIQUE,
+		password     TEXT NOT NULL,
+		verified_at  TIMESTAMPTZ,
+		role         VARCHAR(20) NOT NULL DEFAULT 'user',
+		deleted_at   TIMESTAMPTZ,
+		version      INTEGER NOT NULL DEFAULT 1,
+		status       VARCHAR(20) NOT NULL DEFAULT 'active',
+		token_version INTEGER NOT NULL DEFAULT 0,
+		last_login_at TIMESTAMPTZ,
+		created_at   TIMESTAMP NOT NULL DEFAULT NOW(),
+		updated_at   TIMESTAMP NOT NULL DEFAULT NOW()
+	);
+	
+	-- Emails table
+	CREATE TABLE IF NOT EXISTS emails (
+		uuid         UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+		to_email     VARCHAR(255) NOT NULL,
+		subject      VARCHAR(255) NOT NULL,
+		body         TEXT NOT NULL,
+		type         VARCHAR(50) NOT NULL,
+		status       VARCHAR(50) NOT NULL DEFAULT 'pending',
+		attempts     INTEGER NOT NULL DEFAULT 0,
+		max_attempts INTEGER NOT NULL DEFAULT 3,
+		error_msg    TEXT,
+		sent_at      TIMESTAMPTZ,
+		created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		plain_body   TEXT NOT NULL DEFAULT '',
+		next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		cc_emails    TEXT[] NOT NULL DEFAULT '{}',
+		bcc_emails   TEXT[] NOT NULL DEFAULT '{}',
+		dedup_key    VARCHAR(255),
+		scheduled_at TIMESTAMPTZ
+	);
+	
+	-- Email attachments table
+	CREATE TABLE IF NOT EXISTS email_attachments (
+		id           BIGSERIAL PRIMARY KEY,
+		email_uuid   UUID NOT NULL,
+		filename     VARCHAR(255) NOT NULL,
+		content_type VARCHAR(255) NOT NULL,
+		content      BYTEA NOT NULL,
+		created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		FOREIGN KEY (email_uuid) REFERENCES emails(uuid) ON DELETE CASCADE
+	);
+	
+	-- Email verification tokens table
+	CREATE TABLE IF NOT EXISTS email_verification_tokens (
+		token_hash   TEXT PRIMARY KEY,
+		user_uuid    UUID NOT NULL REFERENCES users(uuid) ON DELETE CASCADE,
+		expires_at   TIMESTAMPTZ NOT NULL,
+		used_at      TIMESTAMPTZ,
+		created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	);
+	
+	-- Indexes
+	CREATE INDEX IF NOT EXISTS idx_users_email ON users(email);
+	CREATE INDEX IF NOT EXISTS idx_emails_status ON emails(status);
+	CREATE INDEX IF NOT EXISTS idx_emails_type ON emails(type);
+	`
+
+	_, err := db.Exec(migrationSQL)
+	return err
+}
+
+var verificationTokenRegex = regexp.MustCompile(`token=([a-f0-9]+)`)
+
+// Helper function to read the verification token from the signup email
+func getVerificationTokenFromEmail(t *testing.T, server *verifyEmailTestServer, to string) string {
+	var body string
+	err := server.db.Get(&body, `
+		SELECT body FROM emails
+		WHERE to_email = $1 AND type = 'verification'
+		ORDER BY created_at DESC
+		LIMIT 1`, to)
+	require.NoError(t, err)
+
+	matches := verificationTokenRegex.FindStringSubmatch(body)
+	require.Len(t, matches, 2)
+
+	return matches[1]
+}
+
+func TestVerifyEmailUseCase_Execute(t *testing.T) {
+	server := setupVerifyEmailTest(t)
+	defer server.cleanup()
+
+	ctx := context.Background()
+
+	tokenMaker, err := jwt.NewPasetoMaker("12345678901234567890123456789012")
+	require.NoError(t, err)
+
+	signUpUC := NewSignUpUseCase(server.repos.User, server.repos.Email, tokenMaker, nil).
+		WithEmailVerification(server.repos.EmailVerification, "http://localhost:3000/verify-email")
+	signInUC := NewSignInUseCase(server.repos.User, tokenMaker).RequireEmailVerification(true)
+	verifyEmailUC := NewVerifyEmailUseCase(server.repos.User, server.repos.EmailVerification)
+
+	t.Run("should block signin until email is verified", func(t *testing.T) {
+		_, err := signUpUC.Execute(ctx, SignUpRequest{
+			Name:     "Verify User",
+			Email:    "verify@example.com",
+			Password: "password123",
+		})
+		require.NoError(t, err)
+
+		// No welcome email, only the verification one
+		var welcomeCount int
+		err = server.db.Get(&welcomeCount, "SELECT COUNT(*) FROM emails WHERE to_email = $1 AND type = 'welcome'", "verify@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, 0, welcomeCount)
+
+		// Signin is rejected
+		result, err := signInUC.Execute(ctx, SignInRequest{Email: "verify@example.com", Password: "password123"})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "email not verified")
+
+		// Verify email
+		verificationToken := getVerificationTokenFromEmail(t, server, "verify@example.com")
+		verified, err := verifyEmailUC.Execute(ctx, VerifyEmailRequest{Token: verificationToken})
+		require.NoError(t, err)
+		assert.False(t, verified.AlreadyVerified)
+
+		// Signin works now
+		result, err = signInUC.Execute(ctx, SignInRequest{Email: "verify@example.com", Password: "password123"})
+		require.NoError(t, err)
+		assert.True(t, result.User.IsVerified())
+	})
+
+	t.Run("should report already verified when a used link is opened again", func(t *testing.T) {
+		_, err := signUpUC.Execute(ctx, SignUpRequest{
+			Name:     "Reuse User",
+			Email:    "verify-reuse@example.com",
+			Password: "password123",
+		})
+		require.NoError(t, err)
+
+		verificationToken := getVerificationTokenFromEmail(t, server, "verify-reuse@example.com")
+
+		first, err := verifyEmailUC.Execute(ctx, VerifyEmailRequest{Token: verificationToken})
+		require.NoError(t, err)
+		assert.False(t, first.AlreadyVerified)
+
+		again, err := verifyEmailUC.Execute(ctx, VerifyEmailRequest{Token: verificationToken})
+		require.NoError(t, err)
+		assert.True(t, again.AlreadyVerified)
+	})
+
+	t.Run("should fail with an unknown or expired token", func(t *testing.T) {
+		_, err := verifyEmailUC.Execute(ctx, VerifyEmailRequest{Token: "unknown"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid or expired verification token")
+
+		_, err = signUpUC.Execute(ctx, SignUpRequest{
+			Name:     "Expired User",
+			Email:    "verify-expired@example.com",
+			Password: "password123",
+		})
+		require.NoError(t, err)
+		_, err = server.db.Exec("UPDATE email_verification_tokens SET expires_at = NOW() - INTERVAL '1 minute'")
+		require.NoError(t, err)
+
+		verificationToken := getVerificationTokenFromEmail(t, server, "verify-expired@example.com")
+		_, err = verifyEmailUC.Execute(ctx, VerifyEmailRequest{Token: verificationToken})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid or expired verification token")
+	})
+
+	t.Run("should fail with empty token", func(t *testing.T) {
+		_, err := verifyEmailUC.Execute(ctx, VerifyEmailRequest{Token: ""})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "verification token is required")
+	})
+
+	t.Run("should allow unverified signin when verification is disabled", func(t *testing.T) {
+		_, err := signUpUC.Execute(ctx, SignUpRequest{
+			Name:     "Optional User",
+			Email:    "verify-optional@example.com",
+			Password: "password123",
+		})
+		require.NoError(t, err)
+
+		signInWithoutVerification := NewSignInUseCase(server.repos.User, tokenMaker)
+
+		result, err := signInWithoutVerification.Execute(ctx, SignInRequest{Email: "verify-optional@example.com", Password: "password123"})
+		require.NoError(t, err)
+		assert.False(t, result.User.IsVerified())
+	})
+}
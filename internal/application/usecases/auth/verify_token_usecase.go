@@ -5,43 +5,97 @@ import (
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/moura95/backend-challenge/internal/domain/token"
 	"github.com/moura95/backend-challenge/internal/domain/user"
 	"github.com/moura95/backend-challenge/internal/infra/security/jwt"
 )
 
 type VerifyTokenUseCase struct {
 	userRepo   user.Repository
+	tokenRepo  token.Repository
 	tokenMaker jwt.Maker
 }
 
-func NewVerifyTokenUseCase(userRepo user.Repository, tokenMaker jwt.Maker) *VerifyTokenUseCase {
+func NewVerifyTokenUseCase(userRepo user.Repository, tokenRepo token.Repository, tokenMaker jwt.Maker) *VerifyTokenUseCase {
 	return &VerifyTokenUseCase{
 		userRepo:   userRepo,
+		tokenRepo:  tokenRepo,
 		tokenMaker: tokenMaker,
 	}
 }
 
-func (uc *VerifyTokenUseCase) Execute(ctx context.Context, token string) (*user.User, error) {
+func (uc *VerifyTokenUseCase) Execute(ctx context.Context, accessToken string) (*user.User, error) {
+	foundUser, _, err := uc.ExecuteWithPayload(ctx, accessToken)
+	return foundUser, err
+}
+
+// ExecuteWithPayload also returns the verified token claims, so callers can
+// expose them without decoding the token again.
+func (uc *VerifyTokenUseCase) ExecuteWithPayload(ctx context.Context, accessToken string) (*user.User, *jwt.Payload, error) {
 	// 1. Validar entrada
-	if token == "" {
-		return nil, fmt.Errorf("usecase: verify token failed: token is required")
+	if accessToken == "" {
+		return nil, nil, fmt.Errorf("usecase: verify token failed: token is required")
 	}
 
 	// 2. Verificar e decodificar token
-	payload, err := uc.tokenMaker.VerifyToken(token)
+	payload, err := uc.tokenMaker.VerifyToken(accessToken)
 	if err != nil {
-		return nil, fmt.Errorf("usecase: verify token failed: invalid token")
+		return nil, nil, fmt.Errorf("usecase: verify token failed: invalid token")
 	}
 
-	// 3. Extrair user ID do payload
+	// Refresh tokens não podem ser usados como access tokens
+	if payload.IsRefresh() {
+		return nil, nil, fmt.Errorf("usecase: verify token failed: invalid token")
+	}
+
+	// 3. Verificar se o token foi revogado (logout)
+	tokenID, err := uuid.Parse(payload.UUID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("usecase: verify token failed: invalid token")
+	}
+
+	revoked, err := uc.tokenRepo.IsRevoked(ctx, tokenID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("usecase: verify token failed: %w", err)
+	}
+	if revoked {
+		return nil, nil, fmt.Errorf("usecase: verify token failed: %w", token.ErrTokenRevoked)
+	}
+
+	// Tokens renovados também caem quando a sessão do login é revogada
+	if payload.SessionID != "" {
+		sessionID, err := uuid.Parse(payload.SessionID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("usecase: verify token failed: invalid token")
+		}
+		revoked, err := uc.tokenRepo.IsRevoked(ctx, sessionID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("usecase: verify token failed: %w", err)
+		}
+		if revoked {
+			return nil, nil, fmt.Errorf("usecase: verify token failed: %w", token.ErrTokenRevoked)
+		}
+	}
+
+	// 4. Extrair user ID do payload
 	userID, err := uuid.Parse(payload.UserUUID)
 	if err != nil {
-		return nil, fmt.Errorf("usecase: verify token failed: invalid user ID in token")
+		return nil, nil, fmt.Errorf("usecase: verify token failed: invalid user ID in token")
 	}
 
 	foundUser, err := uc.userRepo.GetByID(ctx, userID)
 	if err != nil {
-		return nil, fmt.Errorf("usecase: verify token failed: user not found")
+		return nil, nil, fmt.Errorf("usecase: verify token failed: %w", user.ErrUserNotFound)
+	}
+
+	// Tokens emitidos antes da suspensão deixam de valer
+	if foundUser.IsSuspended() {
+		return nil, nil, fmt.Errorf("usecase: verify token failed: %w", user.ErrAccountSuspended)
+	}
+
+	// Tokens emitidos antes de o usuário revogar todas as sessões
+	if payload.TokenVersion != foundUser.TokenVersion {
+		return nil, nil, fmt.Errorf("usecase: verify token failed: %w", token.ErrTokenRevoked)
 	}
-	return foundUser, nil
+	return foundUser, payload, nil
 }
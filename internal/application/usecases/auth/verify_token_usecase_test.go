@@ -80,10 +80,25 @@ func runVerifyTokenMigrations(db *sqlx.DB) error {
 		name         VARCHAR(255) NOT NULL,
 		email        VARCHAR(100) NOT NULL UNIQUE,
 		password     TEXT NOT NULL,
+		verified_at  TIMESTAMPTZ,
+		role         VARCHAR(20) NOT NULL DEFAULT 'user',
+		deleted_at   TIMESTAMPTZ,
+		version      INTEGER NOT NULL DEFAULT 1,
+		status       VARCHAR(20) NOT NULL DEFAULT 'active',
+		token_version INTEGER NOT NULL DEFAULT 0,
+		last_login_at TIMESTAMPTZ,
 		created_at   TIMESTAMP NOT NULL DEFAULT NOW(),
 		updated_at   TIMESTAMP NOT NULL DEFAULT NOW()
 	);
 	
+	-- Revoked tokens table
+	CREATE TABLE IF NOT EXISTS revoked_tokens (
+		token_uuid   UUID PRIMARY KEY,
+		user_uuid    UUID NOT NULL,
+		expires_at   TIMESTAMPTZ NOT NULL,
+		revoked_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	);
+	
 	-- Indexes
 	CREATE INDEX IF NOT EXISTS idx_users_email ON users(email);
 	`
@@ -105,7 +120,7 @@ func createUserAndToken(t *testing.T, server *verifyTokenTestServer, tokenMaker
 	require.NoError(t, err)
 
 	// Generate token for this user
-	token, _, err := tokenMaker.CreateToken(testUser.ID, 24*time.Hour)
+	token, _, err := tokenMaker.CreateToken(testUser.ID, 0, 24*time.Hour)
 	require.NoError(t, err)
 
 	return testUser, token
@@ -126,7 +141,7 @@ func TestVerifyTokenUseCase_Execute(t *testing.T) {
 		testUser, validToken := createUserAndToken(t, server, tokenMaker, "john@example.com", "password123", "John Doe")
 
 		// Create use case
-		useCase := NewVerifyTokenUseCase(server.repos.User, tokenMaker)
+		useCase := NewVerifyTokenUseCase(server.repos.User, server.repos.Token, tokenMaker)
 
 		// Execute
 		result, err := useCase.Execute(ctx, validToken)
@@ -141,7 +156,7 @@ func TestVerifyTokenUseCase_Execute(t *testing.T) {
 
 	t.Run("should fail with empty token", func(t *testing.T) {
 		// Create use case
-		useCase := NewVerifyTokenUseCase(server.repos.User, tokenMaker)
+		useCase := NewVerifyTokenUseCase(server.repos.User, server.repos.Token, tokenMaker)
 
 		// Execute with empty token
 		result, err := useCase.Execute(ctx, "")
@@ -154,7 +169,7 @@ func TestVerifyTokenUseCase_Execute(t *testing.T) {
 
 	t.Run("should fail with invalid token format", func(t *testing.T) {
 		// Create use case
-		useCase := NewVerifyTokenUseCase(server.repos.User, tokenMaker)
+		useCase := NewVerifyTokenUseCase(server.repos.User, server.repos.Token, tokenMaker)
 
 		// Execute with invalid token
 		result, err := useCase.Execute(ctx, "invalid.token.format")
@@ -174,11 +189,11 @@ func TestVerifyTokenUseCase_Execute(t *testing.T) {
 		require.NoError(t, err)
 
 		// Generate expired token (negative duration)
-		expiredToken, _, err := tokenMaker.CreateToken(testUser.ID, -1*time.Hour)
+		expiredToken, _, err := tokenMaker.CreateToken(testUser.ID, 0, -1*time.Hour)
 		require.NoError(t, err)
 
 		// Create use case
-		useCase := NewVerifyTokenUseCase(server.repos.User, tokenMaker)
+		useCase := NewVerifyTokenUseCase(server.repos.User, server.repos.Token, tokenMaker)
 
 		// Execute with expired token
 		result, err := useCase.Execute(ctx, expiredToken)
@@ -192,11 +207,11 @@ func TestVerifyTokenUseCase_Execute(t *testing.T) {
 	t.Run("should fail with token for non-existent user", func(t *testing.T) {
 		// Generate token for non-existent user
 		fakeUserID := uuid.New()
-		fakeToken, _, err := tokenMaker.CreateToken(fakeUserID, 24*time.Hour)
+		fakeToken, _, err := tokenMaker.CreateToken(fakeUserID, 0, 24*time.Hour)
 		require.NoError(t, err)
 
 		// Create use case
-		useCase := NewVerifyTokenUseCase(server.repos.User, tokenMaker)
+		useCase := NewVerifyTokenUseCase(server.repos.User, server.repos.Token, tokenMaker)
 
 		// Execute with token for non-existent user
 		result, err := useCase.Execute(ctx, fakeToken)
@@ -209,7 +224,7 @@ func TestVerifyTokenUseCase_Execute(t *testing.T) {
 
 	t.Run("should fail with malformed token", func(t *testing.T) {
 		// Create use case
-		useCase := NewVerifyTokenUseCase(server.repos.User, tokenMaker)
+		useCase := NewVerifyTokenUseCase(server.repos.User, server.repos.Token, tokenMaker)
 
 		// Execute with malformed token
 		result, err := useCase.Execute(ctx, "clearly.not.a.valid.jwt.token.format")
@@ -227,7 +242,7 @@ func TestVerifyTokenUseCase_Execute(t *testing.T) {
 		user3, token3 := createUserAndToken(t, server, tokenMaker, "user3@example.com", "password123", "User 3")
 
 		// Create use case
-		useCase := NewVerifyTokenUseCase(server.repos.User, tokenMaker)
+		useCase := NewVerifyTokenUseCase(server.repos.User, server.repos.Token, tokenMaker)
 
 		// Test each token
 		testCases := []struct {
@@ -248,9 +263,27 @@ func TestVerifyTokenUseCase_Execute(t *testing.T) {
 		}
 	})
 
+	t.Run("should reject refresh token used as access token", func(t *testing.T) {
+		// Create test user and a refresh token for it
+		testUser, _ := createUserAndToken(t, server, tokenMaker, "refresh-as-access@example.com", "password123", "Refresh User")
+		refreshToken, _, err := tokenMaker.CreateRefreshToken(testUser.ID, 0, time.Hour)
+		require.NoError(t, err)
+
+		// Create use case
+		useCase := NewVerifyTokenUseCase(server.repos.User, server.repos.Token, tokenMaker)
+
+		// Execute with refresh token
+		result, err := useCase.Execute(ctx, refreshToken)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "invalid token")
+	})
+
 	t.Run("should handle token with whitespace", func(t *testing.T) {
 		// Create use case
-		useCase := NewVerifyTokenUseCase(server.repos.User, tokenMaker)
+		useCase := NewVerifyTokenUseCase(server.repos.User, server.repos.Token, tokenMaker)
 
 		// Execute with whitespace token
 		result, err := useCase.Execute(ctx, "   ")
@@ -266,7 +299,7 @@ func TestVerifyTokenUseCase_Execute(t *testing.T) {
 		testUser, validToken := createUserAndToken(t, server, tokenMaker, "repeat@example.com", "password123", "Repeat User")
 
 		// Create use case
-		useCase := NewVerifyTokenUseCase(server.repos.User, tokenMaker)
+		useCase := NewVerifyTokenUseCase(server.repos.User, server.repos.Token, tokenMaker)
 
 		// Execute multiple times
 		for i := 0; i < 3; i++ {
@@ -285,7 +318,7 @@ func TestVerifyTokenUseCase_Execute(t *testing.T) {
 		require.NoError(t, err)
 
 		// Create use case
-		useCase := NewVerifyTokenUseCase(server.repos.User, tokenMaker)
+		useCase := NewVerifyTokenUseCase(server.repos.User, server.repos.Token, tokenMaker)
 
 		// Execute with token for deleted user
 		result, err := useCase.Execute(ctx, validToken)
@@ -295,4 +328,26 @@ func TestVerifyTokenUseCase_Execute(t *testing.T) {
 		assert.Nil(t, result)
 		assert.Contains(t, err.Error(), "user not found")
 	})
+	t.Run("should reject revoked token", func(t *testing.T) {
+		// Create test user and get token
+		testUser, validToken := createUserAndToken(t, server, tokenMaker, "revoked@example.com", "password123", "Revoked User")
+
+		payload, err := tokenMaker.VerifyToken(validToken)
+		require.NoError(t, err)
+
+		// Revoke token
+		err = server.repos.Token.Revoke(ctx, uuid.MustParse(payload.UUID), testUser.ID, payload.ExpiredAt)
+		require.NoError(t, err)
+
+		// Create use case
+		useCase := NewVerifyTokenUseCase(server.repos.User, server.repos.Token, tokenMaker)
+
+		// Execute
+		result, err := useCase.Execute(ctx, validToken)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "token revoked")
+	})
 }
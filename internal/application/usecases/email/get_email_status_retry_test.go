@@ -0,0 +1,63 @@
+package email
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moura95/backend-challenge/internal/domain/email"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// statusEmailRepository serves emails from memory by ID.
+type statusEmailRepository struct {
+	email.Repository
+	emails map[uuid.UUID]*email.Email
+}
+
+func (r *statusEmailRepository) GetByID(ctx context.Context, id uuid.UUID) (*email.Email, error) {
+	e, ok := r.emails[id]
+	if !ok {
+		return nil, email.ErrEmailNotFound
+	}
+	return e, nil
+}
+
+func TestGetEmailStatusUseCase_NextAttemptAt(t *testing.T) {
+	newEmail := func(t *testing.T) *email.Email {
+		e, err := email.NewNotificationEmail("retry@example.com", "Retry", "Body")
+		require.NoError(t, err)
+		return e
+	}
+
+	t.Run("recently failed email reports the backoff delay", func(t *testing.T) {
+		failed := newEmail(t)
+		failed.MarkAsFailed("SMTP timeout")
+		failedAt := time.Now()
+
+		uc := NewGetEmailStatusUseCase(&statusEmailRepository{emails: map[uuid.UUID]*email.Email{failed.ID: failed}})
+		status, err := uc.Execute(context.Background(), failed.ID.String())
+		require.NoError(t, err)
+
+		// Primeira falha: RetryBaseDelay mais até 20% de jitter
+		require.NotNil(t, status.NextAttemptAt)
+		assert.Equal(t, "pending", status.Status)
+		assert.True(t, status.NextAttemptAt.After(failedAt))
+		delay := status.NextAttemptAt.Sub(failedAt)
+		assert.GreaterOrEqual(t, delay, email.RetryBaseDelay-time.Second)
+		assert.LessOrEqual(t, delay, email.RetryBaseDelay+email.RetryBaseDelay/5)
+	})
+
+	t.Run("sent email has no next attempt", func(t *testing.T) {
+		sent := newEmail(t)
+		sent.MarkAsSent()
+
+		uc := NewGetEmailStatusUseCase(&statusEmailRepository{emails: map[uuid.UUID]*email.Email{sent.ID: sent}})
+		status, err := uc.Execute(context.Background(), sent.ID.String())
+		require.NoError(t, err)
+
+		assert.Nil(t, status.NextAttemptAt)
+	})
+}
@@ -0,0 +1,76 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moura95/backend-challenge/internal/domain/email"
+)
+
+type EmailStatusResponse struct {
+	EmailID     string     `json:"email_id"`
+	To          string     `json:"to"`
+	Subject     string     `json:"subject"`
+	Type        string     `json:"type"`
+	Status      string     `json:"status"`
+	Attempts    int        `json:"attempts"`
+	MaxAttempts int        `json:"max_attempts"`
+	LastError   string     `json:"last_error,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	SentAt      *time.Time `json:"sent_at"`
+	// NextAttemptAt is when a pending email will be tried (again); it moves
+	// forward with the retry backoff after each failed attempt
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"`
+}
+
+type GetEmailStatusUseCase struct {
+	emailRepo email.Repository
+}
+
+func NewGetEmailStatusUseCase(emailRepo email.Repository) *GetEmailStatusUseCase {
+	return &GetEmailStatusUseCase{
+		emailRepo: emailRepo,
+	}
+}
+
+func (uc *GetEmailStatusUseCase) Execute(ctx context.Context, emailID string) (*EmailStatusResponse, error) {
+	// 1. Validar ID
+	parsedID, err := uuid.Parse(emailID)
+	if err != nil {
+		return nil, fmt.Errorf("usecase: get email status failed: invalid email ID format")
+	}
+
+	// 2. Buscar email
+	emailEntity, err := uc.emailRepo.GetByID(ctx, parsedID)
+	if err != nil {
+		return nil, fmt.Errorf("usecase: get email status failed: %w", err)
+	}
+
+	// 3. Montar resposta
+	return toEmailStatusResponse(emailEntity), nil
+}
+
+func toEmailStatusResponse(emailEntity *email.Email) *EmailStatusResponse {
+	response := &EmailStatusResponse{
+		EmailID:     emailEntity.ID.String(),
+		To:          emailEntity.To,
+		Subject:     emailEntity.Subject,
+		Type:        string(emailEntity.Type),
+		Status:      string(emailEntity.Status),
+		Attempts:    emailEntity.Attempts,
+		MaxAttempts: emailEntity.MaxAttempts,
+		LastError:   emailEntity.ErrorMsg,
+		CreatedAt:   emailEntity.CreatedAt,
+		SentAt:      emailEntity.SentAt,
+	}
+
+	// Só emails pendentes têm uma próxima tentativa
+	if emailEntity.Status == email.StatusPending && !emailEntity.NextAttemptAt.IsZero() {
+		nextAttemptAt := emailEntity.NextAttemptAt
+		response.NextAttemptAt = &nextAttemptAt
+	}
+
+	return response
+}
@@ -0,0 +1,59 @@
+package email
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/moura95/backend-challenge/internal/domain/email"
+)
+
+func TestGetEmailStatusUseCase_Execute(t *testing.T) {
+	server := setupSendWelcomeEmailTest(t)
+	defer server.cleanup()
+
+	ctx := context.Background()
+	useCase := NewGetEmailStatusUseCase(server.repos.Email)
+
+	t.Run("should return pending email with last error", func(t *testing.T) {
+		// Arrange
+		testEmail, err := email.NewNotificationEmail("status@example.com", "Status", "Body")
+		require.NoError(t, err)
+		require.NoError(t, server.repos.Email.Create(ctx, testEmail))
+
+		testEmail.MarkAsFailed("SMTP timeout")
+		require.NoError(t, server.repos.Email.Update(ctx, testEmail))
+
+		// Act
+		status, err := useCase.Execute(ctx, testEmail.ID.String())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, testEmail.ID.String(), status.EmailID)
+		assert.Equal(t, "notification", status.Type)
+		assert.Equal(t, "pending", status.Status)
+		assert.Equal(t, 1, status.Attempts)
+		assert.Equal(t, "SMTP timeout", status.LastError)
+		assert.Nil(t, status.SentAt)
+		require.NotNil(t, status.NextAttemptAt)
+		assert.WithinDuration(t, testEmail.NextAttemptAt, *status.NextAttemptAt, time.Second)
+	})
+
+	t.Run("should fail with unknown email", func(t *testing.T) {
+		_, err := useCase.Execute(ctx, uuid.New().String())
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "email not found")
+	})
+
+	t.Run("should fail with invalid ID", func(t *testing.T) {
+		_, err := useCase.Execute(ctx, "not-a-uuid")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid email ID format")
+	})
+}
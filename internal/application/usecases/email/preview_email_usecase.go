@@ -0,0 +1,92 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/moura95/backend-challenge/internal/domain/email"
+)
+
+const (
+	previewRecipient = "preview@example.com"
+	previewLink      = "https://example.com/preview?token=preview"
+)
+
+// ErrPreviewTypeNotSupported is returned for email types without a
+// server-side body template (notification bodies come from the caller).
+var ErrPreviewTypeNotSupported = errors.New("invalid preview type: must be welcome, password_reset or verification")
+
+type PreviewEmailRequest struct {
+	Type string
+	Name string
+}
+
+type PreviewEmailResponse struct {
+	Subject string
+	Body    string
+}
+
+// PreviewEmailUseCase renders an email body exactly as it would be queued,
+// without persisting or publishing anything.
+type PreviewEmailUseCase struct {
+	welcomeTemplate *email.WelcomeTemplate
+}
+
+func NewPreviewEmailUseCase() *PreviewEmailUseCase {
+	return &PreviewEmailUseCase{}
+}
+
+// WithWelcomeTemplate usa o mesmo template configurado no signup.
+func (uc *PreviewEmailUseCase) WithWelcomeTemplate(tmpl *email.WelcomeTemplate) *PreviewEmailUseCase {
+	uc.welcomeTemplate = tmpl
+	return uc
+}
+
+func (uc *PreviewEmailUseCase) Execute(ctx context.Context, req PreviewEmailRequest) (*PreviewEmailResponse, error) {
+	// 1. Validar nome
+	if req.Name == "" {
+		return nil, fmt.Errorf("usecase: preview email failed: name is required")
+	}
+
+	// 2. Montar o email em memória, com destinatário e links fictícios
+	userID := uuid.New().String()
+
+	var (
+		emailEntity *email.Email
+		err         error
+	)
+	switch email.EmailType(req.Type) {
+	case email.EmailTypeWelcome:
+		emailEntity, err = email.NewWelcomeEmailWithTemplate(email.WelcomeEmailData{
+			UserID:    userID,
+			UserName:  req.Name,
+			UserEmail: previewRecipient,
+		}, uc.welcomeTemplate)
+	case email.EmailTypePasswordReset:
+		emailEntity, err = email.NewPasswordResetEmail(email.PasswordResetEmailData{
+			UserID:    userID,
+			UserName:  req.Name,
+			UserEmail: previewRecipient,
+			ResetLink: previewLink,
+		})
+	case email.EmailTypeVerification:
+		emailEntity, err = email.NewVerificationEmail(email.VerificationEmailData{
+			UserID:           userID,
+			UserName:         req.Name,
+			UserEmail:        previewRecipient,
+			VerificationLink: previewLink,
+		})
+	default:
+		return nil, fmt.Errorf("usecase: preview email failed: %w", ErrPreviewTypeNotSupported)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("usecase: preview email failed: %w", err)
+	}
+
+	return &PreviewEmailResponse{
+		Subject: emailEntity.Subject,
+		Body:    emailEntity.Body,
+	}, nil
+}
@@ -0,0 +1,54 @@
+package email
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/moura95/backend-challenge/internal/domain/email"
+)
+
+func TestPreviewEmailUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should render the welcome body with the escaped name", func(t *testing.T) {
+		preview, err := NewPreviewEmailUseCase().Execute(ctx, PreviewEmailRequest{Type: "welcome", Name: "Ana <b>"})
+
+		require.NoError(t, err)
+		assert.Contains(t, preview.Body, "Ana &lt;b&gt;")
+		assert.NotContains(t, preview.Body, "Ana <b>")
+		assert.NotEmpty(t, preview.Subject)
+	})
+
+	t.Run("should use the configured welcome template", func(t *testing.T) {
+		tmpl, err := email.NewWelcomeTemplate("Oi {{.UserName}}", "<p>Custom {{.UserName}}</p>")
+		require.NoError(t, err)
+
+		preview, err := NewPreviewEmailUseCase().WithWelcomeTemplate(tmpl).
+			Execute(ctx, PreviewEmailRequest{Type: "welcome", Name: "Ana"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "Oi Ana", preview.Subject)
+		assert.Equal(t, "<p>Custom Ana</p>", preview.Body)
+	})
+
+	t.Run("should render reset and verification bodies with a placeholder link", func(t *testing.T) {
+		for _, emailType := range []string{"password_reset", "verification"} {
+			preview, err := NewPreviewEmailUseCase().Execute(ctx, PreviewEmailRequest{Type: emailType, Name: "Ana"})
+
+			require.NoError(t, err, emailType)
+			assert.Contains(t, preview.Body, "Ana", emailType)
+			assert.Contains(t, preview.Body, previewLink, emailType)
+		}
+	})
+
+	t.Run("should reject unsupported types and missing names", func(t *testing.T) {
+		_, err := NewPreviewEmailUseCase().Execute(ctx, PreviewEmailRequest{Type: "notification", Name: "Ana"})
+		assert.ErrorIs(t, err, ErrPreviewTypeNotSupported)
+
+		_, err = NewPreviewEmailUseCase().Execute(ctx, PreviewEmailRequest{Type: "welcome"})
+		assert.ErrorContains(t, err, "name is required")
+	})
+}
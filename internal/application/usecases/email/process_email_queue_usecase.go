@@ -2,17 +2,47 @@ package email
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/moura95/backend-challenge/internal/domain/email"
+	"github.com/moura95/backend-challenge/internal/infra/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// defaultBatchConcurrency is how many emails of a batch are sent in parallel.
+const defaultBatchConcurrency = 5
+
+// defaultSendingTimeout is how long an email may stay in sending before
+// ProcessPendingEmails considers its worker dead and returns it to pending.
+const defaultSendingTimeout = 10 * time.Minute
+
+// Batch sizes for ProcessPendingEmails: non-positive uses the default and
+// larger values are capped.
+const (
+	DefaultProcessBatchSize = 50
+	MaxProcessBatchSize     = 500
+)
+
+// ProcessPendingResult summarizes one ProcessPendingEmails batch. Processed
+// counts the pending emails picked up; those neither sent nor failed were
+// skipped (e.g. locked by another worker).
+type ProcessPendingResult struct {
+	Processed int `json:"processed"`
+	Sent      int `json:"sent"`
+	Failed    int `json:"failed"`
+}
+
 type ProcessEmailQueueUseCase struct {
 	emailRepo        email.Repository
 	emailSender      email.EmailService
 	maxRetryAttempts int
 	retryDelay       time.Duration
+	concurrency      int
+	sendingTimeout   time.Duration
 }
 
 func NewProcessEmailQueueUseCase(
@@ -24,37 +54,149 @@ func NewProcessEmailQueueUseCase(
 		emailSender:      emailSender,
 		maxRetryAttempts: 3,
 		retryDelay:       5 * time.Minute,
+		concurrency:      defaultBatchConcurrency,
+		sendingTimeout:   defaultSendingTimeout,
+	}
+}
+
+// WithConcurrency define quantos emails do lote são processados em paralelo.
+// Valores menores que 1 processam sequencialmente.
+func (uc *ProcessEmailQueueUseCase) WithConcurrency(workers int) *ProcessEmailQueueUseCase {
+	if workers < 1 {
+		workers = 1
 	}
+	uc.concurrency = workers
+	return uc
+}
+
+// WithSendingTimeout define após quanto tempo um email preso em sending volta
+// para pending. Deve ser maior que o timeout de envio SMTP.
+func (uc *ProcessEmailQueueUseCase) WithSendingTimeout(timeout time.Duration) *ProcessEmailQueueUseCase {
+	if timeout > 0 {
+		uc.sendingTimeout = timeout
+	}
+	return uc
 }
 
 func (uc *ProcessEmailQueueUseCase) Execute(ctx context.Context, message email.QueueMessage) error {
-	emailEntity, err := uc.emailRepo.GetByID(ctx, message.EmailID)
+	ctx, span := tracing.Start(ctx, "ProcessEmailQueueUseCase.Execute",
+		trace.WithAttributes(attribute.String("email.id", message.EmailID.String())))
+	_, err := uc.execute(ctx, message)
+	tracing.End(span, err)
+	return err
+}
+
+// processOutcome é o que aconteceu com um email ao ser processado.
+type processOutcome int
+
+const (
+	outcomeSkipped processOutcome = iota
+	outcomeSent
+	outcomeFailed
+)
+
+func (uc *ProcessEmailQueueUseCase) execute(ctx context.Context, message email.QueueMessage) (processOutcome, error) {
+	// 1. Reivindicar o email: com a linha travada, marcar como sending e
+	// confirmar antes do envio. Outros workers e lotes concorrentes ignoram
+	// emails em envio, sem que a transação fique aberta durante o SMTP.
+	var (
+		claimed  *email.Email
+		claimErr error
+	)
+	err := uc.emailRepo.LockForProcessing(ctx, message.EmailID, func(emailEntity *email.Email, repo email.Repository) error {
+		ok, err := uc.claim(emailEntity)
+		if err != nil || !ok {
+			claimErr = err
+			return nil
+		}
+		if err := repo.Update(ctx, emailEntity); err != nil {
+			return err
+		}
+		claimed = emailEntity
+		return nil
+	})
+	if errors.Is(err, email.ErrEmailLocked) {
+		fmt.Printf("Email ID %s is being processed by another worker, skipping\n", message.EmailID.String())
+		return outcomeSkipped, nil
+	}
+	if err != nil && ctx.Err() != nil {
+		// A transação foi desfeita pelo cancelamento: o email continua pendente
+		return outcomeSkipped, fmt.Errorf("usecase: process email queue failed: %w", err)
+	}
 	if err != nil {
-		return fmt.Errorf("usecase: process email queue failed: %w", err)
+		return outcomeFailed, fmt.Errorf("usecase: process email queue failed: %w", err)
+	}
+	if claimErr != nil {
+		return outcomeFailed, claimErr
+	}
+	if claimed == nil {
+		return outcomeSkipped, nil
 	}
 
+	// 5. Enviar fora da transação e registrar o resultado
+	return uc.deliver(ctx, claimed)
+}
+
+// claim decide se o email deve ser enviado agora e, nesse caso, o marca como
+// sending. Retorna false para emails que devem ser ignorados.
+func (uc *ProcessEmailQueueUseCase) claim(emailEntity *email.Email) (bool, error) {
 	fmt.Printf("Processing email ID: %s for user %s\n",
 		emailEntity.ID.String(), emailEntity.To)
 
 	// 2. Validar se email precisa ser processado
+	if emailEntity.Status == email.StatusSending {
+		fmt.Printf("Email ID %s is already being sent, skipping\n", emailEntity.ID.String())
+		return false, nil
+	}
+
 	if err := uc.validateEmailForProcessing(emailEntity); err != nil {
-		return err
+		return false, err
 	}
 
 	if emailEntity.Status == email.StatusSent {
-		fmt.Printf("Email ID %s already sent, skipping\n", emailEntity.ID.String())
-		return nil
+		return false, nil
+	}
+
+	// 3. Respeitar o backoff: só tentar quando a próxima tentativa estiver vencida
+	if !emailEntity.IsDue(time.Now()) {
+		fmt.Printf("Email ID %s scheduled for retry at %s, skipping\n",
+			emailEntity.ID.String(), emailEntity.NextAttemptAt.Format(time.RFC3339))
+		return false, nil
 	}
 
-	// 3. Tentar enviar email
-	err = uc.attemptEmailSend(ctx, emailEntity)
+	// 4. Marcar como em envio
+	if err := emailEntity.MarkAsSending(); err != nil {
+		return false, fmt.Errorf("usecase: process email queue failed: %w", err)
+	}
+
+	return true, nil
+}
+
+func (uc *ProcessEmailQueueUseCase) deliver(ctx context.Context, emailEntity *email.Email) (processOutcome, error) {
+	err := uc.attemptEmailSend(ctx, emailEntity)
+
+	// O resultado do envio é persistido mesmo se ctx for cancelado depois,
+	// para o email não ficar preso em sending
+	persistCtx := context.WithoutCancel(ctx)
+
+	if err != nil && ctx.Err() != nil {
+		// Envio interrompido pelo cancelamento não conta como tentativa
+		emailEntity.ReleaseSending()
+		if updateErr := uc.emailRepo.Update(persistCtx, emailEntity); updateErr != nil {
+			fmt.Printf("Failed to release email ID %s: %v\n", emailEntity.ID.String(), updateErr)
+		}
+		return outcomeSkipped, fmt.Errorf("usecase: process email queue failed: %w", ctx.Err())
+	}
 	if err != nil {
-		// 4. Tratar falha no envio
-		return uc.handleSendFailure(ctx, emailEntity, err)
+		// 6. Tratar falha no envio
+		return outcomeFailed, uc.handleSendFailure(persistCtx, uc.emailRepo, emailEntity, err)
 	}
 
-	// 5. Marcar como enviado com sucesso
-	return uc.markEmailAsSent(ctx, emailEntity)
+	// 7. Marcar como enviado com sucesso
+	if err := uc.markEmailAsSent(persistCtx, uc.emailRepo, emailEntity); err != nil {
+		return outcomeFailed, err
+	}
+	return outcomeSent, nil
 }
 
 func (uc *ProcessEmailQueueUseCase) validateEmailForProcessing(emailEntity *email.Email) error {
@@ -80,20 +222,20 @@ func (uc *ProcessEmailQueueUseCase) attemptEmailSend(ctx context.Context, emailE
 	return nil
 }
 
-func (uc *ProcessEmailQueueUseCase) handleSendFailure(ctx context.Context, emailEntity *email.Email, sendErr error) error {
+func (uc *ProcessEmailQueueUseCase) handleSendFailure(ctx context.Context, repo email.Repository, emailEntity *email.Email, sendErr error) error {
 	// Marcar como falha
 	emailEntity.MarkAsFailed(sendErr.Error())
 
 	// Persistir o estado de falha
-	updateErr := uc.emailRepo.Update(ctx, emailEntity)
+	updateErr := repo.Update(ctx, emailEntity)
 	if updateErr != nil {
 		return fmt.Errorf("usecase: process email queue failed: send error and update failed. Send error: %w, Update error: %v",
 			sendErr, updateErr)
 	}
 
 	if emailEntity.CanRetry() {
-		fmt.Printf("Email send failed but will retry. Email ID: %s, Error: %v\n",
-			emailEntity.ID.String(), sendErr)
+		fmt.Printf("Email send failed but will retry at %s. Email ID: %s, Error: %v\n",
+			emailEntity.NextAttemptAt.Format(time.RFC3339), emailEntity.ID.String(), sendErr)
 		return nil
 	}
 
@@ -101,12 +243,12 @@ func (uc *ProcessEmailQueueUseCase) handleSendFailure(ctx context.Context, email
 		emailEntity.MaxAttempts, sendErr)
 }
 
-func (uc *ProcessEmailQueueUseCase) markEmailAsSent(ctx context.Context, emailEntity *email.Email) error {
+func (uc *ProcessEmailQueueUseCase) markEmailAsSent(ctx context.Context, repo email.Repository, emailEntity *email.Email) error {
 	// Marcar como enviado
 	emailEntity.MarkAsSent()
 
 	// Persistir o sucesso
-	err := uc.emailRepo.Update(ctx, emailEntity)
+	err := repo.Update(ctx, emailEntity)
 	if err != nil {
 		return fmt.Errorf("usecase: process email queue failed: update after successful send failed: %w", err)
 	}
@@ -115,34 +257,93 @@ func (uc *ProcessEmailQueueUseCase) markEmailAsSent(ctx context.Context, emailEn
 	return nil
 }
 
-func (uc *ProcessEmailQueueUseCase) ProcessPendingEmails(ctx context.Context, batchSize int) error {
+// ProcessPendingEmails devolve para pending os emails presos em sending,
+// processa os pendentes cuja próxima tentativa já venceu e retorna quantos
+// foram enviados e quantos falharam. Se ctx for cancelado, nenhum novo envio
+// começa, os restantes continuam pendentes e o erro do contexto é retornado
+// junto com o resultado parcial.
+func (uc *ProcessEmailQueueUseCase) ProcessPendingEmails(ctx context.Context, batchSize int) (*ProcessPendingResult, error) {
+	if batchSize <= 0 {
+		batchSize = DefaultProcessBatchSize
+	}
+	batchSize = min(batchSize, MaxProcessBatchSize)
+
+	// Emails presos em sending (worker caiu no meio do envio) voltam para a fila
+	reclaimed, err := uc.emailRepo.ReclaimStaleSending(ctx, uc.sendingTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("usecase: process pending emails failed: %w", err)
+	}
+	if reclaimed > 0 {
+		fmt.Printf("Reclaimed %d emails stuck in sending\n", reclaimed)
+	}
+
 	pendingEmails, err := uc.emailRepo.GetPendingEmails(ctx, batchSize)
 	if err != nil {
-		return fmt.Errorf("usecase: process pending emails failed: %w", err)
+		return nil, fmt.Errorf("usecase: process pending emails failed: %w", err)
 	}
 
+	result := &ProcessPendingResult{Processed: len(pendingEmails)}
 	if len(pendingEmails) == 0 {
-		return nil // Nenhum email pendente
+		return result, nil // Nenhum email pendente
 	}
 
-	successCount := 0
-	failureCount := 0
+	// Pool de workers limitado: cada email segue sua própria transição de status
+	// (com lock por linha) e falhas individuais não interrompem o lote
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+
+	jobs := make(chan *email.Email)
+	workers := max(1, min(uc.concurrency, len(pendingEmails)))
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for emailEntity := range jobs {
+				if ctx.Err() != nil {
+					continue
+				}
 
+				message := email.QueueMessage{
+					EmailID: emailEntity.ID,
+					Type:    emailEntity.Type,
+				}
+
+				outcome, err := uc.execute(ctx, message)
+				if err != nil {
+					fmt.Printf("Failed to process email ID %s: %v\n", emailEntity.ID.String(), err)
+				}
+
+				mu.Lock()
+				switch outcome {
+				case outcomeSent:
+					result.Sent++
+				case outcomeFailed:
+					result.Failed++
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+dispatch:
 	for _, emailEntity := range pendingEmails {
-		message := email.QueueMessage{
-			EmailID: emailEntity.ID, //
-			Type:    emailEntity.Type,
+		select {
+		case jobs <- emailEntity:
+		case <-ctx.Done():
+			break dispatch
 		}
+	}
+	close(jobs)
+	wg.Wait()
 
-		err := uc.Execute(ctx, message)
-		if err != nil {
-			failureCount++
-			fmt.Printf("Failed to process email ID %s: %v\n", emailEntity.ID.String(), err)
-		} else {
-			successCount++
-		}
+	if err := ctx.Err(); err != nil {
+		fmt.Printf("Batch processing cancelled. Success: %d, Failures: %d\n", result.Sent, result.Failed)
+		return result, fmt.Errorf("usecase: process pending emails failed: %w", err)
 	}
 
-	fmt.Printf("Batch processing completed. Success: %d, Failures: %d\n", successCount, failureCount)
-	return nil
+	fmt.Printf("Batch processing completed. Success: %d, Failures: %d\n", result.Sent, result.Failed)
+	return result, nil
 }
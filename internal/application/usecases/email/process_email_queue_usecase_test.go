@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -89,7 +90,24 @@ func runEmailQueueMigrations(db *sqlx.DB) error {
 		error_msg    TEXT,
 		sent_at      TIMESTAMPTZ,
 		created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
-		updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		plain_body   TEXT NOT NULL DEFAULT '',
+		next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		cc_emails    TEXT[] NOT NULL DEFAULT '{}',
+		bcc_emails   TEXT[] NOT NULL DEFAULT '{}',
+		dedup_key    VARCHAR(255),
+		scheduled_at TIMESTAMPTZ
+	);
+	
+	-- Email attachments table
+	CREATE TABLE IF NOT EXISTS email_attachments (
+		id           BIGSERIAL PRIMARY KEY,
+		email_uuid   UUID NOT NULL,
+		filename     VARCHAR(255) NOT NULL,
+		content_type VARCHAR(255) NOT NULL,
+		content      BYTEA NOT NULL,
+		created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		FOREIGN KEY (email_uuid) REFERENCES emails(uuid) ON DELETE CASCADE
 	);
 	
 	-- Indexes
@@ -233,6 +251,43 @@ func TestProcessEmailQueueUseCase_Execute(t *testing.T) {
 		assert.Equal(t, "email send failed: SMTP connection failed", updatedEmail.ErrorMsg)
 	})
 
+	t.Run("should send only once when the same message is delivered concurrently", func(t *testing.T) {
+		testEmail := createTestEmailForQueue(t, server, "concurrent@example.com", "Concurrent", "Body")
+
+		// Slow send so the second delivery arrives while the first holds the lock
+		mockEmailService := new(MockEmailService)
+		mockEmailService.On("SendEmailAuto", mock.Anything, mock.AnythingOfType("*email.Email")).
+			After(200 * time.Millisecond).Return(nil)
+
+		useCase := NewProcessEmailQueueUseCase(server.repos.Email, mockEmailService)
+
+		message := email.QueueMessage{
+			EmailID: testEmail.ID,
+			Type:    email.EmailTypeWelcome,
+		}
+
+		var wg sync.WaitGroup
+		errs := make([]error, 2)
+		for i := range errs {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				errs[i] = useCase.Execute(ctx, message)
+			}(i)
+		}
+		wg.Wait()
+
+		// Assert
+		for _, err := range errs {
+			assert.NoError(t, err)
+		}
+		mockEmailService.AssertNumberOfCalls(t, "SendEmailAuto", 1)
+
+		updatedEmail, err := server.repos.Email.GetByID(ctx, testEmail.ID)
+		require.NoError(t, err)
+		assert.Equal(t, email.StatusSent, updatedEmail.Status)
+	})
+
 	t.Run("should fail permanently after max attempts", func(t *testing.T) {
 		// Create test email with max attempts reached
 		testEmail := createTestEmailForQueue(t, server, "maxfail@example.com", "Max Fail Test", "Body")
@@ -318,11 +373,12 @@ func TestProcessEmailQueueUseCase_ProcessPendingEmails(t *testing.T) {
 		useCase := NewProcessEmailQueueUseCase(server.repos.Email, mockEmailService)
 
 		// Execute batch processing
-		err := useCase.ProcessPendingEmails(ctx, 10)
+		result, err := useCase.ProcessPendingEmails(ctx, 10)
 
 		// Assert
 		require.NoError(t, err)
 		mockEmailService.AssertExpectations(t)
+		assert.Equal(t, &ProcessPendingResult{Processed: 3, Sent: 3, Failed: 0}, result)
 
 		// Verify all emails are sent
 		emails := []*email.Email{email1, email2, email3}
@@ -351,11 +407,12 @@ func TestProcessEmailQueueUseCase_ProcessPendingEmails(t *testing.T) {
 		useCase := NewProcessEmailQueueUseCase(server.repos.Email, mockEmailService)
 
 		// Execute batch processing
-		err := useCase.ProcessPendingEmails(ctx, 10)
+		result, err := useCase.ProcessPendingEmails(ctx, 10)
 
 		// Assert - should not error even with some failures
 		require.NoError(t, err)
 		mockEmailService.AssertExpectations(t)
+		assert.Equal(t, &ProcessPendingResult{Processed: 2, Sent: 1, Failed: 1}, result)
 
 		// Verify success email is sent
 		updatedSuccess, err := server.repos.Email.GetByID(ctx, successEmail.ID)
@@ -381,7 +438,7 @@ func TestProcessEmailQueueUseCase_ProcessPendingEmails(t *testing.T) {
 		useCase := NewProcessEmailQueueUseCase(freshServer.repos.Email, mockEmailService)
 
 		// Execute batch processing
-		err := useCase.ProcessPendingEmails(ctx, 10)
+		_, err := useCase.ProcessPendingEmails(ctx, 10)
 
 		// Assert - should not error with empty batch
 		require.NoError(t, err)
@@ -403,7 +460,7 @@ func TestProcessEmailQueueUseCase_ProcessPendingEmails(t *testing.T) {
 		useCase := NewProcessEmailQueueUseCase(server.repos.Email, mockEmailService)
 
 		// Execute with batch size 3
-		err := useCase.ProcessPendingEmails(ctx, 3)
+		_, err := useCase.ProcessPendingEmails(ctx, 3)
 
 		// Assert
 		require.NoError(t, err)
@@ -421,6 +478,85 @@ func TestProcessEmailQueueUseCase_ProcessPendingEmails(t *testing.T) {
 		assert.GreaterOrEqual(t, pendingCount, 2) // At least 2 should remain pending
 	})
 
+	t.Run("should schedule retry with backoff and skip email until due", func(t *testing.T) {
+		// Fresh server so emails left pending by other subtests don't interfere
+		freshServer := setupEmailQueueTest(t)
+		defer freshServer.cleanup()
+
+		testEmail := createTestEmailForQueue(t, freshServer, "backoff@example.com", "Backoff", "Body")
+
+		// First attempt fails
+		failingService := new(MockEmailService)
+		failingService.On("SendEmailAuto", ctx, mock.AnythingOfType("*email.Email")).Return(errors.New("SMTP timeout")).Once()
+
+		result, err := NewProcessEmailQueueUseCase(freshServer.repos.Email, failingService).ProcessPendingEmails(ctx, 10)
+		require.NoError(t, err)
+		failingService.AssertExpectations(t)
+		assert.Equal(t, &ProcessPendingResult{Processed: 1, Sent: 0, Failed: 1}, result)
+
+		// Retry is scheduled in the future
+		updatedEmail, err := freshServer.repos.Email.GetByID(ctx, testEmail.ID)
+		require.NoError(t, err)
+		assert.Equal(t, email.StatusPending, updatedEmail.Status)
+		assert.Equal(t, 1, updatedEmail.Attempts)
+		assert.True(t, updatedEmail.NextAttemptAt.After(time.Now()))
+
+		// Before the retry time the email is not picked up
+		idleService := new(MockEmailService)
+		result, err = NewProcessEmailQueueUseCase(freshServer.repos.Email, idleService).ProcessPendingEmails(ctx, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.Processed)
+		idleService.AssertNotCalled(t, "SendEmailAuto")
+
+		// Once the retry time passes the email is sent
+		_, err = freshServer.db.Exec("UPDATE emails SET next_attempt_at = NOW() - INTERVAL '1 second' WHERE uuid = $1", testEmail.ID)
+		require.NoError(t, err)
+
+		succeedingService := new(MockEmailService)
+		succeedingService.On("SendEmailAuto", ctx, mock.AnythingOfType("*email.Email")).Return(nil).Once()
+
+		_, err = NewProcessEmailQueueUseCase(freshServer.repos.Email, succeedingService).ProcessPendingEmails(ctx, 10)
+		require.NoError(t, err)
+		succeedingService.AssertExpectations(t)
+
+		sentEmail, err := freshServer.repos.Email.GetByID(ctx, testEmail.ID)
+		require.NoError(t, err)
+		assert.Equal(t, email.StatusSent, sentEmail.Status)
+	})
+
+	t.Run("should process batch in parallel with worker pool", func(t *testing.T) {
+		// Fresh server so emails left pending by other subtests don't interfere
+		freshServer := setupEmailQueueTest(t)
+		defer freshServer.cleanup()
+
+		const total = 10
+		const sendLatency = 100 * time.Millisecond
+		for i := 0; i < total; i++ {
+			createTestEmailForQueue(t, freshServer, fmt.Sprintf("parallel%d@example.com", i), "Parallel", "Body")
+		}
+
+		// Slow SMTP server
+		mockEmailService := new(MockEmailService)
+		mockEmailService.On("SendEmailAuto", ctx, mock.AnythingOfType("*email.Email")).
+			After(sendLatency).Return(nil).Times(total)
+
+		useCase := NewProcessEmailQueueUseCase(freshServer.repos.Email, mockEmailService).WithConcurrency(total)
+
+		start := time.Now()
+		_, err := useCase.ProcessPendingEmails(ctx, total)
+		elapsed := time.Since(start)
+
+		// Assert - sequential processing would take total * sendLatency
+		require.NoError(t, err)
+		mockEmailService.AssertExpectations(t)
+		assert.Less(t, elapsed, total*sendLatency/2)
+
+		var sentCount int
+		err = freshServer.db.Get(&sentCount, "SELECT COUNT(*) FROM emails WHERE status = 'sent'")
+		require.NoError(t, err)
+		assert.Equal(t, total, sentCount)
+	})
+
 	t.Run("should handle repository errors gracefully", func(t *testing.T) {
 		// This test would require more complex mocking of repository
 		// For now, we'll test a scenario where email processing fails due to update error
@@ -0,0 +1,118 @@
+package email
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/moura95/backend-challenge/internal/domain/email"
+)
+
+// inflightSender records the persisted status of each email when its send
+// starts and blocks until release is closed.
+type inflightSender struct {
+	repo    *memoryEmailRepository
+	started chan struct{}
+	release chan struct{}
+	mu      sync.Mutex
+	seen    []email.Status
+}
+
+func (s *inflightSender) SendEmail(ctx context.Context, e *email.Email) error {
+	s.mu.Lock()
+	s.seen = append(s.seen, s.repo.get(e.ID).Status)
+	s.mu.Unlock()
+
+	if s.started != nil {
+		s.started <- struct{}{}
+	}
+	if s.release != nil {
+		<-s.release
+	}
+	return nil
+}
+
+func (s *inflightSender) SendEmailDev(ctx context.Context, e *email.Email) error {
+	return s.SendEmail(ctx, e)
+}
+
+func (s *inflightSender) SendEmailAuto(ctx context.Context, e *email.Email) error {
+	return s.SendEmail(ctx, e)
+}
+
+func (s *inflightSender) calls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.seen)
+}
+
+func TestProcessEmailQueueUseCase_SendingStatus(t *testing.T) {
+	newEmail := func(t *testing.T) *email.Email {
+		e, err := email.NewNotificationEmail("user@example.com", "Subject", "<p>Body</p>")
+		require.NoError(t, err)
+		return e
+	}
+
+	t.Run("persists sending before the SMTP call", func(t *testing.T) {
+		e := newEmail(t)
+		repo := newMemoryEmailRepository(e)
+		sender := &inflightSender{repo: repo}
+
+		err := NewProcessEmailQueueUseCase(repo, sender).Execute(context.Background(), email.QueueMessage{EmailID: e.ID, Type: e.Type})
+		require.NoError(t, err)
+
+		assert.Equal(t, []email.Status{email.StatusSending}, sender.seen)
+		assert.Equal(t, email.StatusSent, repo.get(e.ID).Status)
+	})
+
+	t.Run("concurrent batch and message skip the in-flight email", func(t *testing.T) {
+		e := newEmail(t)
+		repo := newMemoryEmailRepository(e)
+		sender := &inflightSender{repo: repo, started: make(chan struct{}, 1), release: make(chan struct{})}
+		useCase := NewProcessEmailQueueUseCase(repo, sender)
+
+		done := make(chan error, 1)
+		go func() {
+			_, err := useCase.ProcessPendingEmails(context.Background(), 10)
+			done <- err
+		}()
+		<-sender.started
+
+		// Enquanto o primeiro envio está em andamento
+		result, err := useCase.ProcessPendingEmails(context.Background(), 10)
+		require.NoError(t, err)
+		assert.Equal(t, ProcessPendingResult{}, *result)
+
+		err = useCase.Execute(context.Background(), email.QueueMessage{EmailID: e.ID, Type: e.Type})
+		require.NoError(t, err)
+
+		close(sender.release)
+		require.NoError(t, <-done)
+
+		assert.Equal(t, 1, sender.calls())
+		assert.Equal(t, email.StatusSent, repo.get(e.ID).Status)
+	})
+
+	t.Run("reclaims emails stuck in sending after the timeout", func(t *testing.T) {
+		stale, fresh := newEmail(t), newEmail(t)
+		require.NoError(t, stale.MarkAsSending())
+		require.NoError(t, fresh.MarkAsSending())
+		repo := newMemoryEmailRepository(stale, fresh)
+		repo.updatedAt[stale.ID] = time.Now().Add(-time.Hour)
+
+		sender := &inflightSender{repo: repo}
+		useCase := NewProcessEmailQueueUseCase(repo, sender).WithSendingTimeout(10 * time.Minute)
+
+		result, err := useCase.ProcessPendingEmails(context.Background(), 10)
+		require.NoError(t, err)
+
+		assert.Equal(t, ProcessPendingResult{Processed: 1, Sent: 1}, *result)
+		assert.Equal(t, email.StatusSent, repo.get(stale.ID).Status)
+		assert.Equal(t, 0, repo.get(stale.ID).Attempts)
+		assert.Equal(t, email.StatusSending, repo.get(fresh.ID).Status)
+	})
+}
@@ -0,0 +1,177 @@
+package email
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/moura95/backend-challenge/internal/domain/email"
+)
+
+// memoryEmailRepository keeps emails in memory, in creation order.
+type memoryEmailRepository struct {
+	email.Repository
+	mu        sync.Mutex
+	order     []uuid.UUID
+	emails    map[uuid.UUID]*email.Email
+	updatedAt map[uuid.UUID]time.Time
+}
+
+func newMemoryEmailRepository(emails ...*email.Email) *memoryEmailRepository {
+	repo := &memoryEmailRepository{emails: map[uuid.UUID]*email.Email{}, updatedAt: map[uuid.UUID]time.Time{}}
+	for _, e := range emails {
+		repo.order = append(repo.order, e.ID)
+		repo.emails[e.ID] = e
+		repo.updatedAt[e.ID] = time.Now()
+	}
+	return repo
+}
+
+func (r *memoryEmailRepository) GetPendingEmails(ctx context.Context, limit int) ([]*email.Email, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var pending []*email.Email
+	for _, id := range r.order {
+		if e := r.emails[id]; e.Status == email.StatusPending && len(pending) < limit {
+			copied := *e
+			pending = append(pending, &copied)
+		}
+	}
+	return pending, nil
+}
+
+func (r *memoryEmailRepository) Update(ctx context.Context, e *email.Email) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	copied := *e
+	r.emails[e.ID] = &copied
+	r.updatedAt[e.ID] = time.Now()
+	return nil
+}
+
+func (r *memoryEmailRepository) ReclaimStaleSending(ctx context.Context, olderThan time.Duration) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var reclaimed int64
+	for id, e := range r.emails {
+		if e.Status == email.StatusSending && time.Since(r.updatedAt[id]) > olderThan {
+			e.Status = email.StatusPending
+			r.updatedAt[id] = time.Now()
+			reclaimed++
+		}
+	}
+	return reclaimed, nil
+}
+
+func (r *memoryEmailRepository) LockForProcessing(ctx context.Context, id uuid.UUID, fn func(*email.Email, email.Repository) error) error {
+	r.mu.Lock()
+	stored, ok := r.emails[id]
+	if !ok {
+		r.mu.Unlock()
+		return email.ErrEmailNotFound
+	}
+	copied := *stored
+	r.mu.Unlock()
+
+	return fn(&copied, r)
+}
+
+func (r *memoryEmailRepository) get(id uuid.UUID) *email.Email {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.emails[id]
+}
+
+// cancellingSender sends the first email and cancels the batch while the
+// second one is in flight, blocking until the cancellation arrives.
+type cancellingSender struct {
+	cancel context.CancelFunc
+	mu     sync.Mutex
+	calls  int
+}
+
+func (s *cancellingSender) SendEmail(ctx context.Context, e *email.Email) error {
+	s.mu.Lock()
+	s.calls++
+	calls := s.calls
+	s.mu.Unlock()
+
+	if calls == 1 {
+		return nil
+	}
+	s.cancel()
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (s *cancellingSender) SendEmailDev(ctx context.Context, e *email.Email) error {
+	return s.SendEmail(ctx, e)
+}
+
+func (s *cancellingSender) SendEmailAuto(ctx context.Context, e *email.Email) error {
+	return s.SendEmail(ctx, e)
+}
+
+func TestProcessEmailQueueUseCase_ProcessPendingEmailsCancellation(t *testing.T) {
+	t.Run("stops mid-batch and leaves the rest pending", func(t *testing.T) {
+		var emails []*email.Email
+		for i := 0; i < 10; i++ {
+			e, err := email.NewNotificationEmail("user@example.com", "Subject", "<p>Body</p>")
+			require.NoError(t, err)
+			emails = append(emails, e)
+		}
+		repo := newMemoryEmailRepository(emails...)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		sender := &cancellingSender{cancel: cancel}
+		useCase := NewProcessEmailQueueUseCase(repo, sender).WithConcurrency(1)
+
+		start := time.Now()
+		result, err := useCase.ProcessPendingEmails(ctx, 10)
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Less(t, time.Since(start), time.Second)
+		require.NotNil(t, result)
+		assert.Equal(t, 1, result.Sent)
+		assert.Equal(t, 0, result.Failed)
+		assert.Equal(t, 2, sender.calls)
+
+		assert.Equal(t, email.StatusSent, repo.get(emails[0].ID).Status)
+		for _, e := range emails[1:] {
+			stored := repo.get(e.ID)
+			assert.Equal(t, email.StatusPending, stored.Status)
+			// O envio interrompido não conta como tentativa
+			assert.Equal(t, 0, stored.Attempts)
+		}
+	})
+
+	t.Run("returns immediately when already cancelled", func(t *testing.T) {
+		e, err := email.NewNotificationEmail("user@example.com", "Subject", "<p>Body</p>")
+		require.NoError(t, err)
+		repo := newMemoryEmailRepository(e)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		sender := &cancellingSender{cancel: cancel}
+
+		_, err = NewProcessEmailQueueUseCase(repo, sender).ProcessPendingEmails(ctx, 10)
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, 0, sender.calls)
+		assert.Equal(t, email.StatusPending, repo.get(e.ID).Status)
+	})
+}
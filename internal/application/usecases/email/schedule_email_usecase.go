@@ -0,0 +1,77 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/moura95/backend-challenge/internal/domain/email"
+)
+
+// ErrScheduleNotInFuture is returned when SendAt is not after the current time.
+var ErrScheduleNotInFuture = errors.New("send_at must be in the future")
+
+type ScheduleEmailRequest struct {
+	To          string             `json:"to"`
+	Cc          []string           `json:"cc,omitempty"`
+	Bcc         []string           `json:"bcc,omitempty"`
+	Subject     string             `json:"subject"`
+	Body        string             `json:"body"`
+	Attachments []email.Attachment `json:"attachments,omitempty"`
+	SendAt      time.Time          `json:"send_at"`
+}
+
+type ScheduleEmailResponse struct {
+	EmailID     string `json:"email_id"`
+	Status      string `json:"status"`
+	ScheduledAt string `json:"scheduled_at"`
+}
+
+// ScheduleEmailUseCase grava uma notificação para ser enviada no futuro. Nada
+// é publicado na fila: o email fica pendente e o processamento periódico de
+// pendentes o envia quando SendAt chegar.
+type ScheduleEmailUseCase struct {
+	emailRepo email.Repository
+	now       func() time.Time
+}
+
+func NewScheduleEmailUseCase(emailRepo email.Repository) *ScheduleEmailUseCase {
+	return &ScheduleEmailUseCase{
+		emailRepo: emailRepo,
+		now:       time.Now,
+	}
+}
+
+func (uc *ScheduleEmailUseCase) Execute(ctx context.Context, req ScheduleEmailRequest) (*ScheduleEmailResponse, error) {
+	// 1. Validar horário de envio
+	if !req.SendAt.After(uc.now()) {
+		return nil, fmt.Errorf("usecase: schedule email failed: %w", ErrScheduleNotInFuture)
+	}
+
+	// 2. Criar entidade de email (valida destinatários, assunto, corpo e anexos)
+	emailEntity, err := email.NewNotificationEmailFromData(email.NotificationEmailData{
+		To:          req.To,
+		Cc:          req.Cc,
+		Bcc:         req.Bcc,
+		Subject:     req.Subject,
+		Body:        req.Body,
+		Attachments: req.Attachments,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("usecase: schedule email failed: %w", err)
+	}
+	emailEntity.Schedule(req.SendAt)
+
+	// 3. Salvar como pendente, ainda não elegível para envio
+	if err := uc.emailRepo.Create(ctx, emailEntity); err != nil {
+		return nil, fmt.Errorf("usecase: schedule email failed: %w", err)
+	}
+
+	// 4. Retornar resposta
+	return &ScheduleEmailResponse{
+		EmailID:     emailEntity.ID.String(),
+		Status:      string(emailEntity.Status),
+		ScheduledAt: req.SendAt.Format(time.RFC3339),
+	}, nil
+}
@@ -0,0 +1,113 @@
+package email
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moura95/backend-challenge/internal/domain/email"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scheduledEmailRepository keeps created emails in memory.
+type scheduledEmailRepository struct {
+	email.Repository
+	emails []*email.Email
+}
+
+func (r *scheduledEmailRepository) Create(ctx context.Context, e *email.Email) error {
+	r.emails = append(r.emails, e)
+	return nil
+}
+
+func (r *scheduledEmailRepository) CreateIfNotExists(ctx context.Context, e *email.Email, window time.Duration) (bool, error) {
+	return true, r.Create(ctx, e)
+}
+
+func TestScheduleEmailUseCase_Execute(t *testing.T) {
+	t.Run("stores a pending email that is not due yet", func(t *testing.T) {
+		repo := &scheduledEmailRepository{}
+		uc := NewScheduleEmailUseCase(repo)
+		sendAt := time.Now().Add(time.Hour)
+
+		response, err := uc.Execute(context.Background(), ScheduleEmailRequest{
+			To:      "user@example.com",
+			Subject: "Reminder",
+			Body:    "Your trial ends tomorrow",
+			SendAt:  sendAt,
+		})
+		require.NoError(t, err)
+
+		require.Len(t, repo.emails, 1)
+		scheduled := repo.emails[0]
+		assert.Equal(t, scheduled.ID.String(), response.EmailID)
+		assert.Equal(t, string(email.StatusPending), response.Status)
+		assert.Equal(t, sendAt.Format(time.RFC3339), response.ScheduledAt)
+		require.NotNil(t, scheduled.ScheduledAt)
+		assert.False(t, scheduled.IsDue(time.Now()))
+		assert.True(t, scheduled.IsDue(sendAt))
+	})
+
+	t.Run("rejects a send time that is not in the future", func(t *testing.T) {
+		repo := &scheduledEmailRepository{}
+		uc := NewScheduleEmailUseCase(repo)
+
+		_, err := uc.Execute(context.Background(), ScheduleEmailRequest{
+			To:      "user@example.com",
+			Subject: "Reminder",
+			Body:    "Too late",
+			SendAt:  time.Now().Add(-time.Minute),
+		})
+		assert.ErrorIs(t, err, ErrScheduleNotInFuture)
+		assert.Empty(t, repo.emails)
+	})
+}
+
+func TestSendWelcomeEmailUseCase_Delay(t *testing.T) {
+	req := SendWelcomeEmailRequest{
+		UserID:    uuid.New().String(),
+		UserName:  "John Doe",
+		UserEmail: "john@example.com",
+	}
+
+	t.Run("delayed welcome email is scheduled instead of queued", func(t *testing.T) {
+		repo := &scheduledEmailRepository{}
+		publisher := &countingWelcomePublisher{}
+		uc := NewSendWelcomeEmailUseCase(repo, publisher)
+
+		delayed := req
+		delayed.Delay = 30 * time.Minute
+		response, err := uc.Execute(context.Background(), delayed)
+		require.NoError(t, err)
+
+		require.Len(t, repo.emails, 1)
+		require.NotNil(t, repo.emails[0].ScheduledAt)
+		assert.NotEmpty(t, response.ScheduledAt)
+		assert.False(t, repo.emails[0].IsDue(time.Now()))
+		assert.Empty(t, publisher.published)
+	})
+
+	t.Run("zero delay queues immediately", func(t *testing.T) {
+		repo := &scheduledEmailRepository{}
+		publisher := &countingWelcomePublisher{}
+		uc := NewSendWelcomeEmailUseCase(repo, publisher)
+
+		response, err := uc.Execute(context.Background(), req)
+		require.NoError(t, err)
+
+		assert.Empty(t, response.ScheduledAt)
+		assert.Nil(t, repo.emails[0].ScheduledAt)
+		assert.Len(t, publisher.published, 1)
+	})
+
+	t.Run("rejects a negative delay", func(t *testing.T) {
+		uc := NewSendWelcomeEmailUseCase(&scheduledEmailRepository{}, &countingWelcomePublisher{})
+
+		delayed := req
+		delayed.Delay = -time.Minute
+		_, err := uc.Execute(context.Background(), delayed)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,119 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/moura95/backend-challenge/internal/domain/email"
+)
+
+// Tamanhos de página usados quando a configuração não define outros
+const (
+	DefaultSearchPageSize    = 10
+	DefaultMaxSearchPageSize = 100
+)
+
+type SearchEmailsRequest struct {
+	To            string     `json:"to"`
+	Status        string     `json:"status"`
+	Type          string     `json:"type"`
+	CreatedAfter  *time.Time `json:"created_after"`
+	CreatedBefore *time.Time `json:"created_before"`
+	Page          int        `json:"page"`
+	PageSize      int        `json:"page_size"`
+}
+
+type SearchEmailsResponse struct {
+	Emails   []*EmailStatusResponse `json:"emails"`
+	Total    int                    `json:"total"`
+	Page     int                    `json:"page"`
+	PageSize int                    `json:"page_size"`
+}
+
+type SearchEmailsUseCase struct {
+	emailRepo       email.Repository
+	defaultPageSize int
+	maxPageSize     int
+}
+
+func NewSearchEmailsUseCase(emailRepo email.Repository) *SearchEmailsUseCase {
+	return &SearchEmailsUseCase{
+		emailRepo:       emailRepo,
+		defaultPageSize: DefaultSearchPageSize,
+		maxPageSize:     DefaultMaxSearchPageSize,
+	}
+}
+
+// WithPageSizeLimits define o tamanho de página padrão e o máximo permitido.
+// Valores não positivos mantêm o padrão; o padrão nunca passa do máximo.
+func (uc *SearchEmailsUseCase) WithPageSizeLimits(defaultSize, maxSize int) *SearchEmailsUseCase {
+	if maxSize > 0 {
+		uc.maxPageSize = maxSize
+	}
+	if defaultSize > 0 {
+		uc.defaultPageSize = defaultSize
+	}
+	if uc.defaultPageSize > uc.maxPageSize {
+		uc.defaultPageSize = uc.maxPageSize
+	}
+	return uc
+}
+
+func (uc *SearchEmailsUseCase) Execute(ctx context.Context, req SearchEmailsRequest) (*SearchEmailsResponse, error) {
+	// 1. Validar filtros
+	status := email.Status(req.Status)
+	switch status {
+	case "", email.StatusPending, email.StatusSending, email.StatusSent, email.StatusFailed:
+	default:
+		return nil, fmt.Errorf("usecase: search emails failed: invalid status: must be pending, sending, sent or failed")
+	}
+
+	emailType := email.EmailType(req.Type)
+	switch emailType {
+	case "", email.EmailTypeWelcome, email.EmailTypePasswordReset, email.EmailTypeVerification, email.EmailTypeNotification:
+	default:
+		return nil, fmt.Errorf("usecase: search emails failed: invalid type: must be welcome, password_reset, verification or notification")
+	}
+
+	if req.CreatedAfter != nil && req.CreatedBefore != nil && req.CreatedAfter.After(*req.CreatedBefore) {
+		return nil, fmt.Errorf("usecase: search emails failed: invalid date range: created_after is after created_before")
+	}
+
+	// 2. Normalizar paginação
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PageSize <= 0 {
+		req.PageSize = uc.defaultPageSize
+	}
+	if req.PageSize > uc.maxPageSize {
+		req.PageSize = uc.maxPageSize
+	}
+
+	// 3. Buscar
+	emails, total, err := uc.emailRepo.Search(ctx, email.SearchParams{
+		To:            req.To,
+		Status:        status,
+		Type:          emailType,
+		CreatedAfter:  req.CreatedAfter,
+		CreatedBefore: req.CreatedBefore,
+		Page:          req.Page,
+		PageSize:      req.PageSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("usecase: search emails failed: %w", err)
+	}
+
+	response := &SearchEmailsResponse{
+		Emails:   make([]*EmailStatusResponse, len(emails)),
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}
+	for i, e := range emails {
+		response.Emails[i] = toEmailStatusResponse(e)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,96 @@
+package email
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/moura95/backend-challenge/internal/domain/email"
+)
+
+// searchEmailRepository records the params it was called with.
+type searchEmailRepository struct {
+	email.Repository
+	params *email.SearchParams
+}
+
+func (r *searchEmailRepository) Search(ctx context.Context, params email.SearchParams) ([]*email.Email, int, error) {
+	r.params = &params
+	return []*email.Email{{To: "match@example.com", Subject: "Hello", Status: email.StatusFailed}}, 1, nil
+}
+
+// pagedEmailRepository pages a fixed list of emails like the SQL search does.
+type pagedEmailRepository struct {
+	email.Repository
+	emails []*email.Email
+}
+
+func (r *pagedEmailRepository) Search(ctx context.Context, params email.SearchParams) ([]*email.Email, int, error) {
+	start := min((params.Page-1)*params.PageSize, len(r.emails))
+	end := min(start+params.PageSize, len(r.emails))
+	return r.emails[start:end], len(r.emails), nil
+}
+
+func TestSearchEmailsUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should pass filters and clamp the page size", func(t *testing.T) {
+		repo := &searchEmailRepository{}
+		uc := NewSearchEmailsUseCase(repo).WithPageSizeLimits(5, 20)
+
+		result, err := uc.Execute(ctx, SearchEmailsRequest{To: "match", Status: "failed", Type: "welcome", PageSize: 50})
+
+		require.NoError(t, err)
+		require.NotNil(t, repo.params)
+		assert.Equal(t, "match", repo.params.To)
+		assert.Equal(t, email.StatusFailed, repo.params.Status)
+		assert.Equal(t, email.EmailTypeWelcome, repo.params.Type)
+		assert.Equal(t, 1, repo.params.Page)
+		assert.Equal(t, 20, repo.params.PageSize)
+
+		assert.Equal(t, 1, result.Total)
+		require.Len(t, result.Emails, 1)
+		assert.Equal(t, "Hello", result.Emails[0].Subject)
+	})
+
+	t.Run("should reject invalid filters before querying", func(t *testing.T) {
+		after := time.Now()
+		before := after.Add(-time.Hour)
+
+		for name, req := range map[string]SearchEmailsRequest{
+			"status":     {Status: "bounced"},
+			"type":       {Type: "newsletter"},
+			"date range": {CreatedAfter: &after, CreatedBefore: &before},
+		} {
+			repo := &searchEmailRepository{}
+			_, err := NewSearchEmailsUseCase(repo).Execute(ctx, req)
+
+			assert.ErrorContains(t, err, "invalid", name)
+			assert.Nil(t, repo.params, name)
+		}
+	})
+
+	t.Run("should report the total of every match while returning one page", func(t *testing.T) {
+		repo := &pagedEmailRepository{}
+		for range 25 {
+			repo.emails = append(repo.emails, &email.Email{To: "match@example.com", Status: email.StatusPending})
+		}
+		uc := NewSearchEmailsUseCase(repo)
+
+		first, err := uc.Execute(ctx, SearchEmailsRequest{PageSize: 10})
+		require.NoError(t, err)
+		assert.Equal(t, 25, first.Total)
+		assert.Equal(t, 1, first.Page)
+		assert.Equal(t, 10, first.PageSize)
+		assert.Len(t, first.Emails, 10)
+
+		last, err := uc.Execute(ctx, SearchEmailsRequest{Page: 3, PageSize: 10})
+		require.NoError(t, err)
+		assert.Equal(t, 25, last.Total)
+		assert.Equal(t, 3, last.Page)
+		assert.Len(t, last.Emails, 5)
+	})
+}
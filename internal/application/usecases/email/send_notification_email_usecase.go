@@ -0,0 +1,108 @@
+package email
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/moura95/backend-challenge/internal/domain/email"
+	"github.com/moura95/backend-challenge/internal/infra/logging"
+	"github.com/moura95/backend-challenge/internal/infra/tracing"
+)
+
+type SendNotificationEmailRequest struct {
+	To          string             `json:"to"`
+	Cc          []string           `json:"cc,omitempty"`
+	Bcc         []string           `json:"bcc,omitempty"`
+	Subject     string             `json:"subject"`
+	Body        string             `json:"body"`
+	Attachments []email.Attachment `json:"attachments,omitempty"`
+}
+
+type SendNotificationEmailResponse struct {
+	EmailID  string `json:"email_id"`
+	Status   string `json:"status"`
+	QueuedAt string `json:"queued_at"`
+}
+
+type SendNotificationEmailUseCase struct {
+	emailRepo email.Repository
+	publisher email.QueuePublisher
+}
+
+func NewSendNotificationEmailUseCase(
+	emailRepo email.Repository,
+	publisher email.QueuePublisher,
+) *SendNotificationEmailUseCase {
+	return &SendNotificationEmailUseCase{
+		emailRepo: emailRepo,
+		publisher: publisher,
+	}
+}
+
+func (uc *SendNotificationEmailUseCase) Execute(ctx context.Context, req SendNotificationEmailRequest) (*SendNotificationEmailResponse, error) {
+	// 1. Criar entidade de email (valida destinatários, assunto, corpo e anexos)
+	emailEntity, err := email.NewNotificationEmailFromData(email.NotificationEmailData{
+		To:          req.To,
+		Cc:          req.Cc,
+		Bcc:         req.Bcc,
+		Subject:     req.Subject,
+		Body:        req.Body,
+		Attachments: req.Attachments,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("usecase: send notification email failed: %w", err)
+	}
+
+	// 2. Salvar no banco
+	err = uc.emailRepo.Create(ctx, emailEntity)
+	if err != nil {
+		return nil, fmt.Errorf("usecase: send notification email failed: %w", err)
+	}
+
+	// 3. Enviar para fila
+	err = uc.sendToQueue(ctx, emailEntity)
+	if err != nil {
+		// Se falhar, marcar como falha
+		emailEntity.MarkAsFailed(err.Error())
+		uc.emailRepo.Update(ctx, emailEntity)
+		return nil, fmt.Errorf("usecase: send notification email failed: %w", err)
+	}
+
+	// 4. Retornar resposta
+	response := &SendNotificationEmailResponse{
+		EmailID:  emailEntity.ID.String(),
+		Status:   string(emailEntity.Status),
+		QueuedAt: emailEntity.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+
+	return response, nil
+}
+
+func (uc *SendNotificationEmailUseCase) sendToQueue(ctx context.Context, emailEntity *email.Email) error {
+	if uc.publisher == nil {
+		return fmt.Errorf("email publisher not configured")
+	}
+
+	// Anexos ficam fora da mensagem: o consumer lê o email (e os anexos) do banco
+	message := email.QueueMessage{
+		EmailID: emailEntity.ID,
+		Type:    emailEntity.Type,
+		Notification: &email.NotificationEmailData{
+			To:      emailEntity.To,
+			Cc:      emailEntity.Cc,
+			Bcc:     emailEntity.Bcc,
+			Subject: emailEntity.Subject,
+			Body:    emailEntity.Body,
+		},
+		RequestID:    logging.RequestIDFromContext(ctx),
+		TraceContext: tracing.Inject(ctx),
+	}
+
+	err := uc.publisher.PublishEmailMessage(message)
+	if err != nil {
+		return fmt.Errorf("failed to publish notification email to queue: %w", err)
+	}
+
+	fmt.Printf("Notification email queued for delivery: %s\n", emailEntity.To)
+	return nil
+}
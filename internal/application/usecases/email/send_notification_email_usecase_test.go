@@ -0,0 +1,205 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/moura95/backend-challenge/internal/domain/email"
+)
+
+// Mock Queue Publisher
+type MockQueuePublisher struct {
+	mock.Mock
+}
+
+func (m *MockQueuePublisher) PublishEmailMessage(message email.QueueMessage) error {
+	args := m.Called(message)
+	return args.Error(0)
+}
+
+func TestSendNotificationEmailUseCase_Execute(t *testing.T) {
+	server := setupSendWelcomeEmailTest(t)
+	defer server.cleanup()
+
+	ctx := context.Background()
+
+	t.Run("should persist and publish notification email", func(t *testing.T) {
+		// Setup mock publisher
+		mockPublisher := new(MockQueuePublisher)
+		mockPublisher.On("PublishEmailMessage", mock.MatchedBy(func(m email.QueueMessage) bool {
+			return m.Type == email.EmailTypeNotification &&
+				m.Notification != nil &&
+				m.Notification.To == "notify@example.com" &&
+				m.Notification.Subject == "Heads up"
+		})).Return(nil)
+
+		useCase := NewSendNotificationEmailUseCase(server.repos.Email, mockPublisher)
+
+		// Execute
+		result, err := useCase.Execute(ctx, SendNotificationEmailRequest{
+			To:      "notify@example.com",
+			Subject: "Heads up",
+			Body:    "<p>Something happened</p>",
+		})
+
+		// Assert
+		require.NoError(t, err)
+		assert.NotEmpty(t, result.EmailID)
+		assert.Equal(t, "pending", result.Status)
+		mockPublisher.AssertExpectations(t)
+
+		var dbEmail struct {
+			Subject string `db:"subject"`
+			Body    string `db:"body"`
+			Type    string `db:"type"`
+		}
+		err = server.db.Get(&dbEmail, "SELECT subject, body, type FROM emails WHERE uuid = $1", result.EmailID)
+		require.NoError(t, err)
+		assert.Equal(t, "Heads up", dbEmail.Subject)
+		assert.Equal(t, "<p>Something happened</p>", dbEmail.Body)
+		assert.Equal(t, "notification", dbEmail.Type)
+	})
+
+	t.Run("should persist and publish cc and bcc recipients", func(t *testing.T) {
+		mockPublisher := new(MockQueuePublisher)
+		mockPublisher.On("PublishEmailMessage", mock.MatchedBy(func(m email.QueueMessage) bool {
+			return m.Notification != nil &&
+				len(m.Notification.Cc) == 1 && m.Notification.Cc[0] == "ops@example.com" &&
+				len(m.Notification.Bcc) == 1 && m.Notification.Bcc[0] == "audit@example.com"
+		})).Return(nil)
+
+		useCase := NewSendNotificationEmailUseCase(server.repos.Email, mockPublisher)
+
+		// Execute
+		result, err := useCase.Execute(ctx, SendNotificationEmailRequest{
+			To:      "notify-copies@example.com",
+			Cc:      []string{"ops@example.com"},
+			Bcc:     []string{"audit@example.com"},
+			Subject: "Heads up",
+			Body:    "<p>Something happened</p>",
+		})
+
+		// Assert
+		require.NoError(t, err)
+		mockPublisher.AssertExpectations(t)
+
+		saved, err := server.repos.Email.GetByID(ctx, uuid.MustParse(result.EmailID))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ops@example.com"}, saved.Cc)
+		assert.Equal(t, []string{"audit@example.com"}, saved.Bcc)
+	})
+
+	t.Run("should persist attachments without publishing their content", func(t *testing.T) {
+		mockPublisher := new(MockQueuePublisher)
+		mockPublisher.On("PublishEmailMessage", mock.MatchedBy(func(m email.QueueMessage) bool {
+			return m.Notification != nil && len(m.Notification.Attachments) == 0
+		})).Return(nil)
+
+		useCase := NewSendNotificationEmailUseCase(server.repos.Email, mockPublisher)
+
+		// Execute
+		result, err := useCase.Execute(ctx, SendNotificationEmailRequest{
+			To:      "notify-invoice@example.com",
+			Subject: "Your invoice",
+			Body:    "<p>Invoice attached</p>",
+			Attachments: []email.Attachment{
+				{Filename: "invoice.txt", ContentType: "text/plain", Content: []byte("total: 42")},
+				{Filename: "data.bin", Content: []byte{0x00, 0x01}},
+			},
+		})
+
+		// Assert
+		require.NoError(t, err)
+		mockPublisher.AssertExpectations(t)
+
+		saved, err := server.repos.Email.GetByID(ctx, uuid.MustParse(result.EmailID))
+		require.NoError(t, err)
+		require.Len(t, saved.Attachments, 2)
+		assert.Equal(t, email.Attachment{Filename: "invoice.txt", ContentType: "text/plain", Content: []byte("total: 42")}, saved.Attachments[0])
+		assert.Equal(t, email.DefaultAttachmentContentType, saved.Attachments[1].ContentType)
+	})
+
+	t.Run("should reject attachments over the size limit", func(t *testing.T) {
+		mockPublisher := new(MockQueuePublisher)
+		useCase := NewSendNotificationEmailUseCase(server.repos.Email, mockPublisher)
+
+		result, err := useCase.Execute(ctx, SendNotificationEmailRequest{
+			To:      "notify@example.com",
+			Subject: "Too big",
+			Body:    "Body",
+			Attachments: []email.Attachment{
+				{Filename: "big.bin", Content: make([]byte, email.MaxAttachmentsSize+1)},
+			},
+		})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "attachments exceed")
+		mockPublisher.AssertNotCalled(t, "PublishEmailMessage")
+	})
+
+	t.Run("should reject an invalid cc address", func(t *testing.T) {
+		mockPublisher := new(MockQueuePublisher)
+		useCase := NewSendNotificationEmailUseCase(server.repos.Email, mockPublisher)
+
+		result, err := useCase.Execute(ctx, SendNotificationEmailRequest{
+			To:      "notify@example.com",
+			Cc:      []string{"not-an-email"},
+			Subject: "Heads up",
+			Body:    "Body",
+		})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "cc email validation failed")
+		mockPublisher.AssertNotCalled(t, "PublishEmailMessage")
+	})
+
+	t.Run("should fail validation without publishing", func(t *testing.T) {
+		mockPublisher := new(MockQueuePublisher)
+		useCase := NewSendNotificationEmailUseCase(server.repos.Email, mockPublisher)
+
+		// Execute
+		result, err := useCase.Execute(ctx, SendNotificationEmailRequest{
+			To:      "notify@example.com",
+			Subject: "",
+			Body:    "Body",
+		})
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "email subject is required")
+		mockPublisher.AssertNotCalled(t, "PublishEmailMessage")
+	})
+
+	t.Run("should mark email as failed when publish fails", func(t *testing.T) {
+		mockPublisher := new(MockQueuePublisher)
+		mockPublisher.On("PublishEmailMessage", mock.AnythingOfType("email.QueueMessage")).Return(errors.New("queue connection failed"))
+
+		useCase := NewSendNotificationEmailUseCase(server.repos.Email, mockPublisher)
+
+		// Execute
+		result, err := useCase.Execute(ctx, SendNotificationEmailRequest{
+			To:      "notify-fail@example.com",
+			Subject: "Heads up",
+			Body:    "Body",
+		})
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "failed to publish notification email to queue")
+
+		var errorMsg string
+		err = server.db.Get(&errorMsg, "SELECT error_msg FROM emails WHERE to_email = $1", "notify-fail@example.com")
+		require.NoError(t, err)
+		assert.Contains(t, errorMsg, "queue connection failed")
+	})
+}
@@ -0,0 +1,111 @@
+package email
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moura95/backend-challenge/internal/domain/email"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// dedupEmailRepository keeps emails in memory and applies the dedup window
+// against an adjustable clock.
+type dedupEmailRepository struct {
+	email.Repository
+	emails []*email.Email
+	now    time.Time
+}
+
+func (r *dedupEmailRepository) Create(ctx context.Context, e *email.Email) error {
+	e.ID = uuid.New()
+	e.CreatedAt = r.now
+	r.emails = append(r.emails, e)
+	return nil
+}
+
+func (r *dedupEmailRepository) CreateIfNotExists(ctx context.Context, e *email.Email, window time.Duration) (bool, error) {
+	if e.DedupKey == "" {
+		e.DedupKey = email.DedupKey(e.Type, e.To)
+	}
+	for _, existing := range r.emails {
+		if existing.DedupKey == e.DedupKey && r.now.Sub(existing.CreatedAt) < window {
+			return false, nil
+		}
+	}
+	return true, r.Create(ctx, e)
+}
+
+type countingWelcomePublisher struct {
+	published []email.WelcomeEmailData
+}
+
+func (p *countingWelcomePublisher) PublishWelcomeEmail(ctx context.Context, data email.WelcomeEmailData) error {
+	p.published = append(p.published, data)
+	return nil
+}
+
+func (p *countingWelcomePublisher) Close() error {
+	return nil
+}
+
+func TestSendWelcomeEmailUseCase_Dedup(t *testing.T) {
+	req := SendWelcomeEmailRequest{
+		UserID:    uuid.New().String(),
+		UserName:  "John Doe",
+		UserEmail: "john@example.com",
+	}
+
+	t.Run("second email within the window is skipped", func(t *testing.T) {
+		repo := &dedupEmailRepository{now: time.Now()}
+		publisher := &countingWelcomePublisher{}
+		uc := NewSendWelcomeEmailUseCase(repo, publisher)
+
+		first, err := uc.Execute(context.Background(), req)
+		require.NoError(t, err)
+		assert.False(t, first.Duplicate)
+
+		second, err := uc.Execute(context.Background(), SendWelcomeEmailRequest{
+			UserID:    req.UserID,
+			UserName:  req.UserName,
+			UserEmail: "JOHN@example.com",
+		})
+		require.NoError(t, err)
+		assert.True(t, second.Duplicate)
+
+		assert.Len(t, repo.emails, 1)
+		assert.Equal(t, email.DedupKey(email.EmailTypeWelcome, "john@example.com"), repo.emails[0].DedupKey)
+		assert.Len(t, publisher.published, 1)
+	})
+
+	t.Run("email after the window is created again", func(t *testing.T) {
+		repo := &dedupEmailRepository{now: time.Now()}
+		publisher := &countingWelcomePublisher{}
+		uc := NewSendWelcomeEmailUseCase(repo, publisher).WithDedupWindow(time.Minute)
+
+		_, err := uc.Execute(context.Background(), req)
+		require.NoError(t, err)
+
+		repo.now = repo.now.Add(2 * time.Minute)
+		response, err := uc.Execute(context.Background(), req)
+		require.NoError(t, err)
+		assert.False(t, response.Duplicate)
+
+		assert.Len(t, repo.emails, 2)
+		assert.Len(t, publisher.published, 2)
+	})
+
+	t.Run("zero window always creates", func(t *testing.T) {
+		repo := &dedupEmailRepository{now: time.Now()}
+		uc := NewSendWelcomeEmailUseCase(repo, &countingWelcomePublisher{}).WithDedupWindow(0)
+
+		for i := 0; i < 2; i++ {
+			_, err := uc.Execute(context.Background(), req)
+			require.NoError(t, err)
+		}
+
+		assert.Len(t, repo.emails, 2)
+	})
+}
@@ -3,6 +3,7 @@ package email
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/moura95/backend-challenge/internal/domain/email"
 )
@@ -11,17 +12,30 @@ type SendWelcomeEmailRequest struct {
 	UserID    string `json:"user_id"`
 	UserName  string `json:"user_name"`
 	UserEmail string `json:"user_email"`
+	// Delay, quando positivo, agenda o email em vez de publicá-lo na fila
+	Delay time.Duration `json:"delay,omitempty"`
 }
 
 type SendWelcomeEmailResponse struct {
 	EmailID  string `json:"email_id"`
 	Status   string `json:"status"`
 	QueuedAt string `json:"queued_at"`
+	// Duplicate is set when a welcome email to the same address was already
+	// created within the dedup window; nothing is persisted or queued.
+	Duplicate bool `json:"duplicate,omitempty"`
+	// ScheduledAt is set for delayed emails, which are not queued yet
+	ScheduledAt string `json:"scheduled_at,omitempty"`
 }
 
+// DefaultWelcomeEmailDedupWindow is how long a welcome email to an address
+// blocks another one to the same address.
+const DefaultWelcomeEmailDedupWindow = 10 * time.Minute
+
 type SendWelcomeEmailUseCase struct {
-	emailRepo email.Repository
-	publisher email.Publisher
+	emailRepo   email.Repository
+	publisher   email.Publisher
+	template    *email.WelcomeTemplate
+	dedupWindow time.Duration
 }
 
 func NewSendWelcomeEmailUseCase(
@@ -29,28 +43,57 @@ func NewSendWelcomeEmailUseCase(
 	publisher email.Publisher,
 ) *SendWelcomeEmailUseCase {
 	return &SendWelcomeEmailUseCase{
-		emailRepo: emailRepo,
-		publisher: publisher,
+		emailRepo:   emailRepo,
+		publisher:   publisher,
+		dedupWindow: DefaultWelcomeEmailDedupWindow,
 	}
 }
 
+// WithTemplate troca o assunto e o corpo do email; nil usa o padrão.
+func (uc *SendWelcomeEmailUseCase) WithTemplate(tmpl *email.WelcomeTemplate) *SendWelcomeEmailUseCase {
+	uc.template = tmpl
+	return uc
+}
+
+// WithDedupWindow ajusta a janela de deduplicação; zero desliga e sempre cria o email.
+func (uc *SendWelcomeEmailUseCase) WithDedupWindow(window time.Duration) *SendWelcomeEmailUseCase {
+	uc.dedupWindow = window
+	return uc
+}
+
 func (uc *SendWelcomeEmailUseCase) Execute(ctx context.Context, req SendWelcomeEmailRequest) (*SendWelcomeEmailResponse, error) {
 	// 1. Validar request
 	if err := uc.validateRequest(req); err != nil {
 		return nil, fmt.Errorf("usecase: send welcome email failed: %w", err)
 	}
 
-	// 2. Criar entidade de email
+	// 2. Criar entidade de email, agendada quando houver atraso
 	emailEntity, err := uc.createWelcomeEmail(req)
 	if err != nil {
 		return nil, fmt.Errorf("usecase: send welcome email failed: %w", err)
 	}
+	if req.Delay > 0 {
+		emailEntity.Schedule(time.Now().Add(req.Delay))
+	}
 
-	// 3. Salvar no banco
-	err = uc.emailRepo.Create(ctx, emailEntity)
+	// 3. Salvar no banco, ignorando repetições dentro da janela
+	created, err := uc.saveEmail(ctx, emailEntity)
 	if err != nil {
 		return nil, fmt.Errorf("usecase: send welcome email failed: %w", err)
 	}
+	if !created {
+		return &SendWelcomeEmailResponse{Duplicate: true}, nil
+	}
+
+	// Agendado: o processamento de pendentes envia quando vencer
+	if emailEntity.ScheduledAt != nil {
+		return &SendWelcomeEmailResponse{
+			EmailID:     emailEntity.ID.String(),
+			Status:      string(emailEntity.Status),
+			QueuedAt:    emailEntity.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+			ScheduledAt: emailEntity.ScheduledAt.Format(time.RFC3339),
+		}, nil
+	}
 
 	// 4. Enviar para fila
 	err = uc.sendToQueue(ctx, req)
@@ -71,6 +114,13 @@ func (uc *SendWelcomeEmailUseCase) Execute(ctx context.Context, req SendWelcomeE
 	return response, nil
 }
 
+func (uc *SendWelcomeEmailUseCase) saveEmail(ctx context.Context, emailEntity *email.Email) (bool, error) {
+	if uc.dedupWindow <= 0 {
+		return true, uc.emailRepo.Create(ctx, emailEntity)
+	}
+	return uc.emailRepo.CreateIfNotExists(ctx, emailEntity, uc.dedupWindow)
+}
+
 func (uc *SendWelcomeEmailUseCase) validateRequest(req SendWelcomeEmailRequest) error {
 	if req.UserName == "" {
 		return fmt.Errorf("user name is required")
@@ -80,6 +130,10 @@ func (uc *SendWelcomeEmailUseCase) validateRequest(req SendWelcomeEmailRequest)
 		return fmt.Errorf("user email is required")
 	}
 
+	if req.Delay < 0 {
+		return fmt.Errorf("delay must not be negative")
+	}
+
 	// Validação de email
 	validator := email.NewEmailValidator()
 	if err := validator.ValidateEmail(req.UserEmail); err != nil {
@@ -96,7 +150,7 @@ func (uc *SendWelcomeEmailUseCase) createWelcomeEmail(req SendWelcomeEmailReques
 		UserEmail: req.UserEmail,
 	}
 
-	welcomeEmail, err := email.NewWelcomeEmail(data)
+	welcomeEmail, err := email.NewWelcomeEmailWithTemplate(data, uc.template)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create welcome email entity: %w", err)
 	}
@@ -18,6 +18,7 @@ import (
 	"github.com/testcontainers/testcontainers-go/wait"
 
 	"github.com/moura95/backend-challenge/internal/domain/email"
+	"github.com/moura95/backend-challenge/internal/infra/messaging/rabbitmq"
 	"github.com/moura95/backend-challenge/internal/infra/repository/adapters"
 )
 
@@ -89,7 +90,24 @@ func runSendWelcomeEmailMigrations(db *sqlx.DB) error {
 		error_msg    TEXT,
 		sent_at      TIMESTAMPTZ,
 		created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
-		updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		plain_body   TEXT NOT NULL DEFAULT '',
+		next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		cc_emails    TEXT[] NOT NULL DEFAULT '{}',
+		bcc_emails   TEXT[] NOT NULL DEFAULT '{}',
+		dedup_key    VARCHAR(255),
+		scheduled_at TIMESTAMPTZ
+	);
+	
+	-- Email attachments table
+	CREATE TABLE IF NOT EXISTS email_attachments (
+		id           BIGSERIAL PRIMARY KEY,
+		email_uuid   UUID NOT NULL,
+		filename     VARCHAR(255) NOT NULL,
+		content_type VARCHAR(255) NOT NULL,
+		content      BYTEA NOT NULL,
+		created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		FOREIGN KEY (email_uuid) REFERENCES emails(uuid) ON DELETE CASCADE
 	);
 	
 	-- Indexes
@@ -266,6 +284,39 @@ func TestSendWelcomeEmailUseCase_Execute(t *testing.T) {
 		mockPublisher.AssertExpectations(t)
 	})
 
+	t.Run("should mark email as failed when broker nacks the publish", func(t *testing.T) {
+		// Setup mock publisher returning a nack from the broker
+		mockPublisher := new(MockEmailPublisher)
+		mockPublisher.On("PublishWelcomeEmail", ctx, mock.AnythingOfType("email.WelcomeEmailData")).
+			Return(fmt.Errorf("rabbitmq: failed to publish to email queue: %w", rabbitmq.ErrPublishNacked))
+
+		// Create use case
+		useCase := NewSendWelcomeEmailUseCase(server.repos.Email, mockPublisher)
+
+		// Test request
+		req := SendWelcomeEmailRequest{
+			UserID:    uuid.New().String(),
+			UserName:  "Nack User",
+			UserEmail: "nack@example.com",
+		}
+
+		// Execute
+		result, err := useCase.Execute(ctx, req)
+
+		// Assert
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, rabbitmq.ErrPublishNacked)
+
+		// Verify email was created with the nack recorded
+		var errorMsg string
+		err = server.db.Get(&errorMsg, "SELECT error_msg FROM emails WHERE to_email = $1", "nack@example.com")
+		require.NoError(t, err)
+		assert.Contains(t, errorMsg, "nacked by broker")
+
+		mockPublisher.AssertExpectations(t)
+	})
+
 	t.Run("should handle nil publisher", func(t *testing.T) {
 		// Create use case with nil publisher
 		useCase := NewSendWelcomeEmailUseCase(server.repos.Email, nil)
@@ -412,6 +463,41 @@ func TestSendWelcomeEmailUseCase_Execute(t *testing.T) {
 		mockPublisher.AssertExpectations(t)
 	})
 
+	t.Run("should create only one welcome email for repeated requests", func(t *testing.T) {
+		// Setup mock publisher: only the first request is queued
+		mockPublisher := new(MockEmailPublisher)
+		mockPublisher.On("PublishWelcomeEmail", ctx, mock.AnythingOfType("email.WelcomeEmailData")).Return(nil).Once()
+
+		// Create use case
+		useCase := NewSendWelcomeEmailUseCase(server.repos.Email, mockPublisher)
+
+		req := SendWelcomeEmailRequest{
+			UserID:    uuid.New().String(),
+			UserName:  "Twice User",
+			UserEmail: "twice@example.com",
+		}
+
+		// Execute twice in quick succession
+		first, err := useCase.Execute(ctx, req)
+		require.NoError(t, err)
+		assert.False(t, first.Duplicate)
+		assert.NotEmpty(t, first.EmailID)
+
+		req.UserEmail = "Twice@Example.com"
+		second, err := useCase.Execute(ctx, req)
+		require.NoError(t, err)
+		assert.True(t, second.Duplicate)
+		assert.Empty(t, second.EmailID)
+
+		// Verify only one row persists
+		var emailCount int
+		err = server.db.Get(&emailCount, "SELECT COUNT(*) FROM emails WHERE LOWER(to_email) = $1", "twice@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, 1, emailCount)
+
+		mockPublisher.AssertExpectations(t)
+	})
+
 	t.Run("should generate correct response format", func(t *testing.T) {
 		// Setup mock publisher
 		mockPublisher := new(MockEmailPublisher)
@@ -0,0 +1,55 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/moura95/backend-challenge/internal/domain/user"
+)
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+type ChangePasswordUseCase struct {
+	userRepo user.Repository
+}
+
+func NewChangePasswordUseCase(userRepo user.Repository) *ChangePasswordUseCase {
+	return &ChangePasswordUseCase{
+		userRepo: userRepo,
+	}
+}
+
+func (uc *ChangePasswordUseCase) Execute(ctx context.Context, userID string, req ChangePasswordRequest) error {
+	parsedID, err := uuid.Parse(userID)
+	if err != nil {
+		return fmt.Errorf("usecase: change password failed: invalid user ID format")
+	}
+
+	// 1. Buscar usuário
+	foundUser, err := uc.userRepo.GetByID(ctx, parsedID)
+	if err != nil {
+		return fmt.Errorf("usecase: change password failed: %w", err)
+	}
+
+	// 2. Conferir senha atual
+	if err := foundUser.CheckPassword(req.CurrentPassword); err != nil {
+		return fmt.Errorf("usecase: change password failed: %w", user.ErrIncorrectPassword)
+	}
+
+	// 3. Validar e aplicar nova senha (mesmas regras do cadastro)
+	if err := foundUser.ChangePassword(req.NewPassword); err != nil {
+		return fmt.Errorf("usecase: change password failed: %w", err)
+	}
+
+	// 4. Persistir
+	err = uc.userRepo.UpdatePassword(ctx, foundUser)
+	if err != nil {
+		return fmt.Errorf("usecase: change password failed: %w", err)
+	}
+
+	return nil
+}
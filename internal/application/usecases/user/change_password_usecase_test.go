@@ -0,0 +1,181 @@
+package user
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+
+	"github.com/moura95/backend-challenge/internal/domain/user"
+	"github.com/moura95/backend-challenge/internal/infra/repository/adapters"
+)
+
+type changePasswordTestServer struct {
+	container *postgres.PostgresContainer
+	db        *sqlx.DB
+	repos     *adapters.Repositories
+	cleanup   func()
+}
+
+func setupChangePasswordTest(t *testing.T) *changePasswordTestServer {
+	ctx := context.Background()
+
+	// Start PostgreSQL container
+	postgresContainer, err := postgres.RunContainer(ctx,
+		testcontainers.WithImage("postgres:15-alpine"),
+		postgres.WithDatabase("testdb"),
+		postgres.WithUsername("test"),
+		postgres.WithPassword("test"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(30*time.Second)),
+	)
+	require.NoError(t, err)
+
+	// Get connection string
+	connStr, err := postgresContainer.ConnectionString(ctx, "sslmode=disable")
+	require.NoError(t, err)
+
+	// Connect to database
+	db, err := sqlx.Connect("postgres", connStr)
+	require.NoError(t, err)
+
+	// Run migrations
+	err = runChangePasswordMigrations(db)
+	require.NoError(t, err)
+
+	// Setup repositories
+	repos := adapters.NewRepositories(db)
+
+	cleanup := func() {
+		db.Close()
+		postgresContainer.Terminate(ctx)
+	}
+
+	return &changePasswordTestServer{
+		container: postgresContainer,
+		db:        db,
+		repos:     repos,
+		cleanup:   cleanup,
+	}
+}
+
+func runChangePasswordMigrations(db *sqlx.DB) error {
+	migrationSQL := `
+	CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+	
+	-- Users table
+	CREATE TABLE IF NOT EXISTS users (
+		uuid         UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+		name         VARCHAR(255) NOT NULL,
+		email        VARCHAR(100) NOT NULL UNIQUE,
+		password     TEXT NOT NULL,
+		verified_at  TIMESTAMPTZ,
+		role         VARCHAR(20) NOT NULL DEFAULT 'user',
+		deleted_at   TIMESTAMPTZ,
+		version      INTEGER NOT NULL DEFAULT 1,
+		status       VARCHAR(20) NOT NULL DEFAULT 'active',
+		token_version INTEGER NOT NULL DEFAULT 0,
+		last_login_at TIMESTAMPTZ,
+		created_at   TIMESTAMP NOT NULL DEFAULT NOW(),
+		updated_at   TIMESTAMP NOT NULL DEFAULT NOW()
+	);
+	
+	-- Indexes
+	CREATE INDEX IF NOT EXISTS idx_users_email ON users(email);
+	`
+
+	_, err := db.Exec(migrationSQL)
+	return err
+}
+
+// Helper function to create a test user in the database
+func createTestUserForChangePassword(t *testing.T, server *changePasswordTestServer, email, password, name string) *user.User {
+	ctx := context.Background()
+
+	// Create user using domain logic
+	testUser, err := user.NewUser(name, email, password)
+	require.NoError(t, err)
+
+	// Save to database
+	err = server.repos.User.Create(ctx, testUser)
+	require.NoError(t, err)
+
+	return testUser
+}
+
+func TestChangePasswordUseCase_Execute(t *testing.T) {
+	server := setupChangePasswordTest(t)
+	defer server.cleanup()
+
+	ctx := context.Background()
+
+	t.Run("should change password successfully", func(t *testing.T) {
+		testUser := createTestUserForChangePassword(t, server, "change@example.com", "password123", "Change User")
+
+		useCase := NewChangePasswordUseCase(server.repos.User)
+		err := useCase.Execute(ctx, testUser.ID.String(), ChangePasswordRequest{
+			CurrentPassword: "password123",
+			NewPassword:     "newpassword456",
+		})
+		require.NoError(t, err)
+
+		stored, err := server.repos.User.GetByID(ctx, testUser.ID)
+		require.NoError(t, err)
+		assert.NoError(t, stored.CheckPassword("newpassword456"))
+		assert.Error(t, stored.CheckPassword("password123"))
+	})
+
+	t.Run("should fail with wrong current password", func(t *testing.T) {
+		testUser := createTestUserForChangePassword(t, server, "wrong@example.com", "password123", "Wrong User")
+
+		useCase := NewChangePasswordUseCase(server.repos.User)
+		err := useCase.Execute(ctx, testUser.ID.String(), ChangePasswordRequest{
+			CurrentPassword: "notmypassword",
+			NewPassword:     "newpassword456",
+		})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "current password is incorrect")
+
+		stored, err := server.repos.User.GetByID(ctx, testUser.ID)
+		require.NoError(t, err)
+		assert.NoError(t, stored.CheckPassword("password123"))
+	})
+
+	t.Run("should fail with weak new password", func(t *testing.T) {
+		testUser := createTestUserForChangePassword(t, server, "weak@example.com", "password123", "Weak User")
+
+		useCase := NewChangePasswordUseCase(server.repos.User)
+		err := useCase.Execute(ctx, testUser.ID.String(), ChangePasswordRequest{
+			CurrentPassword: "password123",
+			NewPassword:     "123",
+		})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "password must be at least 6 characters long")
+
+		stored, err := server.repos.User.GetByID(ctx, testUser.ID)
+		require.NoError(t, err)
+		assert.NoError(t, stored.CheckPassword("password123"))
+	})
+
+	t.Run("should fail with invalid user ID", func(t *testing.T) {
+		useCase := NewChangePasswordUseCase(server.repos.User)
+		err := useCase.Execute(ctx, "not-a-uuid", ChangePasswordRequest{
+			CurrentPassword: "password123",
+			NewPassword:     "newpassword456",
+		})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid user ID format")
+	})
+}
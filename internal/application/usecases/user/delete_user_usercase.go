@@ -18,17 +18,30 @@ func NewDeleteUserUseCase(userRepo user.Repository) *DeleteUserUseCase {
 	}
 }
 
-func (uc *DeleteUserUseCase) Execute(ctx context.Context, userID string) error {
+// Execute exige a senha atual: um token roubado não basta para apagar a conta.
+func (uc *DeleteUserUseCase) Execute(ctx context.Context, userID, password string) error {
 	parsedID, err := uuid.Parse(userID)
 	if err != nil {
 		return fmt.Errorf("usecase: delete user failed: invalid user ID format")
 	}
 
-	_, err = uc.userRepo.GetByID(ctx, parsedID)
+	// 1. Confirmação obrigatória
+	if password == "" {
+		return fmt.Errorf("usecase: delete user failed: password is required")
+	}
+
+	// 2. Buscar usuário
+	foundUser, err := uc.userRepo.GetByID(ctx, parsedID)
 	if err != nil {
 		return fmt.Errorf("usecase: delete user failed: %w", err)
 	}
 
+	// 3. Conferir senha atual
+	if err := foundUser.CheckPassword(password); err != nil {
+		return fmt.Errorf("usecase: delete user failed: %w", user.ErrIncorrectPassword)
+	}
+
+	// 4. Remover (soft delete)
 	err = uc.userRepo.Delete(ctx, parsedID)
 	if err != nil {
 		return fmt.Errorf("usecase: delete user failed: %w", err)
@@ -2,6 +2,7 @@ package user
 
 import (
 	"context"
+	"database/sql"
 	"testing"
 	"time"
 
@@ -79,6 +80,13 @@ func runDeleteUserMigrations(db *sqlx.DB) error {
 		name         VARCHAR(255) NOT NULL,
 		email        VARCHAR(100) NOT NULL UNIQUE,
 		password     TEXT NOT NULL,
+		verified_at  TIMESTAMPTZ,
+		role         VARCHAR(20) NOT NULL DEFAULT 'user',
+		deleted_at   TIMESTAMPTZ,
+		version      INTEGER NOT NULL DEFAULT 1,
+		status       VARCHAR(20) NOT NULL DEFAULT 'active',
+		token_version INTEGER NOT NULL DEFAULT 0,
+		last_login_at TIMESTAMPTZ,
 		created_at   TIMESTAMP NOT NULL DEFAULT NOW(),
 		updated_at   TIMESTAMP NOT NULL DEFAULT NOW()
 	);
@@ -96,7 +104,24 @@ func runDeleteUserMigrations(db *sqlx.DB) error {
 		error_msg    TEXT,
 		sent_at      TIMESTAMPTZ,
 		created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
-		updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		plain_body   TEXT NOT NULL DEFAULT '',
+		next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		cc_emails    TEXT[] NOT NULL DEFAULT '{}',
+		bcc_emails   TEXT[] NOT NULL DEFAULT '{}',
+		dedup_key    VARCHAR(255),
+		scheduled_at TIMESTAMPTZ
+	);
+	
+	-- Email attachments table
+	CREATE TABLE IF NOT EXISTS email_attachments (
+		id           BIGSERIAL PRIMARY KEY,
+		email_uuid   UUID NOT NULL,
+		filename     VARCHAR(255) NOT NULL,
+		content_type VARCHAR(255) NOT NULL,
+		content      BYTEA NOT NULL,
+		created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		FOREIGN KEY (email_uuid) REFERENCES emails(uuid) ON DELETE CASCADE
 	);
 	
 	-- Indexes
@@ -123,10 +148,10 @@ func createTestUserForDelete(t *testing.T, server *deleteUserTestServer, email,
 	return testUser
 }
 
-// Helper function to check if user exists in database
+// Helper function to check if an active (not soft-deleted) user exists in database
 func userExistsInDB(t *testing.T, server *deleteUserTestServer, userID uuid.UUID) bool {
 	var count int
-	err := server.db.Get(&count, "SELECT COUNT(*) FROM users WHERE uuid = $1", userID)
+	err := server.db.Get(&count, "SELECT COUNT(*) FROM users WHERE uuid = $1 AND deleted_at IS NULL", userID)
 	require.NoError(t, err)
 	return count > 0
 }
@@ -148,13 +173,40 @@ func TestDeleteUserUseCase_Execute(t *testing.T) {
 		useCase := NewDeleteUserUseCase(server.repos.User)
 
 		// Execute
-		err := useCase.Execute(ctx, testUser.ID.String())
+		err := useCase.Execute(ctx, testUser.ID.String(), "password123")
 
 		// Assert
 		require.NoError(t, err)
 
 		// Verify user no longer exists in database
 		assert.False(t, userExistsInDB(t, server, testUser.ID))
+
+		// Row is kept for audit, only marked as deleted
+		var deletedAt sql.NullTime
+		err = server.db.Get(&deletedAt, "SELECT deleted_at FROM users WHERE uuid = $1", testUser.ID)
+		require.NoError(t, err)
+		assert.True(t, deletedAt.Valid)
+	})
+
+	t.Run("should require the current password", func(t *testing.T) {
+		testUser := createTestUserForDelete(t, server, "nopassword@example.com", "password123", "No Password")
+		useCase := NewDeleteUserUseCase(server.repos.User)
+
+		err := useCase.Execute(ctx, testUser.ID.String(), "")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "password is required")
+		assert.True(t, userExistsInDB(t, server, testUser.ID))
+	})
+
+	t.Run("should reject a wrong password", func(t *testing.T) {
+		testUser := createTestUserForDelete(t, server, "wrongpassword@example.com", "password123", "Wrong Password")
+		useCase := NewDeleteUserUseCase(server.repos.User)
+
+		err := useCase.Execute(ctx, testUser.ID.String(), "not-my-password")
+
+		assert.ErrorIs(t, err, user.ErrIncorrectPassword)
+		assert.True(t, userExistsInDB(t, server, testUser.ID))
 	})
 
 	t.Run("should fail with invalid user ID format", func(t *testing.T) {
@@ -162,7 +214,7 @@ func TestDeleteUserUseCase_Execute(t *testing.T) {
 		useCase := NewDeleteUserUseCase(server.repos.User)
 
 		// Execute with invalid UUID format
-		err := useCase.Execute(ctx, "invalid-uuid-format")
+		err := useCase.Execute(ctx, "invalid-uuid-format", "password123")
 
 		// Assert
 		assert.Error(t, err)
@@ -174,7 +226,7 @@ func TestDeleteUserUseCase_Execute(t *testing.T) {
 		useCase := NewDeleteUserUseCase(server.repos.User)
 
 		// Execute with empty user ID
-		err := useCase.Execute(ctx, "")
+		err := useCase.Execute(ctx, "", "password123")
 
 		// Assert
 		assert.Error(t, err)
@@ -189,7 +241,7 @@ func TestDeleteUserUseCase_Execute(t *testing.T) {
 		nonExistentID := uuid.New()
 
 		// Execute
-		err := useCase.Execute(ctx, nonExistentID.String())
+		err := useCase.Execute(ctx, nonExistentID.String(), "password123")
 
 		// Assert
 		assert.Error(t, err)
@@ -204,11 +256,11 @@ func TestDeleteUserUseCase_Execute(t *testing.T) {
 		useCase := NewDeleteUserUseCase(server.repos.User)
 
 		// Delete user first time
-		err := useCase.Execute(ctx, testUser.ID.String())
+		err := useCase.Execute(ctx, testUser.ID.String(), "password123")
 		require.NoError(t, err)
 
 		// Try to delete same user again
-		err = useCase.Execute(ctx, testUser.ID.String())
+		err = useCase.Execute(ctx, testUser.ID.String(), "password123")
 
 		// Assert
 		assert.Error(t, err)
@@ -231,7 +283,7 @@ func TestDeleteUserUseCase_Execute(t *testing.T) {
 			assert.True(t, userExistsInDB(t, server, u.ID))
 
 			// Delete user
-			err := useCase.Execute(ctx, u.ID.String())
+			err := useCase.Execute(ctx, u.ID.String(), "password123")
 			require.NoError(t, err)
 
 			// Verify user is deleted
@@ -248,7 +300,7 @@ func TestDeleteUserUseCase_Execute(t *testing.T) {
 
 		// Test with uppercase UUID
 		upperCaseID := testUser.ID.String()
-		err := useCase.Execute(ctx, upperCaseID)
+		err := useCase.Execute(ctx, upperCaseID, "password123")
 
 		// Assert
 		require.NoError(t, err)
@@ -268,7 +320,7 @@ func TestDeleteUserUseCase_Execute(t *testing.T) {
 		}
 
 		for _, invalidID := range malformedUUIDs {
-			err := useCase.Execute(ctx, invalidID)
+			err := useCase.Execute(ctx, invalidID, "password123")
 			assert.Error(t, err)
 			assert.Contains(t, err.Error(), "invalid user ID format")
 		}
@@ -284,7 +336,7 @@ func TestDeleteUserUseCase_Execute(t *testing.T) {
 		useCase := NewDeleteUserUseCase(server.repos.User)
 
 		// Delete one user
-		err := useCase.Execute(ctx, userToDelete.ID.String())
+		err := useCase.Execute(ctx, userToDelete.ID.String(), "password123")
 		require.NoError(t, err)
 
 		// Verify deleted user is gone
@@ -303,7 +355,7 @@ func TestDeleteUserUseCase_Execute(t *testing.T) {
 		useCase := NewDeleteUserUseCase(server.repos.User)
 
 		// Execute with whitespace (should fail since UUID parsing is strict)
-		err := useCase.Execute(ctx, "  "+testUser.ID.String()+"  ")
+		err := useCase.Execute(ctx, "  "+testUser.ID.String()+"  ", "password123")
 
 		// Assert - should fail because UUID parsing doesn't trim whitespace
 		assert.Error(t, err)
@@ -316,7 +368,7 @@ func TestDeleteUserUseCase_Execute(t *testing.T) {
 	t.Run("should count users before and after deletion", func(t *testing.T) {
 		// Count initial users
 		var initialCount int
-		err := server.db.Get(&initialCount, "SELECT COUNT(*) FROM users")
+		err := server.db.Get(&initialCount, "SELECT COUNT(*) FROM users WHERE deleted_at IS NULL")
 		require.NoError(t, err)
 
 		// Create test user
@@ -324,18 +376,18 @@ func TestDeleteUserUseCase_Execute(t *testing.T) {
 
 		// Count after creation
 		var afterCreateCount int
-		err = server.db.Get(&afterCreateCount, "SELECT COUNT(*) FROM users")
+		err = server.db.Get(&afterCreateCount, "SELECT COUNT(*) FROM users WHERE deleted_at IS NULL")
 		require.NoError(t, err)
 		assert.Equal(t, initialCount+1, afterCreateCount)
 
 		// Create use case and delete user
 		useCase := NewDeleteUserUseCase(server.repos.User)
-		err = useCase.Execute(ctx, testUser.ID.String())
+		err = useCase.Execute(ctx, testUser.ID.String(), "password123")
 		require.NoError(t, err)
 
 		// Count after deletion
 		var afterDeleteCount int
-		err = server.db.Get(&afterDeleteCount, "SELECT COUNT(*) FROM users")
+		err = server.db.Get(&afterDeleteCount, "SELECT COUNT(*) FROM users WHERE deleted_at IS NULL")
 		require.NoError(t, err)
 		assert.Equal(t, initialCount, afterDeleteCount)
 	})
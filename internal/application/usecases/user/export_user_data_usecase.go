@@ -0,0 +1,77 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moura95/backend-challenge/internal/domain/email"
+	"github.com/moura95/backend-challenge/internal/domain/user"
+)
+
+// ExportedProfile traz todos os dados do usuário, exceto o hash da senha
+type ExportedProfile struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Email      string     `json:"email"`
+	Role       string     `json:"role"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	VerifiedAt *time.Time `json:"verified_at,omitempty"`
+	DeletedAt  *time.Time `json:"deleted_at,omitempty"`
+}
+
+type UserDataExport struct {
+	ExportedAt time.Time       `json:"exported_at"`
+	Profile    ExportedProfile `json:"profile"`
+	Emails     []*email.Email  `json:"emails"`
+}
+
+type ExportUserDataUseCase struct {
+	userRepo  user.Repository
+	emailRepo email.Repository
+}
+
+func NewExportUserDataUseCase(userRepo user.Repository, emailRepo email.Repository) *ExportUserDataUseCase {
+	return &ExportUserDataUseCase{
+		userRepo:  userRepo,
+		emailRepo: emailRepo,
+	}
+}
+
+func (uc *ExportUserDataUseCase) Execute(ctx context.Context, userID string) (*UserDataExport, error) {
+	// 1. Validar ID
+	parsedID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("usecase: export user data failed: invalid user ID format")
+	}
+
+	// 2. Buscar perfil
+	foundUser, err := uc.userRepo.GetByID(ctx, parsedID)
+	if err != nil {
+		return nil, fmt.Errorf("usecase: export user data failed: %w", err)
+	}
+
+	// 3. Buscar emails enviados para o endereço atual
+	emails, err := uc.emailRepo.GetByRecipient(ctx, foundUser.Email)
+	if err != nil {
+		return nil, fmt.Errorf("usecase: export user data failed: %w", err)
+	}
+
+	// 4. Montar documento
+	return &UserDataExport{
+		ExportedAt: time.Now().UTC(),
+		Profile: ExportedProfile{
+			ID:         foundUser.ID.String(),
+			Name:       foundUser.Name,
+			Email:      foundUser.Email,
+			Role:       string(foundUser.Role),
+			CreatedAt:  foundUser.CreatedAt,
+			UpdatedAt:  foundUser.UpdatedAt,
+			VerifiedAt: foundUser.VerifiedAt,
+			DeletedAt:  foundUser.DeletedAt,
+		},
+		Emails: emails,
+	}, nil
+}
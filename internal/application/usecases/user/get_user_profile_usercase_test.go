@@ -80,6 +80,13 @@ func runGetUserProfileMigrations(db *sqlx.DB) error {
 		name         VARCHAR(255) NOT NULL,
 		email        VARCHAR(100) NOT NULL UNIQUE,
 		password     TEXT NOT NULL,
+		verified_at  TIMESTAMPTZ,
+		role         VARCHAR(20) NOT NULL DEFAULT 'user',
+		deleted_at   TIMESTAMPTZ,
+		version      INTEGER NOT NULL DEFAULT 1,
+		status       VARCHAR(20) NOT NULL DEFAULT 'active',
+		token_version INTEGER NOT NULL DEFAULT 0,
+		last_login_at TIMESTAMPTZ,
 		created_at   TIMESTAMP NOT NULL DEFAULT NOW(),
 		updated_at   TIMESTAMP NOT NULL DEFAULT NOW()
 	);
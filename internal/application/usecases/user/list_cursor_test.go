@@ -0,0 +1,44 @@
+package user
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/moura95/backend-challenge/internal/domain/user"
+)
+
+func TestListCursor(t *testing.T) {
+	t.Run("should round-trip created_at and id", func(t *testing.T) {
+		u := &user.User{
+			ID:        uuid.New(),
+			CreatedAt: time.Date(2024, 3, 10, 15, 4, 5, 123456000, time.UTC),
+		}
+
+		cursor := encodeListCursor(u)
+		decoded, err := decodeListCursor(cursor)
+
+		require.NoError(t, err)
+		assert.Equal(t, u.ID, decoded.ID)
+		assert.True(t, u.CreatedAt.Equal(decoded.CreatedAt))
+	})
+
+	t.Run("should reject malformed cursors", func(t *testing.T) {
+		invalid := []string{
+			"not base64!",
+			base64.RawURLEncoding.EncodeToString([]byte("no-separator")),
+			base64.RawURLEncoding.EncodeToString([]byte("yesterday|" + uuid.New().String())),
+			base64.RawURLEncoding.EncodeToString([]byte(time.Now().Format(time.RFC3339Nano) + "|not-a-uuid")),
+		}
+
+		for _, cursor := range invalid {
+			_, err := decodeListCursor(cursor)
+			assert.Error(t, err, cursor)
+			assert.Contains(t, err.Error(), "invalid cursor")
+		}
+	})
+}
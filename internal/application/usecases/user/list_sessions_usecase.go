@@ -0,0 +1,62 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moura95/backend-challenge/internal/domain/token"
+)
+
+type SessionResponse struct {
+	ID        string    `json:"id"`
+	UserAgent string    `json:"user_agent"`
+	IPAddress string    `json:"ip_address"`
+	IssuedAt  time.Time `json:"issued_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+	// Current marks the session of the token used in this request
+	Current bool `json:"current"`
+}
+
+type ListSessionsResponse struct {
+	Sessions []SessionResponse `json:"sessions"`
+}
+
+type ListSessionsUseCase struct {
+	sessionRepo token.SessionRepository
+}
+
+func NewListSessionsUseCase(sessionRepo token.SessionRepository) *ListSessionsUseCase {
+	return &ListSessionsUseCase{
+		sessionRepo: sessionRepo,
+	}
+}
+
+func (uc *ListSessionsUseCase) Execute(ctx context.Context, userID string, currentSessionID string) (*ListSessionsResponse, error) {
+	parsedID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("usecase: list sessions failed: invalid user ID format")
+	}
+
+	// 1. Buscar sessões ativas (não revogadas e não expiradas)
+	sessions, err := uc.sessionRepo.ListActive(ctx, parsedID)
+	if err != nil {
+		return nil, fmt.Errorf("usecase: list sessions failed: %w", err)
+	}
+
+	// 2. Montar resposta
+	response := &ListSessionsResponse{Sessions: make([]SessionResponse, 0, len(sessions))}
+	for _, session := range sessions {
+		response.Sessions = append(response.Sessions, SessionResponse{
+			ID:        session.ID.String(),
+			UserAgent: session.UserAgent,
+			IPAddress: session.IPAddress,
+			IssuedAt:  session.IssuedAt,
+			ExpiresAt: session.ExpiresAt,
+			Current:   session.ID.String() == currentSessionID,
+		})
+	}
+
+	return response, nil
+}
@@ -0,0 +1,122 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moura95/backend-challenge/internal/domain/email"
+	"github.com/moura95/backend-challenge/internal/domain/user"
+)
+
+type ListUserEmailsRequest struct {
+	UserID      string `json:"user_id"`
+	Page        int    `json:"page"`
+	PageSize    int    `json:"page_size"`
+	IncludeBody bool   `json:"include_body"` // O corpo só vem quando pedido explicitamente
+}
+
+// UserEmail é o resumo de um email enviado ao usuário
+type UserEmail struct {
+	ID        string     `json:"id"`
+	Subject   string     `json:"subject"`
+	Type      string     `json:"type"`
+	Status    string     `json:"status"`
+	CreatedAt time.Time  `json:"created_at"`
+	SentAt    *time.Time `json:"sent_at"`
+	Body      string     `json:"body,omitempty"`
+}
+
+type ListUserEmailsResponse struct {
+	Emails   []UserEmail `json:"emails"`
+	Total    int         `json:"total"`
+	Page     int         `json:"page"`
+	PageSize int         `json:"page_size"`
+}
+
+type ListUserEmailsUseCase struct {
+	userRepo        user.Repository
+	emailRepo       email.Repository
+	defaultPageSize int
+	maxPageSize     int
+}
+
+func NewListUserEmailsUseCase(userRepo user.Repository, emailRepo email.Repository) *ListUserEmailsUseCase {
+	return &ListUserEmailsUseCase{
+		userRepo:        userRepo,
+		emailRepo:       emailRepo,
+		defaultPageSize: DefaultPageSize,
+		maxPageSize:     DefaultMaxPageSize,
+	}
+}
+
+// WithPageSizeLimits define o tamanho de página padrão e o máximo permitido,
+// com as mesmas regras da listagem de usuários.
+func (uc *ListUserEmailsUseCase) WithPageSizeLimits(defaultSize, maxSize int) *ListUserEmailsUseCase {
+	if maxSize > 0 {
+		uc.maxPageSize = maxSize
+	}
+	if defaultSize > 0 {
+		uc.defaultPageSize = defaultSize
+	}
+	if uc.defaultPageSize > uc.maxPageSize {
+		uc.defaultPageSize = uc.maxPageSize
+	}
+	return uc
+}
+
+func (uc *ListUserEmailsUseCase) Execute(ctx context.Context, req ListUserEmailsRequest) (*ListUserEmailsResponse, error) {
+	// 1. Validar ID e paginação
+	parsedID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("usecase: list user emails failed: invalid user ID format")
+	}
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PageSize <= 0 {
+		req.PageSize = uc.defaultPageSize
+	}
+	if req.PageSize > uc.maxPageSize {
+		req.PageSize = uc.maxPageSize
+	}
+
+	// 2. Buscar o endereço atual do usuário
+	foundUser, err := uc.userRepo.GetByID(ctx, parsedID)
+	if err != nil {
+		return nil, fmt.Errorf("usecase: list user emails failed: %w", err)
+	}
+
+	// 3. Listar os emails enviados para esse endereço
+	emails, total, err := uc.emailRepo.ListByRecipient(ctx, foundUser.Email, email.RecipientListParams{
+		Page:        req.Page,
+		PageSize:    req.PageSize,
+		IncludeBody: req.IncludeBody,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("usecase: list user emails failed: %w", err)
+	}
+
+	response := &ListUserEmailsResponse{
+		Emails:   make([]UserEmail, len(emails)),
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}
+	for i, e := range emails {
+		response.Emails[i] = UserEmail{
+			ID:        e.ID.String(),
+			Subject:   e.Subject,
+			Type:      string(e.Type),
+			Status:    string(e.Status),
+			CreatedAt: e.CreatedAt,
+			SentAt:    e.SentAt,
+		}
+		if req.IncludeBody {
+			response.Emails[i].Body = e.Body
+		}
+	}
+
+	return response, nil
+}
@@ -0,0 +1,83 @@
+package user
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/moura95/backend-challenge/internal/domain/email"
+	"github.com/moura95/backend-challenge/internal/domain/user"
+)
+
+type recipientUserRepository struct {
+	user.Repository
+	user *user.User
+}
+
+func (r *recipientUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*user.User, error) {
+	if r.user == nil || r.user.ID != id {
+		return nil, user.ErrUserNotFound
+	}
+	return r.user, nil
+}
+
+// recipientEmailRepository records the params it was called with.
+type recipientEmailRepository struct {
+	email.Repository
+	to     string
+	params email.RecipientListParams
+}
+
+func (r *recipientEmailRepository) ListByRecipient(ctx context.Context, to string, params email.RecipientListParams) ([]*email.Email, int, error) {
+	r.to, r.params = to, params
+	return []*email.Email{{
+		ID:      uuid.New(),
+		To:      to,
+		Subject: "Welcome",
+		Body:    "<p>Hi</p>",
+		Type:    email.EmailTypeWelcome,
+		Status:  email.StatusSent,
+	}}, 1, nil
+}
+
+func TestListUserEmailsUseCase_Execute(t *testing.T) {
+	owner := &user.User{ID: uuid.New(), Email: "owner@example.com"}
+	ctx := context.Background()
+
+	t.Run("should list by the user's current address with clamped paging", func(t *testing.T) {
+		emailRepo := &recipientEmailRepository{}
+		uc := NewListUserEmailsUseCase(&recipientUserRepository{user: owner}, emailRepo).WithPageSizeLimits(5, 20)
+
+		result, err := uc.Execute(ctx, ListUserEmailsRequest{UserID: owner.ID.String(), PageSize: 500})
+
+		require.NoError(t, err)
+		assert.Equal(t, "owner@example.com", emailRepo.to)
+		assert.Equal(t, email.RecipientListParams{Page: 1, PageSize: 20}, emailRepo.params)
+		assert.Equal(t, 1, result.Total)
+		require.Len(t, result.Emails, 1)
+		assert.Equal(t, "sent", result.Emails[0].Status)
+		assert.Empty(t, result.Emails[0].Body)
+	})
+
+	t.Run("should return bodies when requested", func(t *testing.T) {
+		emailRepo := &recipientEmailRepository{}
+		uc := NewListUserEmailsUseCase(&recipientUserRepository{user: owner}, emailRepo)
+
+		result, err := uc.Execute(ctx, ListUserEmailsRequest{UserID: owner.ID.String(), IncludeBody: true})
+
+		require.NoError(t, err)
+		assert.True(t, emailRepo.params.IncludeBody)
+		assert.Equal(t, DefaultPageSize, emailRepo.params.PageSize)
+		assert.Equal(t, "<p>Hi</p>", result.Emails[0].Body)
+	})
+
+	t.Run("should reject invalid user IDs", func(t *testing.T) {
+		uc := NewListUserEmailsUseCase(&recipientUserRepository{}, &recipientEmailRepository{})
+
+		_, err := uc.Execute(ctx, ListUserEmailsRequest{UserID: "not-a-uuid"})
+		assert.ErrorContains(t, err, "invalid user ID format")
+	})
+}
@@ -3,30 +3,67 @@ package user
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/moura95/backend-challenge/internal/domain/user"
 )
 
 type ListUsersRequest struct {
-	Page     int    `json:"page"`
-	PageSize int    `json:"page_size"`
-	Search   string `json:"search"`
+	Page           int    `json:"page"`
+	PageSize       int    `json:"page_size"`
+	Search         string `json:"search"`
+	IncludeDeleted bool   `json:"include_deleted"`
+	SortBy         string `json:"sort_by"`
+	SortOrder      string `json:"sort_order"`
+	Cursor         string `json:"cursor"` // Quando presente, usa paginação por cursor em vez de page
+	// Janela opcional de criação (inclusiva), combinável com a busca
+	CreatedAfter  *time.Time `json:"created_after"`
+	CreatedBefore *time.Time `json:"created_before"`
 }
 
 type ListUsersResponse struct {
-	Users []*user.User `json:"users"`
-	Total int          `json:"total"`
-	Page  int          `json:"page"`
+	Users      []*user.User `json:"users"`
+	Total      int          `json:"total"`
+	Page       int          `json:"page"`
+	PageSize   int          `json:"page_size"` // Tamanho efetivo, após aplicar padrão e limite
+	NextCursor string       `json:"next_cursor"`
 }
 
+// Tamanhos de página usados quando a configuração não define outros; também
+// são os padrões de DEFAULT_PAGE_SIZE e MAX_PAGE_SIZE
+const (
+	DefaultPageSize    = 10
+	DefaultMaxPageSize = 100
+)
+
 type ListUsersUseCase struct {
-	userRepo user.Repository
+	userRepo        user.Repository
+	defaultPageSize int
+	maxPageSize     int
 }
 
 func NewListUsersUseCase(userRepo user.Repository) *ListUsersUseCase {
 	return &ListUsersUseCase{
-		userRepo: userRepo,
+		userRepo:        userRepo,
+		defaultPageSize: DefaultPageSize,
+		maxPageSize:     DefaultMaxPageSize,
+	}
+}
+
+// WithPageSizeLimits define o tamanho de página padrão e o máximo permitido.
+// Valores não positivos mantêm o padrão; o padrão nunca passa do máximo.
+func (uc *ListUsersUseCase) WithPageSizeLimits(defaultSize, maxSize int) *ListUsersUseCase {
+	if maxSize > 0 {
+		uc.maxPageSize = maxSize
+	}
+	if defaultSize > 0 {
+		uc.defaultPageSize = defaultSize
 	}
+	if uc.defaultPageSize > uc.maxPageSize {
+		uc.defaultPageSize = uc.maxPageSize
+	}
+	return uc
 }
 
 func (uc *ListUsersUseCase) Execute(ctx context.Context, req ListUsersRequest) (*ListUsersResponse, error) {
@@ -34,16 +71,45 @@ func (uc *ListUsersUseCase) Execute(ctx context.Context, req ListUsersRequest) (
 		req.Page = 1
 	}
 	if req.PageSize <= 0 {
-		req.PageSize = 10
+		req.PageSize = uc.defaultPageSize
+	}
+	if req.PageSize > uc.maxPageSize {
+		req.PageSize = uc.maxPageSize
 	}
-	if req.PageSize > 100 {
-		req.PageSize = 100
+
+	if req.CreatedAfter != nil && req.CreatedBefore != nil && req.CreatedAfter.After(*req.CreatedBefore) {
+		return nil, fmt.Errorf("usecase: list users failed: invalid date range: created_after is after created_before")
+	}
+
+	sortBy, sortOrder, err := normalizeSort(req.SortBy, req.SortOrder)
+	if err != nil {
+		return nil, fmt.Errorf("usecase: list users failed: %w", err)
+	}
+	keysetOrder := sortBy == user.SortByCreatedAt && sortOrder == user.SortOrderDesc
+
+	var after *user.ListCursor
+	if req.Cursor != "" {
+		// O cursor só é válido na ordem padrão (created_at desc)
+		if !keysetOrder {
+			return nil, fmt.Errorf("usecase: list users failed: invalid sort for cursor pagination")
+		}
+		after, err = decodeListCursor(req.Cursor)
+		if err != nil {
+			return nil, fmt.Errorf("usecase: list users failed: %w", err)
+		}
+		req.Page = 1
 	}
 
 	params := user.ListParams{
-		Page:     req.Page,
-		PageSize: req.PageSize,
-		Search:   req.Search,
+		Page:           req.Page,
+		PageSize:       req.PageSize,
+		Search:         req.Search,
+		IncludeDeleted: req.IncludeDeleted,
+		SortBy:         sortBy,
+		SortOrder:      sortOrder,
+		After:          after,
+		CreatedAfter:   req.CreatedAfter,
+		CreatedBefore:  req.CreatedBefore,
 	}
 
 	users, total, err := uc.userRepo.List(ctx, params)
@@ -52,10 +118,38 @@ func (uc *ListUsersUseCase) Execute(ctx context.Context, req ListUsersRequest) (
 	}
 
 	response := &ListUsersResponse{
-		Users: users,
-		Total: total,
-		Page:  req.Page,
+		Users:    users,
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}
+
+	// Página cheia na ordem padrão: devolve o cursor da última linha
+	if keysetOrder && len(users) > 0 && len(users) == req.PageSize {
+		response.NextCursor = encodeListCursor(users[len(users)-1])
 	}
 
 	return response, nil
 }
+
+// normalizeSort aplica o padrão (created_at desc) e rejeita colunas fora da allowlist.
+func normalizeSort(sortBy, sortOrder string) (string, string, error) {
+	sortBy = strings.ToLower(strings.TrimSpace(sortBy))
+	sortOrder = strings.ToLower(strings.TrimSpace(sortOrder))
+
+	if sortBy == "" {
+		sortBy = user.SortByCreatedAt
+	}
+	if !user.SortableFields[sortBy] {
+		return "", "", fmt.Errorf("invalid sort field: %s", sortBy)
+	}
+
+	if sortOrder == "" {
+		sortOrder = user.SortOrderDesc
+	}
+	if sortOrder != user.SortOrderAsc && sortOrder != user.SortOrderDesc {
+		return "", "", fmt.Errorf("invalid sort order: %s", sortOrder)
+	}
+
+	return sortBy, sortOrder, nil
+}
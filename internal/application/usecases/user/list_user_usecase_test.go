@@ -79,6 +79,13 @@ func runListUsersMigrations(db *sqlx.DB) error {
 		name         VARCHAR(255) NOT NULL,
 		email        VARCHAR(100) NOT NULL UNIQUE,
 		password     TEXT NOT NULL,
+		verified_at  TIMESTAMPTZ,
+		role         VARCHAR(20) NOT NULL DEFAULT 'user',
+		deleted_at   TIMESTAMPTZ,
+		version      INTEGER NOT NULL DEFAULT 1,
+		status       VARCHAR(20) NOT NULL DEFAULT 'active',
+		token_version INTEGER NOT NULL DEFAULT 0,
+		last_login_at TIMESTAMPTZ,
 		created_at   TIMESTAMP NOT NULL DEFAULT NOW(),
 		updated_at   TIMESTAMP NOT NULL DEFAULT NOW()
 	);
@@ -286,6 +293,21 @@ func TestListUsersUseCase_Execute(t *testing.T) {
 		result2, err := useCase.Execute(ctx, req2)
 		require.NoError(t, err)
 		assert.LessOrEqual(t, len(result2.Users), 100) // Should cap at 100
+		assert.Equal(t, DefaultMaxPageSize, result2.PageSize)
+	})
+
+	t.Run("should clamp to configured page size limits", func(t *testing.T) {
+		useCase := NewListUsersUseCase(server.repos.User).WithPageSizeLimits(5, 50)
+
+		result, err := useCase.Execute(ctx, ListUsersRequest{Page: 1, PageSize: 500})
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(result.Users), 50)
+		assert.Equal(t, 50, result.PageSize)
+
+		result, err = useCase.Execute(ctx, ListUsersRequest{Page: 1})
+		require.NoError(t, err)
+		assert.Len(t, result.Users, 5)
+		assert.Equal(t, 5, result.PageSize)
 	})
 
 	t.Run("should handle empty database", func(t *testing.T) {
@@ -398,3 +420,283 @@ func TestListUsersUseCase_Execute(t *testing.T) {
 		}
 	})
 }
+
+func TestListUsersUseCase_Sorting(t *testing.T) {
+	server := setupListUsersTest(t)
+	defer server.cleanup()
+
+	ctx := context.Background()
+
+	// Created in this order so created_at order differs from name order
+	names := []string{"Charlie Brown", "Alice Johnson", "Bob Smith"}
+	for i, name := range names {
+		testUser, err := user.NewUser(name, fmt.Sprintf("sort%d@example.com", i), "password123")
+		require.NoError(t, err)
+		require.NoError(t, server.repos.User.Create(ctx, testUser))
+	}
+
+	useCase := NewListUsersUseCase(server.repos.User)
+
+	userNames := func(users []*user.User) []string {
+		result := make([]string, len(users))
+		for i, u := range users {
+			result[i] = u.Name
+		}
+		return result
+	}
+
+	t.Run("should sort by name ascending", func(t *testing.T) {
+		result, err := useCase.Execute(ctx, ListUsersRequest{SortBy: "name", SortOrder: "asc"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Alice Johnson", "Bob Smith", "Charlie Brown"}, userNames(result.Users))
+	})
+
+	t.Run("should sort by name descending", func(t *testing.T) {
+		result, err := useCase.Execute(ctx, ListUsersRequest{SortBy: "name", SortOrder: "desc"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Charlie Brown", "Bob Smith", "Alice Johnson"}, userNames(result.Users))
+	})
+
+	t.Run("should sort by created_at ascending", func(t *testing.T) {
+		result, err := useCase.Execute(ctx, ListUsersRequest{SortBy: "created_at", SortOrder: "asc"})
+
+		require.NoError(t, err)
+		assert.Equal(t, names, userNames(result.Users))
+	})
+
+	t.Run("should sort by created_at descending", func(t *testing.T) {
+		result, err := useCase.Execute(ctx, ListUsersRequest{SortBy: "created_at", SortOrder: "desc"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Bob Smith", "Alice Johnson", "Charlie Brown"}, userNames(result.Users))
+	})
+
+	t.Run("should default to created_at descending", func(t *testing.T) {
+		result, err := useCase.Execute(ctx, ListUsersRequest{})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Bob Smith", "Alice Johnson", "Charlie Brown"}, userNames(result.Users))
+	})
+
+	t.Run("should accept mixed case sort params", func(t *testing.T) {
+		result, err := useCase.Execute(ctx, ListUsersRequest{SortBy: "Name", SortOrder: "ASC"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Alice Johnson", "Bob Smith", "Charlie Brown"}, userNames(result.Users))
+	})
+
+	t.Run("should reject unknown sort field", func(t *testing.T) {
+		for _, sortBy := range []string{"password", "uuid", "name; DROP TABLE users; --"} {
+			result, err := useCase.Execute(ctx, ListUsersRequest{SortBy: sortBy})
+
+			assert.Error(t, err)
+			assert.Nil(t, result)
+			assert.Contains(t, err.Error(), "invalid sort field")
+		}
+	})
+
+	t.Run("should reject unknown sort order", func(t *testing.T) {
+		result, err := useCase.Execute(ctx, ListUsersRequest{SortBy: "name", SortOrder: "sideways"})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "invalid sort order")
+	})
+}
+
+func TestListUsersUseCase_Total(t *testing.T) {
+	server := setupListUsersTest(t)
+	defer server.cleanup()
+
+	ctx := context.Background()
+
+	createTestUsersForList(t, server)
+	useCase := NewListUsersUseCase(server.repos.User)
+
+	t.Run("should return total of all matching users, not page size", func(t *testing.T) {
+		result, err := useCase.Execute(ctx, ListUsersRequest{Page: 1, PageSize: 2})
+
+		require.NoError(t, err)
+		assert.Len(t, result.Users, 2)
+		assert.Equal(t, 10, result.Total)
+	})
+
+	t.Run("should keep total on later pages", func(t *testing.T) {
+		result, err := useCase.Execute(ctx, ListUsersRequest{Page: 5, PageSize: 2})
+
+		require.NoError(t, err)
+		assert.Len(t, result.Users, 2)
+		assert.Equal(t, 10, result.Total)
+
+		result, err = useCase.Execute(ctx, ListUsersRequest{Page: 6, PageSize: 2})
+
+		require.NoError(t, err)
+		assert.Empty(t, result.Users)
+		assert.Equal(t, 10, result.Total)
+	})
+
+	t.Run("should apply search filter to total", func(t *testing.T) {
+		result, err := useCase.Execute(ctx, ListUsersRequest{Page: 1, PageSize: 2, Search: "test.com"})
+
+		require.NoError(t, err)
+		assert.Len(t, result.Users, 2)
+		assert.Equal(t, 4, result.Total)
+	})
+}
+
+func TestListUsersUseCase_Cursor(t *testing.T) {
+	server := setupListUsersTest(t)
+	defer server.cleanup()
+
+	ctx := context.Background()
+
+	createTestUsersForList(t, server)
+	useCase := NewListUsersUseCase(server.repos.User)
+
+	t.Run("should walk all users with cursor pages", func(t *testing.T) {
+		seen := make(map[string]bool)
+		var ordered []*user.User
+
+		result, err := useCase.Execute(ctx, ListUsersRequest{PageSize: 3})
+		require.NoError(t, err)
+		require.NotEmpty(t, result.NextCursor)
+		ordered = append(ordered, result.Users...)
+
+		for result.NextCursor != "" {
+			result, err = useCase.Execute(ctx, ListUsersRequest{PageSize: 3, Cursor: result.NextCursor})
+			require.NoError(t, err)
+			ordered = append(ordered, result.Users...)
+		}
+
+		for _, u := range ordered {
+			assert.False(t, seen[u.ID.String()], "user should not appear twice")
+			seen[u.ID.String()] = true
+		}
+		assert.Len(t, ordered, 10)
+
+		for i := 1; i < len(ordered); i++ {
+			assert.False(t, ordered[i].CreatedAt.After(ordered[i-1].CreatedAt), "should be ordered by created_at desc")
+		}
+	})
+
+	t.Run("should prefer cursor over page", func(t *testing.T) {
+		first, err := useCase.Execute(ctx, ListUsersRequest{PageSize: 2})
+		require.NoError(t, err)
+
+		second, err := useCase.Execute(ctx, ListUsersRequest{Page: 50, PageSize: 2, Cursor: first.NextCursor})
+		require.NoError(t, err)
+
+		require.Len(t, second.Users, 2)
+		assert.NotEqual(t, first.Users[1].ID, second.Users[0].ID)
+	})
+
+	t.Run("should return empty page past the end", func(t *testing.T) {
+		all, err := useCase.Execute(ctx, ListUsersRequest{PageSize: 10})
+		require.NoError(t, err)
+		require.Len(t, all.Users, 10)
+		require.NotEmpty(t, all.NextCursor)
+
+		result, err := useCase.Execute(ctx, ListUsersRequest{PageSize: 10, Cursor: all.NextCursor})
+
+		require.NoError(t, err)
+		assert.Empty(t, result.Users)
+		assert.Empty(t, result.NextCursor)
+	})
+
+	t.Run("should reject malformed cursor", func(t *testing.T) {
+		result, err := useCase.Execute(ctx, ListUsersRequest{Cursor: "not-a-cursor!"})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "invalid cursor")
+	})
+
+	t.Run("should reject cursor with custom sort", func(t *testing.T) {
+		first, err := useCase.Execute(ctx, ListUsersRequest{PageSize: 2})
+		require.NoError(t, err)
+
+		result, err := useCase.Execute(ctx, ListUsersRequest{Cursor: first.NextCursor, SortBy: "name", SortOrder: "asc"})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "invalid sort for cursor pagination")
+	})
+}
+
+func TestListUsersUseCase_CreatedRange(t *testing.T) {
+	server := setupListUsersTest(t)
+	defer server.cleanup()
+
+	ctx := context.Background()
+
+	createTestUsersForList(t, server)
+	useCase := NewListUsersUseCase(server.repos.User)
+
+	// Backdate some signups using the DB clock
+	_, err := server.db.Exec("UPDATE users SET created_at = NOW() - INTERVAL '10 days' WHERE email IN ('alice@example.com', 'bob@example.com')")
+	require.NoError(t, err)
+	_, err = server.db.Exec("UPDATE users SET created_at = NOW() - INTERVAL '3 days' WHERE email IN ('charlie@test.com', 'diana@example.com')")
+	require.NoError(t, err)
+
+	now := time.Now()
+	ptr := func(t time.Time) *time.Time { return &t }
+
+	emailsOf := func(users []*user.User) []string {
+		emails := make([]string, len(users))
+		for i, u := range users {
+			emails[i] = u.Email
+		}
+		return emails
+	}
+
+	t.Run("should include only users inside the window", func(t *testing.T) {
+		result, err := useCase.Execute(ctx, ListUsersRequest{
+			CreatedAfter:  ptr(now.Add(-5 * 24 * time.Hour)),
+			CreatedBefore: ptr(now.Add(-24 * time.Hour)),
+		})
+
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"charlie@test.com", "diana@example.com"}, emailsOf(result.Users))
+		assert.Equal(t, 2, result.Total)
+	})
+
+	t.Run("should support open ended ranges", func(t *testing.T) {
+		result, err := useCase.Execute(ctx, ListUsersRequest{
+			CreatedBefore: ptr(now.Add(-24 * time.Hour)),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 4, result.Total)
+
+		result, err = useCase.Execute(ctx, ListUsersRequest{
+			CreatedAfter: ptr(now.Add(-24 * time.Hour)),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 6, result.Total)
+	})
+
+	t.Run("should combine with search", func(t *testing.T) {
+		result, err := useCase.Execute(ctx, ListUsersRequest{
+			Search:        "example.com",
+			CreatedBefore: ptr(now.Add(-24 * time.Hour)),
+		})
+
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"alice@example.com", "bob@example.com", "diana@example.com"}, emailsOf(result.Users))
+		assert.Equal(t, 3, result.Total)
+	})
+
+	t.Run("should reject created_after after created_before", func(t *testing.T) {
+		result, err := useCase.Execute(ctx, ListUsersRequest{
+			CreatedAfter:  ptr(now),
+			CreatedBefore: ptr(now.Add(-24 * time.Hour)),
+		})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "invalid date range")
+	})
+}
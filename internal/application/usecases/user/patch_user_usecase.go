@@ -0,0 +1,68 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/moura95/backend-challenge/internal/domain/user"
+)
+
+// PatchUserRequest: nil mantém o valor atual; qualquer valor presente,
+// mesmo vazio, é aplicado (e validado).
+type PatchUserRequest struct {
+	Name  *string `json:"name"`
+	Email *string `json:"email"`
+}
+
+type PatchUserUseCase struct {
+	userRepo user.Repository
+}
+
+func NewPatchUserUseCase(userRepo user.Repository) *PatchUserUseCase {
+	return &PatchUserUseCase{
+		userRepo: userRepo,
+	}
+}
+
+func (uc *PatchUserUseCase) Execute(ctx context.Context, userID string, req PatchUserRequest) (*user.User, error) {
+	// 1. Validar ID
+	parsedID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("usecase: patch user failed: invalid user ID format")
+	}
+
+	// 2. Buscar usuário atual
+	foundUser, err := uc.userRepo.GetByID(ctx, parsedID)
+	if err != nil {
+		return nil, fmt.Errorf("usecase: patch user failed: %w", err)
+	}
+
+	// 3. Nada a alterar: devolve o perfil atual sem gravar
+	if req.Name == nil && req.Email == nil {
+		return foundUser, nil
+	}
+
+	// 4. Email novo precisa estar livre
+	if req.Email != nil && user.NormalizeEmail(*req.Email) != foundUser.Email {
+		exists, err := uc.userRepo.EmailExists(ctx, user.NormalizeEmail(*req.Email))
+		if err != nil {
+			return nil, fmt.Errorf("usecase: patch user failed: %w", err)
+		}
+		if exists {
+			return nil, fmt.Errorf("usecase: patch user failed: %w", user.ErrEmailExists)
+		}
+	}
+
+	// 5. Aplicar e validar os campos presentes
+	if err = foundUser.PatchUser(req.Name, req.Email); err != nil {
+		return nil, fmt.Errorf("usecase: patch user failed: %w", err)
+	}
+
+	// 6. Persistir (com controle de versão)
+	if err = uc.userRepo.Update(ctx, foundUser); err != nil {
+		return nil, fmt.Errorf("usecase: patch user failed: %w", err)
+	}
+
+	return foundUser, nil
+}
@@ -0,0 +1,63 @@
+package user
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/moura95/backend-challenge/internal/domain/user"
+)
+
+func TestPatchUserUseCase_Execute(t *testing.T) {
+	server := setupUpdateUserTest(t)
+	defer server.cleanup()
+
+	ctx := context.Background()
+	useCase := NewPatchUserUseCase(server.repos.User)
+
+	t.Run("should update only the present field", func(t *testing.T) {
+		testUser := createTestUserForUpdate(t, server, "patchname@example.com", "password123", "Before Patch")
+		name := "After Patch"
+
+		result, err := useCase.Execute(ctx, testUser.ID.String(), PatchUserRequest{Name: &name})
+
+		require.NoError(t, err)
+		assert.Equal(t, "After Patch", result.Name)
+		assert.Equal(t, "patchname@example.com", result.Email)
+	})
+
+	t.Run("should return current profile when nothing is present", func(t *testing.T) {
+		testUser := createTestUserForUpdate(t, server, "patchnoop@example.com", "password123", "Noop User")
+
+		result, err := useCase.Execute(ctx, testUser.ID.String(), PatchUserRequest{})
+
+		require.NoError(t, err)
+		assert.Equal(t, "Noop User", result.Name)
+		assert.Equal(t, testUser.Version, result.Version)
+	})
+
+	t.Run("should reject a present empty email", func(t *testing.T) {
+		testUser := createTestUserForUpdate(t, server, "patchempty@example.com", "password123", "Empty Email")
+		empty := ""
+
+		result, err := useCase.Execute(ctx, testUser.ID.String(), PatchUserRequest{Email: &empty})
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+		var validationErr *user.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Contains(t, validationErr.Fields, user.FieldEmail)
+	})
+
+	t.Run("should reject an email already in use", func(t *testing.T) {
+		createTestUserForUpdate(t, server, "patchtaken@example.com", "password123", "Taken")
+		testUser := createTestUserForUpdate(t, server, "patchmover@example.com", "password123", "Mover")
+		taken := "patchtaken@example.com"
+
+		_, err := useCase.Execute(ctx, testUser.ID.String(), PatchUserRequest{Email: &taken})
+
+		assert.ErrorIs(t, err, user.ErrEmailExists)
+	})
+}
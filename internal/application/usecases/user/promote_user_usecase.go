@@ -0,0 +1,46 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/moura95/backend-challenge/internal/domain/user"
+)
+
+type PromoteUserUseCase struct {
+	userRepo user.Repository
+}
+
+func NewPromoteUserUseCase(userRepo user.Repository) *PromoteUserUseCase {
+	return &PromoteUserUseCase{
+		userRepo: userRepo,
+	}
+}
+
+// Execute promove o usuário com o email informado para admin
+func (uc *PromoteUserUseCase) Execute(ctx context.Context, email string) (*user.User, error) {
+	// 1. Validar entrada
+	email = user.NormalizeEmail(email)
+	if email == "" {
+		return nil, fmt.Errorf("usecase: promote user failed: email is required")
+	}
+
+	// 2. Buscar usuário
+	foundUser, err := uc.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, fmt.Errorf("usecase: promote user failed: %w", err)
+	}
+
+	// 3. Nada a fazer se já for admin
+	if foundUser.IsAdmin() {
+		return foundUser, nil
+	}
+
+	// 4. Atualizar papel
+	if err := uc.userRepo.UpdateRole(ctx, foundUser.ID, user.RoleAdmin); err != nil {
+		return nil, fmt.Errorf("usecase: promote user failed: %w", err)
+	}
+	foundUser.Role = user.RoleAdmin
+
+	return foundUser, nil
+}
@@ -0,0 +1,165 @@
+package user
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+
+	"github.com/moura95/backend-challenge/internal/domain/user"
+	"github.com/moura95/backend-challenge/internal/infra/repository/adapters"
+)
+
+type promoteUserTestServer struct {
+	container *postgres.PostgresContainer
+	db        *sqlx.DB
+	repos     *adapters.Repositories
+	cleanup   func()
+}
+
+func setupPromoteUserTest(t *testing.T) *promoteUserTestServer {
+	ctx := context.Background()
+
+	// Start PostgreSQL container
+	postgresContainer, err := postgres.RunContainer(ctx,
+		testcontainers.WithImage("postgres:15-alpine"),
+		postgres.WithDatabase("testdb"),
+		postgres.WithUsername("test"),
+		postgres.WithPassword("test"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(30*time.Second)),
+	)
+	require.NoError(t, err)
+
+	// Get connection string
+	connStr, err := postgresContainer.ConnectionString(ctx, "sslmode=disable")
+	require.NoError(t, err)
+
+	// Connect to database
+	db, err := sqlx.Connect("postgres", connStr)
+	require.NoError(t, err)
+
+	// Run migrations
+	err = runPromoteUserMigrations(db)
+	require.NoError(t, err)
+
+	// Setup repositories
+	repos := adapters.NewRepositories(db)
+
+	cleanup := func() {
+		db.Close()
+		postgresContainer.Terminate(ctx)
+	}
+
+	return &promoteUserTestServer{
+		container: postgresContainer,
+		db:        db,
+		repos:     repos,
+		cleanup:   cleanup,
+	}
+}
+
+func runPromoteUserMigrations(db *sqlx.DB) error {
+	migrationSQL := `
+	CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+	
+	-- Users table
+	CREATE TABLE IF NOT EXISTS users (
+		uuid         UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+		name         VARCHAR(255) NOT NULL,
+		email        VARCHAR(100) NOT NULL UNIQUE,
+		password     TEXT NOT NULL,
+		verified_at  TIMESTAMPTZ,
+		role         VARCHAR(20) NOT NULL DEFAULT 'user',
+		deleted_at   TIMESTAMPTZ,
+		version      INTEGER NOT NULL DEFAULT 1,
+		status       VARCHAR(20) NOT NULL DEFAULT 'active',
+		token_version INTEGER NOT NULL DEFAULT 0,
+		last_login_at TIMESTAMPTZ,
+		created_at   TIMESTAMP NOT NULL DEFAULT NOW(),
+		updated_at   TIMESTAMP NOT NULL DEFAULT NOW()
+	);
+	
+	-- Indexes
+	CREATE INDEX IF NOT EXISTS idx_users_email ON users(email);
+	`
+
+	_, err := db.Exec(migrationSQL)
+	return err
+}
+
+// Helper function to create a test user in the database
+func createTestUserForPromote(t *testing.T, server *promoteUserTestServer, email, password, name string) *user.User {
+	ctx := context.Background()
+
+	// Create user using domain logic
+	testUser, err := user.NewUser(name, email, password)
+	require.NoError(t, err)
+
+	// Save to database
+	err = server.repos.User.Create(ctx, testUser)
+	require.NoError(t, err)
+
+	return testUser
+}
+
+func TestPromoteUserUseCase_Execute(t *testing.T) {
+	server := setupPromoteUserTest(t)
+	defer server.cleanup()
+
+	ctx := context.Background()
+
+	t.Run("should promote user to admin", func(t *testing.T) {
+		testUser := createTestUserForPromote(t, server, "promote@example.com", "password123", "Promote User")
+		assert.Equal(t, user.RoleUser, testUser.Role)
+
+		useCase := NewPromoteUserUseCase(server.repos.User)
+		promoted, err := useCase.Execute(ctx, "promote@example.com")
+
+		require.NoError(t, err)
+		assert.Equal(t, user.RoleAdmin, promoted.Role)
+
+		stored, err := server.repos.User.GetByID(ctx, testUser.ID)
+		require.NoError(t, err)
+		assert.True(t, stored.IsAdmin())
+	})
+
+	t.Run("should be idempotent for existing admin", func(t *testing.T) {
+		createTestUserForPromote(t, server, "already@example.com", "password123", "Already Admin")
+
+		useCase := NewPromoteUserUseCase(server.repos.User)
+		_, err := useCase.Execute(ctx, "already@example.com")
+		require.NoError(t, err)
+
+		promoted, err := useCase.Execute(ctx, "already@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, user.RoleAdmin, promoted.Role)
+	})
+
+	t.Run("should fail for unknown email", func(t *testing.T) {
+		useCase := NewPromoteUserUseCase(server.repos.User)
+		promoted, err := useCase.Execute(ctx, "unknown@example.com")
+
+		assert.Error(t, err)
+		assert.Nil(t, promoted)
+		assert.Contains(t, err.Error(), "user not found")
+	})
+
+	t.Run("should fail with empty email", func(t *testing.T) {
+		useCase := NewPromoteUserUseCase(server.repos.User)
+		promoted, err := useCase.Execute(ctx, "  ")
+
+		assert.Error(t, err)
+		assert.Nil(t, promoted)
+		assert.Contains(t, err.Error(), "email is required")
+	})
+}
@@ -0,0 +1,48 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/moura95/backend-challenge/internal/domain/token"
+)
+
+// RevokeSessionUseCase encerra uma sessão do usuário: o ID da sessão entra
+// na lista de tokens revogados, o que derruba o access token do login, o
+// refresh token e todo access token renovado a partir dele.
+type RevokeSessionUseCase struct {
+	sessionRepo token.SessionRepository
+	tokenRepo   token.Repository
+}
+
+func NewRevokeSessionUseCase(sessionRepo token.SessionRepository, tokenRepo token.Repository) *RevokeSessionUseCase {
+	return &RevokeSessionUseCase{
+		sessionRepo: sessionRepo,
+		tokenRepo:   tokenRepo,
+	}
+}
+
+func (uc *RevokeSessionUseCase) Execute(ctx context.Context, userID string, sessionID string) error {
+	parsedUserID, err := uuid.Parse(userID)
+	if err != nil {
+		return fmt.Errorf("usecase: revoke session failed: invalid user ID format")
+	}
+	parsedSessionID, err := uuid.Parse(sessionID)
+	if err != nil {
+		return fmt.Errorf("usecase: revoke session failed: %w", token.ErrSessionNotFound)
+	}
+
+	// 1. Marcar a sessão como revogada (somente sessões do próprio usuário)
+	session, err := uc.sessionRepo.Revoke(ctx, parsedSessionID, parsedUserID)
+	if err != nil {
+		return fmt.Errorf("usecase: revoke session failed: %w", err)
+	}
+
+	// 2. Revogar os tokens da sessão até ela expirar
+	if err := uc.tokenRepo.Revoke(ctx, session.ID, parsedUserID, session.ExpiresAt); err != nil {
+		return fmt.Errorf("usecase: revoke session failed: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/moura95/backend-challenge/internal/domain/token"
+	"github.com/moura95/backend-challenge/internal/domain/user"
+)
+
+// RevokeSessionsUseCase invalida todos os tokens já emitidos para o usuário,
+// inclusive o da própria requisição, incrementando sua token version.
+type RevokeSessionsUseCase struct {
+	userRepo    user.Repository
+	sessionRepo token.SessionRepository
+}
+
+func NewRevokeSessionsUseCase(userRepo user.Repository) *RevokeSessionsUseCase {
+	return &RevokeSessionsUseCase{
+		userRepo: userRepo,
+	}
+}
+
+// WithSessions também marca as sessões registradas como revogadas, para que
+// deixem de aparecer na listagem.
+func (uc *RevokeSessionsUseCase) WithSessions(repo token.SessionRepository) *RevokeSessionsUseCase {
+	uc.sessionRepo = repo
+	return uc
+}
+
+func (uc *RevokeSessionsUseCase) Execute(ctx context.Context, userID string) error {
+	parsedID, err := uuid.Parse(userID)
+	if err != nil {
+		return fmt.Errorf("usecase: revoke sessions failed: invalid user ID format")
+	}
+
+	if _, err := uc.userRepo.IncrementTokenVersion(ctx, parsedID); err != nil {
+		return fmt.Errorf("usecase: revoke sessions failed: %w", err)
+	}
+
+	if uc.sessionRepo != nil {
+		if err := uc.sessionRepo.RevokeAll(ctx, parsedID); err != nil {
+			return fmt.Errorf("usecase: revoke sessions failed: %w", err)
+		}
+	}
+
+	return nil
+}
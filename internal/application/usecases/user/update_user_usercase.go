@@ -14,8 +14,15 @@ type UpdateUserRequest struct {
 	Email string `json:"email"`
 }
 
+// UpdateTxRunner executa fn numa única transação com um repositório de
+// usuários ligado a ela; a transação é confirmada somente se fn retornar nil.
+type UpdateTxRunner func(ctx context.Context, fn func(userRepo user.Repository) error) error
+
 type UpdateUserUseCase struct {
 	userRepo user.Repository
+
+	// Quando configurado, a checagem de email e a escrita rodam numa transação
+	runInTx UpdateTxRunner
 }
 
 func NewUpdateUserUseCase(userRepo user.Repository) *UpdateUserUseCase {
@@ -24,36 +31,61 @@ func NewUpdateUserUseCase(userRepo user.Repository) *UpdateUserUseCase {
 	}
 }
 
+// WithTransaction lê, valida e grava o usuário numa única transação.
+func (uc *UpdateUserUseCase) WithTransaction(runner UpdateTxRunner) *UpdateUserUseCase {
+	uc.runInTx = runner
+	return uc
+}
+
+// Execute checks the new email before writing so the common case gets a clean
+// ErrEmailExists. Two requests claiming the same email at once both pass the
+// check; the unique index on users.email lets only one write commit and the
+// repository reports the other as ErrEmailExists.
 func (uc *UpdateUserUseCase) Execute(ctx context.Context, userID string, req UpdateUserRequest) (*user.User, error) {
 	parsedID, err := uuid.Parse(userID)
 	if err != nil {
 		return nil, fmt.Errorf("usecase: update user failed: invalid user ID format")
 	}
 
-	foundUser, err := uc.userRepo.GetByID(ctx, parsedID)
-	if err != nil {
-		return nil, fmt.Errorf("usecase: update user failed: %w", err)
-	}
-
-	if strings.TrimSpace(req.Email) != "" && req.Email != foundUser.Email {
-		exists, err := uc.userRepo.EmailExists(ctx, req.Email)
+	var updatedUser *user.User
+	err = uc.inTx(ctx, func(userRepo user.Repository) error {
+		foundUser, err := userRepo.GetByID(ctx, parsedID)
 		if err != nil {
-			return nil, fmt.Errorf("usecase: update user failed: %w", err)
+			return err
 		}
-		if exists {
-			return nil, fmt.Errorf("usecase: update user failed: email already exists")
+
+		if strings.TrimSpace(req.Email) != "" && user.NormalizeEmail(req.Email) != foundUser.Email {
+			exists, err := userRepo.EmailExists(ctx, user.NormalizeEmail(req.Email))
+			if err != nil {
+				return err
+			}
+			if exists {
+				return user.ErrEmailExists
+			}
 		}
-	}
 
-	err = foundUser.UpdateUser(req.Name, req.Email)
-	if err != nil {
-		return nil, fmt.Errorf("usecase: update user failed: %w", err)
-	}
+		if err := foundUser.UpdateUser(req.Name, req.Email); err != nil {
+			return err
+		}
 
-	err = uc.userRepo.Update(ctx, foundUser)
+		if err := userRepo.Update(ctx, foundUser); err != nil {
+			return err
+		}
+
+		updatedUser = foundUser
+		return nil
+	})
 	if err != nil {
 		return nil, fmt.Errorf("usecase: update user failed: %w", err)
 	}
 
-	return foundUser, nil
+	return updatedUser, nil
+}
+
+// inTx usa o UpdateTxRunner configurado ou, sem ele, o repositório direto.
+func (uc *UpdateUserUseCase) inTx(ctx context.Context, fn func(user.Repository) error) error {
+	if uc.runInTx == nil {
+		return fn(uc.userRepo)
+	}
+	return uc.runInTx(ctx, fn)
 }
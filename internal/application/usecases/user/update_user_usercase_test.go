@@ -3,6 +3,7 @@ package user
 import (
 	"context"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -80,6 +81,13 @@ func runUpdateUserMigrations(db *sqlx.DB) error {
 		name         VARCHAR(255) NOT NULL,
 		email        VARCHAR(100) NOT NULL UNIQUE,
 		password     TEXT NOT NULL,
+		verified_at  TIMESTAMPTZ,
+		role         VARCHAR(20) NOT NULL DEFAULT 'user',
+		deleted_at   TIMESTAMPTZ,
+		version      INTEGER NOT NULL DEFAULT 1,
+		status       VARCHAR(20) NOT NULL DEFAULT 'active',
+		token_version INTEGER NOT NULL DEFAULT 0,
+		last_login_at TIMESTAMPTZ,
 		created_at   TIMESTAMP NOT NULL DEFAULT NOW(),
 		updated_at   TIMESTAMP NOT NULL DEFAULT NOW()
 	);
@@ -268,6 +276,70 @@ func TestUpdateUserUseCase_Execute(t *testing.T) {
 		assert.Equal(t, user1.Email, dbEmail) // Should remain unchanged
 	})
 
+	t.Run("should fail with stale version and succeed with fresh one", func(t *testing.T) {
+		testUser := createTestUserForUpdate(t, server, "versioned@example.com", "password123", "Versioned")
+
+		// Concurrent writer bumps the version behind the stale copy
+		stale, err := server.repos.User.GetByID(ctx, testUser.ID)
+		require.NoError(t, err)
+		_, err = server.db.Exec("UPDATE users SET version = version + 1 WHERE uuid = $1", testUser.ID)
+		require.NoError(t, err)
+
+		stale.Name = "Stale Writer"
+		err = server.repos.User.Update(ctx, stale)
+		assert.ErrorIs(t, err, user.ErrConflict)
+
+		// The use case reloads the current version before updating
+		useCase := NewUpdateUserUseCase(server.repos.User)
+		result, err := useCase.Execute(ctx, testUser.ID.String(), UpdateUserRequest{Name: "Fresh Writer"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "Fresh Writer", result.Name)
+
+		var version int
+		err = server.db.Get(&version, "SELECT version FROM users WHERE uuid = $1", testUser.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 3, version)
+	})
+
+	t.Run("should let only one of two concurrent updates claim an email", func(t *testing.T) {
+		first := createTestUserForUpdate(t, server, "race1@example.com", "password123", "Race One")
+		second := createTestUserForUpdate(t, server, "race2@example.com", "password123", "Race Two")
+
+		useCase := NewUpdateUserUseCase(server.repos.User).
+			WithTransaction(func(ctx context.Context, fn func(user.Repository) error) error {
+				return server.repos.WithTx(ctx, func(tx *adapters.Repositories) error {
+					return fn(tx.User)
+				})
+			})
+
+		errs := make([]error, 2)
+		var wg sync.WaitGroup
+		for i, racer := range []*user.User{first, second} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, errs[i] = useCase.Execute(ctx, racer.ID.String(), UpdateUserRequest{Email: "race.winner@example.com"})
+			}()
+		}
+		wg.Wait()
+
+		succeeded := 0
+		for _, err := range errs {
+			if err == nil {
+				succeeded++
+				continue
+			}
+			assert.ErrorIs(t, err, user.ErrEmailExists)
+		}
+		assert.Equal(t, 1, succeeded)
+
+		var claimed int
+		err := server.db.Get(&claimed, "SELECT COUNT(*) FROM users WHERE email = $1", "race.winner@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, 1, claimed)
+	})
+
 	t.Run("should allow updating to same email", func(t *testing.T) {
 		// Create test user
 		testUser := createTestUserForUpdate(t, server, "same@example.com", "password123", "Same User")
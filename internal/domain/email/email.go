@@ -1,6 +1,11 @@
 package email
 
 import (
+	"fmt"
+	"html/template"
+	"math/rand/v2"
+	"strings"
+	texttemplate "text/template"
 	"time"
 
 	"github.com/google/uuid"
@@ -9,29 +14,105 @@ import (
 type EmailType string
 
 const (
-	EmailTypeWelcome EmailType = "welcome"
+	EmailTypeWelcome       EmailType = "welcome"
+	EmailTypePasswordReset EmailType = "password_reset"
+	EmailTypeVerification  EmailType = "verification"
+	EmailTypeNotification  EmailType = "notification"
 )
 
 type Status string
 
 const (
 	StatusPending Status = "pending"
+	StatusSending Status = "sending" // Claimed by a worker; the SMTP send is in flight
 	StatusSent    Status = "sent"
 	StatusFailed  Status = "failed"
 )
 
+const (
+	// RetryBaseDelay is the wait before the first retry; it doubles on each failure.
+	RetryBaseDelay = time.Minute
+	// RetryMaxDelay caps the backoff between retries.
+	RetryMaxDelay = time.Hour
+	// retryJitterFraction bounds the random extra delay to 1/5 (20%) of the backoff.
+	retryJitterFraction = 5
+)
+
+const (
+	// DefaultMaxAttempts is used for email types without a configured value.
+	DefaultMaxAttempts = 3
+	// MaxAttemptsLimit is the highest max attempts an email may have.
+	MaxAttemptsLimit = 10
+)
+
+// maxAttemptsByType é configurado uma única vez na inicialização (EMAIL_MAX_ATTEMPTS_*).
+var maxAttemptsByType = map[EmailType]int{}
+
+// SetMaxAttempts define o máximo de tentativas usado pelos construtores de
+// cada tipo. Tipos ausentes ou com zero usam DefaultMaxAttempts; valores fora
+// de 1–MaxAttemptsLimit são rejeitados sem alterar a configuração atual.
+func SetMaxAttempts(byType map[EmailType]int) error {
+	configured := make(map[EmailType]int, len(byType))
+	for emailType, attempts := range byType {
+		if attempts == 0 {
+			continue
+		}
+		if attempts < 1 || attempts > MaxAttemptsLimit {
+			return fmt.Errorf("max attempts for %s emails must be between 1 and %d, got %d", emailType, MaxAttemptsLimit, attempts)
+		}
+		configured[emailType] = attempts
+	}
+
+	maxAttemptsByType = configured
+	return nil
+}
+
+// MaxAttemptsFor returns the max attempts given to new emails of the type.
+func MaxAttemptsFor(emailType EmailType) int {
+	if attempts, ok := maxAttemptsByType[emailType]; ok {
+		return attempts
+	}
+	return DefaultMaxAttempts
+}
+
 type Email struct {
-	ID          uuid.UUID  `json:"id"`
-	To          string     `json:"to"`
-	Subject     string     `json:"subject"`
-	Body        string     `json:"body"`
-	Type        EmailType  `json:"type"`
-	Status      Status     `json:"status"`
-	Attempts    int        `json:"attempts"`
-	MaxAttempts int        `json:"max_attempts"`
-	CreatedAt   time.Time  `json:"created_at"`
-	SentAt      *time.Time `json:"sent_at,omitempty"`
-	ErrorMsg    string     `json:"error_msg,omitempty"`
+	ID            uuid.UUID  `json:"id"`
+	To            string     `json:"to"`
+	Cc            []string   `json:"cc,omitempty"`
+	Bcc           []string   `json:"bcc,omitempty"`
+	Subject       string     `json:"subject"`
+	Body          string     `json:"body"`
+	PlainBody     string     `json:"plain_body,omitempty"`
+	Type          EmailType  `json:"type"`
+	Status        Status     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	MaxAttempts   int        `json:"max_attempts"`
+	CreatedAt     time.Time  `json:"created_at"`
+	SentAt        *time.Time `json:"sent_at,omitempty"`
+	ErrorMsg      string     `json:"error_msg,omitempty"`
+	NextAttemptAt time.Time  `json:"next_attempt_at"`
+	// Groups emails that must not be created twice within a window (see Repository.CreateIfNotExists)
+	DedupKey string `json:"dedup_key,omitempty"`
+	// Scheduled emails stay pending and are not sent before this time
+	ScheduledAt *time.Time `json:"scheduled_at,omitempty"`
+	// Files sent along with the message (notification emails only)
+	Attachments []Attachment `json:"attachments,omitempty"`
+}
+
+// DedupKey identifies emails of the given type to the given recipient;
+// the address is compared case-insensitively.
+func DedupKey(emailType EmailType, to string) string {
+	return string(emailType) + ":" + strings.ToLower(strings.TrimSpace(to))
+}
+
+// DefaultAttachmentContentType is used when an attachment has no content type.
+const DefaultAttachmentContentType = "application/octet-stream"
+
+// Attachment is a file sent with an email. Content is base64 in JSON.
+type Attachment struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	Content     []byte `json:"content"`
 }
 
 type WelcomeEmailData struct {
@@ -40,22 +121,100 @@ type WelcomeEmailData struct {
 	UserEmail string `json:"user_email"`
 }
 
+// DefaultWelcomeEmailSubject is used when no custom subject is configured
+const DefaultWelcomeEmailSubject = "Welcome to Backend Challenge!"
+
+// WelcomeTemplate renders the welcome email subject (text/template) and
+// HTML body (html/template). Both receive WelcomeEmailData, so {{.UserName}}
+// and {{.UserEmail}} are available.
+type WelcomeTemplate struct {
+	subject *texttemplate.Template
+	body    *template.Template
+	// custom body: the built-in plain text version would not match it
+	customBody bool
+}
+
+// NewWelcomeTemplate parses a custom subject and body; an empty value keeps
+// the default for that part.
+func NewWelcomeTemplate(subject, body string) (*WelcomeTemplate, error) {
+	tmpl := DefaultWelcomeTemplate()
+
+	if subject != "" {
+		parsed, err := texttemplate.New("welcome_subject").Parse(subject)
+		if err != nil {
+			return nil, fmt.Errorf("invalid welcome email subject template: %w", err)
+		}
+		tmpl.subject = parsed
+	}
+
+	if body != "" {
+		parsed, err := template.New("welcome_body").Parse(body)
+		if err != nil {
+			return nil, fmt.Errorf("invalid welcome email body template: %w", err)
+		}
+		tmpl.body = parsed
+		tmpl.customBody = true
+	}
+
+	return tmpl, nil
+}
+
+// DefaultWelcomeTemplate renders the built-in welcome email.
+func DefaultWelcomeTemplate() *WelcomeTemplate {
+	return &WelcomeTemplate{
+		subject: defaultWelcomeSubjectTemplate,
+		body:    welcomeEmailTemplate,
+	}
+}
+
+func (t *WelcomeTemplate) render(data WelcomeEmailData) (subject, body, plainBody string, err error) {
+	var subjectBuf, bodyBuf strings.Builder
+
+	if err = t.subject.Execute(&subjectBuf, data); err != nil {
+		return "", "", "", fmt.Errorf("failed to render welcome email subject: %w", err)
+	}
+	if err = t.body.Execute(&bodyBuf, data); err != nil {
+		return "", "", "", fmt.Errorf("failed to render welcome email body: %w", err)
+	}
+
+	if !t.customBody {
+		plainBody = generateWelcomeEmailPlainBody(data.UserName)
+	}
+
+	return strings.TrimSpace(subjectBuf.String()), bodyBuf.String(), plainBody, nil
+}
+
 func NewWelcomeEmail(data WelcomeEmailData) (*Email, error) {
+	return NewWelcomeEmailWithTemplate(data, nil)
+}
+
+// NewWelcomeEmailWithTemplate builds the welcome email from tmpl; nil uses
+// the default template.
+func NewWelcomeEmailWithTemplate(data WelcomeEmailData, tmpl *WelcomeTemplate) (*Email, error) {
 	validator := NewEmailValidator()
 
 	if err := validator.ValidateWelcomeEmailData(data); err != nil {
 		return nil, err
 	}
 
+	if tmpl == nil {
+		tmpl = DefaultWelcomeTemplate()
+	}
+	subject, body, plainBody, err := tmpl.render(data)
+	if err != nil {
+		return nil, err
+	}
+
 	email := &Email{
 		ID:          uuid.New(),
 		To:          data.UserEmail,
-		Subject:     "Welcome to Backend Challenge!",
-		Body:        generateWelcomeEmailBody(data.UserName),
+		Subject:     subject,
+		Body:        body,
+		PlainBody:   plainBody,
 		Type:        EmailTypeWelcome,
 		Status:      StatusPending,
 		Attempts:    0,
-		MaxAttempts: 3,
+		MaxAttempts: MaxAttemptsFor(EmailTypeWelcome),
 		CreatedAt:   time.Now(),
 	}
 
@@ -66,6 +225,196 @@ func NewWelcomeEmail(data WelcomeEmailData) (*Email, error) {
 	return email, nil
 }
 
+type PasswordResetEmailData struct {
+	UserID    string `json:"user_id"`
+	UserName  string `json:"user_name"`
+	UserEmail string `json:"user_email"`
+	ResetLink string `json:"reset_link"`
+}
+
+// PasswordResetMessageData is the queue payload of a password reset message.
+// The reset link carries the token, so it stays in the stored email body and
+// is never published to the broker.
+type PasswordResetMessageData struct {
+	UserID    string `json:"user_id"`
+	UserEmail string `json:"user_email"`
+}
+
+func NewPasswordResetEmail(data PasswordResetEmailData) (*Email, error) {
+	validator := NewEmailValidator()
+
+	if err := validator.ValidatePasswordResetEmailData(data); err != nil {
+		return nil, err
+	}
+
+	email := &Email{
+		ID:          uuid.New(),
+		To:          data.UserEmail,
+		Subject:     "Reset your Backend Challenge password",
+		Body:        generatePasswordResetEmailBody(data.UserName, data.ResetLink),
+		PlainBody:   generatePasswordResetEmailPlainBody(data.UserName, data.ResetLink),
+		Type:        EmailTypePasswordReset,
+		Status:      StatusPending,
+		Attempts:    0,
+		MaxAttempts: MaxAttemptsFor(EmailTypePasswordReset),
+		CreatedAt:   time.Now(),
+	}
+
+	if err := validator.ValidateEmailEntity(email); err != nil {
+		return nil, err
+	}
+
+	return email, nil
+}
+
+// NewPasswordSetupEmail invites a user created by an admin to choose a
+// password. The link is a regular password reset link.
+func NewPasswordSetupEmail(data PasswordResetEmailData) (*Email, error) {
+	validator := NewEmailValidator()
+
+	if err := validator.ValidatePasswordResetEmailData(data); err != nil {
+		return nil, err
+	}
+
+	email := &Email{
+		ID:          uuid.New(),
+		To:          data.UserEmail,
+		Subject:     "Set up your Backend Challenge password",
+		Body:        generatePasswordSetupEmailBody(data.UserName, data.ResetLink),
+		PlainBody:   generatePasswordSetupEmailPlainBody(data.UserName, data.ResetLink),
+		Type:        EmailTypePasswordReset,
+		Status:      StatusPending,
+		Attempts:    0,
+		MaxAttempts: MaxAttemptsFor(EmailTypePasswordReset),
+		CreatedAt:   time.Now(),
+	}
+
+	if err := validator.ValidateEmailEntity(email); err != nil {
+		return nil, err
+	}
+
+	return email, nil
+}
+
+type VerificationEmailData struct {
+	UserID           string `json:"user_id"`
+	UserName         string `json:"user_name"`
+	UserEmail        string `json:"user_email"`
+	VerificationLink string `json:"verification_link"`
+}
+
+func NewVerificationEmail(data VerificationEmailData) (*Email, error) {
+	validator := NewEmailValidator()
+
+	if err := validator.ValidateVerificationEmailData(data); err != nil {
+		return nil, err
+	}
+
+	email := &Email{
+		ID:          uuid.New(),
+		To:          data.UserEmail,
+		Subject:     "Confirm your Backend Challenge email",
+		Body:        generateVerificationEmailBody(data.UserName, data.VerificationLink),
+		PlainBody:   generateVerificationEmailPlainBody(data.UserName, data.VerificationLink),
+		Type:        EmailTypeVerification,
+		Status:      StatusPending,
+		Attempts:    0,
+		MaxAttempts: MaxAttemptsFor(EmailTypeVerification),
+		CreatedAt:   time.Now(),
+	}
+
+	if err := validator.ValidateEmailEntity(email); err != nil {
+		return nil, err
+	}
+
+	return email, nil
+}
+
+type NotificationEmailData struct {
+	To          string       `json:"to"`
+	Cc          []string     `json:"cc,omitempty"`
+	Bcc         []string     `json:"bcc,omitempty"`
+	Subject     string       `json:"subject"`
+	Body        string       `json:"body"`
+	Attachments []Attachment `json:"attachments,omitempty"`
+}
+
+// NewNotificationEmail builds a transactional email with a caller-supplied
+// subject and HTML body.
+func NewNotificationEmail(to, subject, body string) (*Email, error) {
+	return NewNotificationEmailFromData(NotificationEmailData{
+		To:      to,
+		Subject: subject,
+		Body:    body,
+	})
+}
+
+// NewNotificationEmailFromData builds a notification email that may also be
+// copied (Cc) or blind-copied (Bcc) to other addresses and carry
+// attachments, up to MaxAttachmentsSize bytes in total.
+func NewNotificationEmailFromData(data NotificationEmailData) (*Email, error) {
+	validator := NewEmailValidator()
+
+	if err := validator.ValidateNotificationEmailData(data); err != nil {
+		return nil, err
+	}
+
+	var attachments []Attachment
+	for _, attachment := range data.Attachments {
+		if attachment.ContentType == "" {
+			attachment.ContentType = DefaultAttachmentContentType
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	email := &Email{
+		ID:          uuid.New(),
+		To:          data.To,
+		Cc:          data.Cc,
+		Bcc:         data.Bcc,
+		Subject:     data.Subject,
+		Body:        data.Body,
+		Attachments: attachments,
+		Type:        EmailTypeNotification,
+		Status:      StatusPending,
+		Attempts:    0,
+		MaxAttempts: MaxAttemptsFor(EmailTypeNotification),
+		CreatedAt:   time.Now(),
+	}
+
+	if err := validator.ValidateEmailEntity(email); err != nil {
+		return nil, err
+	}
+
+	return email, nil
+}
+
+// Recipients returns every envelope recipient: To, then Cc, then Bcc.
+func (e *Email) Recipients() []string {
+	recipients := make([]string, 0, 1+len(e.Cc)+len(e.Bcc))
+	recipients = append(recipients, e.To)
+	recipients = append(recipients, e.Cc...)
+	return append(recipients, e.Bcc...)
+}
+
+// MarkAsSending claims a pending email for delivery so other workers skip it
+// until it is marked as sent or failed, or released back to pending.
+func (e *Email) MarkAsSending() error {
+	if e.Status != StatusPending {
+		return fmt.Errorf("%w: status is %s", ErrEmailNotPending, e.Status)
+	}
+	e.Status = StatusSending
+	return nil
+}
+
+// ReleaseSending returns an email claimed with MarkAsSending to pending
+// without counting an attempt, e.g. when the send was interrupted.
+func (e *Email) ReleaseSending() {
+	if e.Status == StatusSending {
+		e.Status = StatusPending
+	}
+}
+
 func (e *Email) MarkAsSent() {
 	e.Status = StatusSent
 	now := time.Now()
@@ -80,25 +429,177 @@ func (e *Email) MarkAsFailed(errorMsg string) {
 		e.Status = StatusFailed
 	} else {
 		e.Status = StatusPending
+		e.NextAttemptAt = time.Now().Add(RetryBackoff(e.Attempts))
 	}
 }
 
+// Schedule delays the first attempt until at.
+func (e *Email) Schedule(at time.Time) {
+	e.ScheduledAt = &at
+	e.NextAttemptAt = at
+}
+
+// IsDue reports whether the email may be attempted at the given time.
+func (e *Email) IsDue(now time.Time) bool {
+	if e.ScheduledAt != nil && e.ScheduledAt.After(now) {
+		return false
+	}
+	return !e.NextAttemptAt.After(now)
+}
+
+// RetryBackoff returns the delay before the next attempt after the given
+// number of failed attempts: RetryBaseDelay * 2^(attempts-1), capped at
+// RetryMaxDelay, plus up to 20% random jitter.
+func RetryBackoff(attempts int) time.Duration {
+	delay := RetryBaseDelay
+	for i := 1; i < attempts && delay < RetryMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > RetryMaxDelay {
+		delay = RetryMaxDelay
+	}
+
+	return delay + rand.N(delay/retryJitterFraction+1)
+}
+
 func (e *Email) CanRetry() bool {
 	return e.Status == StatusPending && e.Attempts < e.MaxAttempts
 }
 
-func generateWelcomeEmailBody(userName string) string {
-	return `
+var defaultWelcomeSubjectTemplate = texttemplate.Must(texttemplate.New("welcome_subject").Parse(DefaultWelcomeEmailSubject))
+
+// welcomeEmailTemplate escapes the user name for its HTML context
+var welcomeEmailTemplate = template.Must(template.New("welcome").Parse(`
 <!DOCTYPE html>
 <html>
 <head>
     <title>Welcome!</title>
 </head>
 <body>
-    <h1>Welcome to Backend Challenge, ` + userName + `!</h1>
+    <h1>Welcome to Backend Challenge, {{.UserName}}!</h1>
     <p>Thank you for signing up! We're excited to have you on board.</p>
     <p>Best regards,<br>The Backend Challenge Team</p>
 </body>
 </html>
+`))
+
+func generateWelcomeEmailPlainBody(userName string) string {
+	return `Welcome to Backend Challenge, ` + userName + `!
+
+Thank you for signing up! We're excited to have you on board.
+
+Best regards,
+The Backend Challenge Team
+`
+}
+
+// passwordResetEmailTemplate escapes the user name and the link for their
+// HTML contexts
+var passwordResetEmailTemplate = template.Must(template.New("password_reset").Parse(`
+<!DOCTYPE html>
+<html>
+<head>
+    <title>Password reset</title>
+</head>
+<body>
+    <h1>Hi {{.UserName}},</h1>
+    <p>We received a request to reset your password. Click the link below to choose a new one:</p>
+    <p><a href="{{.ResetLink}}">Reset my password</a></p>
+    <p>If you did not request a password reset, you can safely ignore this email.</p>
+    <p>Best regards,<br>The Backend Challenge Team</p>
+</body>
+</html>
+`))
+
+func generatePasswordResetEmailBody(userName, resetLink string) string {
+	var body strings.Builder
+	// Writing to a strings.Builder cannot fail, and the template only reads known fields
+	_ = passwordResetEmailTemplate.Execute(&body, PasswordResetEmailData{UserName: userName, ResetLink: resetLink})
+	return body.String()
+}
+
+func generatePasswordResetEmailPlainBody(userName, resetLink string) string {
+	return `Hi ` + userName + `,
+
+We received a request to reset your password. Open the link below to choose a new one:
+
+` + resetLink + `
+
+If you did not request a password reset, you can safely ignore this email.
+
+Best regards,
+The Backend Challenge Team
+`
+}
+
+// passwordSetupEmailTemplate escapes the user name and the link for their
+// HTML contexts
+var passwordSetupEmailTemplate = template.Must(template.New("password_setup").Parse(`
+<!DOCTYPE html>
+<html>
+<head>
+    <title>Set up your password</title>
+</head>
+<body>
+    <h1>Welcome to Backend Challenge, {{.UserName}}!</h1>
+    <p>An account was created for you. Click the link below to choose your password:</p>
+    <p><a href="{{.ResetLink}}">Set up my password</a></p>
+    <p>Best regards,<br>The Backend Challenge Team</p>
+</body>
+</html>
+`))
+
+func generatePasswordSetupEmailBody(userName, setupLink string) string {
+	var body strings.Builder
+	// Writing to a strings.Builder cannot fail, and the template only reads known fields
+	_ = passwordSetupEmailTemplate.Execute(&body, PasswordResetEmailData{UserName: userName, ResetLink: setupLink})
+	return body.String()
+}
+
+func generatePasswordSetupEmailPlainBody(userName, setupLink string) string {
+	return `Welcome to Backend Challenge, ` + userName + `!
+
+An account was created for you. Open the link below to choose your password:
+
+` + setupLink + `
+
+Best regards,
+The Backend Challenge Team
+`
+}
+
+// verificationEmailTemplate escapes the user name and the link for their
+// HTML contexts
+var verificationEmailTemplate = template.Must(template.New("verification").Parse(`
+<!DOCTYPE html>
+<html>
+<head>
+    <title>Confirm your email</title>
+</head>
+<body>
+    <h1>Welcome to Backend Challenge, {{.UserName}}!</h1>
+    <p>Please confirm your email address to activate your account:</p>
+    <p><a href="{{.VerificationLink}}">Confirm my email</a></p>
+    <p>Best regards,<br>The Backend Challenge Team</p>
+</body>
+</html>
+`))
+
+func generateVerificationEmailBody(userName, verificationLink string) string {
+	var body strings.Builder
+	// Writing to a strings.Builder cannot fail, and the template only reads known fields
+	_ = verificationEmailTemplate.Execute(&body, VerificationEmailData{UserName: userName, VerificationLink: verificationLink})
+	return body.String()
+}
+
+func generateVerificationEmailPlainBody(userName, verificationLink string) string {
+	return `Welcome to Backend Challenge, ` + userName + `!
+
+Please confirm your email address to activate your account:
+
+` + verificationLink + `
+
+Best regards,
+The Backend Challenge Team
 `
 }
@@ -1,7 +1,9 @@
 package email
 
 import (
+	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -30,6 +32,9 @@ func TestNewWelcomeEmail(t *testing.T) {
 		assert.Equal(t, "Welcome to Backend Challenge!", email.Subject)
 		assert.Contains(t, email.Body, "John Doe")
 		assert.Contains(t, email.Body, "Welcome to Backend Challenge")
+		assert.Contains(t, email.PlainBody, "John Doe")
+		assert.NotContains(t, email.PlainBody, "<")
+		assert.NotContains(t, email.PlainBody, ">")
 		assert.Equal(t, EmailTypeWelcome, email.Type)
 		assert.Equal(t, StatusPending, email.Status)
 		assert.Equal(t, 0, email.Attempts)
@@ -149,6 +154,164 @@ func TestNewWelcomeEmail(t *testing.T) {
 	})
 }
 
+func TestNewPasswordResetEmail(t *testing.T) {
+	t.Run("should create password reset email with reset link", func(t *testing.T) {
+		// Arrange
+		data := PasswordResetEmailData{
+			UserID:    uuid.New().String(),
+			UserName:  "John Doe",
+			UserEmail: "john@example.com",
+			ResetLink: "http://localhost:3000/reset-password?token=abc123",
+		}
+
+		// Act
+		email, err := NewPasswordResetEmail(data)
+
+		// Assert
+		require.NoError(t, err)
+		assert.NotNil(t, email)
+		assert.Equal(t, "john@example.com", email.To)
+		assert.Equal(t, EmailTypePasswordReset, email.Type)
+		assert.Equal(t, StatusPending, email.Status)
+		assert.Contains(t, email.Body, "John Doe")
+		assert.Contains(t, email.Body, `href="http://localhost:3000/reset-password?token=abc123"`)
+	})
+
+	t.Run("should escape HTML in user name", func(t *testing.T) {
+		// Arrange
+		data := PasswordResetEmailData{
+			UserID:    uuid.New().String(),
+			UserName:  `<a href="https://evil.example">John</a>`,
+			UserEmail: "john@example.com",
+			ResetLink: "http://localhost:3000/reset-password?token=abc123",
+		}
+
+		// Act
+		email, err := NewPasswordResetEmail(data)
+
+		// Assert
+		require.NoError(t, err)
+		assert.NotContains(t, email.Body, `<a href="https://evil.example">`)
+		assert.Contains(t, email.Body, "&lt;a href=&#34;https://evil.example&#34;&gt;John&lt;/a&gt;")
+		assert.Contains(t, email.Body, `href="http://localhost:3000/reset-password?token=abc123"`)
+	})
+
+	t.Run("should fail without reset link", func(t *testing.T) {
+		// Arrange
+		data := PasswordResetEmailData{
+			UserID:    uuid.New().String(),
+			UserName:  "John Doe",
+			UserEmail: "john@example.com",
+		}
+
+		// Act
+		email, err := NewPasswordResetEmail(data)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, email)
+		assert.Contains(t, err.Error(), "reset link is required")
+	})
+
+	t.Run("should fail with invalid email format", func(t *testing.T) {
+		// Arrange
+		data := PasswordResetEmailData{
+			UserID:    uuid.New().String(),
+			UserName:  "John Doe",
+			UserEmail: "invalid-email",
+			ResetLink: "http://localhost:3000/reset-password?token=abc123",
+		}
+
+		// Act
+		email, err := NewPasswordResetEmail(data)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, email)
+		assert.Contains(t, err.Error(), "invalid email format")
+	})
+}
+
+func TestNewPasswordSetupEmail(t *testing.T) {
+	t.Run("should escape HTML in user name", func(t *testing.T) {
+		// Arrange
+		data := PasswordResetEmailData{
+			UserID:    uuid.New().String(),
+			UserName:  `<img src=x onerror="alert(1)">`,
+			UserEmail: "john@example.com",
+			ResetLink: "http://localhost:3000/reset-password?token=abc123",
+		}
+
+		// Act
+		email, err := NewPasswordSetupEmail(data)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, EmailTypePasswordReset, email.Type)
+		assert.NotContains(t, email.Body, "<img")
+		assert.Contains(t, email.Body, "&lt;img src=x onerror=&#34;alert(1)&#34;&gt;")
+		assert.Contains(t, email.Body, `href="http://localhost:3000/reset-password?token=abc123"`)
+	})
+}
+
+func TestNewVerificationEmail(t *testing.T) {
+	t.Run("should create verification email with link", func(t *testing.T) {
+		// Arrange
+		data := VerificationEmailData{
+			UserID:           uuid.New().String(),
+			UserName:         "John Doe",
+			UserEmail:        "john@example.com",
+			VerificationLink: "http://localhost:3000/verify-email?token=abc123",
+		}
+
+		// Act
+		email, err := NewVerificationEmail(data)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "john@example.com", email.To)
+		assert.Equal(t, EmailTypeVerification, email.Type)
+		assert.Equal(t, StatusPending, email.Status)
+		assert.Contains(t, email.Body, `href="http://localhost:3000/verify-email?token=abc123"`)
+	})
+
+	t.Run("should escape HTML in user name", func(t *testing.T) {
+		// Arrange
+		data := VerificationEmailData{
+			UserID:           uuid.New().String(),
+			UserName:         `<script>alert("xss")</script>`,
+			UserEmail:        "john@example.com",
+			VerificationLink: "http://localhost:3000/verify-email?token=abc123",
+		}
+
+		// Act
+		email, err := NewVerificationEmail(data)
+
+		// Assert
+		require.NoError(t, err)
+		assert.NotContains(t, email.Body, "<script>")
+		assert.Contains(t, email.Body, "&lt;script&gt;")
+		assert.Contains(t, email.Body, `href="http://localhost:3000/verify-email?token=abc123"`)
+	})
+
+	t.Run("should fail without verification link", func(t *testing.T) {
+		// Arrange
+		data := VerificationEmailData{
+			UserID:    uuid.New().String(),
+			UserName:  "John Doe",
+			UserEmail: "john@example.com",
+		}
+
+		// Act
+		email, err := NewVerificationEmail(data)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, email)
+		assert.Contains(t, err.Error(), "verification link is required")
+	})
+}
+
 func TestEmail_MarkAsSent(t *testing.T) {
 	t.Run("should mark email as sent with timestamp", func(t *testing.T) {
 		// Arrange
@@ -194,6 +357,58 @@ func TestEmail_MarkAsSent(t *testing.T) {
 	})
 }
 
+func TestEmail_MarkAsSending(t *testing.T) {
+	t.Run("should claim a pending email", func(t *testing.T) {
+		email := &Email{ID: uuid.New(), Status: StatusPending, MaxAttempts: 3}
+
+		require.NoError(t, email.MarkAsSending())
+
+		assert.Equal(t, StatusSending, email.Status)
+		assert.Equal(t, 0, email.Attempts)
+		assert.False(t, email.CanRetry())
+	})
+
+	t.Run("should reject emails that are not pending", func(t *testing.T) {
+		for _, status := range []Status{StatusSending, StatusSent, StatusFailed} {
+			email := &Email{ID: uuid.New(), Status: status, MaxAttempts: 3}
+
+			err := email.MarkAsSending()
+
+			assert.ErrorIs(t, err, ErrEmailNotPending, status)
+			assert.Equal(t, status, email.Status)
+		}
+	})
+
+	t.Run("should release back to pending without counting an attempt", func(t *testing.T) {
+		email := &Email{ID: uuid.New(), Status: StatusPending, MaxAttempts: 3}
+		require.NoError(t, email.MarkAsSending())
+
+		email.ReleaseSending()
+
+		assert.Equal(t, StatusPending, email.Status)
+		assert.Equal(t, 0, email.Attempts)
+		assert.True(t, email.CanRetry())
+	})
+
+	t.Run("should finish as sent or failed", func(t *testing.T) {
+		sent := &Email{ID: uuid.New(), Status: StatusPending, MaxAttempts: 3}
+		require.NoError(t, sent.MarkAsSending())
+		sent.MarkAsSent()
+		assert.Equal(t, StatusSent, sent.Status)
+
+		retried := &Email{ID: uuid.New(), Status: StatusPending, MaxAttempts: 3}
+		require.NoError(t, retried.MarkAsSending())
+		retried.MarkAsFailed("SMTP connection failed")
+		assert.Equal(t, StatusPending, retried.Status)
+		assert.Equal(t, 1, retried.Attempts)
+
+		failed := &Email{ID: uuid.New(), Status: StatusPending, Attempts: 2, MaxAttempts: 3}
+		require.NoError(t, failed.MarkAsSending())
+		failed.MarkAsFailed("Final SMTP failure")
+		assert.Equal(t, StatusFailed, failed.Status)
+	})
+}
+
 func TestEmail_MarkAsFailed(t *testing.T) {
 	t.Run("should increment attempts and stay pending when under max attempts", func(t *testing.T) {
 		// Arrange
@@ -213,6 +428,8 @@ func TestEmail_MarkAsFailed(t *testing.T) {
 		assert.Equal(t, StatusPending, email.Status)
 		assert.Equal(t, 1, email.Attempts)
 		assert.Equal(t, errorMsg, email.ErrorMsg)
+		assert.True(t, email.NextAttemptAt.After(time.Now()))
+		assert.False(t, email.IsDue(time.Now()))
 	})
 
 	t.Run("should mark as failed when reaching max attempts", func(t *testing.T) {
@@ -233,8 +450,166 @@ func TestEmail_MarkAsFailed(t *testing.T) {
 		assert.Equal(t, StatusFailed, email.Status)
 		assert.Equal(t, 3, email.Attempts)
 		assert.Equal(t, errorMsg, email.ErrorMsg)
+		assert.True(t, email.NextAttemptAt.IsZero())
+	})
+
+}
+
+func TestSetMaxAttempts(t *testing.T) {
+	t.Cleanup(func() { require.NoError(t, SetMaxAttempts(nil)) })
+
+	data := PasswordResetEmailData{
+		UserID:    uuid.New().String(),
+		UserName:  "John Doe",
+		UserEmail: "john@example.com",
+		ResetLink: "https://example.com/reset?token=abc",
+	}
+
+	t.Run("type configured with one attempt fails after a single failure", func(t *testing.T) {
+		require.NoError(t, SetMaxAttempts(map[EmailType]int{EmailTypePasswordReset: 1}))
+
+		email, err := NewPasswordResetEmail(data)
+		require.NoError(t, err)
+		assert.Equal(t, 1, email.MaxAttempts)
+		assert.True(t, email.CanRetry())
+
+		email.MarkAsFailed("SMTP connection failed")
+
+		assert.Equal(t, StatusFailed, email.Status)
+		assert.Equal(t, 1, email.Attempts)
+		assert.False(t, email.CanRetry())
+	})
+
+	t.Run("unconfigured and zero types keep the default", func(t *testing.T) {
+		require.NoError(t, SetMaxAttempts(map[EmailType]int{
+			EmailTypeNotification: 5,
+			EmailTypeWelcome:      0,
+		}))
+
+		assert.Equal(t, 5, MaxAttemptsFor(EmailTypeNotification))
+		assert.Equal(t, DefaultMaxAttempts, MaxAttemptsFor(EmailTypeWelcome))
+		assert.Equal(t, DefaultMaxAttempts, MaxAttemptsFor(EmailTypeVerification))
+
+		email, err := NewNotificationEmail("john@example.com", "Subject", "<p>Body</p>")
+		require.NoError(t, err)
+		assert.Equal(t, 5, email.MaxAttempts)
+	})
+
+	t.Run("rejects values out of range and keeps the previous configuration", func(t *testing.T) {
+		require.NoError(t, SetMaxAttempts(map[EmailType]int{EmailTypeVerification: 2}))
+
+		err := SetMaxAttempts(map[EmailType]int{EmailTypeVerification: MaxAttemptsLimit + 1})
+		assert.Error(t, err)
+		assert.Error(t, SetMaxAttempts(map[EmailType]int{EmailTypeVerification: -1}))
+		assert.Equal(t, 2, MaxAttemptsFor(EmailTypeVerification))
+	})
+}
+
+func TestSetMaxBodySize(t *testing.T) {
+	t.Cleanup(func() { require.NoError(t, SetMaxBodySize(0)) })
+
+	t.Run("default rejects a notification body above 64 KiB", func(t *testing.T) {
+		_, err := NewNotificationEmail("john@example.com", "Subject", strings.Repeat("a", DefaultMaxBodySize+1))
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrBodyTooLarge)
+
+		email, err := NewNotificationEmail("john@example.com", "Subject", strings.Repeat("a", DefaultMaxBodySize))
+		require.NoError(t, err)
+		assert.Len(t, email.Body, DefaultMaxBodySize)
+	})
+
+	t.Run("oversized notification is rejected at construction", func(t *testing.T) {
+		require.NoError(t, SetMaxBodySize(1024))
+
+		_, err := NewNotificationEmail("john@example.com", "Subject", "<p>"+strings.Repeat("a", 1024)+"</p>")
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrBodyTooLarge)
+		assert.Contains(t, err.Error(), "exceeds 1024 bytes")
+
+		_, err = NewNotificationEmailFromData(NotificationEmailData{
+			To:      "john@example.com",
+			Subject: "Subject",
+			Body:    strings.Repeat("a", 1025),
+		})
+		assert.ErrorIs(t, err, ErrBodyTooLarge)
+	})
+
+	t.Run("welcome emails fit well under a small limit", func(t *testing.T) {
+		require.NoError(t, SetMaxBodySize(4096))
+
+		_, err := NewWelcomeEmail(WelcomeEmailData{
+			UserID:    uuid.New().String(),
+			UserName:  "John Doe",
+			UserEmail: "john@example.com",
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("zero restores the default and negative is rejected", func(t *testing.T) {
+		require.NoError(t, SetMaxBodySize(2048))
+
+		assert.Error(t, SetMaxBodySize(-1))
+		assert.Equal(t, 2048, MaxBodySize())
+
+		require.NoError(t, SetMaxBodySize(0))
+		assert.Equal(t, DefaultMaxBodySize, MaxBodySize())
+	})
+}
+
+func TestEmail_IsDue(t *testing.T) {
+	now := time.Now()
+
+	t.Run("should be due when never scheduled", func(t *testing.T) {
+		email := &Email{}
+		assert.True(t, email.IsDue(now))
+	})
+
+	t.Run("should be due once next attempt time has passed", func(t *testing.T) {
+		email := &Email{NextAttemptAt: now.Add(-time.Second)}
+		assert.True(t, email.IsDue(now))
 	})
 
+	t.Run("should not be due before next attempt time", func(t *testing.T) {
+		email := &Email{NextAttemptAt: now.Add(time.Minute)}
+		assert.False(t, email.IsDue(now))
+	})
+
+	t.Run("should not be due before scheduled time", func(t *testing.T) {
+		email := &Email{}
+		email.Schedule(now.Add(time.Hour))
+		require.NotNil(t, email.ScheduledAt)
+		assert.False(t, email.IsDue(now))
+		assert.True(t, email.IsDue(now.Add(time.Hour)))
+	})
+}
+
+func TestRetryBackoff(t *testing.T) {
+	t.Run("should double the delay on each attempt with bounded jitter", func(t *testing.T) {
+		for attempts, base := range map[int]time.Duration{
+			1: RetryBaseDelay,
+			2: 2 * RetryBaseDelay,
+			3: 4 * RetryBaseDelay,
+		} {
+			delay := RetryBackoff(attempts)
+			assert.GreaterOrEqual(t, delay, base)
+			assert.LessOrEqual(t, delay, base+base/5)
+		}
+	})
+
+	t.Run("should cap the delay at the maximum", func(t *testing.T) {
+		delay := RetryBackoff(100)
+		assert.GreaterOrEqual(t, delay, RetryMaxDelay)
+		assert.LessOrEqual(t, delay, RetryMaxDelay+RetryMaxDelay/5)
+	})
+}
+
+// renderWelcomeBody renders the body through the default template, the same
+// path NewWelcomeEmailWithTemplate takes.
+func renderWelcomeBody(t *testing.T, userName string) string {
+	t.Helper()
+	_, body, _, err := DefaultWelcomeTemplate().render(WelcomeEmailData{UserName: userName})
+	require.NoError(t, err)
+	return body
 }
 
 func TestGenerateWelcomeEmailBody(t *testing.T) {
@@ -243,7 +618,7 @@ func TestGenerateWelcomeEmailBody(t *testing.T) {
 		userName := "John Doe"
 
 		// Act
-		body := generateWelcomeEmailBody(userName)
+		body := renderWelcomeBody(t, userName)
 
 		// Assert
 		assert.Contains(t, body, "<!DOCTYPE html>")
@@ -260,10 +635,24 @@ func TestGenerateWelcomeEmailBody(t *testing.T) {
 		userName := "José María & Co."
 
 		// Act
-		body := generateWelcomeEmailBody(userName)
+		body := renderWelcomeBody(t, userName)
+
+		// Assert
+		assert.Contains(t, body, "José María &amp; Co.")
+	})
+
+	t.Run("should escape HTML in user name", func(t *testing.T) {
+		// Arrange
+		userName := "<b>Mallory</b>"
+
+		// Act
+		body := renderWelcomeBody(t, userName)
 
 		// Assert
-		assert.Contains(t, body, "José María & Co.")
+		assert.Contains(t, body, "Welcome to Backend Challenge, &lt;b&gt;Mallory&lt;/b&gt;!")
+		assert.NotContains(t, body, "<b>")
+		assert.Contains(t, body, "<h1>")
+		assert.Contains(t, body, "The Backend Challenge Team")
 	})
 
 	t.Run("should handle empty user name", func(t *testing.T) {
@@ -271,7 +660,7 @@ func TestGenerateWelcomeEmailBody(t *testing.T) {
 		userName := ""
 
 		// Act
-		body := generateWelcomeEmailBody(userName)
+		body := renderWelcomeBody(t, userName)
 
 		// Assert
 		assert.Contains(t, body, "Welcome to Backend Challenge, !")
@@ -283,7 +672,7 @@ func TestGenerateWelcomeEmailBody(t *testing.T) {
 		userName := "Test User"
 
 		// Act
-		body := generateWelcomeEmailBody(userName)
+		body := renderWelcomeBody(t, userName)
 
 		// Assert
 		assert.Contains(t, body, "<title>Welcome!</title>")
@@ -295,15 +684,111 @@ func TestGenerateWelcomeEmailBody(t *testing.T) {
 	})
 }
 
+func TestNewWelcomeEmailWithTemplate(t *testing.T) {
+	data := WelcomeEmailData{
+		UserID:    "user-123",
+		UserName:  "Ana & Bob",
+		UserEmail: "ana@example.com",
+	}
+
+	t.Run("should reject a rendered subject over the column limit", func(t *testing.T) {
+		// Arrange
+		tmpl, err := NewWelcomeTemplate(strings.Repeat("s", 250)+" {{.UserName}}", "")
+		require.NoError(t, err)
+
+		// Act
+		email, err := NewWelcomeEmailWithTemplate(data, tmpl)
+
+		// Assert
+		assert.EqualError(t, err, "email subject exceeds 255 characters")
+		assert.Nil(t, email)
+	})
+
+	t.Run("should render custom subject and body", func(t *testing.T) {
+		// Arrange
+		tmpl, err := NewWelcomeTemplate(
+			"Hello {{.UserName}}, welcome to Acme",
+			"<p>Hi {{.UserName}}, your account {{.UserEmail}} is ready.</p>",
+		)
+		require.NoError(t, err)
+
+		// Act
+		email, err := NewWelcomeEmailWithTemplate(data, tmpl)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "Hello Ana & Bob, welcome to Acme", email.Subject)
+		assert.Equal(t, "<p>Hi Ana &amp; Bob, your account ana@example.com is ready.</p>", email.Body)
+		assert.Empty(t, email.PlainBody) // the default plain text would not match the custom body
+		assert.Equal(t, EmailTypeWelcome, email.Type)
+	})
+
+	t.Run("should keep defaults for empty values", func(t *testing.T) {
+		// Arrange
+		tmpl, err := NewWelcomeTemplate("", "")
+		require.NoError(t, err)
+
+		// Act
+		custom, err := NewWelcomeEmailWithTemplate(data, tmpl)
+		require.NoError(t, err)
+		standard, err := NewWelcomeEmail(data)
+		require.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, DefaultWelcomeEmailSubject, custom.Subject)
+		assert.Equal(t, standard.Body, custom.Body)
+		assert.Equal(t, standard.PlainBody, custom.PlainBody)
+	})
+
+	t.Run("should override only the subject", func(t *testing.T) {
+		// Arrange
+		tmpl, err := NewWelcomeTemplate("Welcome aboard, {{.UserName}}", "")
+		require.NoError(t, err)
+
+		// Act
+		email, err := NewWelcomeEmailWithTemplate(data, tmpl)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "Welcome aboard, Ana & Bob", email.Subject)
+		assert.Contains(t, email.Body, "Welcome to Backend Challenge, Ana &amp; Bob!")
+		assert.NotEmpty(t, email.PlainBody)
+	})
+
+	t.Run("should reject templates that do not parse", func(t *testing.T) {
+		_, err := NewWelcomeTemplate("Hello {{.UserName", "")
+		assert.ErrorContains(t, err, "invalid welcome email subject template")
+
+		_, err = NewWelcomeTemplate("", "<p>{{if}}</p>")
+		assert.ErrorContains(t, err, "invalid welcome email body template")
+	})
+
+	t.Run("should fail when the template uses an unknown field", func(t *testing.T) {
+		// Arrange
+		tmpl, err := NewWelcomeTemplate("", "<p>{{.Unknown}}</p>")
+		require.NoError(t, err)
+
+		// Act
+		email, err := NewWelcomeEmailWithTemplate(data, tmpl)
+
+		// Assert
+		assert.ErrorContains(t, err, "failed to render welcome email body")
+		assert.Nil(t, email)
+	})
+}
+
 func TestEmailTypes_Constants(t *testing.T) {
 	t.Run("should have correct email type constants", func(t *testing.T) {
 		assert.Equal(t, EmailType("welcome"), EmailTypeWelcome)
+		assert.Equal(t, EmailType("password_reset"), EmailTypePasswordReset)
+		assert.Equal(t, EmailType("verification"), EmailTypeVerification)
 	})
 }
 
 func TestEmailStatus_Constants(t *testing.T) {
 	t.Run("should have correct status constants", func(t *testing.T) {
 		assert.Equal(t, Status("pending"), StatusPending)
+		assert.Equal(t, Status("sending"), StatusSending)
 		assert.Equal(t, Status("sent"), StatusSent)
 		assert.Equal(t, Status("failed"), StatusFailed)
 	})
@@ -325,6 +810,260 @@ func TestWelcomeEmailData_Struct(t *testing.T) {
 	})
 }
 
+func TestNewNotificationEmail(t *testing.T) {
+	t.Run("should create notification email with caller-supplied content", func(t *testing.T) {
+		// Act
+		email, err := NewNotificationEmail("john@example.com", "Your report is ready", "<p>Report</p>")
+
+		// Assert
+		require.NoError(t, err)
+		assert.NotEmpty(t, email.ID)
+		assert.Equal(t, "john@example.com", email.To)
+		assert.Equal(t, "Your report is ready", email.Subject)
+		assert.Equal(t, "<p>Report</p>", email.Body)
+		assert.Equal(t, EmailTypeNotification, email.Type)
+		assert.Equal(t, StatusPending, email.Status)
+		assert.Equal(t, 3, email.MaxAttempts)
+	})
+
+	t.Run("should accept subject of exactly 255 characters", func(t *testing.T) {
+		_, err := NewNotificationEmail("john@example.com", strings.Repeat("é", 255), "Body")
+		assert.NoError(t, err)
+	})
+
+	t.Run("should accept recipient of exactly 255 characters", func(t *testing.T) {
+		_, err := NewNotificationEmail(strings.Repeat("a", 243)+"@example.com", "Subject", "Body")
+		assert.NoError(t, err)
+	})
+
+	tests := []struct {
+		name        string
+		to          string
+		subject     string
+		body        string
+		expectedErr string
+	}{
+		{"invalid recipient", "not-an-email", "Subject", "Body", "invalid email format"},
+		{"empty recipient", "", "Subject", "Body", "email is required"},
+		{"empty subject", "john@example.com", "", "Body", "email subject is required"},
+		{"subject too long", "john@example.com", strings.Repeat("a", 256), "Body", "subject exceeds 255 characters"},
+		{"recipient too long", strings.Repeat("a", 244) + "@example.com", "Subject", "Body", "recipient exceeds 255 characters"},
+		{"subject with line break", "john@example.com", "Hi\r\nBcc: x@example.com", "Body", "must not contain line breaks"},
+		{"empty body", "john@example.com", "Subject", "", "email body is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run("should fail with "+tt.name, func(t *testing.T) {
+			email, err := NewNotificationEmail(tt.to, tt.subject, tt.body)
+
+			assert.Error(t, err)
+			assert.Nil(t, email)
+			assert.Contains(t, err.Error(), tt.expectedErr)
+		})
+	}
+}
+
+func TestNewNotificationEmailFromData(t *testing.T) {
+	t.Run("should keep cc and bcc and list every recipient", func(t *testing.T) {
+		email, err := NewNotificationEmailFromData(NotificationEmailData{
+			To:      "john@example.com",
+			Cc:      []string{"ops@example.com"},
+			Bcc:     []string{"audit@example.com"},
+			Subject: "Incident report",
+			Body:    "<p>Report</p>",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ops@example.com"}, email.Cc)
+		assert.Equal(t, []string{"audit@example.com"}, email.Bcc)
+		assert.Equal(t, []string{"john@example.com", "ops@example.com", "audit@example.com"}, email.Recipients())
+	})
+
+	t.Run("should fail with an invalid cc address", func(t *testing.T) {
+		_, err := NewNotificationEmailFromData(NotificationEmailData{
+			To: "john@example.com", Cc: []string{"not-an-email"}, Subject: "Subject", Body: "Body",
+		})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cc email validation failed")
+	})
+
+	t.Run("should fail with an invalid bcc address", func(t *testing.T) {
+		_, err := NewNotificationEmailFromData(NotificationEmailData{
+			To: "john@example.com", Bcc: []string{""}, Subject: "Subject", Body: "Body",
+		})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "bcc email validation failed")
+	})
+
+	t.Run("should keep welcome emails to a single recipient", func(t *testing.T) {
+		email, err := NewWelcomeEmail(WelcomeEmailData{UserID: "1", UserName: "John", UserEmail: "john@example.com"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"john@example.com"}, email.Recipients())
+
+		email.Cc = []string{"ops@example.com"}
+		err = NewEmailValidator().ValidateEmailEntity(email)
+		assert.EqualError(t, err, "only notification emails support cc and bcc")
+	})
+
+	t.Run("should keep attachments and default their content type", func(t *testing.T) {
+		email, err := NewNotificationEmailFromData(NotificationEmailData{
+			To:      "john@example.com",
+			Subject: "Invoice",
+			Body:    "<p>Invoice attached</p>",
+			Attachments: []Attachment{
+				{Filename: "invoice.txt", ContentType: "text/plain", Content: []byte("total: 42")},
+				{Filename: "data.bin", Content: []byte{0x01}},
+			},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, email.Attachments, 2)
+		assert.Equal(t, "text/plain", email.Attachments[0].ContentType)
+		assert.Equal(t, DefaultAttachmentContentType, email.Attachments[1].ContentType)
+	})
+
+	t.Run("should accept attachments of exactly the size limit", func(t *testing.T) {
+		_, err := NewNotificationEmailFromData(NotificationEmailData{
+			To: "john@example.com", Subject: "Subject", Body: "Body",
+			Attachments: []Attachment{
+				{Filename: "a.bin", Content: make([]byte, MaxAttachmentsSize/2)},
+				{Filename: "b.bin", Content: make([]byte, MaxAttachmentsSize/2)},
+			},
+		})
+
+		require.NoError(t, err)
+	})
+
+	attachmentTests := []struct {
+		name        string
+		attachments []Attachment
+		expected    string
+	}{
+		{"total size over the limit", []Attachment{
+			{Filename: "a.bin", Content: make([]byte, MaxAttachmentsSize/2)},
+			{Filename: "b.bin", Content: make([]byte, MaxAttachmentsSize/2+1)},
+		}, "attachments exceed"},
+		{"missing filename", []Attachment{{Content: []byte("x")}}, "attachment filename is required"},
+		{"filename with line break", []Attachment{{Filename: "a\r\nX-Evil: 1", Content: []byte("x")}}, "is invalid"},
+		{"filename with path", []Attachment{{Filename: "../etc/passwd", Content: []byte("x")}}, "is invalid"},
+		{"invalid content type", []Attachment{{Filename: "a.txt", ContentType: "text/", Content: []byte("x")}}, "invalid content type"},
+		{"empty content", []Attachment{{Filename: "a.txt"}}, "is empty"},
+	}
+	for _, tt := range attachmentTests {
+		t.Run("should fail with attachment "+tt.name, func(t *testing.T) {
+			_, err := NewNotificationEmailFromData(NotificationEmailData{
+				To: "john@example.com", Subject: "Subject", Body: "Body", Attachments: tt.attachments,
+			})
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expected)
+		})
+	}
+
+	t.Run("should keep welcome emails attachment-free", func(t *testing.T) {
+		email, err := NewWelcomeEmail(WelcomeEmailData{UserID: "1", UserName: "John", UserEmail: "john@example.com"})
+		require.NoError(t, err)
+
+		email.Attachments = []Attachment{{Filename: "a.txt", Content: []byte("x")}}
+		err = NewEmailValidator().ValidateEmailEntity(email)
+		assert.EqualError(t, err, "only notification emails support attachments")
+	})
+}
+
+func TestQueueMessage_JSON(t *testing.T) {
+	t.Run("should round-trip request ID", func(t *testing.T) {
+		// Arrange
+		message := QueueMessage{
+			EmailID: uuid.New(),
+			Type:    EmailTypeWelcome,
+			Data: WelcomeEmailData{
+				UserID:    uuid.New().String(),
+				UserName:  "John Doe",
+				UserEmail: "john@example.com",
+			},
+			RequestID: "req-789",
+		}
+
+		// Act
+		body, err := json.Marshal(message)
+		require.NoError(t, err)
+
+		var decoded QueueMessage
+		err = json.Unmarshal(body, &decoded)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, message, decoded)
+		assert.Contains(t, string(body), `"request_id":"req-789"`)
+	})
+
+	t.Run("should round-trip notification data", func(t *testing.T) {
+		// Arrange
+		message := QueueMessage{
+			EmailID: uuid.New(),
+			Type:    EmailTypeNotification,
+			Notification: &NotificationEmailData{
+				To:      "john@example.com",
+				Subject: "Heads up",
+				Body:    "<p>Hello</p>",
+			},
+		}
+
+		// Act
+		body, err := json.Marshal(message)
+		require.NoError(t, err)
+
+		var decoded QueueMessage
+		err = json.Unmarshal(body, &decoded)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, message, decoded)
+		assert.Equal(t, "john@example.com", decoded.Recipient())
+	})
+
+	t.Run("should round-trip password reset data without the link", func(t *testing.T) {
+		// Arrange
+		message := QueueMessage{
+			EmailID: uuid.New(),
+			Type:    EmailTypePasswordReset,
+			PasswordReset: &PasswordResetMessageData{
+				UserID:    uuid.New().String(),
+				UserEmail: "john@example.com",
+			},
+		}
+
+		// Act
+		body, err := json.Marshal(message)
+		require.NoError(t, err)
+
+		var decoded QueueMessage
+		err = json.Unmarshal(body, &decoded)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, message, decoded)
+		assert.Equal(t, "john@example.com", decoded.Recipient())
+		assert.NotContains(t, string(body), "reset_link")
+	})
+
+	t.Run("should accept messages without request ID", func(t *testing.T) {
+		// Arrange
+		body := []byte(`{"email_id":"` + uuid.New().String() + `","type":"welcome","data":{"user_email":"john@example.com"}}`)
+
+		// Act
+		var decoded QueueMessage
+		err := json.Unmarshal(body, &decoded)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Empty(t, decoded.RequestID)
+		assert.Equal(t, EmailTypeWelcome, decoded.Type)
+	})
+}
+
 func TestEmail_CompleteWorkflow(t *testing.T) {
 	t.Run("should handle complete email lifecycle", func(t *testing.T) {
 		// Arrange - Create email
@@ -2,21 +2,107 @@ package email
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+// ErrEmailNotFound is returned when no email matches the given id.
+var ErrEmailNotFound = errors.New("email not found")
+
+// ErrEmailLocked is returned by LockForProcessing when another worker is
+// already processing the email.
+var ErrEmailLocked = errors.New("email is locked by another worker")
+
+// ErrEmailNotPending is returned by MarkAsSending when the email is not
+// waiting to be sent (already claimed, sent or failed).
+var ErrEmailNotPending = errors.New("email is not pending")
+
+// ErrMessageUnroutable is returned by a QueuePublisher when the broker has no
+// queue bound for the message, so it would be dropped.
+var ErrMessageUnroutable = errors.New("queue message is unroutable")
+
+// Attachments are stored with Create and loaded by GetByID and
+// LockForProcessing; the listing methods leave them empty.
 type Repository interface {
 	Create(ctx context.Context, email *Email) error
+	// CreateIfNotExists creates the email unless one with the same dedup key
+	// was created within window, in which case it returns false and leaves
+	// email untouched. An empty DedupKey defaults to DedupKey(email.Type, email.To).
+	CreateIfNotExists(ctx context.Context, email *Email, window time.Duration) (bool, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*Email, error)
 	Update(ctx context.Context, email *Email) error
 	GetPendingEmails(ctx context.Context, limit int) ([]*Email, error)
+	// CountPending counts the emails GetPendingEmails would return without a limit.
+	CountPending(ctx context.Context) (int, error)
+	// GetByRecipient returns every email addressed to the given address, oldest first.
+	GetByRecipient(ctx context.Context, to string) ([]*Email, error)
+	// ListByRecipient returns one page of the emails addressed to the given
+	// address, newest first, and their total. Bodies are left empty unless
+	// params.IncludeBody is set.
+	ListByRecipient(ctx context.Context, to string, params RecipientListParams) ([]*Email, int, error)
+	// LockForProcessing locks the email row and runs fn in a transaction.
+	// fn receives the locked email and a repository bound to the transaction;
+	// its updates are committed only if fn returns nil.
+	LockForProcessing(ctx context.Context, id uuid.UUID, fn func(*Email, Repository) error) error
+	// ReclaimStaleSending returns to pending the emails left in sending for
+	// longer than olderThan (e.g. a worker crashed mid-send) and reports how many.
+	ReclaimStaleSending(ctx context.Context, olderThan time.Duration) (int64, error)
+	Stats(ctx context.Context) (*DeliveryStats, error)
+	// Search returns one page of the emails matching every set filter,
+	// newest first, and their total. Bodies are not loaded.
+	Search(ctx context.Context, params SearchParams) ([]*Email, int, error)
+}
+
+type RecipientListParams struct {
+	Page        int  `json:"page"`
+	PageSize    int  `json:"page_size"`
+	IncludeBody bool `json:"include_body"`
+}
+
+// SearchParams filters the admin email search; zero values are ignored.
+type SearchParams struct {
+	To            string     `json:"to"` // Substring of the recipient address, case-insensitive
+	Status        Status     `json:"status"`
+	Type          EmailType  `json:"type"`
+	CreatedAfter  *time.Time `json:"created_after"`
+	CreatedBefore *time.Time `json:"created_before"`
+	Page          int        `json:"page"`
+	PageSize      int        `json:"page_size"`
+}
+
+// Email counts per delivery status
+type DeliveryStats struct {
+	Pending int `json:"pending"`
+	Sent    int `json:"sent"`
+	Failed  int `json:"failed"`
 }
 
 type QueueMessage struct {
-	EmailID uuid.UUID        `json:"email_id"`
-	Type    EmailType        `json:"type"`
-	Data    WelcomeEmailData `json:"data"`
+	EmailID       uuid.UUID                 `json:"email_id"`
+	Type          EmailType                 `json:"type"`
+	Data          WelcomeEmailData          `json:"data"`
+	Notification  *NotificationEmailData    `json:"notification,omitempty"`   // Set for EmailTypeNotification messages
+	PasswordReset *PasswordResetMessageData `json:"password_reset,omitempty"` // Set for password reset messages (no link: it carries the token)
+	RequestID     string                    `json:"request_id,omitempty"`     // Correlates the consumer with the originating HTTP request
+	TraceContext  map[string]string         `json:"trace_context,omitempty"`  // W3C trace headers (traceparent) of the publishing span
+}
+
+// Recipient returns the address the message is destined to.
+func (m QueueMessage) Recipient() string {
+	if m.Notification != nil {
+		return m.Notification.To
+	}
+	if m.PasswordReset != nil {
+		return m.PasswordReset.UserEmail
+	}
+	return m.Data.UserEmail
+}
+
+// QueuePublisher publishes queue messages for emails already persisted.
+type QueuePublisher interface {
+	PublishEmailMessage(message QueueMessage) error
 }
 
 type Publisher interface {
@@ -37,6 +123,23 @@ type SMTPConfig struct {
 	Username string `json:"username"`
 	Password string `json:"password"`
 	From     string `json:"from"`
+	// Optional From display name and Reply-To address
+	FromName string `json:"from_name"`
+	ReplyTo  string `json:"reply_to"`
+
+	// Timeouts for connecting and for the whole send. Zero uses the
+	// transport defaults.
+	DialTimeout time.Duration `json:"dial_timeout"`
+	SendTimeout time.Duration `json:"send_timeout"`
+
+	// Authenticated connections kept open and reused across sends. Zero
+	// opens a new connection for every email.
+	MaxConnections int `json:"max_connections"`
+
+	// Dev mode writes each message as a .eml file in DevOutputDir instead
+	// of contacting the SMTP server.
+	DevMode      bool   `json:"dev_mode"`
+	DevOutputDir string `json:"dev_output_dir"`
 }
 
 type EmailService interface {
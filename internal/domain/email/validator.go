@@ -1,10 +1,57 @@
 package email
 
 import (
+	"errors"
 	"fmt"
+	"mime"
 	"regexp"
+	"strings"
+	"unicode/utf8"
 )
 
+// Limites das colunas to_email e subject (VARCHAR(255)), contados em caracteres
+const (
+	MaxRecipientLength = 255
+	MaxSubjectLength   = 255
+)
+
+const (
+	// MaxAttachmentsSize limita a soma do conteúdo de todos os anexos (10 MiB)
+	MaxAttachmentsSize = 10 << 20
+	// MaxAttachmentFilenameLength é o limite da coluna filename (VARCHAR(255))
+	MaxAttachmentFilenameLength = 255
+)
+
+// DefaultMaxBodySize limita o corpo HTML quando EMAIL_MAX_BODY_BYTES não é definido (64 KiB)
+const DefaultMaxBodySize = 64 << 10
+
+// ErrBodyTooLarge is returned when an email body exceeds the configured
+// maximum size (see SetMaxBodySize).
+var ErrBodyTooLarge = errors.New("email body is too large")
+
+// maxBodySize é configurado uma única vez na inicialização (EMAIL_MAX_BODY_BYTES).
+var maxBodySize = DefaultMaxBodySize
+
+// SetMaxBodySize define o tamanho máximo, em bytes, do corpo aceito pelos
+// construtores. Zero restaura DefaultMaxBodySize; valores negativos são
+// rejeitados sem alterar a configuração atual.
+func SetMaxBodySize(size int) error {
+	if size < 0 {
+		return fmt.Errorf("max email body size must not be negative, got %d", size)
+	}
+	if size == 0 {
+		size = DefaultMaxBodySize
+	}
+
+	maxBodySize = size
+	return nil
+}
+
+// MaxBodySize returns the largest body, in bytes, new emails may have.
+func MaxBodySize() int {
+	return maxBodySize
+}
+
 type EmailValidator struct{}
 
 func NewEmailValidator() *EmailValidator {
@@ -16,6 +63,10 @@ func (v *EmailValidator) ValidateEmail(email string) error {
 		return fmt.Errorf("email is required")
 	}
 
+	if utf8.RuneCountInString(email) > MaxRecipientLength {
+		return fmt.Errorf("email recipient exceeds %d characters", MaxRecipientLength)
+	}
+
 	emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
 	if !emailRegex.MatchString(email) {
 		return fmt.Errorf("invalid email format")
@@ -24,13 +75,68 @@ func (v *EmailValidator) ValidateEmail(email string) error {
 	return nil
 }
 
+// ValidateCopies valida cada endereço de uma lista de cópia (Cc ou Bcc);
+// field identifica a lista na mensagem de erro.
+func (v *EmailValidator) ValidateCopies(field string, addresses []string) error {
+	for _, address := range addresses {
+		if err := v.ValidateEmail(address); err != nil {
+			return fmt.Errorf("%s email validation failed: %w", field, err)
+		}
+	}
+
+	return nil
+}
+
+// ValidateAttachments valida nome e tipo de cada anexo e o tamanho total.
+// Content type vazio é aceito: o construtor usa DefaultAttachmentContentType.
+func (v *EmailValidator) ValidateAttachments(attachments []Attachment) error {
+	total := 0
+	for _, attachment := range attachments {
+		if attachment.Filename == "" {
+			return fmt.Errorf("attachment filename is required")
+		}
+
+		if utf8.RuneCountInString(attachment.Filename) > MaxAttachmentFilenameLength {
+			return fmt.Errorf("attachment filename exceeds %d characters", MaxAttachmentFilenameLength)
+		}
+
+		// Quebras de linha injetariam headers; separadores viram caminhos no cliente
+		if strings.ContainsAny(attachment.Filename, "\r\n/\\") {
+			return fmt.Errorf("attachment filename %q is invalid", attachment.Filename)
+		}
+
+		if attachment.ContentType != "" {
+			if _, _, err := mime.ParseMediaType(attachment.ContentType); err != nil {
+				return fmt.Errorf("attachment %q has invalid content type: %w", attachment.Filename, err)
+			}
+		}
+
+		if len(attachment.Content) == 0 {
+			return fmt.Errorf("attachment %q is empty", attachment.Filename)
+		}
+
+		total += len(attachment.Content)
+		if total > MaxAttachmentsSize {
+			return fmt.Errorf("attachments exceed %d bytes", MaxAttachmentsSize)
+		}
+	}
+
+	return nil
+}
+
 func (v *EmailValidator) ValidateSubject(subject string) error {
 	if subject == "" {
 		return fmt.Errorf("email subject is required")
 	}
 
-	if len(subject) > 255 {
-		return fmt.Errorf("email subject must be less than 255 characters")
+	// Mesmo limite do VARCHAR(255), que conta caracteres e não bytes
+	if utf8.RuneCountInString(subject) > MaxSubjectLength {
+		return fmt.Errorf("email subject exceeds %d characters", MaxSubjectLength)
+	}
+
+	// Quebras de linha permitiriam injetar headers na mensagem
+	if strings.ContainsAny(subject, "\r\n") {
+		return fmt.Errorf("email subject must not contain line breaks")
 	}
 
 	return nil
@@ -41,8 +147,9 @@ func (v *EmailValidator) ValidateBody(body string) error {
 		return fmt.Errorf("email body is required")
 	}
 
-	if len(body) > 65535 { // 64KB limit
-		return fmt.Errorf("email body is too large")
+	// A coluna body é TEXT: sem este limite uma notificação gravaria megabytes
+	if len(body) > maxBodySize {
+		return fmt.Errorf("%w: exceeds %d bytes", ErrBodyTooLarge, maxBodySize)
 	}
 
 	return nil
@@ -50,7 +157,7 @@ func (v *EmailValidator) ValidateBody(body string) error {
 
 func (v *EmailValidator) ValidateType(emailType EmailType) error {
 	switch emailType {
-	case EmailTypeWelcome:
+	case EmailTypeWelcome, EmailTypePasswordReset, EmailTypeVerification, EmailTypeNotification:
 		return nil
 	default:
 		return fmt.Errorf("invalid email type: %s", emailType)
@@ -74,8 +181,31 @@ func (v *EmailValidator) ValidateEmailEntity(email *Email) error {
 		return err
 	}
 
-	if email.MaxAttempts <= 0 || email.MaxAttempts > 10 {
-		return fmt.Errorf("max attempts must be between 1 and 10")
+	// Somente notificações têm cópias; os demais tipos vão só para o usuário
+	if len(email.Cc) > 0 || len(email.Bcc) > 0 {
+		if email.Type != EmailTypeNotification {
+			return fmt.Errorf("only notification emails support cc and bcc")
+		}
+		if err := v.ValidateCopies("cc", email.Cc); err != nil {
+			return err
+		}
+		if err := v.ValidateCopies("bcc", email.Bcc); err != nil {
+			return err
+		}
+	}
+
+	// Anexos também são exclusivos das notificações
+	if len(email.Attachments) > 0 {
+		if email.Type != EmailTypeNotification {
+			return fmt.Errorf("only notification emails support attachments")
+		}
+		if err := v.ValidateAttachments(email.Attachments); err != nil {
+			return err
+		}
+	}
+
+	if email.MaxAttempts <= 0 || email.MaxAttempts > MaxAttemptsLimit {
+		return fmt.Errorf("max attempts must be between 1 and %d", MaxAttemptsLimit)
 	}
 
 	return nil
@@ -96,3 +226,67 @@ func (v *EmailValidator) ValidateWelcomeEmailData(data WelcomeEmailData) error {
 
 	return nil
 }
+
+func (v *EmailValidator) ValidatePasswordResetEmailData(data PasswordResetEmailData) error {
+	if data.UserID == "" {
+		return fmt.Errorf("user ID is required")
+	}
+
+	if data.UserName == "" {
+		return fmt.Errorf("user name is required")
+	}
+
+	if err := v.ValidateEmail(data.UserEmail); err != nil {
+		return fmt.Errorf("user email validation failed: %w", err)
+	}
+
+	if data.ResetLink == "" {
+		return fmt.Errorf("reset link is required")
+	}
+
+	return nil
+}
+
+func (v *EmailValidator) ValidateVerificationEmailData(data VerificationEmailData) error {
+	if data.UserID == "" {
+		return fmt.Errorf("user ID is required")
+	}
+
+	if data.UserName == "" {
+		return fmt.Errorf("user name is required")
+	}
+
+	if err := v.ValidateEmail(data.UserEmail); err != nil {
+		return fmt.Errorf("user email validation failed: %w", err)
+	}
+
+	if data.VerificationLink == "" {
+		return fmt.Errorf("verification link is required")
+	}
+
+	return nil
+}
+
+func (v *EmailValidator) ValidateNotificationEmailData(data NotificationEmailData) error {
+	if err := v.ValidateEmail(data.To); err != nil {
+		return fmt.Errorf("recipient email validation failed: %w", err)
+	}
+
+	if err := v.ValidateCopies("cc", data.Cc); err != nil {
+		return err
+	}
+
+	if err := v.ValidateCopies("bcc", data.Bcc); err != nil {
+		return err
+	}
+
+	if err := v.ValidateSubject(data.Subject); err != nil {
+		return err
+	}
+
+	if err := v.ValidateBody(data.Body); err != nil {
+		return err
+	}
+
+	return v.ValidateAttachments(data.Attachments)
+}
@@ -0,0 +1,79 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Sentinel errors for refresh and revoked tokens.
+var (
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrRefreshTokenExpired = errors.New("refresh token has expired")
+	ErrRefreshTokenRevoked = errors.New("refresh token revoked")
+	ErrTokenRevoked        = errors.New("token revoked")
+	ErrSessionNotFound     = errors.New("session not found")
+)
+
+// Sentinel errors for signup idempotency keys.
+var (
+	ErrIdempotencyKeyNotFound = errors.New("idempotency key not found")
+	ErrIdempotencyKeyExists   = errors.New("idempotency key already in use")
+	ErrIdempotencyKeyReused   = errors.New("idempotency key was used with a different request")
+)
+
+type Repository interface {
+	Revoke(ctx context.Context, tokenID uuid.UUID, userID uuid.UUID, expiresAt time.Time) error
+	IsRevoked(ctx context.Context, tokenID uuid.UUID) (bool, error)
+	DeleteExpired(ctx context.Context) (int64, error)
+}
+
+// Session is one sign-in. Its ID is carried by the access and refresh tokens
+// issued then and by every access token refreshed from them, so revoking
+// the session revokes all of them.
+type Session struct {
+	ID             uuid.UUID
+	UserID         uuid.UUID
+	RefreshTokenID uuid.UUID
+	UserAgent      string
+	IPAddress      string
+	IssuedAt       time.Time
+	ExpiresAt      time.Time
+	Revoked        bool
+}
+
+type SessionRepository interface {
+	Create(ctx context.Context, session *Session) error
+	// ListActive returns the user's unrevoked, unexpired sessions, newest first.
+	ListActive(ctx context.Context, userID uuid.UUID) ([]*Session, error)
+	// Revoke marks one of the user's sessions as revoked and returns it;
+	// ErrSessionNotFound when it does not exist or belongs to another user.
+	Revoke(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*Session, error)
+	RevokeAll(ctx context.Context, userID uuid.UUID) error
+}
+
+type PasswordResetRepository interface {
+	Create(ctx context.Context, tokenHash string, userID uuid.UUID, expiresAt time.Time) error
+	Consume(ctx context.Context, tokenHash string) (uuid.UUID, error)
+}
+
+type EmailVerificationRepository interface {
+	Create(ctx context.Context, tokenHash string, userID uuid.UUID, expiresAt time.Time) error
+	Consume(ctx context.Context, tokenHash string) (uuid.UUID, error)
+	// GetUserID returns the owner of the token even if it was already used
+	// or has expired.
+	GetUserID(ctx context.Context, tokenHash string) (uuid.UUID, error)
+}
+
+// IdempotencyRepository maps a client-supplied key to the user created by
+// the first request that carried it, until the key expires.
+type IdempotencyRepository interface {
+	// Create fails with ErrIdempotencyKeyExists while an unexpired entry
+	// for the key exists; an expired one is replaced.
+	Create(ctx context.Context, key string, userID uuid.UUID, expiresAt time.Time) error
+	// Get returns ErrIdempotencyKeyNotFound for unknown or expired keys.
+	Get(ctx context.Context, key string) (uuid.UUID, error)
+	DeleteExpired(ctx context.Context) (int64, error)
+}
@@ -2,28 +2,127 @@ package user
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+// Sentinel errors returned by the repository and the auth/user use cases.
+// Callers wrap them with %w; handlers match them with errors.Is.
+var (
+	ErrEmailExists        = errors.New("email already exists")
+	ErrUserNotFound       = errors.New("user not found")
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrTooManyAttempts    = errors.New("too many attempts")
+	ErrEmailNotVerified   = errors.New("email not verified")
+	ErrIncorrectPassword  = errors.New("current password is incorrect")
+	ErrConflict           = errors.New("user was modified concurrently")
+	ErrAccountSuspended   = errors.New("account suspended")
+)
+
 type Repository interface {
 	Create(ctx context.Context, user *User) error
 
 	GetByID(ctx context.Context, id uuid.UUID) (*User, error)
 
+	// GetByIDs fetches several users in one query, in the order of ids.
+	// Unknown or deleted IDs are skipped and duplicates returned once.
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*User, error)
+
 	GetByEmail(ctx context.Context, email string) (*User, error)
 
+	// Update applies the change only if user.Version still matches the
+	// stored row, returning ErrConflict otherwise. On success the version
+	// is incremented.
 	Update(ctx context.Context, user *User) error
 
+	UpdatePassword(ctx context.Context, user *User) error
+
+	MarkEmailVerified(ctx context.Context, id uuid.UUID) error
+
+	UpdateRole(ctx context.Context, id uuid.UUID, role Role) error
+
+	// UpdateStatus returns ErrUserNotFound when the user does not exist or is deleted.
+	UpdateStatus(ctx context.Context, id uuid.UUID, status Status) error
+
+	// IncrementTokenVersion invalidates every token issued so far and returns
+	// the new version; ErrUserNotFound when the user does not exist or is deleted.
+	IncrementTokenVersion(ctx context.Context, id uuid.UUID) (int, error)
+
+	// UpdateLastLogin records a successful sign-in at the given time; an
+	// older time than the stored one is ignored, so late writes never move it back.
+	UpdateLastLogin(ctx context.Context, id uuid.UUID, at time.Time) error
+
 	Delete(ctx context.Context, id uuid.UUID) error
 
+	// DeleteMany soft-deletes every listed user atomically and returns the
+	// IDs actually deleted; unknown or already deleted IDs are skipped.
+	DeleteMany(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error)
+
 	List(ctx context.Context, params ListParams) ([]*User, int, error)
 
 	EmailExists(ctx context.Context, email string) (bool, error)
+
+	Stats(ctx context.Context) (*SignupStats, error)
+}
+
+// LoginEventRepository keeps the audit trail of signin attempts.
+type LoginEventRepository interface {
+	Create(ctx context.Context, event *LoginEvent) error
+	// ListByUser returns the user's most recent events first.
+	ListByUser(ctx context.Context, userID uuid.UUID, limit int) ([]*LoginEvent, error)
+}
+
+// One signin attempt. UserID is nil when the email matched no account.
+type LoginEvent struct {
+	ID        int64      `json:"id"`
+	UserID    *uuid.UUID `json:"user_id,omitempty"`
+	Email     string     `json:"email"`
+	IPAddress string     `json:"ip_address"`
+	UserAgent string     `json:"user_agent"`
+	Success   bool       `json:"success"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// Active user counts, total and by signup window (measured with the DB clock)
+type SignupStats struct {
+	Total          int `json:"total"`
+	CreatedLast24h int `json:"created_last_24h"`
+	CreatedLast7d  int `json:"created_last_7d"`
+	CreatedLast30d int `json:"created_last_30d"`
 }
 
 type ListParams struct {
-	Page     int    `json:"page"`
-	PageSize int    `json:"page_size"`
-	Search   string `json:"search"` // Search by name or email
+	Page           int         `json:"page"`
+	PageSize       int         `json:"page_size"`
+	Search         string      `json:"search"`          // Search by name or email
+	IncludeDeleted bool        `json:"include_deleted"` // Include soft-deleted users (admin recovery)
+	SortBy         string      `json:"sort_by"`         // One of SortableFields
+	SortOrder      string      `json:"sort_order"`      // asc or desc
+	After          *ListCursor `json:"-"`               // Keyset pagination: rows strictly after this position (created_at desc)
+	CreatedAfter   *time.Time  `json:"created_after"`   // Only users created at or after this instant
+	CreatedBefore  *time.Time  `json:"created_before"`  // Only users created at or before this instant
+}
+
+// Position of the last row of a page, used for keyset pagination
+type ListCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+const (
+	SortByName      = "name"
+	SortByEmail     = "email"
+	SortByCreatedAt = "created_at"
+
+	SortOrderAsc  = "asc"
+	SortOrderDesc = "desc"
+)
+
+// Allowlist of columns accepted for ordering the user list
+var SortableFields = map[string]bool{
+	SortByName:      true,
+	SortByEmail:     true,
+	SortByCreatedAt: true,
 }
@@ -1,41 +1,86 @@
 package user
 
 import (
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/moura95/backend-challenge/internal/infra/security/crypto"
 )
 
+type Role string
+
+const (
+	RoleUser  Role = "user"
+	RoleAdmin Role = "admin"
+)
+
+// Status controls whether the account can sign in. Suspension is reversible,
+// unlike deletion.
+type Status string
+
+const (
+	StatusActive    Status = "active"
+	StatusSuspended Status = "suspended"
+)
+
 type User struct {
-	ID        uuid.UUID `json:"id"`
-	Name      string    `json:"name"`
-	Email     string    `json:"email"`
-	Password  string    `json:"-"` // Never expose password in JSON
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	Email      string     `json:"email"`
+	Password   string     `json:"-"` // Never expose password in JSON
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	VerifiedAt *time.Time `json:"verified_at,omitempty"`
+	Role       Role       `json:"role"`
+	Status     Status     `json:"status"`
+	DeletedAt  *time.Time `json:"deleted_at,omitempty"`
+	Version    int        `json:"-"` // Optimistic lock: incremented on every profile update
+
+	// TokenVersion is embedded in issued tokens; bumping it revokes them all
+	TokenVersion int `json:"-"`
+
+	// LastLoginAt is the last successful sign-in; nil until the first one
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+}
+
+// NormalizeEmail trims and lowercases an address so that lookups and the
+// uniqueness check treat Mixed@Example.Com and mixed@example.com as one account.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// NormalizeName trims surrounding whitespace, keeping the spacing inside the
+// name, so that "  John  " is stored as "John" and a blank name fails the
+// minimum length.
+func NormalizeName(name string) string {
+	return strings.TrimSpace(name)
 }
 
 func NewUser(name, email, password string) (*User, error) {
 	validator := NewUserValidator()
+	name = NormalizeName(name)
+	email = NormalizeEmail(email)
 
 	// Create user instance
 	user := &User{
 		ID:        uuid.New(),
 		Name:      name,
 		Email:     email,
+		Role:      RoleUser,
+		Status:    StatusActive,
+		Version:   1,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
 
-	// Validate user data
-	if err := validator.ValidateUser(user); err != nil {
-		return nil, err
-	}
-
-	// Validate password strength
-	if err := validator.ValidatePassword(password); err != nil {
-		return nil, err
+	// Validate user data and password strength, reporting every failing field
+	validationErr := NewValidationError()
+	validationErr.Add(FieldName, validator.ValidateName(name))
+	validationErr.Add(FieldEmail, validator.ValidateEmail(email))
+	validationErr.Add(FieldPassword, validator.ValidatePassword(password))
+	if validationErr.HasErrors() {
+		return nil, validationErr
 	}
 
 	// Hash password
@@ -52,6 +97,7 @@ func (u *User) UpdateUser(name, email string) error {
 	validator := NewUserValidator()
 
 	if name != "" {
+		name = NormalizeName(name)
 		if err := validator.ValidateName(name); err != nil {
 			return err
 		}
@@ -62,29 +108,136 @@ func (u *User) UpdateUser(name, email string) error {
 		if err := validator.ValidateEmail(email); err != nil {
 			return err
 		}
-		u.Email = email
+		u.Email = NormalizeEmail(email)
 	}
 
 	u.UpdatedAt = time.Now()
 	return nil
 }
 
+// PatchUser sets only the fields that are present (non-nil). A present
+// value is validated even when empty, so it cannot silently mean "unchanged".
+func (u *User) PatchUser(name, email *string) error {
+	validator := NewUserValidator()
+	errs := NewValidationError()
+
+	if name != nil {
+		trimmed := NormalizeName(*name)
+		name = &trimmed
+		errs.Add(FieldName, validator.ValidateName(*name))
+	}
+	if email != nil {
+		errs.Add(FieldEmail, validator.ValidateEmail(*email))
+	}
+	if errs.HasErrors() {
+		return errs
+	}
+
+	if name == nil && email == nil {
+		return nil
+	}
+
+	if name != nil {
+		u.Name = *name
+	}
+	if email != nil {
+		u.Email = NormalizeEmail(*email)
+	}
+
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
+func (u *User) ChangePassword(password string) error {
+	validator := NewUserValidator()
+
+	if err := validator.ValidatePassword(password); err != nil {
+		return err
+	}
+
+	hashedPassword, err := crypto.HashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	u.Password = hashedPassword
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
 func (u *User) CheckPassword(password string) error {
 	return crypto.CheckPassword(password, u.Password)
 }
 
+// PasswordNeedsRehash reports whether the stored hash is weaker than the
+// configured hashing cost.
+func (u *User) PasswordNeedsRehash() bool {
+	return crypto.NeedsRehash(u.Password)
+}
+
+// RehashPassword re-hashes the already verified plaintext with the current
+// cost. Unlike ChangePassword it skips the password policy: the password
+// itself does not change.
+func (u *User) RehashPassword(password string) error {
+	hashedPassword, err := crypto.HashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	u.Password = hashedPassword
+	return nil
+}
+
+func (u *User) IsVerified() bool {
+	return u.VerifiedAt != nil
+}
+
+func (u *User) MarkAsVerified() {
+	if u.VerifiedAt != nil {
+		return
+	}
+	now := time.Now()
+	u.VerifiedAt = &now
+	u.UpdatedAt = now
+}
+
+func (u *User) IsDeleted() bool {
+	return u.DeletedAt != nil
+}
+
+func (u *User) IsAdmin() bool {
+	return u.Role == RoleAdmin
+}
+
+func (u *User) IsSuspended() bool {
+	return u.Status == StatusSuspended
+}
+
+func (u *User) HasRole(role Role) bool {
+	return u.Role == role
+}
+
 func (u *User) ToResponse() UserResponse {
 	return UserResponse{
-		ID:        u.ID.String(),
-		Name:      u.Name,
-		Email:     u.Email,
-		CreatedAt: u.CreatedAt,
+		ID:          u.ID.String(),
+		Name:        u.Name,
+		Email:       u.Email,
+		Role:        string(u.Role),
+		Status:      string(u.Status),
+		CreatedAt:   u.CreatedAt,
+		DeletedAt:   u.DeletedAt,
+		LastLoginAt: u.LastLoginAt,
 	}
 }
 
 type UserResponse struct {
-	ID        string    `json:"id"`
-	Name      string    `json:"name"`
-	Email     string    `json:"email"`
-	CreatedAt time.Time `json:"created_at"`
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	Email     string     `json:"email"`
+	Role      string     `json:"role"`
+	Status    string     `json:"status"`
+	CreatedAt time.Time  `json:"created_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	// Omitted until the user signs in for the first time
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
 }
@@ -1,12 +1,15 @@
 package user
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/moura95/backend-challenge/internal/infra/security/crypto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
 )
 
 func TestNewUser(t *testing.T) {
@@ -106,6 +109,25 @@ func TestNewUser(t *testing.T) {
 		assert.Contains(t, err.Error(), "password must be at least 6 characters")
 	})
 
+	t.Run("should report all invalid fields at once", func(t *testing.T) {
+		// Act
+		user, err := NewUser("", "not-an-email", "123")
+
+		// Assert
+		require.Error(t, err)
+		assert.Nil(t, user)
+
+		validationErr, ok := err.(*ValidationError)
+		require.True(t, ok)
+		assert.Len(t, validationErr.Fields, 3)
+		assert.Contains(t, validationErr.Fields, FieldEmail)
+		assert.Contains(t, validationErr.Fields, FieldName)
+		assert.Contains(t, validationErr.Fields, FieldPassword)
+		assert.Contains(t, err.Error(), "invalid email format")
+		assert.Contains(t, err.Error(), "name must be at least 2 characters")
+		assert.Contains(t, err.Error(), "password must be at least 6 characters")
+	})
+
 	t.Run("should handle special characters in name", func(t *testing.T) {
 		// Arrange
 		name := "José María Ñoño-García O'Connor"
@@ -150,6 +172,93 @@ func TestNewUser(t *testing.T) {
 
 }
 
+func TestNormalizeEmail(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"john@example.com", "john@example.com"},
+		{"Mixed@Example.Com", "mixed@example.com"},
+		{"  padded@example.com\t", "padded@example.com"},
+		{"", ""},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, NormalizeEmail(tc.input), tc.input)
+	}
+}
+
+func TestUser_EmailNormalization(t *testing.T) {
+	t.Run("should store a normalized email on creation", func(t *testing.T) {
+		user, err := NewUser("John Doe", "  John.Doe@Example.COM ", "password123")
+
+		require.NoError(t, err)
+		assert.Equal(t, "john.doe@example.com", user.Email)
+	})
+
+	t.Run("should normalize email on update", func(t *testing.T) {
+		user, err := NewUser("John Doe", "john@example.com", "password123")
+		require.NoError(t, err)
+
+		require.NoError(t, user.UpdateUser("", "New@Example.Com"))
+		assert.Equal(t, "new@example.com", user.Email)
+	})
+
+	t.Run("should normalize email on patch", func(t *testing.T) {
+		user, err := NewUser("John Doe", "john@example.com", "password123")
+		require.NoError(t, err)
+
+		email := " Patched@Example.Com"
+		require.NoError(t, user.PatchUser(nil, &email))
+		assert.Equal(t, "patched@example.com", user.Email)
+	})
+}
+
+func TestUser_NameTrimming(t *testing.T) {
+	t.Run("should trim the name on creation", func(t *testing.T) {
+		user, err := NewUser("  Jo  ", "jo@example.com", "password123")
+
+		require.NoError(t, err)
+		assert.Equal(t, "Jo", user.Name)
+	})
+
+	t.Run("should keep internal spacing", func(t *testing.T) {
+		user, err := NewUser("\tJohn  Doe ", "john@example.com", "password123")
+
+		require.NoError(t, err)
+		assert.Equal(t, "John  Doe", user.Name)
+	})
+
+	t.Run("should reject a blank name as too short", func(t *testing.T) {
+		_, err := NewUser("   ", "blank@example.com", "password123")
+
+		var validationErr *ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Contains(t, validationErr.Fields[FieldName], "at least 2 characters")
+	})
+
+	t.Run("should trim the name on update", func(t *testing.T) {
+		user, err := NewUser("John Doe", "john@example.com", "password123")
+		require.NoError(t, err)
+
+		require.NoError(t, user.UpdateUser("  Jo  ", ""))
+		assert.Equal(t, "Jo", user.Name)
+
+		err = user.UpdateUser("   ", "")
+		assert.ErrorContains(t, err, "at least 2 characters")
+		assert.Equal(t, "Jo", user.Name)
+	})
+
+	t.Run("should trim the name on patch", func(t *testing.T) {
+		user, err := NewUser("John Doe", "john@example.com", "password123")
+		require.NoError(t, err)
+
+		name := "  Jo  "
+		require.NoError(t, user.PatchUser(&name, nil))
+		assert.Equal(t, "Jo", user.Name)
+	})
+}
+
 func TestUser_UpdateUser(t *testing.T) {
 	// Helper function to create a test user
 	createTestUser := func() *User {
@@ -242,6 +351,45 @@ func TestUser_UpdateUser(t *testing.T) {
 
 }
 
+func TestUser_PatchUser(t *testing.T) {
+	strPtr := func(s string) *string { return &s }
+
+	t.Run("should leave nil fields unchanged", func(t *testing.T) {
+		user, err := NewUser("John Doe", "john@example.com", "password123")
+		require.NoError(t, err)
+
+		err = user.PatchUser(strPtr("John Patched"), nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "John Patched", user.Name)
+		assert.Equal(t, "john@example.com", user.Email)
+	})
+
+	t.Run("should not touch the user when nothing is present", func(t *testing.T) {
+		user, err := NewUser("John Doe", "john@example.com", "password123")
+		require.NoError(t, err)
+		updatedAt := user.UpdatedAt
+
+		err = user.PatchUser(nil, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, updatedAt, user.UpdatedAt)
+	})
+
+	t.Run("should validate present empty values", func(t *testing.T) {
+		user, err := NewUser("John Doe", "john@example.com", "password123")
+		require.NoError(t, err)
+
+		err = user.PatchUser(strPtr(""), strPtr(""))
+
+		var validationErr *ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Contains(t, validationErr.Fields, FieldName)
+		assert.Contains(t, validationErr.Fields, FieldEmail)
+		assert.Equal(t, "John Doe", user.Name)
+	})
+}
+
 func TestUser_CheckPassword(t *testing.T) {
 	t.Run("should verify correct password", func(t *testing.T) {
 		// Arrange
@@ -296,6 +444,121 @@ func TestUser_CheckPassword(t *testing.T) {
 	})
 }
 
+func TestNewUser_BcryptCost(t *testing.T) {
+	defer crypto.SetBcryptCost(crypto.DefaultBcryptCost)
+
+	for _, cost := range []int{bcrypt.MinCost, 6} {
+		t.Run(fmt.Sprintf("should hash password with cost %d", cost), func(t *testing.T) {
+			// Arrange
+			require.NoError(t, crypto.SetBcryptCost(cost))
+
+			// Act
+			user, err := NewUser("John Doe", "john@example.com", "password123")
+
+			// Assert
+			require.NoError(t, err)
+			assert.True(t, strings.HasPrefix(user.Password, fmt.Sprintf("$2a$%02d$", cost)))
+
+			hashCost, err := bcrypt.Cost([]byte(user.Password))
+			require.NoError(t, err)
+			assert.Equal(t, cost, hashCost)
+			assert.NoError(t, user.CheckPassword("password123"))
+		})
+	}
+
+	t.Run("should use configured cost when changing password", func(t *testing.T) {
+		// Arrange
+		require.NoError(t, crypto.SetBcryptCost(5))
+		user, err := NewUser("John Doe", "john@example.com", "password123")
+		require.NoError(t, err)
+
+		// Act
+		err = user.ChangePassword("newPassword123")
+
+		// Assert
+		require.NoError(t, err)
+		hashCost, err := bcrypt.Cost([]byte(user.Password))
+		require.NoError(t, err)
+		assert.Equal(t, 5, hashCost)
+		assert.NoError(t, user.CheckPassword("newPassword123"))
+	})
+}
+
+func TestUser_ChangePassword(t *testing.T) {
+	t.Run("should hash and replace password", func(t *testing.T) {
+		// Arrange
+		user, err := NewUser("John Doe", "john@example.com", "oldPassword123")
+		require.NoError(t, err)
+		oldHash := user.Password
+
+		// Act
+		err = user.ChangePassword("newPassword123")
+
+		// Assert
+		require.NoError(t, err)
+		assert.NotEqual(t, oldHash, user.Password)
+		assert.NoError(t, user.CheckPassword("newPassword123"))
+		assert.Error(t, user.CheckPassword("oldPassword123"))
+	})
+
+	t.Run("should reject weak password", func(t *testing.T) {
+		// Arrange
+		user, err := NewUser("John Doe", "john@example.com", "oldPassword123")
+		require.NoError(t, err)
+		oldHash := user.Password
+
+		// Act
+		err = user.ChangePassword("123")
+
+		// Assert
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "password must be at least 6 characters long")
+		assert.Equal(t, oldHash, user.Password)
+	})
+
+	t.Run("should apply the configured password policy", func(t *testing.T) {
+		user, err := NewUser("John Doe", "john@example.com", "oldPassword123")
+		require.NoError(t, err)
+
+		require.NoError(t, crypto.SetPasswordPolicy(crypto.PasswordPolicy{MinLength: 8, RequireSpecial: true}))
+		defer crypto.SetPasswordPolicy(crypto.DefaultPasswordPolicy())
+
+		err = user.ChangePassword("newPassword123")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "password must contain at least one special character")
+
+		assert.NoError(t, user.ChangePassword("newPassword123!"))
+	})
+}
+
+func TestUser_Role(t *testing.T) {
+	t.Run("should default to user role", func(t *testing.T) {
+		// Arrange & Act
+		user, err := NewUser("John Doe", "john@example.com", "password123")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, RoleUser, user.Role)
+		assert.False(t, user.IsAdmin())
+		assert.Equal(t, "user", user.ToResponse().Role)
+	})
+
+	t.Run("should report admin role", func(t *testing.T) {
+		// Arrange
+		user, err := NewUser("Admin", "admin@example.com", "password123")
+		require.NoError(t, err)
+
+		// Act
+		user.Role = RoleAdmin
+
+		// Assert
+		assert.True(t, user.IsAdmin())
+		assert.True(t, user.HasRole(RoleAdmin))
+		assert.False(t, user.HasRole(RoleUser))
+		assert.Equal(t, "admin", user.ToResponse().Role)
+	})
+}
+
 func TestUser_CompleteWorkflow(t *testing.T) {
 	t.Run("should handle complete user lifecycle", func(t *testing.T) {
 		// Arrange - Create user
@@ -3,19 +3,65 @@ package user
 import (
 	"fmt"
 	"regexp"
+	"sort"
+	"strings"
 
 	"github.com/moura95/backend-challenge/internal/infra/security/crypto"
 )
 
+// Field names reported in ValidationError
+const (
+	FieldName     = "name"
+	FieldEmail    = "email"
+	FieldPassword = "password"
+)
+
+// ValidationError collects every field that failed validation instead of
+// stopping at the first one.
+type ValidationError struct {
+	Fields map[string]string `json:"fields"`
+}
+
+func NewValidationError() *ValidationError {
+	return &ValidationError{Fields: map[string]string{}}
+}
+
+// Add records the error for a field; nil errors are ignored.
+func (e *ValidationError) Add(field string, err error) {
+	if err != nil {
+		e.Fields[field] = err.Error()
+	}
+}
+
+func (e *ValidationError) HasErrors() bool {
+	return len(e.Fields) > 0
+}
+
+func (e *ValidationError) Error() string {
+	fields := make([]string, 0, len(e.Fields))
+	for field := range e.Fields {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	messages := make([]string, 0, len(fields))
+	for _, field := range fields {
+		messages = append(messages, fmt.Sprintf("%s: %s", field, e.Fields[field]))
+	}
+	return "validation failed: " + strings.Join(messages, "; ")
+}
+
 type UserValidator struct{}
 
 func NewUserValidator() *UserValidator {
 	return &UserValidator{}
 }
 
+// ValidateEmail checks the normalized address (see NormalizeEmail), which is
+// the form that gets stored.
 func (v *UserValidator) ValidateEmail(email string) error {
 	emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
-	if !emailRegex.MatchString(email) {
+	if !emailRegex.MatchString(NormalizeEmail(email)) {
 		return fmt.Errorf("invalid email format")
 	}
 	return nil
@@ -32,17 +78,16 @@ func (v *UserValidator) ValidateName(name string) error {
 }
 
 func (v *UserValidator) ValidatePassword(password string) error {
-	return crypto.ValidatePasswordStrength(password)
+	return crypto.ValidatePassword(password)
 }
 
 func (v *UserValidator) ValidateUser(user *User) error {
-	if err := v.ValidateName(user.Name); err != nil {
-		return err
-	}
+	validationErr := NewValidationError()
+	validationErr.Add(FieldName, v.ValidateName(user.Name))
+	validationErr.Add(FieldEmail, v.ValidateEmail(user.Email))
 
-	if err := v.ValidateEmail(user.Email); err != nil {
-		return err
+	if validationErr.HasErrors() {
+		return validationErr
 	}
-
 	return nil
 }
@@ -0,0 +1,41 @@
+// Package buildinfo exposes build metadata injected at link time, e.g.:
+//
+//	go build -ldflags "-X github.com/moura95/backend-challenge/internal/infra/buildinfo.Version=v1.2.0 \
+//	  -X github.com/moura95/backend-challenge/internal/infra/buildinfo.Commit=$(git rev-parse HEAD) \
+//	  -X github.com/moura95/backend-challenge/internal/infra/buildinfo.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+package buildinfo
+
+import "runtime"
+
+const unknown = "unknown"
+
+// Set via -ldflags -X; builds without them report "unknown".
+var (
+	Version   = unknown
+	Commit    = unknown
+	BuildTime = unknown
+)
+
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"build_time"`
+	GoVersion string `json:"go_version"`
+}
+
+func Get() Info {
+	return Info{
+		Version:   orUnknown(Version),
+		Commit:    orUnknown(Commit),
+		BuildTime: orUnknown(BuildTime),
+		GoVersion: runtime.Version(),
+	}
+}
+
+// orUnknown covers an explicit empty -X value
+func orUnknown(value string) string {
+	if value == "" {
+		return unknown
+	}
+	return value
+}
@@ -1,20 +1,200 @@
 package config
 
 import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
 	"github.com/spf13/viper"
+
+	userUC "github.com/moura95/backend-challenge/internal/application/usecases/user"
+)
+
+// DefaultAccessTokenDuration is used when ACCESS_TOKEN_DURATION is not set.
+const DefaultAccessTokenDuration = 24 * time.Hour
+
+// DefaultRememberMeTokenDuration is used when REMEMBER_ME_TOKEN_DURATION is not set.
+const DefaultRememberMeTokenDuration = 30 * 24 * time.Hour
+
+// DefaultMaxRequestBodyBytes is used when MAX_REQUEST_BODY_BYTES is not set.
+const DefaultMaxRequestBodyBytes = 1 << 20
+
+// DefaultEmailBacklogThreshold is used when EMAIL_BACKLOG_THRESHOLD is not set.
+const DefaultEmailBacklogThreshold = 1000
+
+// TokenSymmetricKeySize is the key length required by the Paseto maker.
+const TokenSymmetricKeySize = 32
+
+// MaxEmailAttempts is the highest EMAIL_MAX_ATTEMPTS_* value (the email
+// validator rejects more than 10 attempts).
+const MaxEmailAttempts = 10
+
+// Token types accepted by TOKEN_TYPE.
+const (
+	TokenTypeLocal  = "local"
+	TokenTypePublic = "public"
+)
+
+// Email providers accepted by EMAIL_PROVIDER.
+const (
+	EmailProviderSMTP = "smtp"
+	EmailProviderLog  = "log"
+	EmailProviderFile = "file"
 )
 
 type Config struct {
 	DBSource          string `mapstructure:"DB_SOURCE"`
 	HTTPServerAddress string `mapstructure:"HTTP_SERVER_ADDRESS"`
+	// Largest JSON body accepted by the API; bigger requests get 413
+	MaxRequestBodyBytes int64 `mapstructure:"MAX_REQUEST_BODY_BYTES"`
+	// Page size for list endpoints when the client sends none, and the cap applied to it
+	DefaultPageSize int `mapstructure:"DEFAULT_PAGE_SIZE"`
+	MaxPageSize     int `mapstructure:"MAX_PAGE_SIZE"`
 
 	// RabbitMQ Configuration
 	RabbitMQURL string `mapstructure:"RABBITMQ_URL"`
+	// How long a publish waits for the broker confirm. Zero uses the default.
+	RabbitMQConfirmTimeout time.Duration `mapstructure:"RABBITMQ_CONFIRM_TIMEOUT"`
+	// Expiration set on every published email message. Zero uses the default (1h).
+	RabbitMQMessageTTL time.Duration `mapstructure:"RABBITMQ_MESSAGE_TTL"`
+	// Email routing: empty exchange publishes straight to the queue; empty
+	// queue uses email_notifications. PASSWORD_RESET_QUEUE gives password
+	// resets their own queue, otherwise they share the default one.
+	RabbitMQExchange   string `mapstructure:"RABBITMQ_EXCHANGE"`
+	EmailQueue         string `mapstructure:"EMAIL_QUEUE"`
+	EmailRoutingKey    string `mapstructure:"EMAIL_ROUTING_KEY"`
+	PasswordResetQueue string `mapstructure:"PASSWORD_RESET_QUEUE"`
+	// Exchange for messages rejected after the retries; each queue gets a
+	// <queue>.dlq bound to it. Empty uses email_notifications.dlx.
+	RabbitMQDeadLetterExchange string `mapstructure:"RABBITMQ_DEAD_LETTER_EXCHANGE"`
+	// Consumer throughput: unacked messages per queue and messages processed
+	// at once. Zero keeps one message at a time.
+	RabbitMQPrefetch     int `mapstructure:"RABBITMQ_PREFETCH"`
+	EmailConsumerWorkers int `mapstructure:"EMAIL_CONSUMER_WORKERS"`
+	// On shutdown, how long the consumer waits for messages being processed.
+	// Zero uses the default.
+	EmailConsumerDrainTimeout time.Duration `mapstructure:"EMAIL_CONSUMER_DRAIN_TIMEOUT"`
 
 	// SMTP Configuration
 	SMTPHost string `mapstructure:"SMTP_HOST"`
 	SMTPPort int    `mapstructure:"SMTP_PORT"`
 	SMTPFrom string `mapstructure:"SMTP_FROM"`
+	// Display name shown in the From header; empty sends the bare address
+	SMTPFromName string `mapstructure:"SMTP_FROM_NAME"`
+	// Reply-To header; empty leaves replies going to SMTP_FROM
+	SMTPReplyTo string `mapstructure:"SMTP_REPLY_TO"`
+
+	// SMTP credentials: when empty, emails are sent without authentication
+	SMTPUsername string `mapstructure:"SMTP_USERNAME"`
+	SMTPPassword string `mapstructure:"SMTP_PASSWORD"`
+
+	// SMTP timeouts for connecting and for the whole send. Zero uses the
+	// defaults (10s and 30s).
+	SMTPDialTimeout time.Duration `mapstructure:"SMTP_DIAL_TIMEOUT"`
+	SMTPSendTimeout time.Duration `mapstructure:"SMTP_SEND_TIMEOUT"`
+	// SMTP connections reused across sends (pool size). Zero opens one
+	// connection per email.
+	SMTPMaxConnections int `mapstructure:"SMTP_MAX_CONNECTIONS"`
+
+	// Email delivery: "smtp" sends through the SMTP_* server, "file" writes
+	// .eml files to EMAIL_DEV_DIR and "log" only logs each message. Empty
+	// falls back to EMAIL_DEV_MODE ("file" when true, "smtp" otherwise).
+	EmailProvider string `mapstructure:"EMAIL_PROVIDER"`
+	// Dev mode: emails are written as .eml files to EMAIL_DEV_DIR instead of
+	// going through SMTP, so SMTP_HOST and SMTP_PORT are not required
+	EmailDevMode bool   `mapstructure:"EMAIL_DEV_MODE"`
+	EmailDevDir  string `mapstructure:"EMAIL_DEV_DIR"`
+
+	// Emails sent in parallel when processing pending batches. Zero uses the default.
+	EmailWorkers int `mapstructure:"EMAIL_WORKERS"`
+	// Emails left in "sending" for longer than this (e.g. the worker crashed
+	// mid-send) go back to pending. Zero uses the default (10m).
+	EmailSendingTimeout time.Duration `mapstructure:"EMAIL_SENDING_TIMEOUT"`
+	// /readyz reports degraded when more emails than this are waiting to be
+	// sent. Zero disables the check.
+	EmailBacklogThreshold int `mapstructure:"EMAIL_BACKLOG_THRESHOLD"`
+
+	// Delivery attempts per email type before it is marked failed (1-10). Zero uses the default (3).
+	EmailMaxAttemptsWelcome       int `mapstructure:"EMAIL_MAX_ATTEMPTS_WELCOME"`
+	EmailMaxAttemptsPasswordReset int `mapstructure:"EMAIL_MAX_ATTEMPTS_PASSWORD_RESET"`
+	EmailMaxAttemptsVerification  int `mapstructure:"EMAIL_MAX_ATTEMPTS_VERIFICATION"`
+	EmailMaxAttemptsNotification  int `mapstructure:"EMAIL_MAX_ATTEMPTS_NOTIFICATION"`
+	// Largest HTML body, in bytes, an email may be created with. Zero uses the default (64 KiB).
+	EmailMaxBodyBytes int `mapstructure:"EMAIL_MAX_BODY_BYTES"`
+
+	// Paseto token flavour: "local" (symmetric) or "public" (Ed25519 signed)
+	TokenType string `mapstructure:"TOKEN_TYPE"`
+	// Paseto symmetric key, exactly 32 characters (local tokens)
+	TokenSymmetricKey string `mapstructure:"TOKEN_SYMMETRIC_KEY"`
+	// Hex-encoded Ed25519 key pair (public tokens)
+	TokenPrivateKey string `mapstructure:"TOKEN_PRIVATE_KEY"`
+	TokenPublicKey  string `mapstructure:"TOKEN_PUBLIC_KEY"`
+	// Lifetime of access tokens issued on signin and refresh
+	AccessTokenDuration time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
+	// Lifetime of access tokens issued on signin with remember_me
+	RememberMeTokenDuration time.Duration `mapstructure:"REMEMBER_ME_TOKEN_DURATION"`
+	// Signin returns the access token as a Secure HttpOnly cookie instead of in the body
+	AuthCookieMode bool `mapstructure:"AUTH_COOKIE_MODE"`
+
+	// How long a signup Idempotency-Key is remembered. Zero uses the default (24h).
+	IdempotencyKeyTTL time.Duration `mapstructure:"IDEMPOTENCY_KEY_TTL"`
+
+	// Password hashing cost (bcrypt, 4-31). Zero uses the default.
+	BcryptCost int `mapstructure:"BCRYPT_COST"`
+
+	// Password policy for signup, change and reset. Zero min length uses the default (6).
+	PasswordMinLength      int  `mapstructure:"PASSWORD_MIN_LENGTH"`
+	PasswordRequireDigit   bool `mapstructure:"PASSWORD_REQUIRE_DIGIT"`
+	PasswordRequireUpper   bool `mapstructure:"PASSWORD_REQUIRE_UPPER"`
+	PasswordRequireLower   bool `mapstructure:"PASSWORD_REQUIRE_LOWER"`
+	PasswordRequireSpecial bool `mapstructure:"PASSWORD_REQUIRE_SPECIAL"`
+
+	// Login lockout: consecutive failures per email before locking, and lock duration
+	LoginMaxAttempts   int           `mapstructure:"LOGIN_MAX_ATTEMPTS"`
+	LoginLockoutWindow time.Duration `mapstructure:"LOGIN_LOCKOUT_WINDOW"`
+
+	// Per-IP token bucket on the /api/auth routes: sustained requests per
+	// second and burst size. Zero uses the defaults (5/s, burst 10).
+	RateLimitRequestsPerSecond float64 `mapstructure:"RATE_LIMIT_REQUESTS_PER_SECOND"`
+	RateLimitBurst             int     `mapstructure:"RATE_LIMIT_BURST"`
+
+	// Proxies (IPs or CIDRs, comma-separated) whose X-Forwarded-For is trusted
+	// to resolve the client IP. Empty uses the connection address.
+	TrustedProxies []string `mapstructure:"TRUSTED_PROXIES"`
+
+	// Email verification: when enabled, signin requires a confirmed email
+	EmailVerificationRequired bool   `mapstructure:"EMAIL_VERIFICATION_REQUIRED"`
+	EmailVerificationURL      string `mapstructure:"EMAIL_VERIFICATION_URL"`
+	// Minimum interval between verification resends to the same email. Zero
+	// uses the default (2m).
+	EmailVerificationResendCooldown time.Duration `mapstructure:"EMAIL_VERIFICATION_RESEND_COOLDOWN"`
+
+	// Send the welcome email on signup (default true)
+	WelcomeEmailEnabled bool `mapstructure:"WELCOME_EMAIL_ENABLED"`
+
+	// Maintenance switch: signup answers 503 while sign-in keeps working
+	SignupDisabled bool `mapstructure:"SIGNUP_DISABLED"`
+
+	// Welcome email white-labeling: subject template and path to an HTML body
+	// template ({{.UserName}} is substituted). Empty values keep the defaults.
+	WelcomeEmailSubject      string `mapstructure:"WELCOME_EMAIL_SUBJECT"`
+	WelcomeEmailTemplateFile string `mapstructure:"WELCOME_EMAIL_TEMPLATE_FILE"`
+
+	// Password reset link sent by email (the token is appended as ?token=)
+	PasswordResetURL string `mapstructure:"PASSWORD_RESET_URL"`
+
+	// Existing user promoted to admin on startup
+	AdminEmail string `mapstructure:"ADMIN_EMAIL"`
+
+	// In-memory cache for user lookups by ID: zero TTL disables it, zero size uses the default
+	UserCacheTTL  time.Duration `mapstructure:"USER_CACHE_TTL"`
+	UserCacheSize int           `mapstructure:"USER_CACHE_SIZE"`
+
+	// OpenTelemetry: spans are exported over OTLP/HTTP to this URL; empty disables tracing
+	OTelExporterEndpoint string `mapstructure:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+	OTelServiceName      string `mapstructure:"OTEL_SERVICE_NAME"`
 }
 
 func LoadConfig(path string) (config Config, err error) {
@@ -23,9 +203,187 @@ func LoadConfig(path string) (config Config, err error) {
 	viper.SetConfigFile(".env")
 
 	viper.AutomaticEnv()
+	viper.SetDefault("TOKEN_TYPE", TokenTypeLocal)
+	viper.SetDefault("WELCOME_EMAIL_ENABLED", true)
+	viper.SetDefault("ACCESS_TOKEN_DURATION", DefaultAccessTokenDuration)
+	viper.SetDefault("REMEMBER_ME_TOKEN_DURATION", DefaultRememberMeTokenDuration)
+	viper.SetDefault("MAX_REQUEST_BODY_BYTES", DefaultMaxRequestBodyBytes)
+	// Mesmos padrões que os use cases de listagem aplicam sem configuração
+	viper.SetDefault("DEFAULT_PAGE_SIZE", userUC.DefaultPageSize)
+	viper.SetDefault("MAX_PAGE_SIZE", userUC.DefaultMaxPageSize)
+	viper.SetDefault("EMAIL_BACKLOG_THRESHOLD", DefaultEmailBacklogThreshold)
 
 	viper.ReadInConfig()
 
 	err = viper.Unmarshal(&config)
 	return
 }
+
+// Validate checks the settings the application cannot start without, so a
+// bad value fails at startup instead of on the first request.
+func (c Config) Validate() error {
+	required := []struct {
+		name  string
+		value string
+	}{
+		{"DB_SOURCE", c.DBSource},
+		{"HTTP_SERVER_ADDRESS", c.HTTPServerAddress},
+		{"RABBITMQ_URL", c.RabbitMQURL},
+		{"SMTP_FROM", c.SMTPFrom},
+	}
+	switch c.EmailProvider {
+	case "", EmailProviderSMTP, EmailProviderLog, EmailProviderFile:
+	default:
+		return fmt.Errorf("config: EMAIL_PROVIDER must be %q, %q or %q, got %q",
+			EmailProviderSMTP, EmailProviderLog, EmailProviderFile, c.EmailProvider)
+	}
+	usesSMTP := c.ResolvedEmailProvider() == EmailProviderSMTP
+	if usesSMTP {
+		required = append(required, struct {
+			name  string
+			value string
+		}{"SMTP_HOST", c.SMTPHost})
+	}
+	switch c.TokenType {
+	case "", TokenTypeLocal:
+		required = append(required, struct {
+			name  string
+			value string
+		}{"TOKEN_SYMMETRIC_KEY", c.TokenSymmetricKey})
+	case TokenTypePublic:
+		required = append(required, []struct {
+			name  string
+			value string
+		}{{"TOKEN_PRIVATE_KEY", c.TokenPrivateKey}, {"TOKEN_PUBLIC_KEY", c.TokenPublicKey}}...)
+	default:
+		return fmt.Errorf("config: TOKEN_TYPE must be %q or %q, got %q", TokenTypeLocal, TokenTypePublic, c.TokenType)
+	}
+	for _, field := range required {
+		if field.value == "" {
+			return fmt.Errorf("config: %s is required", field.name)
+		}
+	}
+
+	rabbitURL, err := url.Parse(c.RabbitMQURL)
+	if err != nil {
+		return fmt.Errorf("config: RABBITMQ_URL is not a valid URL: %w", err)
+	}
+	if rabbitURL.Scheme != "amqp" && rabbitURL.Scheme != "amqps" {
+		return fmt.Errorf("config: RABBITMQ_URL must use amqp or amqps scheme, got %q", rabbitURL.Scheme)
+	}
+	if rabbitURL.Host == "" {
+		return fmt.Errorf("config: RABBITMQ_URL must include a host")
+	}
+
+	if !isBareAddress(c.SMTPFrom) {
+		return fmt.Errorf("config: SMTP_FROM must be a valid email address, got %q", c.SMTPFrom)
+	}
+	if strings.ContainsAny(c.SMTPFromName, "\r\n") {
+		return fmt.Errorf("config: SMTP_FROM_NAME must not contain line breaks")
+	}
+	if c.SMTPReplyTo != "" && !isBareAddress(c.SMTPReplyTo) {
+		return fmt.Errorf("config: SMTP_REPLY_TO must be a valid email address, got %q", c.SMTPReplyTo)
+	}
+
+	if usesSMTP && (c.SMTPPort < 1 || c.SMTPPort > 65535) {
+		return fmt.Errorf("config: SMTP_PORT must be between 1 and 65535, got %d", c.SMTPPort)
+	}
+	if c.SMTPMaxConnections < 0 {
+		return fmt.Errorf("config: SMTP_MAX_CONNECTIONS must not be negative, got %d", c.SMTPMaxConnections)
+	}
+
+	for _, setting := range []struct {
+		key   string
+		value int
+	}{
+		{"EMAIL_MAX_ATTEMPTS_WELCOME", c.EmailMaxAttemptsWelcome},
+		{"EMAIL_MAX_ATTEMPTS_PASSWORD_RESET", c.EmailMaxAttemptsPasswordReset},
+		{"EMAIL_MAX_ATTEMPTS_VERIFICATION", c.EmailMaxAttemptsVerification},
+		{"EMAIL_MAX_ATTEMPTS_NOTIFICATION", c.EmailMaxAttemptsNotification},
+	} {
+		if setting.value < 0 || setting.value > MaxEmailAttempts {
+			return fmt.Errorf("config: %s must be between 0 and %d, got %d", setting.key, MaxEmailAttempts, setting.value)
+		}
+	}
+
+	if c.EmailMaxBodyBytes < 0 {
+		return fmt.Errorf("config: EMAIL_MAX_BODY_BYTES must not be negative, got %d", c.EmailMaxBodyBytes)
+	}
+
+	if c.PasswordMinLength < 0 {
+		return fmt.Errorf("config: PASSWORD_MIN_LENGTH must not be negative, got %d", c.PasswordMinLength)
+	}
+
+	if c.RateLimitRequestsPerSecond < 0 {
+		return fmt.Errorf("config: RATE_LIMIT_REQUESTS_PER_SECOND must not be negative, got %g", c.RateLimitRequestsPerSecond)
+	}
+	if c.RateLimitBurst < 0 {
+		return fmt.Errorf("config: RATE_LIMIT_BURST must not be negative, got %d", c.RateLimitBurst)
+	}
+
+	if c.TokenType != TokenTypePublic && len(c.TokenSymmetricKey) != TokenSymmetricKeySize {
+		return fmt.Errorf("config: TOKEN_SYMMETRIC_KEY must be exactly %d characters, got %d",
+			TokenSymmetricKeySize, len(c.TokenSymmetricKey))
+	}
+
+	if c.MaxRequestBodyBytes <= 0 {
+		return fmt.Errorf("config: MAX_REQUEST_BODY_BYTES must be positive, got %d", c.MaxRequestBodyBytes)
+	}
+
+	if c.RabbitMQMessageTTL < 0 {
+		return fmt.Errorf("config: RABBITMQ_MESSAGE_TTL must not be negative, got %s", c.RabbitMQMessageTTL)
+	}
+
+	if c.RabbitMQPrefetch < 0 {
+		return fmt.Errorf("config: RABBITMQ_PREFETCH must not be negative, got %d", c.RabbitMQPrefetch)
+	}
+
+	if c.EmailConsumerWorkers < 0 {
+		return fmt.Errorf("config: EMAIL_CONSUMER_WORKERS must not be negative, got %d", c.EmailConsumerWorkers)
+	}
+
+	if c.MaxPageSize <= 0 {
+		return fmt.Errorf("config: MAX_PAGE_SIZE must be positive, got %d", c.MaxPageSize)
+	}
+
+	if c.DefaultPageSize <= 0 || c.DefaultPageSize > c.MaxPageSize {
+		return fmt.Errorf("config: DEFAULT_PAGE_SIZE must be between 1 and MAX_PAGE_SIZE (%d), got %d",
+			c.MaxPageSize, c.DefaultPageSize)
+	}
+
+	if c.IdempotencyKeyTTL < 0 {
+		return fmt.Errorf("config: IDEMPOTENCY_KEY_TTL must not be negative, got %s", c.IdempotencyKeyTTL)
+	}
+
+	if c.UserCacheTTL < 0 {
+		return fmt.Errorf("config: USER_CACHE_TTL must not be negative, got %s", c.UserCacheTTL)
+	}
+
+	if c.AccessTokenDuration <= 0 {
+		return fmt.Errorf("config: ACCESS_TOKEN_DURATION must be positive, got %s", c.AccessTokenDuration)
+	}
+	if c.RememberMeTokenDuration <= 0 {
+		return fmt.Errorf("config: REMEMBER_ME_TOKEN_DURATION must be positive, got %s", c.RememberMeTokenDuration)
+	}
+
+	return nil
+}
+
+// ResolvedEmailProvider devolve o provider de email em uso: EMAIL_PROVIDER
+// quando definido, senão "file" no modo dev e "smtp" fora dele.
+func (c Config) ResolvedEmailProvider() string {
+	if c.EmailProvider != "" {
+		return c.EmailProvider
+	}
+	if c.EmailDevMode {
+		return EmailProviderFile
+	}
+	return EmailProviderSMTP
+}
+
+// isBareAddress aceita apenas o endereço, sem nome nem <>: o nome de
+// exibição vem de SMTP_FROM_NAME.
+func isBareAddress(value string) bool {
+	address, err := mail.ParseAddress(value)
+	return err == nil && address.Name == "" && address.Address == value
+}
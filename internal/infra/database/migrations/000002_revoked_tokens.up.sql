@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS revoked_tokens (
+                                              token_uuid   UUID PRIMARY KEY,
+                                              user_uuid    UUID NOT NULL,
+                                              expires_at   TIMESTAMPTZ NOT NULL,
+                                              revoked_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_revoked_tokens_expires_at ON revoked_tokens(expires_at);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS password_reset_tokens (
+                                                     token_hash   TEXT PRIMARY KEY,
+                                                     user_uuid    UUID NOT NULL,
+                                                     expires_at   TIMESTAMPTZ NOT NULL,
+                                                     used_at      TIMESTAMPTZ,
+                                                     created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                                                     FOREIGN KEY (user_uuid) REFERENCES users(uuid) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_password_reset_tokens_user_uuid ON password_reset_tokens(user_uuid);
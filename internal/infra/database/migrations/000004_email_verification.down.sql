@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS email_verification_tokens CASCADE;
+ALTER TABLE users DROP COLUMN IF EXISTS verified_at;
\ No newline at end of file
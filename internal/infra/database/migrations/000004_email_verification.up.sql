@@ -0,0 +1,12 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS verified_at TIMESTAMPTZ;
+
+CREATE TABLE IF NOT EXISTS email_verification_tokens (
+                                                         token_hash   TEXT PRIMARY KEY,
+                                                         user_uuid    UUID NOT NULL,
+                                                         expires_at   TIMESTAMPTZ NOT NULL,
+                                                         used_at      TIMESTAMPTZ,
+                                                         created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                                                         FOREIGN KEY (user_uuid) REFERENCES users(uuid) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_email_verification_tokens_user_uuid ON email_verification_tokens(user_uuid);
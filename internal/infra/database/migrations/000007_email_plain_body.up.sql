@@ -0,0 +1 @@
+ALTER TABLE emails ADD COLUMN IF NOT EXISTS plain_body TEXT NOT NULL DEFAULT '';
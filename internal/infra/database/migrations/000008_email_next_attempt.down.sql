@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_emails_status_next_attempt_at;
+ALTER TABLE emails DROP COLUMN IF EXISTS next_attempt_at;
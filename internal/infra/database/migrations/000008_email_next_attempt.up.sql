@@ -0,0 +1,3 @@
+ALTER TABLE emails ADD COLUMN IF NOT EXISTS next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+CREATE INDEX IF NOT EXISTS idx_emails_status_next_attempt_at ON emails(status, next_attempt_at);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+                                                idempotency_key VARCHAR(255) PRIMARY KEY,
+                                                user_uuid       UUID NOT NULL,
+                                                expires_at      TIMESTAMPTZ NOT NULL,
+                                                created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                                                FOREIGN KEY (user_uuid) REFERENCES users(uuid) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
@@ -0,0 +1,30 @@
+-- Emails are stored lowercased; uniqueness ignores case.
+-- Precondition: no two users may have emails that differ only in case or in
+-- surrounding spaces (e.g. A@x.com and a@x.com). Those accounts have to be
+-- merged or renamed by hand first; the check below aborts listing them.
+DO $$
+DECLARE
+    duplicates TEXT;
+BEGIN
+    SELECT string_agg(normalized || ' (' || total || ' users)', ', ')
+      INTO duplicates
+      FROM (
+          SELECT LOWER(TRIM(email)) AS normalized, COUNT(*) AS total
+            FROM users
+           GROUP BY LOWER(TRIM(email))
+          HAVING COUNT(*) > 1
+      ) AS clashes;
+
+    IF duplicates IS NOT NULL THEN
+        RAISE EXCEPTION 'cannot make users.email case-insensitive, resolve these duplicate emails first: %', duplicates;
+    END IF;
+END $$;
+
+-- The old case-sensitive constraint goes first so the update below can only
+-- conflict with the new index
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_email_key;
+DROP INDEX IF EXISTS idx_users_email;
+
+UPDATE users SET email = LOWER(TRIM(email)) WHERE email <> LOWER(TRIM(email));
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email_lower ON users (LOWER(email));
@@ -0,0 +1,2 @@
+ALTER TABLE emails DROP COLUMN IF EXISTS bcc_emails;
+ALTER TABLE emails DROP COLUMN IF EXISTS cc_emails;
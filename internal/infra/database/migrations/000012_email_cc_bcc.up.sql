@@ -0,0 +1,2 @@
+ALTER TABLE emails ADD COLUMN IF NOT EXISTS cc_emails TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE emails ADD COLUMN IF NOT EXISTS bcc_emails TEXT[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS login_events (
+                                            id          BIGSERIAL PRIMARY KEY,
+                                            user_uuid   UUID,
+                                            email       VARCHAR(255) NOT NULL,
+                                            ip_address  VARCHAR(64) NOT NULL DEFAULT '',
+                                            user_agent  TEXT NOT NULL DEFAULT '',
+                                            success     BOOLEAN NOT NULL,
+                                            created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                                            FOREIGN KEY (user_uuid) REFERENCES users(uuid) ON DELETE CASCADE
+);
+
+-- Tentativas com email desconhecido ficam sem user_uuid
+CREATE INDEX idx_login_events_user_created_at ON login_events(user_uuid, created_at DESC);
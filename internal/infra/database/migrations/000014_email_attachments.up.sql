@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS email_attachments (
+                                                 id           BIGSERIAL PRIMARY KEY,
+                                                 email_uuid   UUID NOT NULL,
+                                                 filename     VARCHAR(255) NOT NULL,
+                                                 content_type VARCHAR(255) NOT NULL,
+                                                 content      BYTEA NOT NULL,
+                                                 created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                                                 FOREIGN KEY (email_uuid) REFERENCES emails(uuid) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_email_attachments_email_uuid ON email_attachments(email_uuid);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_emails_dedup_key_created_at;
+ALTER TABLE emails DROP COLUMN IF EXISTS dedup_key;
@@ -0,0 +1,4 @@
+ALTER TABLE emails ADD COLUMN IF NOT EXISTS dedup_key VARCHAR(255);
+
+CREATE INDEX IF NOT EXISTS idx_emails_dedup_key_created_at ON emails(dedup_key, created_at)
+    WHERE dedup_key IS NOT NULL;
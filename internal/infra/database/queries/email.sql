@@ -1,8 +1,22 @@
 -- name: CreateEmail :one
-INSERT INTO emails (to_email, subject, body, type, status, attempts, max_attempts)
-VALUES ($1, $2, $3, $4, $5, $6, $7)
+INSERT INTO emails (to_email, subject, body, plain_body, type, status, attempts, max_attempts, cc_emails, bcc_emails, dedup_key, scheduled_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 RETURNING *;
 
+-- name: CreateEmailIfNotExists :one
+INSERT INTO emails (to_email, subject, body, plain_body, type, status, attempts, max_attempts, cc_emails, bcc_emails, scheduled_at, dedup_key)
+SELECT $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, sqlc.arg('dedup_key')::varchar
+WHERE NOT EXISTS (
+    SELECT 1
+    FROM emails
+    WHERE dedup_key = sqlc.arg('dedup_key')::varchar
+      AND created_at > NOW() - make_interval(secs => sqlc.arg('window_seconds')::float8)
+)
+RETURNING *;
+
+-- name: LockEmailDedupKey :exec
+SELECT pg_advisory_xact_lock(hashtext(sqlc.arg('dedup_key')::varchar));
+
 -- name: GetEmailByID :one
 SELECT *
 FROM emails
@@ -15,6 +29,7 @@ SET
     attempts = COALESCE(sqlc.narg('attempts'), attempts),
     error_msg = COALESCE(sqlc.narg('error_msg'), error_msg),
     sent_at = COALESCE(sqlc.narg('sent_at'), sent_at),
+    next_attempt_at = COALESCE(sqlc.narg('next_attempt_at'), next_attempt_at),
     updated_at = NOW()
 WHERE uuid = $1;
 
@@ -22,5 +37,74 @@ WHERE uuid = $1;
 SELECT *
 FROM emails
 WHERE status = 'pending'
-ORDER BY created_at ASC
-LIMIT $1;
\ No newline at end of file
+  AND next_attempt_at <= NOW()
+  AND (scheduled_at IS NULL OR scheduled_at <= NOW())
+ORDER BY next_attempt_at ASC, created_at ASC
+LIMIT $1;
+
+-- name: GetEmailsByRecipient :many
+SELECT *
+FROM emails
+WHERE to_email = $1
+ORDER BY created_at ASC;
+
+-- name: CountEmailsByRecipient :one
+SELECT COUNT(*)
+FROM emails
+WHERE to_email = $1;
+
+-- name: CountPendingEmails :one
+SELECT COUNT(*)
+FROM emails
+WHERE status = 'pending'
+  AND next_attempt_at <= NOW()
+  AND (scheduled_at IS NULL OR scheduled_at <= NOW());
+
+-- name: ListEmailsByRecipient :many
+SELECT uuid, to_email, subject, type, status, sent_at, created_at,
+       (CASE WHEN sqlc.arg('include_body')::bool THEN body ELSE '' END)::text AS body
+FROM emails
+WHERE to_email = sqlc.arg('to_email')
+ORDER BY created_at DESC, uuid DESC
+LIMIT sqlc.arg('limit')::int
+    OFFSET sqlc.arg('offset')::int;
+
+-- name: LockEmailForProcessing :one
+SELECT *
+FROM emails
+WHERE uuid = $1
+FOR UPDATE SKIP LOCKED;
+
+-- name: ReclaimStaleSendingEmails :execrows
+UPDATE emails
+SET status     = 'pending',
+    updated_at = NOW()
+WHERE status = 'sending'
+  AND updated_at < sqlc.arg('stale_before')::timestamptz;
+
+-- name: GetEmailStats :one
+SELECT COUNT(*) FILTER (WHERE status = 'pending') AS pending,
+       COUNT(*) FILTER (WHERE status = 'sent')    AS sent,
+       COUNT(*) FILTER (WHERE status = 'failed')  AS failed
+FROM emails;
+
+-- name: CountSearchEmails :one
+SELECT COUNT(*)
+FROM emails
+WHERE (sqlc.narg('to_email')::text IS NULL OR to_email ILIKE '%' || sqlc.narg('to_email')::text || '%')
+  AND (sqlc.narg('status')::text IS NULL OR status = sqlc.narg('status')::text)
+  AND (sqlc.narg('type')::text IS NULL OR type = sqlc.narg('type')::text)
+  AND (sqlc.narg('created_after')::timestamptz IS NULL OR created_at >= sqlc.narg('created_after')::timestamptz)
+  AND (sqlc.narg('created_before')::timestamptz IS NULL OR created_at <= sqlc.narg('created_before')::timestamptz);
+
+-- name: SearchEmails :many
+SELECT uuid, to_email, subject, type, status, attempts, max_attempts, error_msg, sent_at, created_at
+FROM emails
+WHERE (sqlc.narg('to_email')::text IS NULL OR to_email ILIKE '%' || sqlc.narg('to_email')::text || '%')
+  AND (sqlc.narg('status')::text IS NULL OR status = sqlc.narg('status')::text)
+  AND (sqlc.narg('type')::text IS NULL OR type = sqlc.narg('type')::text)
+  AND (sqlc.narg('created_after')::timestamptz IS NULL OR created_at >= sqlc.narg('created_after')::timestamptz)
+  AND (sqlc.narg('created_before')::timestamptz IS NULL OR created_at <= sqlc.narg('created_before')::timestamptz)
+ORDER BY created_at DESC, uuid DESC
+LIMIT sqlc.arg('limit')::int
+    OFFSET sqlc.arg('offset')::int;
@@ -0,0 +1,9 @@
+-- name: CreateEmailAttachment :exec
+INSERT INTO email_attachments (email_uuid, filename, content_type, content)
+VALUES ($1, $2, $3, $4);
+
+-- name: ListEmailAttachments :many
+SELECT *
+FROM email_attachments
+WHERE email_uuid = $1
+ORDER BY id ASC;
\ No newline at end of file
@@ -0,0 +1,16 @@
+-- name: CreateEmailVerificationToken :exec
+INSERT INTO email_verification_tokens (token_hash, user_uuid, expires_at)
+VALUES ($1, $2, $3);
+
+-- name: ConsumeEmailVerificationToken :one
+UPDATE email_verification_tokens
+SET used_at = NOW()
+WHERE token_hash = $1
+  AND used_at IS NULL
+  AND expires_at > NOW()
+RETURNING user_uuid;
+
+-- name: GetEmailVerificationTokenUser :one
+SELECT user_uuid
+FROM email_verification_tokens
+WHERE token_hash = $1;
@@ -0,0 +1,20 @@
+-- name: CreateIdempotencyKey :execrows
+-- Replaces the key only if the previous one already expired
+INSERT INTO idempotency_keys (idempotency_key, user_uuid, expires_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (idempotency_key) DO UPDATE
+SET user_uuid  = EXCLUDED.user_uuid,
+    expires_at = EXCLUDED.expires_at,
+    created_at = NOW()
+WHERE idempotency_keys.expires_at <= NOW();
+
+-- name: GetIdempotencyKey :one
+SELECT *
+FROM idempotency_keys
+WHERE idempotency_key = $1
+  AND expires_at > NOW();
+
+-- name: DeleteExpiredIdempotencyKeys :execrows
+DELETE
+FROM idempotency_keys
+WHERE expires_at <= NOW();
\ No newline at end of file
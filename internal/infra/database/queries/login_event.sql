@@ -0,0 +1,10 @@
+-- name: CreateLoginEvent :exec
+INSERT INTO login_events (user_uuid, email, ip_address, user_agent, success)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: ListLoginEventsByUser :many
+SELECT *
+FROM login_events
+WHERE user_uuid = $1
+ORDER BY created_at DESC, id DESC
+LIMIT $2;
\ No newline at end of file
@@ -0,0 +1,12 @@
+-- name: RevokeToken :exec
+INSERT INTO revoked_tokens (token_uuid, user_uuid, expires_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (token_uuid) DO NOTHING;
+
+-- name: IsTokenRevoked :one
+SELECT EXISTS(SELECT 1 FROM revoked_tokens WHERE token_uuid = $1);
+
+-- name: DeleteExpiredRevokedTokens :execrows
+DELETE
+FROM revoked_tokens
+WHERE expires_at < NOW();
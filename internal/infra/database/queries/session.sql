@@ -12,4 +12,24 @@ RETURNING *;
 -- name: GetSessionByID :one
 SELECT *
 FROM user_sessions
-WHERE uuid = $1;
\ No newline at end of file
+WHERE uuid = $1;
+-- name: ListActiveSessionsByUser :many
+SELECT *
+FROM user_sessions
+WHERE user_uuid = $1
+  AND is_blocked = false
+  AND expires_at > NOW()
+ORDER BY created_at DESC;
+
+-- name: RevokeSession :one
+UPDATE user_sessions
+SET is_blocked = true
+WHERE uuid = $1
+  AND user_uuid = $2
+RETURNING *;
+
+-- name: RevokeUserSessions :exec
+UPDATE user_sessions
+SET is_blocked = true
+WHERE user_uuid = $1
+  AND is_blocked = false;
@@ -6,12 +6,20 @@ RETURNING *;
 -- name: GetUserByID :one
 SELECT *
 FROM users
-WHERE users.uuid = $1;
+WHERE users.uuid = $1
+  AND deleted_at IS NULL;
+
+-- name: GetUsersByIDs :many
+SELECT *
+FROM users
+WHERE uuid = ANY(sqlc.arg('ids')::uuid[])
+  AND deleted_at IS NULL;
 
 -- name: GetUserByEmail :one
 SELECT *
 FROM users
-WHERE email = $1;
+WHERE LOWER(email) = LOWER($1)
+  AND deleted_at IS NULL;
 
 -- name: GetUserPasswordByID :one
 SELECT password
@@ -24,19 +32,50 @@ FROM users
 WHERE uuid = $1
 RETURNING *;
 
--- name: UpdateUserByUUID :exec
+-- name: SoftDeleteUser :execrows
+UPDATE users
+SET deleted_at = NOW(),
+    updated_at = NOW()
+WHERE uuid = $1
+  AND deleted_at IS NULL;
+
+-- name: SoftDeleteUsers :many
+UPDATE users
+SET deleted_at = NOW(),
+    updated_at = NOW()
+WHERE uuid = ANY(sqlc.arg('ids')::uuid[])
+  AND deleted_at IS NULL
+RETURNING uuid;
+
+-- name: UpdateUserByUUID :execrows
 UPDATE users
 SET
     name   = COALESCE(sqlc.narg('name'), name),
     email = COALESCE(sqlc.narg('email'), email),
+    version = version + 1,
     updated_at = NOW()
-WHERE uuid = $1;
+WHERE uuid = $1
+  AND version = sqlc.arg('version');
 
 -- name: EmailExists :one
-SELECT EXISTS(SELECT 1 FROM users WHERE email = $1);
+SELECT EXISTS(SELECT 1 FROM users WHERE LOWER(email) = LOWER($1) AND deleted_at IS NULL);
+
+-- name: CountUsers :one
+SELECT COUNT(*)
+FROM users
+WHERE
+    CASE
+        WHEN sqlc.narg('search')::text IS NOT NULL THEN
+            (name ILIKE '%' || sqlc.narg('search')::text || '%' OR
+             email ILIKE '%' || sqlc.narg('search')::text || '%')
+        ELSE TRUE
+        END
+  AND (sqlc.arg('include_deleted')::bool OR deleted_at IS NULL)
+  AND (sqlc.narg('created_after')::timestamp IS NULL OR created_at >= sqlc.narg('created_after')::timestamp)
+  AND (sqlc.narg('created_before')::timestamp IS NULL OR created_at <= sqlc.narg('created_before')::timestamp);
 
 -- name: ListUsers :many
-SELECT uuid, name, email, created_at, updated_at
+SELECT uuid, name, email, role, status, created_at, updated_at, deleted_at, last_login_at
 FROM users
 WHERE
     CASE
@@ -45,6 +84,80 @@ WHERE
              email ILIKE '%' || sqlc.narg('search')::text || '%')
         ELSE TRUE
         END
-ORDER BY created_at DESC
+  AND (sqlc.arg('include_deleted')::bool OR deleted_at IS NULL)
+  AND (sqlc.narg('created_after')::timestamp IS NULL OR created_at >= sqlc.narg('created_after')::timestamp)
+  AND (sqlc.narg('created_before')::timestamp IS NULL OR created_at <= sqlc.narg('created_before')::timestamp)
+ORDER BY
+    CASE WHEN sqlc.arg('sort_by')::text = 'name' AND sqlc.arg('sort_order')::text = 'asc' THEN name END ASC,
+    CASE WHEN sqlc.arg('sort_by')::text = 'name' AND sqlc.arg('sort_order')::text = 'desc' THEN name END DESC,
+    CASE WHEN sqlc.arg('sort_by')::text = 'email' AND sqlc.arg('sort_order')::text = 'asc' THEN email END ASC,
+    CASE WHEN sqlc.arg('sort_by')::text = 'email' AND sqlc.arg('sort_order')::text = 'desc' THEN email END DESC,
+    CASE WHEN sqlc.arg('sort_by')::text = 'created_at' AND sqlc.arg('sort_order')::text = 'asc' THEN created_at END ASC,
+    created_at DESC,
+    uuid DESC
 LIMIT sqlc.narg('limit')::int
-    OFFSET sqlc.narg('offset')::int;
\ No newline at end of file
+    OFFSET sqlc.narg('offset')::int;
+
+-- name: ListUsersAfterCursor :many
+SELECT uuid, name, email, role, status, created_at, updated_at, deleted_at, last_login_at
+FROM users
+WHERE
+    CASE
+        WHEN sqlc.narg('search')::text IS NOT NULL THEN
+            (name ILIKE '%' || sqlc.narg('search')::text || '%' OR
+             email ILIKE '%' || sqlc.narg('search')::text || '%')
+        ELSE TRUE
+        END
+  AND (sqlc.arg('include_deleted')::bool OR deleted_at IS NULL)
+  AND (sqlc.narg('created_after')::timestamp IS NULL OR created_at >= sqlc.narg('created_after')::timestamp)
+  AND (sqlc.narg('created_before')::timestamp IS NULL OR created_at <= sqlc.narg('created_before')::timestamp)
+  AND (created_at, uuid) < (sqlc.arg('cursor_created_at')::timestamp, sqlc.arg('cursor_uuid')::uuid)
+ORDER BY created_at DESC, uuid DESC
+LIMIT sqlc.arg('limit')::int;
+
+-- name: UpdateUserPassword :exec
+UPDATE users
+SET password   = $2,
+    updated_at = NOW()
+WHERE uuid = $1;
+
+-- name: MarkUserEmailVerified :exec
+UPDATE users
+SET verified_at = COALESCE(verified_at, NOW()),
+    updated_at  = NOW()
+WHERE uuid = $1;
+
+-- name: UpdateUserRole :exec
+UPDATE users
+SET role       = $2,
+    updated_at = NOW()
+WHERE uuid = $1;
+
+-- name: UpdateUserStatus :execrows
+UPDATE users
+SET status     = $2,
+    updated_at = NOW()
+WHERE uuid = $1
+  AND deleted_at IS NULL;
+
+-- name: IncrementUserTokenVersion :one
+UPDATE users
+SET token_version = token_version + 1,
+    updated_at    = NOW()
+WHERE uuid = $1
+  AND deleted_at IS NULL
+RETURNING token_version;
+
+-- name: UpdateUserLastLogin :exec
+UPDATE users
+SET last_login_at = $2
+WHERE uuid = $1
+  AND (last_login_at IS NULL OR last_login_at < $2);
+
+-- name: GetUserStats :one
+SELECT COUNT(*)                                                          AS total,
+       COUNT(*) FILTER (WHERE created_at >= NOW() - INTERVAL '24 hours') AS created_last_24h,
+       COUNT(*) FILTER (WHERE created_at >= NOW() - INTERVAL '7 days')   AS created_last_7d,
+       COUNT(*) FILTER (WHERE created_at >= NOW() - INTERVAL '30 days')  AS created_last_30d
+FROM users
+WHERE deleted_at IS NULL;
\ No newline at end of file
@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/moura95/backend-challenge/internal/domain/email"
+	"github.com/moura95/backend-challenge/internal/infra/config"
+	"github.com/moura95/backend-challenge/internal/infra/email/smtp"
+)
+
+// ErrUnknownProvider is returned for an EMAIL_PROVIDER value with no
+// implementation.
+var ErrUnknownProvider = errors.New("unknown email provider")
+
+// NewEmailService devolve a implementação de email.EmailService escolhida
+// por EMAIL_PROVIDER: SMTP em produção (ou Mailhog em staging), arquivos
+// .eml ou apenas log em CI.
+func NewEmailService(cfg config.Config, logger *zap.SugaredLogger) (email.EmailService, error) {
+	switch provider := cfg.ResolvedEmailProvider(); provider {
+	case config.EmailProviderSMTP:
+		return smtp.NewSMTPServiceFromConfig(cfg), nil
+	case config.EmailProviderFile:
+		return smtp.NewFileServiceFromConfig(cfg), nil
+	case config.EmailProviderLog:
+		return NewLogService(logger), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownProvider, provider)
+	}
+}
+
+// LogService não entrega nada: só registra no log cada email que seria
+// enviado (EMAIL_PROVIDER=log). O corpo fica de fora, pois pode conter links
+// com tokens.
+type LogService struct {
+	logger *zap.SugaredLogger
+}
+
+func NewLogService(logger *zap.SugaredLogger) *LogService {
+	if logger == nil {
+		logger = zap.NewNop().Sugar()
+	}
+	return &LogService{logger: logger}
+}
+
+func (s *LogService) SendEmail(ctx context.Context, emailEntity *email.Email) error {
+	s.logger.Infow("Email not sent (log provider)",
+		"email_id", emailEntity.ID,
+		"type", emailEntity.Type,
+		"to", emailEntity.To,
+		"subject", emailEntity.Subject,
+	)
+	return nil
+}
+
+func (s *LogService) SendEmailDev(ctx context.Context, emailEntity *email.Email) error {
+	return s.SendEmail(ctx, emailEntity)
+}
+
+func (s *LogService) SendEmailAuto(ctx context.Context, emailEntity *email.Email) error {
+	return s.SendEmail(ctx, emailEntity)
+}
@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/moura95/backend-challenge/internal/domain/email"
+	"github.com/moura95/backend-challenge/internal/infra/config"
+	"github.com/moura95/backend-challenge/internal/infra/email/smtp"
+)
+
+func TestNewEmailService(t *testing.T) {
+	testCases := []struct {
+		name     string
+		cfg      config.Config
+		expected email.EmailService
+	}{
+		{"smtp", config.Config{EmailProvider: config.EmailProviderSMTP}, &smtp.SMTPService{}},
+		{"file", config.Config{EmailProvider: config.EmailProviderFile}, &smtp.FileService{}},
+		{"log", config.Config{EmailProvider: config.EmailProviderLog}, &LogService{}},
+		{"empty defaults to smtp", config.Config{}, &smtp.SMTPService{}},
+		{"empty in dev mode defaults to file", config.Config{EmailDevMode: true}, &smtp.FileService{}},
+		{"explicit provider wins over dev mode", config.Config{EmailProvider: config.EmailProviderSMTP, EmailDevMode: true}, &smtp.SMTPService{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service, err := NewEmailService(tc.cfg, zap.NewNop().Sugar())
+
+			require.NoError(t, err)
+			assert.IsType(t, tc.expected, service)
+		})
+	}
+
+	t.Run("unknown provider", func(t *testing.T) {
+		service, err := NewEmailService(config.Config{EmailProvider: "mailgun"}, zap.NewNop().Sugar())
+
+		require.ErrorIs(t, err, ErrUnknownProvider)
+		assert.Contains(t, err.Error(), `"mailgun"`)
+		assert.Nil(t, service)
+	})
+}
+
+func TestLogService_SendEmail(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	service := NewLogService(zap.New(core).Sugar())
+
+	emailEntity := &email.Email{
+		ID:      uuid.New(),
+		To:      "john@example.com",
+		Subject: "Welcome",
+		Body:    "<p>reset token: secret</p>",
+		Type:    email.EmailTypeWelcome,
+	}
+
+	require.NoError(t, service.SendEmailAuto(context.Background(), emailEntity))
+
+	entries := logs.All()
+	require.Len(t, entries, 1)
+	fields := entries[0].ContextMap()
+	assert.Equal(t, "john@example.com", fields["to"])
+	assert.Equal(t, "Welcome", fields["subject"])
+	assert.NotContains(t, entries[0].Message, "secret")
+	assert.NotContains(t, fields, "body")
+}
@@ -0,0 +1,36 @@
+package smtp
+
+import (
+	"context"
+
+	"github.com/moura95/backend-challenge/internal/domain/email"
+	"github.com/moura95/backend-challenge/internal/infra/config"
+)
+
+// FileService grava cada email como arquivo .eml em DevOutputDir em vez de
+// enviá-lo (EMAIL_PROVIDER=file). O arquivo tem exatamente a mensagem que
+// iria pelo SMTP.
+type FileService struct {
+	writer *SMTPService
+}
+
+func NewFileService(config email.SMTPConfig) *FileService {
+	return &FileService{writer: NewSMTPService(config)}
+}
+
+// NewFileServiceFromConfig monta o serviço com SMTP_FROM* e EMAIL_DEV_DIR.
+func NewFileServiceFromConfig(cfg config.Config) *FileService {
+	return NewFileService(smtpConfigFrom(cfg))
+}
+
+func (s *FileService) SendEmail(ctx context.Context, emailEntity *email.Email) error {
+	return s.writer.SendEmailDev(ctx, emailEntity)
+}
+
+func (s *FileService) SendEmailDev(ctx context.Context, emailEntity *email.Email) error {
+	return s.writer.SendEmailDev(ctx, emailEntity)
+}
+
+func (s *FileService) SendEmailAuto(ctx context.Context, emailEntity *email.Email) error {
+	return s.writer.SendEmailDev(ctx, emailEntity)
+}
@@ -0,0 +1,142 @@
+package smtp
+
+import (
+	"context"
+	"net"
+	"net/smtp"
+	"sync"
+	"time"
+)
+
+// maxIdleTime descarta conexões paradas há mais tempo que isso: servidores
+// SMTP costumam derrubar sessões ociosas em cerca de um minuto.
+const maxIdleTime = 30 * time.Second
+
+// pooledConn é uma sessão SMTP já autenticada.
+type pooledConn struct {
+	// Conexão TCP crua: os prazos são aplicados nela mesmo após STARTTLS
+	conn     net.Conn
+	client   *smtp.Client
+	lastUsed time.Time
+	stop     func() bool
+}
+
+// bind aplica o prazo de ctx à conexão; cancelar ctx interrompe qualquer
+// leitura/escrita pendente.
+func (pc *pooledConn) bind(ctx context.Context) {
+	deadline, _ := ctx.Deadline()
+	pc.conn.SetDeadline(deadline)
+
+	conn := pc.conn
+	pc.stop = context.AfterFunc(ctx, func() {
+		conn.SetDeadline(time.Now())
+	})
+}
+
+// unbind solta a conexão do contexto do envio. Retorna false se o
+// cancelamento já foi disparado e a conexão não pode mais ser usada.
+func (pc *pooledConn) unbind() bool {
+	if pc.stop != nil && !pc.stop() {
+		return false
+	}
+	pc.stop = nil
+	pc.conn.SetDeadline(time.Time{})
+	return true
+}
+
+func (pc *pooledConn) close() {
+	pc.unbind()
+	if pc.client != nil {
+		pc.client.Close()
+		return
+	}
+	pc.conn.Close()
+}
+
+// quit encerra a sessão educadamente, fechando a conexão mesmo se o QUIT falhar.
+func (pc *pooledConn) quit() {
+	if err := pc.client.Quit(); err != nil {
+		pc.client.Close()
+	}
+}
+
+// connPool limita as conexões abertas ao mesmo tempo e guarda as ociosas
+// para o próximo envio.
+type connPool struct {
+	// Uma vaga por conexão: ocupada enquanto em uso ou sendo aberta
+	slots chan struct{}
+
+	mu     sync.Mutex
+	idle   []*pooledConn
+	closed bool
+}
+
+func newConnPool(size int) *connPool {
+	return &connPool{slots: make(chan struct{}, size)}
+}
+
+// acquire espera uma vaga livre até ctx terminar.
+func (p *connPool) acquire(ctx context.Context) error {
+	select {
+	case p.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (p *connPool) release() {
+	<-p.slots
+}
+
+// takeIdle devolve a conexão ociosa usada mais recentemente, fechando as
+// que passaram de maxIdleTime; nil quando não há nenhuma.
+func (p *connPool) takeIdle() *pooledConn {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for len(p.idle) > 0 {
+		pc := p.idle[len(p.idle)-1]
+		p.idle = p.idle[:len(p.idle)-1]
+		if time.Since(pc.lastUsed) <= maxIdleTime {
+			return pc
+		}
+		pc.close()
+	}
+	return nil
+}
+
+// put devolve a conexão ao pool e libera sua vaga; conexões com erro, ou
+// devolvidas depois de close, são fechadas.
+func (p *connPool) put(pc *pooledConn, healthy bool) {
+	defer p.release()
+
+	if !healthy || !pc.unbind() {
+		pc.close()
+		return
+	}
+
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		pc.quit()
+		return
+	}
+	pc.lastUsed = time.Now()
+	p.idle = append(p.idle, pc)
+	p.mu.Unlock()
+}
+
+// close encerra as conexões ociosas com QUIT.
+func (p *connPool) close() {
+	p.mu.Lock()
+	idle := p.idle
+	p.idle = nil
+	p.closed = true
+	p.mu.Unlock()
+
+	for _, pc := range idle {
+		pc.conn.SetDeadline(time.Now().Add(DefaultDialTimeout))
+		pc.quit()
+	}
+}
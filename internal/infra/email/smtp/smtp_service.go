@@ -1,125 +1,470 @@
 package smtp
 
 import (
+	"bytes"
 	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
 	"net/smtp"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/moura95/backend-challenge/internal/domain/email"
+	"github.com/moura95/backend-challenge/internal/infra/config"
 )
 
+const (
+	// Porta SMTPS: TLS implícito desde a conexão
+	implicitTLSPort = 465
+	// Porta de submissão: exige STARTTLS antes de autenticar
+	startTLSPort = 587
+)
+
+// DefaultDevOutputDir recebe os arquivos .eml quando DevOutputDir está vazio
+const DefaultDevOutputDir = "tmp/emails"
+
+// Timeouts usados quando a configuração não define os seus
+const (
+	DefaultDialTimeout = 10 * time.Second
+	DefaultSendTimeout = 30 * time.Second
+)
+
+// ErrTimeout indica que a conexão ou o envio excedeu o timeout configurado.
+var ErrTimeout = errors.New("smtp: timeout")
+
 type SMTPService struct {
-	config email.SMTPConfig
+	config    email.SMTPConfig
+	tlsConfig *tls.Config
+	// nil quando MaxConnections é zero: uma conexão por envio
+	pool *connPool
 }
 
 func NewSMTPService(config email.SMTPConfig) *SMTPService {
-	return &SMTPService{
-		config: config,
+	if config.DialTimeout <= 0 {
+		config.DialTimeout = DefaultDialTimeout
+	}
+	if config.SendTimeout <= 0 {
+		config.SendTimeout = DefaultSendTimeout
+	}
+
+	service := &SMTPService{
+		config:    config,
+		tlsConfig: &tls.Config{ServerName: config.Host},
 	}
+	if config.MaxConnections > 0 {
+		service.pool = newConnPool(config.MaxConnections)
+	}
+	return service
+}
+
+// NewSMTPServiceFromConfig monta o serviço com as variáveis SMTP_*. A escolha
+// entre SMTP e arquivos fica com EMAIL_PROVIDER, então o modo dev não é ligado
+// aqui.
+func NewSMTPServiceFromConfig(cfg config.Config) *SMTPService {
+	return NewSMTPService(smtpConfigFrom(cfg))
 }
 
+func smtpConfigFrom(cfg config.Config) email.SMTPConfig {
+	return email.SMTPConfig{
+		Host:     cfg.SMTPHost,
+		Port:     cfg.SMTPPort,
+		Username: cfg.SMTPUsername,
+		Password: cfg.SMTPPassword,
+		From:     cfg.SMTPFrom,
+		FromName: cfg.SMTPFromName,
+		ReplyTo:  cfg.SMTPReplyTo,
+
+		DialTimeout: cfg.SMTPDialTimeout,
+		SendTimeout: cfg.SMTPSendTimeout,
+
+		MaxConnections: cfg.SMTPMaxConnections,
+
+		DevOutputDir: cfg.EmailDevDir,
+	}
+}
+
+// SendEmail envia o email negociando TLS pela porta configurada e
+// autenticando com PLAIN apenas quando há credenciais. Conexão e envio são
+// limitados por DialTimeout e SendTimeout; estourar qualquer um deles
+// retorna um erro que satisfaz errors.Is(err, ErrTimeout).
 func (s *SMTPService) SendEmail(ctx context.Context, emailEntity *email.Email) error {
-	// Preparar dados do email
-	auth := smtp.PlainAuth("", s.config.Username, s.config.Password, s.config.Host)
+	ctx, cancel := context.WithTimeout(ctx, s.config.SendTimeout)
+	defer cancel()
 
-	// Construir headers
-	headers := make(map[string]string)
-	headers["From"] = s.config.From
-	headers["To"] = emailEntity.To
-	headers["Subject"] = emailEntity.Subject
-	headers["MIME-Version"] = "1.0"
-	headers["Content-Type"] = "text/html; charset=\"utf-8\""
-
-	// Construir mensagem
-	message := ""
-	for k, v := range headers {
-		message += fmt.Sprintf("%s: %s\r\n", k, v)
-	}
-	message += "\r\n" + emailEntity.Body
-
-	// Endereço do servidor
-	addr := fmt.Sprintf("%s:%d", s.config.Host, s.config.Port)
-
-	// Enviar email
-	err := smtp.SendMail(
-		addr,
-		auth,
-		s.config.From,
-		[]string{emailEntity.To},
-		[]byte(message),
-	)
+	err := s.send(ctx, emailEntity)
+	// Cancelamento pelo chamador não é timeout
+	if err != nil && !errors.Is(ctx.Err(), context.Canceled) && isTimeout(err) {
+		return fmt.Errorf("%w: %w", ErrTimeout, err)
+	}
+	return err
+}
+
+func (s *SMTPService) send(ctx context.Context, emailEntity *email.Email) error {
+	pc, err := s.getConn(ctx)
+	if err != nil {
+		return err
+	}
 
+	err = s.deliver(pc.client, emailEntity)
+	if s.pool == nil {
+		// Sem pool, cada envio encerra a própria conexão
+		if err == nil {
+			err = pc.client.Quit()
+		}
+		pc.close()
+	} else {
+		// Conexões com erro são descartadas, as demais voltam ao pool
+		s.pool.put(pc, err == nil)
+	}
 	if err != nil {
-		return fmt.Errorf("smtp: failed to send email: %w", err)
+		return fmt.Errorf("smtp: %w", err)
 	}
 
 	fmt.Printf("Email sent successfully to %s\n", emailEntity.To)
 	return nil
 }
 
+// getConn devolve uma conexão autenticada: sem pool, sempre nova; com pool,
+// reusa uma ociosa que responda ao NOOP ou abre outra se houver vaga.
+func (s *SMTPService) getConn(ctx context.Context) (*pooledConn, error) {
+	if s.pool == nil {
+		return s.connect(ctx)
+	}
+
+	if err := s.pool.acquire(ctx); err != nil {
+		return nil, fmt.Errorf("smtp: failed to connect: %w", err)
+	}
+
+	for pc := s.pool.takeIdle(); pc != nil; pc = s.pool.takeIdle() {
+		pc.bind(ctx)
+		if err := pc.client.Noop(); err == nil {
+			return pc, nil
+		}
+		pc.close()
+	}
+
+	pc, err := s.connect(ctx)
+	if err != nil {
+		s.pool.release()
+		return nil, err
+	}
+	return pc, nil
+}
+
+// connect abre a conexão, negocia TLS e autentica.
+func (s *SMTPService) connect(ctx context.Context) (*pooledConn, error) {
+	pc, err := s.dial(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("smtp: failed to connect: %w", err)
+	}
+	client := pc.client
+
+	// STARTTLS obrigatório na 587, oportunista nas demais portas sem TLS implícito
+	if s.config.Port != implicitTLSPort {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err = client.StartTLS(s.tlsConfig); err != nil {
+				pc.close()
+				return nil, fmt.Errorf("smtp: failed to start tls: %w", err)
+			}
+		} else if s.config.Port == startTLSPort {
+			pc.close()
+			return nil, fmt.Errorf("smtp: server does not support STARTTLS")
+		}
+	}
+
+	// Autenticar somente se houver credenciais
+	if s.hasCredentials() {
+		if ok, _ := client.Extension("AUTH"); !ok {
+			pc.close()
+			return nil, fmt.Errorf("smtp: server does not support AUTH")
+		}
+		auth := smtp.PlainAuth("", s.config.Username, s.config.Password, s.config.Host)
+		if err = client.Auth(auth); err != nil {
+			pc.close()
+			return nil, fmt.Errorf("smtp: failed to authenticate: %w", err)
+		}
+	}
+
+	return pc, nil
+}
+
+// Close encerra as conexões ociosas do pool; as que estiverem em uso são
+// fechadas ao terminar o envio. Sem pool não faz nada.
+func (s *SMTPService) Close() error {
+	if s.pool == nil {
+		return nil
+	}
+	s.pool.close()
+	return nil
+}
+
+// SendEmailDev grava a mensagem (headers + corpo) como arquivo .eml em
+// DevOutputDir, sem contatar o servidor SMTP.
 func (s *SMTPService) SendEmailDev(ctx context.Context, emailEntity *email.Email) error {
+	message, err := s.buildMessage(emailEntity)
+	if err != nil {
+		return fmt.Errorf("smtp dev: failed to build message: %w", err)
+	}
 
-	// Construir headers
-	headers := make(map[string]string)
-	headers["From"] = s.config.From
-	headers["To"] = emailEntity.To
-	headers["Subject"] = emailEntity.Subject
-	headers["MIME-Version"] = "1.0"
-	headers["Content-Type"] = "text/html; charset=\"utf-8\""
+	dir := s.config.DevOutputDir
+	if dir == "" {
+		dir = DefaultDevOutputDir
+	}
+	if err = os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("smtp dev: failed to create output dir: %w", err)
+	}
+
+	// Timestamp primeiro para os arquivos ficarem em ordem de envio
+	name := fmt.Sprintf("%s_%s.eml", time.Now().UTC().Format("20060102T150405.000000000"), emailEntity.ID)
+	path := filepath.Join(dir, name)
+	if err = os.WriteFile(path, message, 0o644); err != nil {
+		return fmt.Errorf("smtp dev: failed to write message: %w", err)
+	}
 
-	// Construir mensagem
-	message := ""
-	for k, v := range headers {
-		message += fmt.Sprintf("%s: %s\r\n", k, v)
+	fmt.Printf("Email to %s written to %s (dev mode)\n", emailEntity.To, path)
+	return nil
+}
+
+// SendEmailAuto grava em disco no modo dev e envia por SMTP caso contrário.
+func (s *SMTPService) SendEmailAuto(ctx context.Context, emailEntity *email.Email) error {
+	if s.config.DevMode {
+		return s.SendEmailDev(ctx, emailEntity)
 	}
-	message += "\r\n" + emailEntity.Body
 
-	// Endereço do servidor
-	addr := fmt.Sprintf("%s:%d", s.config.Host, s.config.Port)
+	return s.SendEmail(ctx, emailEntity)
+}
 
-	// Conectar sem autenticação
-	client, err := smtp.Dial(addr)
+func (s *SMTPService) hasCredentials() bool {
+	return s.config.Username != "" || s.config.Password != ""
+}
+
+func (s *SMTPService) addr() string {
+	return net.JoinHostPort(s.config.Host, fmt.Sprintf("%d", s.config.Port))
+}
+
+// dial conecta respeitando DialTimeout e liga a conexão ao prazo de ctx,
+// para que um servidor que não responde não bloqueie o envio.
+func (s *SMTPService) dial(ctx context.Context) (*pooledConn, error) {
+	dialer := &net.Dialer{Timeout: s.config.DialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", s.addr())
+	if err != nil {
+		return nil, err
+	}
+
+	pc := &pooledConn{conn: conn}
+	pc.bind(ctx)
+
+	if s.config.Port == implicitTLSPort {
+		tlsConn := tls.Client(conn, s.tlsConfig)
+		if err = tlsConn.HandshakeContext(ctx); err != nil {
+			pc.unbind()
+			conn.Close()
+			return nil, err
+		}
+		conn = tlsConn
+	}
+
+	pc.client, err = smtp.NewClient(conn, s.config.Host)
 	if err != nil {
-		return fmt.Errorf("smtp dev: failed to connect: %w", err)
+		pc.unbind()
+		conn.Close()
+		return nil, err
+	}
+	return pc, nil
+}
+
+// isTimeout reconhece prazos estourados tanto do contexto quanto da rede.
+func isTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
 	}
-	defer client.Close()
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
 
+func (s *SMTPService) deliver(client *smtp.Client, emailEntity *email.Email) error {
 	// Configurar remetente
-	if err = client.Mail(s.config.From); err != nil {
-		return fmt.Errorf("smtp dev: failed to set sender: %w", err)
+	if err := client.Mail(s.config.From); err != nil {
+		return fmt.Errorf("failed to set sender: %w", err)
+	}
+
+	// Configurar destinatários do envelope: To, Cc e Bcc (este fica fora dos headers)
+	for _, recipient := range emailEntity.Recipients() {
+		if err := client.Rcpt(recipient); err != nil {
+			return fmt.Errorf("failed to set recipient %s: %w", recipient, err)
+		}
 	}
 
-	// Configurar destinatário
-	if err = client.Rcpt(emailEntity.To); err != nil {
-		return fmt.Errorf("smtp dev: failed to set recipient: %w", err)
+	message, err := s.buildMessage(emailEntity)
+	if err != nil {
+		return fmt.Errorf("failed to build message: %w", err)
 	}
 
 	// Enviar dados
 	w, err := client.Data()
 	if err != nil {
-		return fmt.Errorf("smtp dev: failed to get data writer: %w", err)
+		return fmt.Errorf("failed to get data writer: %w", err)
 	}
 
-	_, err = w.Write([]byte(message))
+	if _, err = w.Write(message); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+
+	if err = w.Close(); err != nil {
+		return fmt.Errorf("failed to close writer: %w", err)
+	}
+
+	return nil
+}
+
+func (s *SMTPService) buildMessage(emailEntity *email.Email) ([]byte, error) {
+	// Construir headers
+	type header struct{ key, value string }
+	headers := []header{
+		{"From", s.fromHeader()},
+		{"To", emailEntity.To},
+	}
+	// Bcc nunca vai nos headers, só no envelope
+	if len(emailEntity.Cc) > 0 {
+		headers = append(headers, header{"Cc", strings.Join(emailEntity.Cc, ", ")})
+	}
+	if s.config.ReplyTo != "" {
+		headers = append(headers, header{"Reply-To", s.config.ReplyTo})
+	}
+	headers = append(headers,
+		header{"Subject", emailEntity.Subject},
+		header{"MIME-Version", "1.0"},
+	)
+
+	var buf bytes.Buffer
+	for _, h := range headers {
+		fmt.Fprintf(&buf, "%s: %s\r\n", h.key, h.value)
+	}
+
+	bodyType, body, err := buildBody(emailEntity)
 	if err != nil {
-		return fmt.Errorf("smtp dev: failed to write message: %w", err)
+		return nil, err
 	}
 
-	err = w.Close()
+	// Sem anexos o corpo é a própria mensagem
+	if len(emailEntity.Attachments) == 0 {
+		fmt.Fprintf(&buf, "Content-Type: %s\r\n\r\n", bodyType)
+		buf.Write(body)
+		return buf.Bytes(), nil
+	}
+
+	// multipart/mixed: corpo primeiro, depois um part por anexo
+	mw := multipart.NewWriter(&buf)
+	fmt.Fprintf(&buf, "Content-Type: multipart/mixed; boundary=%q\r\n\r\n", mw.Boundary())
+
+	w, err := mw.CreatePart(textproto.MIMEHeader{"Content-Type": {bodyType}})
 	if err != nil {
-		return fmt.Errorf("smtp dev: failed to close writer: %w", err)
+		return nil, err
+	}
+	if _, err = w.Write(body); err != nil {
+		return nil, err
 	}
 
-	fmt.Printf("Email sent successfully to %s (dev mode)\n", emailEntity.To)
-	return nil
+	for _, attachment := range emailEntity.Attachments {
+		if err = writeAttachment(mw, attachment); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = mw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
 }
 
-func (s *SMTPService) SendEmailAuto(ctx context.Context, emailEntity *email.Email) error {
-	// Se não tem username/password, usar modo dev
-	if s.config.Username == "" && s.config.Password == "" {
-		return s.SendEmailDev(ctx, emailEntity)
+// fromHeader devolve "Nome <endereço>" quando há nome de exibição (codificado
+// em RFC 2047 se não for ASCII) e o endereço puro caso contrário.
+func (s *SMTPService) fromHeader() string {
+	if s.config.FromName == "" {
+		return s.config.From
+	}
+	return (&mail.Address{Name: s.config.FromName, Address: s.config.From}).String()
+}
+
+// buildBody monta o corpo e devolve seu Content-Type: só o HTML, ou
+// multipart/alternative quando há versão em texto puro.
+func buildBody(emailEntity *email.Email) (string, []byte, error) {
+	if emailEntity.PlainBody == "" {
+		return "text/html; charset=\"utf-8\"", []byte(emailEntity.Body), nil
 	}
 
-	// Senão usar modo com autenticação
-	return s.SendEmail(ctx, emailEntity)
+	// multipart/alternative: texto puro primeiro, HTML por último (preferido)
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+
+	parts := []struct{ contentType, body string }{
+		{"text/plain; charset=\"utf-8\"", emailEntity.PlainBody},
+		{"text/html; charset=\"utf-8\"", emailEntity.Body},
+	}
+	for _, p := range parts {
+		w, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {p.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return "", nil, err
+		}
+		qp := quotedprintable.NewWriter(w)
+		if _, err = qp.Write([]byte(p.body)); err != nil {
+			return "", nil, err
+		}
+		if err = qp.Close(); err != nil {
+			return "", nil, err
+		}
+	}
+
+	if err := mw.Close(); err != nil {
+		return "", nil, err
+	}
+
+	return fmt.Sprintf("multipart/alternative; boundary=%q", mw.Boundary()), buf.Bytes(), nil
+}
+
+// Linhas base64 de no máximo 76 caracteres (RFC 2045)
+const base64LineLength = 76
+
+// writeAttachment adiciona o anexo como part base64 com
+// Content-Disposition: attachment.
+func writeAttachment(mw *multipart.Writer, attachment email.Attachment) error {
+	contentType := attachment.ContentType
+	if contentType == "" {
+		contentType = email.DefaultAttachmentContentType
+	}
+
+	w, err := mw.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {contentType},
+		"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename})},
+		"Content-Transfer-Encoding": {"base64"},
+	})
+	if err != nil {
+		return err
+	}
+
+	encoded := base64.StdEncoding.EncodeToString(attachment.Content)
+	for len(encoded) > 0 {
+		n := min(base64LineLength, len(encoded))
+		if _, err = io.WriteString(w, encoded[:n]+"\r\n"); err != nil {
+			return err
+		}
+		encoded = encoded[n:]
+	}
+
+	return nil
 }
@@ -0,0 +1,608 @@
+package smtp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/moura95/backend-challenge/internal/domain/email"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSMTPServer speaks just enough SMTP to accept one message and
+// records the commands it received.
+type fakeSMTPServer struct {
+	listener net.Listener
+	mu       sync.Mutex
+	commands []string
+	data     string
+	done     chan struct{}
+
+	// Used by the counting server, which accepts many connections
+	connections  atomic.Int32
+	dropAfterOne bool
+}
+
+func newFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	srv := &fakeSMTPServer{listener: ln, done: make(chan struct{})}
+	go srv.serve()
+
+	t.Cleanup(func() { ln.Close() })
+	return srv
+}
+
+func (f *fakeSMTPServer) port() int {
+	return f.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (f *fakeSMTPServer) serve() {
+	defer close(f.done)
+
+	conn, err := f.listener.Accept()
+	if err != nil {
+		return
+	}
+	f.session(conn)
+}
+
+// newCountingSMTPServer accepts any number of connections and counts them.
+// With dropAfterOne, the server hangs up after each message.
+func newCountingSMTPServer(t *testing.T, dropAfterOne bool) *fakeSMTPServer {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	srv := &fakeSMTPServer{listener: ln, done: make(chan struct{}), dropAfterOne: dropAfterOne}
+	go srv.serveAll()
+
+	t.Cleanup(func() { ln.Close() })
+	return srv
+}
+
+func (f *fakeSMTPServer) serveAll() {
+	defer close(f.done)
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for {
+		conn, err := f.listener.Accept()
+		if err != nil {
+			return
+		}
+		f.connections.Add(1)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			f.session(conn)
+		}()
+	}
+}
+
+func (f *fakeSMTPServer) count(verb string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	n := 0
+	for _, c := range f.commands {
+		if strings.HasPrefix(strings.ToUpper(c), strings.ToUpper(verb)) {
+			n++
+		}
+	}
+	return n
+}
+
+func (f *fakeSMTPServer) session(conn net.Conn) {
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	write := func(line string) { conn.Write([]byte(line + "\r\n")) }
+
+	write("220 localhost fake smtp")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		f.mu.Lock()
+		f.commands = append(f.commands, line)
+		f.mu.Unlock()
+
+		verb := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		switch verb {
+		case "EHLO":
+			write("250-localhost")
+			write("250 AUTH PLAIN")
+		case "AUTH":
+			write("235 2.7.0 Authentication successful")
+		case "MAIL", "RCPT":
+			write("250 OK")
+		case "DATA":
+			write("354 End data with <CR><LF>.<CR><LF>")
+			var body strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				body.WriteString(l)
+			}
+			f.mu.Lock()
+			f.data = body.String()
+			f.mu.Unlock()
+			write("250 OK")
+			if f.dropAfterOne {
+				return
+			}
+		case "QUIT":
+			write("221 Bye")
+			return
+		default:
+			write("250 OK")
+		}
+	}
+}
+
+func (f *fakeSMTPServer) received(verb string) bool {
+	<-f.done
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, c := range f.commands {
+		if strings.HasPrefix(strings.ToUpper(c), strings.ToUpper(verb)) {
+			return true
+		}
+	}
+	return false
+}
+
+func newTestEmail() *email.Email {
+	return &email.Email{
+		To:      "john@example.com",
+		Subject: "Welcome",
+		Body:    "<p>Hello</p>",
+	}
+}
+
+func TestSMTPService_SendEmail(t *testing.T) {
+	t.Run("authenticates when credentials are set", func(t *testing.T) {
+		srv := newFakeSMTPServer(t)
+		service := NewSMTPService(email.SMTPConfig{
+			Host:     "localhost",
+			Port:     srv.port(),
+			Username: "user",
+			Password: "secret",
+			From:     "noreply@example.com",
+		})
+
+		err := service.SendEmail(context.Background(), newTestEmail())
+		require.NoError(t, err)
+
+		assert.True(t, srv.received("AUTH PLAIN"))
+		assert.Contains(t, srv.data, "Subject: Welcome")
+		assert.Contains(t, srv.data, "<p>Hello</p>")
+	})
+
+	t.Run("skips auth without credentials", func(t *testing.T) {
+		srv := newFakeSMTPServer(t)
+		service := NewSMTPService(email.SMTPConfig{
+			Host: "localhost",
+			Port: srv.port(),
+			From: "noreply@example.com",
+		})
+
+		err := service.SendEmail(context.Background(), newTestEmail())
+		require.NoError(t, err)
+
+		assert.False(t, srv.received("AUTH"))
+		assert.True(t, srv.received("MAIL FROM:<noreply@example.com>"))
+	})
+
+	t.Run("delivers to cc and bcc without exposing bcc in headers", func(t *testing.T) {
+		srv := newFakeSMTPServer(t)
+		service := NewSMTPService(email.SMTPConfig{
+			Host: "localhost",
+			Port: srv.port(),
+			From: "noreply@example.com",
+		})
+
+		emailEntity := newTestEmail()
+		emailEntity.Type = email.EmailTypeNotification
+		emailEntity.Cc = []string{"ops@example.com", "lead@example.com"}
+		emailEntity.Bcc = []string{"audit@example.com"}
+
+		err := service.SendEmail(context.Background(), emailEntity)
+		require.NoError(t, err)
+
+		for _, recipient := range []string{"john@example.com", "ops@example.com", "lead@example.com", "audit@example.com"} {
+			assert.True(t, srv.received("RCPT TO:<"+recipient+">"), recipient)
+		}
+
+		msg, err := mail.ReadMessage(strings.NewReader(srv.data))
+		require.NoError(t, err)
+		assert.Equal(t, "john@example.com", msg.Header.Get("To"))
+		assert.Equal(t, "ops@example.com, lead@example.com", msg.Header.Get("Cc"))
+		assert.Empty(t, msg.Header.Get("Bcc"))
+		assert.NotContains(t, srv.data, "audit@example.com")
+	})
+
+	t.Run("sends attachments as multipart/mixed", func(t *testing.T) {
+		srv := newFakeSMTPServer(t)
+		service := NewSMTPService(email.SMTPConfig{
+			Host: "localhost",
+			Port: srv.port(),
+			From: "noreply@example.com",
+		})
+
+		emailEntity := newTestEmail()
+		emailEntity.Type = email.EmailTypeNotification
+		emailEntity.PlainBody = "Hello John"
+		emailEntity.Attachments = []email.Attachment{
+			{Filename: "report.txt", ContentType: "text/plain", Content: []byte("monthly report\n")},
+		}
+
+		err := service.SendEmail(context.Background(), emailEntity)
+		require.NoError(t, err)
+		require.True(t, srv.received("DATA"))
+
+		assert.Contains(t, srv.data, "Content-Disposition: attachment; filename=report.txt")
+
+		msg, err := mail.ReadMessage(strings.NewReader(srv.data))
+		require.NoError(t, err)
+		mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+		require.NoError(t, err)
+		assert.Equal(t, "multipart/mixed", mediaType)
+
+		reader := multipart.NewReader(msg.Body, params["boundary"])
+
+		// Primeiro part: o corpo, ainda com as versões texto e HTML
+		body, err := reader.NextPart()
+		require.NoError(t, err)
+		bodyType, _, err := mime.ParseMediaType(body.Header.Get("Content-Type"))
+		require.NoError(t, err)
+		assert.Equal(t, "multipart/alternative", bodyType)
+
+		// Segundo part: o anexo em base64
+		attachment, err := reader.NextPart()
+		require.NoError(t, err)
+		assert.Equal(t, "report.txt", attachment.FileName())
+		assert.Equal(t, "text/plain", attachment.Header.Get("Content-Type"))
+		assert.Equal(t, "base64", attachment.Header.Get("Content-Transfer-Encoding"))
+		encoded, err := io.ReadAll(attachment)
+		require.NoError(t, err)
+		content, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(string(encoded), "\r\n", ""))
+		require.NoError(t, err)
+		assert.Equal(t, "monthly report\n", string(content))
+
+		_, err = reader.NextPart()
+		assert.Equal(t, io.EOF, err)
+	})
+}
+
+func TestSMTPService_ConnectionPool(t *testing.T) {
+	t.Run("reuses at most MaxConnections connections", func(t *testing.T) {
+		srv := newCountingSMTPServer(t, false)
+		service := NewSMTPService(email.SMTPConfig{
+			Host:           "localhost",
+			Port:           srv.port(),
+			Username:       "user",
+			Password:       "secret",
+			From:           "noreply@example.com",
+			MaxConnections: 2,
+		})
+
+		const total = 20
+		var wg sync.WaitGroup
+		errs := make(chan error, total)
+		for i := 0; i < total; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs <- service.SendEmail(context.Background(), newTestEmail())
+			}()
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			require.NoError(t, err)
+		}
+
+		connections := int(srv.connections.Load())
+		assert.LessOrEqual(t, connections, 2)
+		assert.Equal(t, total, srv.count("MAIL FROM"))
+		// Autentica uma vez por conexão, não por email
+		assert.Equal(t, connections, srv.count("AUTH"))
+
+		require.NoError(t, service.Close())
+		srv.listener.Close()
+		<-srv.done
+		assert.Equal(t, connections, srv.count("QUIT"))
+	})
+
+	t.Run("sequential sends share one connection", func(t *testing.T) {
+		srv := newCountingSMTPServer(t, false)
+		service := NewSMTPService(email.SMTPConfig{
+			Host:           "localhost",
+			Port:           srv.port(),
+			From:           "noreply@example.com",
+			MaxConnections: 4,
+		})
+		t.Cleanup(func() { service.Close() })
+
+		for i := 0; i < 5; i++ {
+			require.NoError(t, service.SendEmail(context.Background(), newTestEmail()))
+		}
+
+		assert.Equal(t, int32(1), srv.connections.Load())
+		// A conexão reusada passa pelo health check antes de cada envio
+		assert.Equal(t, 4, srv.count("NOOP"))
+	})
+
+	t.Run("replaces connections the server closed", func(t *testing.T) {
+		srv := newCountingSMTPServer(t, true)
+		service := NewSMTPService(email.SMTPConfig{
+			Host:           "localhost",
+			Port:           srv.port(),
+			From:           "noreply@example.com",
+			MaxConnections: 1,
+		})
+		t.Cleanup(func() { service.Close() })
+
+		for i := 0; i < 3; i++ {
+			require.NoError(t, service.SendEmail(context.Background(), newTestEmail()))
+		}
+
+		assert.Equal(t, int32(3), srv.connections.Load())
+		assert.Equal(t, 3, srv.count("MAIL FROM"))
+	})
+
+	t.Run("opens a connection per email without a pool", func(t *testing.T) {
+		srv := newCountingSMTPServer(t, false)
+		service := NewSMTPService(email.SMTPConfig{
+			Host: "localhost",
+			Port: srv.port(),
+			From: "noreply@example.com",
+		})
+
+		for i := 0; i < 3; i++ {
+			require.NoError(t, service.SendEmail(context.Background(), newTestEmail()))
+		}
+
+		assert.Equal(t, int32(3), srv.connections.Load())
+		assert.Equal(t, 3, srv.count("QUIT"))
+	})
+}
+
+func TestSMTPService_Timeouts(t *testing.T) {
+	t.Run("fails within the timeout on a non-listening port", func(t *testing.T) {
+		// Reservar uma porta livre e fechá-la para ninguém escutar nela
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		port := ln.Addr().(*net.TCPAddr).Port
+		ln.Close()
+
+		service := NewSMTPService(email.SMTPConfig{
+			Host:        "127.0.0.1",
+			Port:        port,
+			From:        "noreply@example.com",
+			DialTimeout: 200 * time.Millisecond,
+			SendTimeout: 500 * time.Millisecond,
+		})
+
+		start := time.Now()
+		err = service.SendEmail(context.Background(), newTestEmail())
+
+		assert.Error(t, err)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("times out when the server never answers", func(t *testing.T) {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		t.Cleanup(func() { ln.Close() })
+
+		// Aceita a conexão mas nunca envia o greeting
+		go func() {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+			io.Copy(io.Discard, conn)
+		}()
+
+		service := NewSMTPService(email.SMTPConfig{
+			Host:        "127.0.0.1",
+			Port:        ln.Addr().(*net.TCPAddr).Port,
+			From:        "noreply@example.com",
+			SendTimeout: 100 * time.Millisecond,
+		})
+
+		start := time.Now()
+		err = service.SendEmail(context.Background(), newTestEmail())
+
+		assert.ErrorIs(t, err, ErrTimeout)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("uses default timeouts when none are configured", func(t *testing.T) {
+		service := NewSMTPService(email.SMTPConfig{Host: "localhost", Port: 1025})
+
+		assert.Equal(t, DefaultDialTimeout, service.config.DialTimeout)
+		assert.Equal(t, DefaultSendTimeout, service.config.SendTimeout)
+	})
+}
+
+func TestSMTPService_BuildMessage(t *testing.T) {
+	service := NewSMTPService(email.SMTPConfig{Host: "localhost", Port: 1025, From: "noreply@example.com"})
+
+	t.Run("emits multipart/alternative with plain and html parts", func(t *testing.T) {
+		emailEntity := newTestEmail()
+		emailEntity.PlainBody = "Hello John"
+
+		raw, err := service.buildMessage(emailEntity)
+		require.NoError(t, err)
+
+		msg, err := mail.ReadMessage(bytes.NewReader(raw))
+		require.NoError(t, err)
+
+		mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+		require.NoError(t, err)
+		assert.Equal(t, "multipart/alternative", mediaType)
+
+		reader := multipart.NewReader(msg.Body, params["boundary"])
+		bodies := map[string]string{}
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+
+			partType, _, err := mime.ParseMediaType(part.Header.Get("Content-Type"))
+			require.NoError(t, err)
+			content, err := io.ReadAll(part)
+			require.NoError(t, err)
+			bodies[partType] = string(content)
+		}
+
+		assert.Equal(t, "Hello John", bodies["text/plain"])
+		assert.Equal(t, "<p>Hello</p>", bodies["text/html"])
+	})
+
+	t.Run("falls back to html only without plain body", func(t *testing.T) {
+		raw, err := service.buildMessage(newTestEmail())
+		require.NoError(t, err)
+
+		msg, err := mail.ReadMessage(bytes.NewReader(raw))
+		require.NoError(t, err)
+		assert.Equal(t, `text/html; charset="utf-8"`, msg.Header.Get("Content-Type"))
+	})
+
+	t.Run("includes display name and reply-to when configured", func(t *testing.T) {
+		service := NewSMTPService(email.SMTPConfig{
+			Host:     "localhost",
+			Port:     1025,
+			From:     "noreply@example.com",
+			FromName: "Backend Challenge",
+			ReplyTo:  "support@example.com",
+		})
+
+		raw, err := service.buildMessage(newTestEmail())
+		require.NoError(t, err)
+
+		msg, err := mail.ReadMessage(bytes.NewReader(raw))
+		require.NoError(t, err)
+		assert.Equal(t, `"Backend Challenge" <noreply@example.com>`, msg.Header.Get("From"))
+		assert.Equal(t, "support@example.com", msg.Header.Get("Reply-To"))
+
+		from, err := msg.Header.AddressList("From")
+		require.NoError(t, err)
+		require.Len(t, from, 1)
+		assert.Equal(t, "Backend Challenge", from[0].Name)
+		assert.Equal(t, "noreply@example.com", from[0].Address)
+	})
+
+	t.Run("falls back to bare from without reply-to", func(t *testing.T) {
+		raw, err := service.buildMessage(newTestEmail())
+		require.NoError(t, err)
+
+		msg, err := mail.ReadMessage(bytes.NewReader(raw))
+		require.NoError(t, err)
+		assert.Equal(t, "noreply@example.com", msg.Header.Get("From"))
+		_, hasReplyTo := msg.Header["Reply-To"]
+		assert.False(t, hasReplyTo)
+	})
+}
+
+func TestSMTPService_SendEmailDev(t *testing.T) {
+	t.Run("writes a readable .eml file without contacting smtp", func(t *testing.T) {
+		dir := t.TempDir()
+		// Unreachable port: any SMTP attempt would fail
+		service := NewSMTPService(email.SMTPConfig{
+			Host:         "localhost",
+			Port:         1,
+			From:         "noreply@example.com",
+			DevMode:      true,
+			DevOutputDir: dir,
+		})
+
+		err := service.SendEmailDev(context.Background(), newTestEmail())
+		require.NoError(t, err)
+
+		files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+
+		raw, err := os.ReadFile(files[0])
+		require.NoError(t, err)
+
+		msg, err := mail.ReadMessage(bytes.NewReader(raw))
+		require.NoError(t, err)
+		assert.Equal(t, "Welcome", msg.Header.Get("Subject"))
+		assert.Equal(t, "john@example.com", msg.Header.Get("To"))
+
+		body, err := io.ReadAll(msg.Body)
+		require.NoError(t, err)
+		assert.Contains(t, string(body), "<p>Hello</p>")
+	})
+
+	t.Run("auto uses dev mode when enabled", func(t *testing.T) {
+		dir := t.TempDir()
+		service := NewSMTPService(email.SMTPConfig{
+			Host:         "localhost",
+			Port:         1,
+			From:         "noreply@example.com",
+			DevMode:      true,
+			DevOutputDir: dir,
+		})
+
+		err := service.SendEmailAuto(context.Background(), newTestEmail())
+		require.NoError(t, err)
+
+		files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+		require.NoError(t, err)
+		assert.Len(t, files, 1)
+	})
+
+	t.Run("auto sends over smtp when dev mode is off", func(t *testing.T) {
+		srv := newFakeSMTPServer(t)
+		dir := t.TempDir()
+		service := NewSMTPService(email.SMTPConfig{
+			Host:         "localhost",
+			Port:         srv.port(),
+			From:         "noreply@example.com",
+			DevOutputDir: dir,
+		})
+
+		err := service.SendEmailAuto(context.Background(), newTestEmail())
+		require.NoError(t, err)
+
+		assert.True(t, srv.received("MAIL FROM:<noreply@example.com>"))
+		files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+		require.NoError(t, err)
+		assert.Empty(t, files)
+	})
+}
@@ -1,17 +1,28 @@
 package gin
 
 import (
-	"net/http"
+	"context"
+	"fmt"
+	"os"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/jmoiron/sqlx"
+	adminUC "github.com/moura95/backend-challenge/internal/application/usecases/admin"
 	authUC "github.com/moura95/backend-challenge/internal/application/usecases/auth"
+	emailUC "github.com/moura95/backend-challenge/internal/application/usecases/email"
 	userUC "github.com/moura95/backend-challenge/internal/application/usecases/user"
+	emailDomain "github.com/moura95/backend-challenge/internal/domain/email"
+	tokenDomain "github.com/moura95/backend-challenge/internal/domain/token"
+	userDomain "github.com/moura95/backend-challenge/internal/domain/user"
 	"github.com/moura95/backend-challenge/internal/infra/config"
+	"github.com/moura95/backend-challenge/internal/infra/email/provider"
+	"github.com/moura95/backend-challenge/internal/infra/logging"
 	"github.com/moura95/backend-challenge/internal/infra/messaging/rabbitmq"
+	"github.com/moura95/backend-challenge/internal/infra/metrics"
 	"github.com/moura95/backend-challenge/internal/infra/repository/adapters"
 	"github.com/moura95/backend-challenge/internal/infra/security/jwt"
+	"github.com/moura95/backend-challenge/internal/infra/security/ratelimit"
 	"github.com/moura95/backend-challenge/internal/interfaces/http/handlers"
 	"github.com/moura95/backend-challenge/internal/interfaces/http/middlewares"
 	swaggerfiles "github.com/swaggo/files"
@@ -28,19 +39,32 @@ type Server struct {
 	rabbit *rabbitmq.Connection
 }
 
-func NewServer(cfg config.Config, db *sqlx.DB, log *zap.SugaredLogger, rabbit *rabbitmq.Connection) *Server {
+func NewServer(cfg config.Config, db *sqlx.DB, log *zap.SugaredLogger, rabbit *rabbitmq.Connection) (*Server, error) {
 	server := &Server{
 		config: &cfg,
 		logger: log,
 		rabbit: rabbit,
 	}
 
-	router := gin.Default()
+	router := gin.New()
+	// ClientIP só usa X-Forwarded-For quando a conexão vem de um proxy confiável
+	if err := router.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		return nil, fmt.Errorf("server: invalid TRUSTED_PROXIES: %w", err)
+	}
+	router.Use(middlewares.RequestID())
+	router.Use(middlewares.Tracing())
+	router.Use(middlewares.RequestLogger(log))
+	// Depois do logger e do tracing, para que o 500 apareça no log e no span
+	router.Use(middlewares.Recovery(log))
+	handlers.RegisterFallbacks(router)
 
-	// Health check endpoint
-	router.GET("/healthz", func(c *gin.Context) {
-		c.Status(http.StatusNoContent)
-	})
+	// Health check endpoints
+	healthHandler := handlers.NewHealthHandler(db, rabbit).
+		WithEmailBacklog(adapters.NewEmailRepository(db), cfg.EmailBacklogThreshold)
+	router.GET("/healthz", healthHandler.Healthz)
+	router.GET("/readyz", healthHandler.Readyz)
+	router.GET("/version", healthHandler.Version)
+	router.GET("/metrics", handlers.NewMetricsHandler(metrics.Default).Metrics)
 
 	// 🚨 SWAGGER CONFIGURATION - URL específica para o doc.json
 	url := ginSwagger.URL("http://localhost:8080/swagger/doc.json")
@@ -52,23 +76,41 @@ func NewServer(cfg config.Config, db *sqlx.DB, log *zap.SugaredLogger, rabbit *r
 	corsConfig.AllowCredentials = true
 	corsConfig.AddAllowHeaders("Authorization")
 	corsConfig.AddAllowHeaders("Content-Type")
+	corsConfig.AddAllowHeaders(logging.RequestIDHeader)
+	corsConfig.AddAllowHeaders(handlers.IdempotencyKeyHeader)
+	corsConfig.AddAllowHeaders(handlers.ConfirmPasswordHeader)
+	corsConfig.AddExposeHeaders(logging.RequestIDHeader)
+	corsConfig.AddExposeHeaders("X-Total-Count", "X-Page", "X-Page-Size", "Link")
 	router.Use(cors.New(corsConfig))
 
 	// Setup routes
-	createRoutes(cfg, db, router, log, rabbit)
+	if err := createRoutes(cfg, db, router, log, rabbit); err != nil {
+		return nil, err
+	}
 
 	server.router = router
-	return server
+	return server, nil
 }
 
-func createRoutes(cfg config.Config, db *sqlx.DB, router *gin.Engine, log *zap.SugaredLogger, rabbit *rabbitmq.Connection) {
-	// Initialize repositories
-	repositories := adapters.NewRepositories(db)
+// newTokenMaker picks the Paseto maker matching TOKEN_TYPE.
+func newTokenMaker(cfg config.Config) (jwt.Maker, error) {
+	if cfg.TokenType == config.TokenTypePublic {
+		return jwt.NewPasetoPublicMaker(cfg.TokenPrivateKey, cfg.TokenPublicKey)
+	}
+	return jwt.NewPasetoMaker(cfg.TokenSymmetricKey)
+}
 
-	// Initialize JWT token maker
-	tokenMaker, err := jwt.NewPasetoMaker("12345678901234567890123456789012") // 32 chars for demo
+func createRoutes(cfg config.Config, db *sqlx.DB, router *gin.Engine, log *zap.SugaredLogger, rabbit *rabbitmq.Connection) error {
+	// Initialize JWT token maker (the key itself is never logged)
+	tokenMaker, err := newTokenMaker(cfg)
 	if err != nil {
-		log.Fatalf("Failed to create token maker: %v", err)
+		return fmt.Errorf("server: failed to create token maker: %w", err)
+	}
+
+	// Initialize repositories
+	repositories := adapters.NewRepositories(db)
+	if cfg.UserCacheTTL > 0 {
+		repositories.User = adapters.NewCachedUserRepository(repositories.User, cfg.UserCacheTTL, cfg.UserCacheSize)
 	}
 
 	// Initialize use cases
@@ -78,25 +120,145 @@ func createRoutes(cfg config.Config, db *sqlx.DB, router *gin.Engine, log *zap.S
 		tokenMaker,
 		rabbit,
 	)
-	signInUC := authUC.NewSignInUseCase(repositories.User, tokenMaker)
-	verifyTokenUC := authUC.NewVerifyTokenUseCase(repositories.User, tokenMaker)
+	authTx := func(ctx context.Context, fn func(userDomain.Repository, emailDomain.Repository, tokenDomain.EmailVerificationRepository, tokenDomain.IdempotencyRepository) error) error {
+		return repositories.WithTx(ctx, func(tx *adapters.Repositories) error {
+			return fn(tx.User, tx.Email, tx.EmailVerification, tx.Idempotency)
+		})
+	}
+	signUpUC.WithTransaction(authTx).WithIdempotency(repositories.Idempotency, cfg.IdempotencyKeyTTL)
+	welcomeTemplate, err := loadWelcomeTemplate(cfg)
+	if err != nil {
+		return err
+	}
+	signUpUC.WithWelcomeTemplate(welcomeTemplate).
+		WithWelcomeEmail(cfg.WelcomeEmailEnabled).
+		WithSignupsDisabled(cfg.SignupDisabled)
+	if cfg.SignupDisabled {
+		log.Warn("Signups are disabled (SIGNUP_DISABLED=true)")
+	}
+	if cfg.EmailVerificationRequired {
+		signUpUC.WithEmailVerification(repositories.EmailVerification, cfg.EmailVerificationURL)
+	}
+	signInUC := authUC.NewSignInUseCase(repositories.User, tokenMaker).
+		RequireEmailVerification(cfg.EmailVerificationRequired).
+		WithLoginLimiter(ratelimit.NewMemoryLoginLimiter(cfg.LoginMaxAttempts, cfg.LoginLockoutWindow)).
+		WithTokenDuration(cfg.AccessTokenDuration).
+		WithRememberMeTokenDuration(cfg.RememberMeTokenDuration).
+		WithLoginAudit(repositories.LoginEvent).
+		WithSessions(repositories.Session)
+	verifyTokenUC := authUC.NewVerifyTokenUseCase(repositories.User, repositories.Token, tokenMaker)
+	refreshTokenUC := authUC.NewRefreshTokenUseCase(repositories.User, repositories.Token, tokenMaker).
+		WithTokenDuration(cfg.AccessTokenDuration)
+	logoutUC := authUC.NewLogoutUseCase(repositories.Token, tokenMaker)
+	requestPasswordResetUC := authUC.NewRequestPasswordResetUseCase(
+		repositories.User,
+		repositories.Email,
+		repositories.PasswordReset,
+		rabbit,
+		cfg.PasswordResetURL,
+	)
+	resetPasswordUC := authUC.NewResetPasswordUseCase(repositories.User, repositories.PasswordReset)
+	verifyEmailUC := authUC.NewVerifyEmailUseCase(repositories.User, repositories.EmailVerification)
+	resendVerificationUC := authUC.NewResendVerificationUseCase(
+		repositories.User,
+		repositories.Email,
+		repositories.EmailVerification,
+		rabbit,
+		cfg.EmailVerificationURL,
+	).WithCooldown(cfg.EmailVerificationResendCooldown).WithTransaction(authTx)
 
 	getUserProfileUC := userUC.NewGetUserProfileUseCase(repositories.User)
-	updateUserUC := userUC.NewUpdateUserUseCase(repositories.User)
+	updateUserUC := userUC.NewUpdateUserUseCase(repositories.User).
+		WithTransaction(func(ctx context.Context, fn func(userDomain.Repository) error) error {
+			return repositories.WithTx(ctx, func(tx *adapters.Repositories) error {
+				return fn(tx.User)
+			})
+		})
+	patchUserUC := userUC.NewPatchUserUseCase(repositories.User)
 	deleteUserUC := userUC.NewDeleteUserUseCase(repositories.User)
-	listUsersUC := userUC.NewListUsersUseCase(repositories.User)
+	listUsersUC := userUC.NewListUsersUseCase(repositories.User).
+		WithPageSizeLimits(cfg.DefaultPageSize, cfg.MaxPageSize)
+	changePasswordUC := userUC.NewChangePasswordUseCase(repositories.User)
+	revokeSessionsUC := userUC.NewRevokeSessionsUseCase(repositories.User).WithSessions(repositories.Session)
+	listSessionsUC := userUC.NewListSessionsUseCase(repositories.Session)
+	revokeSessionUC := userUC.NewRevokeSessionUseCase(repositories.Session, repositories.Token)
+	exportUserDataUC := userUC.NewExportUserDataUseCase(repositories.User, repositories.Email)
+	listUserEmailsUC := userUC.NewListUserEmailsUseCase(repositories.User, repositories.Email).
+		WithPageSizeLimits(cfg.DefaultPageSize, cfg.MaxPageSize)
+
+	getEmailStatusUC := emailUC.NewGetEmailStatusUseCase(repositories.Email)
+	searchEmailsUC := emailUC.NewSearchEmailsUseCase(repositories.Email).
+		WithPageSizeLimits(cfg.DefaultPageSize, cfg.MaxPageSize)
+	previewEmailUC := emailUC.NewPreviewEmailUseCase().WithWelcomeTemplate(welcomeTemplate)
+	getStatsUC := adminUC.NewGetStatsUseCase(repositories.User, repositories.Email)
+	listLoginEventsUC := adminUC.NewListLoginEventsUseCase(repositories.LoginEvent)
+	suspendUserUC := adminUC.NewSuspendUserUseCase(repositories.User)
+	reactivateUserUC := adminUC.NewReactivateUserUseCase(repositories.User)
+	bulkDeleteUsersUC := adminUC.NewBulkDeleteUsersUseCase(repositories.User)
+	importUsersUC := adminUC.NewImportUsersUseCase(
+		repositories.User,
+		repositories.Email,
+		repositories.PasswordReset,
+		rabbit,
+		cfg.PasswordResetURL,
+	).WithTransaction(func(ctx context.Context, fn func(userDomain.Repository, emailDomain.Repository, tokenDomain.PasswordResetRepository) error) error {
+		return repositories.WithTx(ctx, func(tx *adapters.Repositories) error {
+			return fn(tx.User, tx.Email, tx.PasswordReset)
+		})
+	})
+	emailService, err := provider.NewEmailService(cfg, log)
+	if err != nil {
+		return fmt.Errorf("server: failed to create email service: %w", err)
+	}
+	processEmailUC := emailUC.NewProcessEmailQueueUseCase(repositories.Email, emailService)
+	if cfg.EmailWorkers > 0 {
+		processEmailUC.WithConcurrency(cfg.EmailWorkers)
+	}
+	if cfg.EmailSendingTimeout > 0 {
+		processEmailUC.WithSendingTimeout(cfg.EmailSendingTimeout)
+	}
 
 	// Initialize handlers
-	authHandler := handlers.NewAuthHandler(signUpUC, signInUC, verifyTokenUC)
-	userHandler := handlers.NewUserHandler(getUserProfileUC, updateUserUC, deleteUserUC, listUsersUC)
+	authHandler := handlers.NewAuthHandler(
+		signUpUC,
+		signInUC,
+		verifyTokenUC,
+		refreshTokenUC,
+		logoutUC,
+		requestPasswordResetUC,
+		resetPasswordUC,
+		verifyEmailUC,
+	).WithTokenCookie(cfg.AuthCookieMode).WithResendVerification(resendVerificationUC)
+	userHandler := handlers.NewUserHandler(getUserProfileUC, updateUserUC, patchUserUC, deleteUserUC, listUsersUC, changePasswordUC)
+	emailStatusHandler := handlers.NewEmailStatusHandler(getEmailStatusUC)
+	emailSearchHandler := handlers.NewEmailSearchHandler(searchEmailsUC)
+	emailPreviewHandler := handlers.NewEmailPreviewHandler(previewEmailUC)
+	statsHandler := handlers.NewStatsHandler(getStatsUC)
+	loginEventsHandler := handlers.NewLoginEventsHandler(listLoginEventsUC)
+	userStatusHandler := handlers.NewUserStatusHandler(suspendUserUC, reactivateUserUC)
+	userImportHandler := handlers.NewUserImportHandler(importUsersUC)
+	userBulkDeleteHandler := handlers.NewUserBulkDeleteHandler(bulkDeleteUsersUC)
+	emailProcessingHandler := handlers.NewEmailProcessingHandler(processEmailUC)
+	exportHandler := handlers.NewExportHandler(exportUserDataUC)
+	accountEmailsHandler := handlers.NewAccountEmailsHandler(listUserEmailsUC)
+	accountSessionsHandler := handlers.NewAccountSessionsHandler(revokeSessionsUC, listSessionsUC, revokeSessionUC)
 
-	// Public routes
-	api := router.Group("/api")
+	// Public routes (every API body is size-limited and must be JSON)
+	api := router.Group("/api", middlewares.BodyLimit(cfg.MaxRequestBodyBytes), middlewares.RequireJSON())
 	{
-		authRoutes := api.Group("/auth")
+		// Limite por IP contra abuso das rotas públicas de autenticação
+		authLimiter := ratelimit.NewTokenBucketLimiter(cfg.RateLimitRequestsPerSecond, cfg.RateLimitBurst)
+		authRoutes := api.Group("/auth", middlewares.RateLimit(authLimiter))
 		{
 			authRoutes.POST("/signup", authHandler.SignUp)
 			authRoutes.POST("/signin", authHandler.SignIn)
+			authRoutes.POST("/refresh", authHandler.RefreshToken)
+			authRoutes.POST("/logout", middlewares.AuthMiddleware(verifyTokenUC), authHandler.Logout)
+			authRoutes.GET("/whoami", middlewares.AuthMiddleware(verifyTokenUC), authHandler.WhoAmI)
+			authRoutes.POST("/password-reset/request", authHandler.RequestPasswordReset)
+			authRoutes.POST("/password-reset/confirm", authHandler.ConfirmPasswordReset)
+			authRoutes.POST("/verify-email", authHandler.VerifyEmail)
+			authRoutes.POST("/verification/resend", authHandler.ResendVerification)
 		}
 	}
 
@@ -108,13 +270,55 @@ func createRoutes(cfg config.Config, db *sqlx.DB, router *gin.Engine, log *zap.S
 		{
 			account.GET("/me", userHandler.GetProfile)
 			account.PUT("/me", userHandler.UpdateProfile)
+			account.PATCH("/me", userHandler.PatchProfile)
 			account.DELETE("/me", userHandler.DeleteProfile)
+			account.PUT("/password", userHandler.ChangePassword)
+			account.GET("/export", exportHandler.ExportAccount)
+			account.GET("/emails", accountEmailsHandler.ListEmails)
+			account.POST("/revoke-sessions", accountSessionsHandler.RevokeSessions)
+			account.DELETE("/sessions/:id", accountSessionsHandler.RevokeSession)
 		}
 
-		protected.GET("/users", userHandler.ListUsers)
+		protected.GET("/users", middlewares.RequireRole(userDomain.RoleAdmin), userHandler.ListUsers)
+		protected.GET("/users/me/sessions", accountSessionsHandler.ListSessions)
+
+		admin := protected.Group("/admin")
+		admin.Use(middlewares.RequireRole(userDomain.RoleAdmin))
+		{
+			admin.GET("/emails", emailSearchHandler.SearchEmails)
+			admin.GET("/emails/preview", emailPreviewHandler.PreviewEmail)
+			admin.GET("/emails/:id", emailStatusHandler.GetEmailStatus)
+			admin.POST("/emails/process", emailProcessingHandler.ProcessPendingEmails)
+			admin.GET("/stats", statsHandler.GetStats)
+			admin.GET("/users/:id/logins", loginEventsHandler.ListLoginEvents)
+			admin.POST("/users/:id/suspend", userStatusHandler.SuspendUser)
+			admin.POST("/users/:id/reactivate", userStatusHandler.ReactivateUser)
+			admin.POST("/users/import", userImportHandler.ImportUsers)
+			admin.POST("/users/bulk-delete", userBulkDeleteHandler.BulkDeleteUsers)
+		}
 	}
 
 	log.Info("Routes configured successfully")
+	return nil
+}
+
+// loadWelcomeTemplate reads WELCOME_EMAIL_TEMPLATE_FILE, so a missing file
+// or a broken template stops the server at startup.
+func loadWelcomeTemplate(cfg config.Config) (*emailDomain.WelcomeTemplate, error) {
+	var body string
+	if cfg.WelcomeEmailTemplateFile != "" {
+		content, err := os.ReadFile(cfg.WelcomeEmailTemplateFile)
+		if err != nil {
+			return nil, fmt.Errorf("server: failed to read welcome email template: %w", err)
+		}
+		body = string(content)
+	}
+
+	tmpl, err := emailDomain.NewWelcomeTemplate(cfg.WelcomeEmailSubject, body)
+	if err != nil {
+		return nil, fmt.Errorf("server: %w", err)
+	}
+	return tmpl, nil
 }
 
 func (s *Server) Start(address string) error {
@@ -124,7 +328,10 @@ func (s *Server) Start(address string) error {
 }
 
 func RunGinServer(cfg config.Config, db *sqlx.DB, log *zap.SugaredLogger, rabbit *rabbitmq.Connection) {
-	server := NewServer(cfg, db, log, rabbit)
+	server, err := NewServer(cfg, db, log, rabbit)
+	if err != nil {
+		log.Fatalf("Failed to create server: %v", err)
+	}
 
 	if err := server.Start(cfg.HTTPServerAddress); err != nil {
 		log.Fatalf("Failed to start server: %v", err)
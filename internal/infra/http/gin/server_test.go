@@ -0,0 +1,50 @@
+package gin
+
+import (
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/moura95/backend-challenge/internal/infra/config"
+)
+
+func TestNewServer_TokenKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("should abort boot with a 31 char key", func(t *testing.T) {
+		cfg := config.Config{TokenSymmetricKey: "1234567890123456789012345678901"}
+
+		server, err := NewServer(cfg, nil, zap.NewNop().Sugar(), nil)
+
+		require.Error(t, err)
+		assert.Nil(t, server)
+		assert.Contains(t, err.Error(), "invalid key size")
+		assert.NotContains(t, err.Error(), cfg.TokenSymmetricKey)
+	})
+
+	t.Run("should abort boot without a key", func(t *testing.T) {
+		server, err := NewServer(config.Config{}, nil, zap.NewNop().Sugar(), nil)
+
+		require.Error(t, err)
+		assert.Nil(t, server)
+		assert.Contains(t, err.Error(), "invalid key size")
+	})
+}
+
+func TestNewServer_TrustedProxies(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := config.Config{
+		TokenSymmetricKey: "12345678901234567890123456789012",
+		TrustedProxies:    []string{"not-an-ip"},
+	}
+
+	server, err := NewServer(cfg, nil, zap.NewNop().Sugar(), nil)
+
+	require.Error(t, err)
+	assert.Nil(t, server)
+	assert.Contains(t, err.Error(), "invalid TRUSTED_PROXIES")
+}
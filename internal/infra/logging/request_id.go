@@ -0,0 +1,36 @@
+package logging
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+const RequestIDHeader = "X-Request-ID"
+
+type requestIDKey struct{}
+
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	if requestID == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// FromContext devolve o logger com o request_id do contexto anexado (quando existir).
+func FromContext(ctx context.Context, base *zap.SugaredLogger) *zap.SugaredLogger {
+	requestID := RequestIDFromContext(ctx)
+	if requestID == "" {
+		return base
+	}
+	return base.With("request_id", requestID)
+}
@@ -0,0 +1,53 @@
+package logging
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRequestIDContext(t *testing.T) {
+	t.Run("should store and read request ID", func(t *testing.T) {
+		ctx := WithRequestID(context.Background(), "req-123")
+
+		assert.Equal(t, "req-123", RequestIDFromContext(ctx))
+	})
+
+	t.Run("should return empty when missing", func(t *testing.T) {
+		assert.Empty(t, RequestIDFromContext(context.Background()))
+	})
+
+	t.Run("should ignore empty request ID", func(t *testing.T) {
+		ctx := context.Background()
+
+		assert.Equal(t, ctx, WithRequestID(ctx, ""))
+	})
+}
+
+func TestFromContext(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	base := zap.New(core).Sugar()
+
+	t.Run("should attach request ID to log entries", func(t *testing.T) {
+		ctx := WithRequestID(context.Background(), "req-456")
+
+		FromContext(ctx, base).Info("hello")
+
+		entries := logs.TakeAll()
+		require.Len(t, entries, 1)
+		assert.Equal(t, "req-456", entries[0].ContextMap()["request_id"])
+	})
+
+	t.Run("should return base logger without request ID", func(t *testing.T) {
+		FromContext(context.Background(), base).Info("hello")
+
+		entries := logs.TakeAll()
+		require.Len(t, entries, 1)
+		assert.NotContains(t, entries[0].ContextMap(), "request_id")
+	})
+}
@@ -3,32 +3,127 @@ package rabbitmq
 import (
 	"fmt"
 	"log"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/moura95/backend-challenge/internal/domain/email"
+	"github.com/moura95/backend-challenge/internal/infra/metrics"
 	"github.com/streadway/amqp"
+	"go.uber.org/zap"
 )
 
+// defaultConfirmTimeout is how long a publish waits for the broker ack.
+const defaultConfirmTimeout = 5 * time.Second
+
+// DefaultMessageTTL is the expiration of published messages when none is configured.
+const DefaultMessageTTL = time.Hour
+
+// Consumer defaults: one unacked message per consumer, processed by one goroutine.
+const (
+	DefaultPrefetch        = 1
+	DefaultConsumerWorkers = 1
+)
+
+// ProcessingTimeMetric is the histogram of consumer processing latency, labeled by message type.
+const ProcessingTimeMetric = "email_consumer_processing_seconds"
+
+// DefaultDrainTimeout is how long a stopping consumer waits for in-flight messages.
+const DefaultDrainTimeout = 30 * time.Second
+
+// amqpChannel is the subset of *amqp.Channel used for queues, publishing and consuming.
+type amqpChannel interface {
+	ExchangeDeclare(name, kind string, durable, autoDelete, internal, noWait bool, args amqp.Table) error
+	QueueDeclare(name string, durable, autoDelete, exclusive, noWait bool, args amqp.Table) (amqp.Queue, error)
+	QueueBind(name, key, exchange string, noWait bool, args amqp.Table) error
+	Publish(exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error
+	Consume(queue, consumer string, autoAck, exclusive, noLocal, noWait bool, args amqp.Table) (<-chan amqp.Delivery, error)
+	Qos(prefetchCount, prefetchSize int, global bool) error
+	Cancel(consumer string, noWait bool) error
+	Close() error
+}
+
 type Connection struct {
 	conn    *amqp.Connection
-	channel *amqp.Channel
+	channel amqpChannel
 	url     string
+
+	// Email routing: exchange plus the queue/routing key for each email type
+	exchange     string
+	defaultRoute Route
+	routes       map[email.EmailType]Route
+	// Rejected messages are dead-lettered here into a DLQ per queue
+	deadLetterExchange string
+
+	// Publisher confirms: publishes are serialized and matched to their ack by delivery tag
+	confirms       <-chan amqp.Confirmation
+	confirmTimeout time.Duration
+	publishSeq     uint64
+	publishMu      sync.Mutex
+	// Mandatory publishes the broker cannot route come back here
+	returns <-chan amqp.Return
+	// Expiration set on every published message
+	messageTTL time.Duration
+
+	// Consumers: unacked messages the broker delivers per queue, and
+	// goroutines processing them
+	prefetch        int
+	consumerWorkers int
+	// On shutdown, how long in-flight messages get to finish
+	drainTimeout time.Duration
+	consumerSeq  atomic.Uint64
+
+	// Processing latency per message type, and the logger of processed messages
+	processingTime *metrics.Histogram
+	logger         *zap.SugaredLogger
 }
 
 type ConnectionConfig struct {
 	URL string
+	// ConfirmTimeout bounds the wait for a publish ack. Zero uses the default.
+	ConfirmTimeout time.Duration
+	// MessageTTL is the expiration of each published message. Zero uses
+	// DefaultMessageTTL.
+	MessageTTL time.Duration
+
+	// Exchange emails are published to. Empty uses the default exchange,
+	// where the routing key is the queue name.
+	Exchange string
+	// DefaultRoute receives every email type without a dedicated route.
+	// An empty queue uses DefaultEmailQueue.
+	DefaultRoute Route
+	// Routes sends specific email types to their own queues.
+	Routes map[email.EmailType]Route
+	// DeadLetterExchange routes messages rejected without requeue to the
+	// queue's DLQ. Empty uses DefaultDeadLetterExchange.
+	DeadLetterExchange string
+
+	// Prefetch is how many unacked messages each queue consumer may hold.
+	// Zero uses DefaultPrefetch.
+	Prefetch int
+	// ConsumerWorkers is how many messages of each queue are processed at
+	// once. Zero uses DefaultConsumerWorkers.
+	ConsumerWorkers int
+	// DrainTimeout bounds how long a cancelled consumer waits for messages
+	// being processed. Zero uses DefaultDrainTimeout.
+	DrainTimeout time.Duration
+
+	// Metrics receives the consumer processing latency histogram. Nil uses
+	// metrics.Default.
+	Metrics *metrics.Registry
+	// Logger records each processed message with its latency. Nil disables it.
+	Logger *zap.SugaredLogger
 }
 
 func NewConnection(config ConnectionConfig) (*Connection, error) {
-	conn := &Connection{
-		url: config.URL,
-	}
+	conn := newConnection(config)
 
 	err := conn.connect()
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to RabbitMQ: %w", err)
 	}
 
-	err = conn.setupQueue("email_notifications")
+	err = conn.setupQueues()
 	if err != nil {
 		return nil, fmt.Errorf("failed to setup email queue: %w", err)
 	}
@@ -36,6 +131,64 @@ func NewConnection(config ConnectionConfig) (*Connection, error) {
 	return conn, nil
 }
 
+func newConnection(config ConnectionConfig) *Connection {
+	conn := &Connection{
+		url:                config.URL,
+		confirmTimeout:     config.ConfirmTimeout,
+		messageTTL:         config.MessageTTL,
+		exchange:           config.Exchange,
+		defaultRoute:       config.DefaultRoute.withDefaults(DefaultEmailQueue),
+		routes:             make(map[email.EmailType]Route, len(config.Routes)),
+		deadLetterExchange: config.DeadLetterExchange,
+		prefetch:           config.Prefetch,
+		consumerWorkers:    config.ConsumerWorkers,
+		drainTimeout:       config.DrainTimeout,
+	}
+	if conn.confirmTimeout <= 0 {
+		conn.confirmTimeout = defaultConfirmTimeout
+	}
+	if conn.messageTTL <= 0 {
+		conn.messageTTL = DefaultMessageTTL
+	}
+	if conn.deadLetterExchange == "" {
+		conn.deadLetterExchange = DefaultDeadLetterExchange
+	}
+	if conn.prefetch <= 0 {
+		conn.prefetch = DefaultPrefetch
+	}
+	if conn.consumerWorkers <= 0 {
+		conn.consumerWorkers = DefaultConsumerWorkers
+	}
+	if conn.drainTimeout <= 0 {
+		conn.drainTimeout = DefaultDrainTimeout
+	}
+
+	registry := config.Metrics
+	if registry == nil {
+		registry = metrics.Default
+	}
+	conn.processingTime = registry.Histogram(
+		ProcessingTimeMetric,
+		"Time to process an email queue message, in seconds.",
+		"type",
+		nil,
+	)
+
+	conn.logger = config.Logger
+	if conn.logger == nil {
+		conn.logger = zap.NewNop().Sugar()
+	}
+
+	for emailType, route := range config.Routes {
+		if route.Queue == "" {
+			continue
+		}
+		conn.routes[emailType] = route.withDefaults(route.Queue)
+	}
+
+	return conn
+}
+
 func (c *Connection) connect() error {
 	var err error
 
@@ -54,36 +207,28 @@ func (c *Connection) connect() error {
 		return fmt.Errorf("failed to connect after 5 attempts: %w", err)
 	}
 
-	c.channel, err = c.conn.Channel()
+	channel, err := c.conn.Channel()
 	if err != nil {
 		return fmt.Errorf("failed to open channel: %w", err)
 	}
 
-	log.Println("Successfully connected to RabbitMQ")
-	return nil
-}
-
-func (c *Connection) setupQueue(queueName string) error {
-	_, err := c.channel.QueueDeclare(
-		queueName, // name
-		true,      // durable
-		false,     // delete when unused
-		false,     // exclusive
-		false,     // no-wait
-		amqp.Table{
-			"x-message-ttl": 3600000, // 1 hour TTL
-		},
-	)
-	if err != nil {
-		return fmt.Errorf("failed to declare email queue: %w", err)
+	// Colocar o canal em modo confirm para saber se o broker aceitou cada mensagem
+	if err = channel.Confirm(false); err != nil {
+		return fmt.Errorf("failed to enable publisher confirms: %w", err)
 	}
+	c.confirms = channel.NotifyPublish(make(chan amqp.Confirmation, 1))
+	// O broker devolve a mensagem antes do ack, então o buffer evita travar o canal
+	c.returns = channel.NotifyReturn(make(chan amqp.Return, 1))
+	c.channel = channel
+	c.publishSeq = 0
 
-	log.Printf("Email queue setup completed")
+	log.Println("Successfully connected to RabbitMQ")
 	return nil
 }
 
 func (c *Connection) Channel() *amqp.Channel {
-	return c.channel
+	channel, _ := c.channel.(*amqp.Channel)
+	return channel
 }
 
 func (c *Connection) Close() error {
@@ -97,5 +242,5 @@ func (c *Connection) Close() error {
 }
 
 func (c *Connection) IsConnected() bool {
-	return c.conn != nil && !c.conn.IsClosed()
+	return c != nil && c.conn != nil && !c.conn.IsClosed()
 }
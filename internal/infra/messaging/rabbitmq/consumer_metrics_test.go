@@ -0,0 +1,78 @@
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/moura95/backend-challenge/internal/domain/email"
+	"github.com/moura95/backend-challenge/internal/infra/metrics"
+	"github.com/streadway/amqp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestConnection_ProcessingTimeMetric(t *testing.T) {
+	newMetricsConnection := func() (*Connection, *metrics.Registry, *observer.ObservedLogs) {
+		core, logs := observer.New(zap.InfoLevel)
+		registry := metrics.NewRegistry()
+		conn := newConnection(ConnectionConfig{Metrics: registry, Logger: zap.New(core).Sugar()})
+		return conn, registry, logs
+	}
+
+	welcomeDelivery := func(t *testing.T, ack amqp.Acknowledger, deliveryType string) amqp.Delivery {
+		body, err := json.Marshal(email.QueueMessage{
+			Type:      email.EmailTypeWelcome,
+			Data:      email.WelcomeEmailData{UserEmail: "john@example.com"},
+			RequestID: "req-123",
+		})
+		require.NoError(t, err)
+		return amqp.Delivery{Acknowledger: ack, DeliveryTag: 1, Type: deliveryType, Body: body}
+	}
+
+	t.Run("records the latency of a welcome message by delivery type", func(t *testing.T) {
+		conn, registry, logs := newMetricsConnection()
+		ack := &fakeAcknowledger{}
+
+		handler := func(ctx context.Context, msg email.QueueMessage) error {
+			time.Sleep(5 * time.Millisecond)
+			return nil
+		}
+		conn.handleDelivery(context.Background(), handler, welcomeDelivery(t, ack, string(email.EmailTypeWelcome)))
+		assert.Equal(t, int32(1), ack.acks.Load())
+
+		snapshot, ok := registry.Histogram(ProcessingTimeMetric, "", "type", nil).Snapshot("welcome")
+		require.True(t, ok)
+		assert.Equal(t, uint64(1), snapshot.Count)
+		assert.GreaterOrEqual(t, snapshot.Sum, (5 * time.Millisecond).Seconds())
+
+		entries := logs.FilterMessage("Email processed successfully").All()
+		require.Len(t, entries, 1)
+		fields := entries[0].ContextMap()
+		assert.Equal(t, "welcome", fields["type"])
+		assert.Equal(t, "john@example.com", fields["recipient"])
+		assert.Equal(t, "req-123", fields["request_id"])
+		assert.Contains(t, fields, "duration_ms")
+	})
+
+	t.Run("falls back to the body type and also records failures", func(t *testing.T) {
+		conn, registry, logs := newMetricsConnection()
+		channel := newFakeChannel()
+		channel.confirms = make(chan amqp.Confirmation, 1)
+		conn.channel = channel
+		conn.confirms = channel.confirms
+
+		handler := func(ctx context.Context, msg email.QueueMessage) error {
+			return assert.AnError
+		}
+		conn.handleDelivery(context.Background(), handler, welcomeDelivery(t, &fakeAcknowledger{}, ""))
+
+		snapshot, ok := registry.Histogram(ProcessingTimeMetric, "", "type", nil).Snapshot("welcome")
+		require.True(t, ok)
+		assert.Equal(t, uint64(1), snapshot.Count)
+		assert.Empty(t, logs.FilterMessage("Email processed successfully").All())
+	})
+}
@@ -0,0 +1,137 @@
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/moura95/backend-challenge/internal/domain/email"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func setupRabbitMQContainer(t *testing.T) (string, func()) {
+	ctx := context.Background()
+
+	// Start RabbitMQ container
+	rabbitContainer, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "rabbitmq:3.7-management",
+			ExposedPorts: []string{"5672/tcp"},
+			Env: map[string]string{
+				"RABBITMQ_DEFAULT_USER": "test",
+				"RABBITMQ_DEFAULT_PASS": "test",
+			},
+			WaitingFor: wait.ForLog("Server startup complete").
+				WithStartupTimeout(60 * time.Second),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+
+	host, err := rabbitContainer.Host(ctx)
+	require.NoError(t, err)
+	port, err := rabbitContainer.MappedPort(ctx, "5672")
+	require.NoError(t, err)
+
+	cleanup := func() {
+		rabbitContainer.Terminate(ctx)
+	}
+
+	return fmt.Sprintf("amqp://test:test@%s:%s/", host, port.Port()), cleanup
+}
+
+func TestConnection_DeadLetterQueue(t *testing.T) {
+	url, cleanup := setupRabbitMQContainer(t)
+	defer cleanup()
+
+	t.Run("should move a message that exceeds retries to the DLQ", func(t *testing.T) {
+		conn, err := NewConnection(ConnectionConfig{URL: url, DefaultRoute: Route{Queue: "dlq_test_emails"}})
+		require.NoError(t, err)
+		defer conn.Close()
+
+		var attempts atomic.Int32
+		handler := func(ctx context.Context, msg email.QueueMessage) error {
+			attempts.Add(1)
+			return assert.AnError
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() {
+			done <- conn.StartEmailConsumers(ctx, handler)
+		}()
+
+		message := email.QueueMessage{Type: email.EmailTypeWelcome, RequestID: "dlq-test"}
+		require.NoError(t, conn.PublishEmailMessage(message))
+
+		// Inspecionar a DLQ por um canal separado do consumer
+		inspect, err := conn.conn.Channel()
+		require.NoError(t, err)
+		defer inspect.Close()
+
+		dlq := DeadLetterQueue("dlq_test_emails")
+		assert.Eventually(t, func() bool {
+			queue, err := inspect.QueueInspect(dlq)
+			return err == nil && queue.Messages == 1
+		}, 10*time.Second, 100*time.Millisecond)
+
+		cancel()
+		<-done
+
+		dead, ok, err := inspect.Get(dlq, true)
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, int32(MaxMessageRetries), dead.Headers[RetryCountHeader])
+		assert.Contains(t, string(dead.Body), "dlq-test")
+		assert.Equal(t, int32(MaxMessageRetries+1), attempts.Load())
+
+		// Nothing is left looping on the main queue
+		queue, err := inspect.QueueInspect("dlq_test_emails")
+		require.NoError(t, err)
+		assert.Equal(t, 0, queue.Messages)
+	})
+}
+
+func TestConnection_PublishUnroutable(t *testing.T) {
+	url, cleanup := setupRabbitMQContainer(t)
+	defer cleanup()
+
+	conn, err := NewConnection(ConnectionConfig{
+		URL:          url,
+		MessageTTL:   10 * time.Minute,
+		Exchange:     "unroutable_test",
+		DefaultRoute: Route{Queue: "unroutable_emails", RoutingKey: "email.default"},
+	})
+	require.NoError(t, err)
+	defer conn.Close()
+
+	inspect, err := conn.conn.Channel()
+	require.NoError(t, err)
+	defer inspect.Close()
+
+	t.Run("should set the configured TTL on published messages", func(t *testing.T) {
+		require.NoError(t, conn.PublishEmailMessage(email.QueueMessage{Type: email.EmailTypeWelcome}))
+
+		published, ok, err := inspect.Get("unroutable_emails", true)
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, "600000", published.Expiration)
+	})
+
+	t.Run("should fail when no queue is bound to the routing key", func(t *testing.T) {
+		require.NoError(t, inspect.QueueUnbind("unroutable_emails", "email.default", "unroutable_test", nil))
+
+		err := conn.PublishEmailMessage(email.QueueMessage{Type: email.EmailTypeWelcome})
+		assert.ErrorIs(t, err, email.ErrMessageUnroutable)
+		assert.Contains(t, err.Error(), `routing key "email.default"`)
+
+		queue, err := inspect.QueueInspect("unroutable_emails")
+		require.NoError(t, err)
+		assert.Equal(t, 0, queue.Messages)
+	})
+}
@@ -5,60 +5,287 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"sync"
+	"time"
 
 	"github.com/moura95/backend-challenge/internal/domain/email"
+	"github.com/moura95/backend-challenge/internal/infra/logging"
+	"github.com/moura95/backend-challenge/internal/infra/tracing"
+	"github.com/streadway/amqp"
 )
 
+const (
+	// RetryCountHeader counts how many times a failed message was requeued.
+	RetryCountHeader = "retry_count"
+	// MaxMessageRetries is how many requeues a failing message gets before
+	// it is rejected into the dead-letter queue.
+	MaxMessageRetries = 3
+)
+
+// StartEmailConsumers consome todas as filas de email configuradas até o
+// contexto ser cancelado ou uma delas falhar.
+func (c *Connection) StartEmailConsumers(ctx context.Context, handler email.MessageHandler) error {
+	if !c.IsConnected() {
+		return fmt.Errorf("RabbitMQ not connected")
+	}
+
+	return c.consumeQueues(ctx, handler, c.Queues())
+}
+
 func (c *Connection) StartEmailConsumer(ctx context.Context, handler email.MessageHandler, queueName string) error {
 	if !c.IsConnected() {
 		return fmt.Errorf("RabbitMQ not connected")
 	}
 
-	// Consumir mensagens
+	return c.consume(ctx, handler, queueName)
+}
+
+func (c *Connection) consumeQueues(ctx context.Context, handler email.MessageHandler, queues []string) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errs := make(chan error, len(queues))
+	for _, queueName := range queues {
+		go func(queueName string) {
+			errs <- c.consume(ctx, handler, queueName)
+		}(queueName)
+	}
+
+	// Se uma fila parar com erro, derrubar as demais
+	var firstErr error
+	for range queues {
+		if err := <-errs; err != nil && firstErr == nil {
+			firstErr = err
+			cancel()
+		}
+	}
+
+	return firstErr
+}
+
+func (c *Connection) consume(ctx context.Context, handler email.MessageHandler, queueName string) error {
+	// Limitar mensagens sem ack entregues a este consumidor
+	if err := c.channel.Qos(c.prefetch, 0, false); err != nil {
+		return fmt.Errorf("failed to set consumer prefetch: %w", err)
+	}
+
+	// Consumir mensagens com uma tag conhecida, para poder cancelar no shutdown
+	consumerTag := fmt.Sprintf("email-%s-%d", queueName, c.consumerSeq.Add(1))
 	messages, err := c.channel.Consume(
 		queueName,
-		"",    // consumer name
-		false, // auto-ack = false
-		false, // exclusive
-		false, // no-local
-		false, // no-wait
-		nil,   // arguments
+		consumerTag, // consumer name
+		false,       // auto-ack = false
+		false,       // exclusive
+		false,       // no-local
+		false,       // no-wait
+		nil,         // arguments
 	)
 	if err != nil {
 		return fmt.Errorf("failed to start consumer: %w", err)
 	}
 
-	log.Printf("%s consumer started", queueName)
+	log.Printf("%s consumer started (prefetch=%d, workers=%d)", queueName, c.prefetch, c.consumerWorkers)
+
+	// O handler não herda o cancelamento do ctx: uma mensagem em andamento
+	// termina (e recebe ack) em vez de ser abandonada no meio do envio
+	handlerCtx, cancelHandlers := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelHandlers()
+
+	// Cada worker confirma (ack) a própria mensagem ao terminar
+	errs := make(chan error, c.consumerWorkers)
+	var wg sync.WaitGroup
+	for i := 0; i < c.consumerWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- c.consumeMessages(ctx, handlerCtx, handler, messages)
+		}()
+	}
+
+	workersDone := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(workersDone)
+	}()
+
+	select {
+	case <-workersDone:
+	case <-ctx.Done():
+		if !c.drain(cancelHandlers, consumerTag, workersDone) {
+			log.Printf("%s consumer drain timed out after %s", queueName, c.drainTimeout)
+			return nil
+		}
+	}
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			log.Printf("Messages channel closed for %s", queueName)
+			return err
+		}
+	}
+
+	log.Printf("%s consumer stopped", queueName)
+	return nil
+}
 
+// drain para de receber entregas cancelando o consumer e espera as mensagens
+// em andamento até drainTimeout; depois disso cancela os handlers. Retorna
+// false se os workers não terminaram a tempo.
+func (c *Connection) drain(cancelHandlers context.CancelFunc, consumerTag string, workersDone <-chan struct{}) bool {
+	if err := c.channel.Cancel(consumerTag, false); err != nil {
+		log.Printf("Failed to cancel consumer %s: %v", consumerTag, err)
+	}
+
+	timer := time.NewTimer(c.drainTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-workersDone:
+		return true
+	case <-timer.C:
+		cancelHandlers()
+		return false
+	}
+}
+
+// consumeMessages processa mensagens até o contexto ser cancelado ou o canal
+// fechar. O handler roda com handlerCtx, que sobrevive ao shutdown.
+func (c *Connection) consumeMessages(ctx, handlerCtx context.Context, handler email.MessageHandler, messages <-chan amqp.Delivery) error {
 	for {
+		// Com o shutdown em curso, não pegar novas mensagens
+		if ctx.Err() != nil {
+			return nil
+		}
+
 		select {
 		case <-ctx.Done():
-			log.Printf("%s consumer stopped", queueName)
 			return nil
 
 		case msg, ok := <-messages:
 			if !ok {
-				log.Printf("Messages channel closed for %s", queueName)
+				// O cancelamento do consumer no shutdown também fecha o canal
+				if ctx.Err() != nil {
+					return nil
+				}
 				return fmt.Errorf("messages channel closed")
 			}
 
-			var queueMessage email.QueueMessage
+			c.handleDelivery(handlerCtx, handler, msg)
+		}
+	}
+}
 
-			// 1. Parse da mensagem
-			if err := json.Unmarshal(msg.Body, &queueMessage); err != nil {
-				log.Printf("Failed to unmarshal message: %v", err)
-				msg.Reject(false) // Mensagem malformada, descarta
-				continue
-			}
+func (c *Connection) handleDelivery(ctx context.Context, handler email.MessageHandler, msg amqp.Delivery) {
+	var queueMessage email.QueueMessage
 
-			// 2. Processar mensagem
-			if err := handler(ctx, queueMessage); err != nil {
-				log.Printf("Failed to process email message: %v", err)
-				msg.Ack(false)
-			} else {
-				log.Printf("Email processed successfully for user %s", queueMessage.Data.UserEmail)
-				msg.Ack(false)
-			}
-		}
+	// 1. Parse da mensagem
+	if err := json.Unmarshal(msg.Body, &queueMessage); err != nil {
+		log.Printf("Failed to unmarshal message: %v", err)
+		msg.Reject(false) // Mensagem malformada, descarta
+		return
+	}
+
+	// Mensagens antigas não têm request_id no corpo
+	if queueMessage.RequestID == "" {
+		queueMessage.RequestID = msg.CorrelationId
+	}
+
+	// 2. Processar mensagem, continuando o trace de quem publicou
+	msgCtx := logging.WithRequestID(ctx, queueMessage.RequestID)
+	msgCtx = tracing.Extract(msgCtx, queueMessage.TraceContext)
+	startTime := time.Now()
+	err := handler(msgCtx, queueMessage)
+	duration := time.Since(startTime)
+
+	// 3. Latência por tipo, com sucesso ou falha
+	messageType := deliveryType(msg, queueMessage)
+	c.processingTime.Observe(messageType, duration.Seconds())
+
+	if err != nil {
+		log.Printf("Failed to process email message (request_id=%s): %v", queueMessage.RequestID, err)
+		c.handleProcessingError(msg, queueMessage.RequestID)
+		return
+	}
+
+	logging.FromContext(msgCtx, c.logger).Infow("Email processed successfully",
+		"recipient", queueMessage.Recipient(),
+		"type", messageType,
+		"duration_ms", duration.Milliseconds(),
+	)
+	msg.Ack(false)
+}
+
+// deliveryType usa o Type da entrega; mensagens publicadas antes dele
+// existir caem no tipo do corpo.
+func deliveryType(msg amqp.Delivery, queueMessage email.QueueMessage) string {
+	if msg.Type != "" {
+		return msg.Type
+	}
+	return string(queueMessage.Type)
+}
+
+// handleProcessingError republica a mensagem com retry_count incrementado;
+// depois de MaxMessageRetries tentativas ela é rejeitada sem requeue e o
+// broker a encaminha para a DLQ.
+func (c *Connection) handleProcessingError(msg amqp.Delivery, requestID string) {
+	retryCount := getRetryCount(msg.Headers)
+	if retryCount >= MaxMessageRetries {
+		log.Printf("Email message exceeded %d retries, sending to DLQ (request_id=%s)", MaxMessageRetries, requestID)
+		msg.Reject(false)
+		return
+	}
+
+	if err := c.requeue(msg, retryCount+1); err != nil {
+		// Sem a cópia, devolver a original ao broker para não perdê-la
+		log.Printf("Failed to requeue email message (request_id=%s): %v", requestID, err)
+		msg.Nack(false, true)
+		return
+	}
+
+	msg.Ack(false)
+}
+
+// requeue publica uma cópia da mensagem no mesmo destino, com o corpo
+// original e o contador de tentativas atualizado.
+func (c *Connection) requeue(msg amqp.Delivery, retryCount int) error {
+	headers := amqp.Table{}
+	for key, value := range msg.Headers {
+		headers[key] = value
+	}
+	headers[RetryCountHeader] = int32(retryCount)
+
+	return c.publish(msg.Exchange, msg.RoutingKey, amqp.Publishing{
+		Headers:       headers,
+		DeliveryMode:  amqp.Persistent,
+		Timestamp:     time.Now(),
+		ContentType:   msg.ContentType,
+		Body:          msg.Body,
+		MessageId:     msg.MessageId,
+		CorrelationId: msg.CorrelationId,
+	})
+}
+
+// getRetryCount lê o header retry_count; ausente ou inválido conta como zero.
+func getRetryCount(headers amqp.Table) int {
+	switch value := headers[RetryCountHeader].(type) {
+	case int:
+		return value
+	case int8:
+		return int(value)
+	case int16:
+		return int(value)
+	case int32:
+		return int(value)
+	case int64:
+		return int(value)
+	case uint8:
+		return int(value)
+	case uint16:
+		return int(value)
+	case uint32:
+		return int(value)
+	default:
+		return 0
 	}
 }
@@ -0,0 +1,313 @@
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/moura95/backend-challenge/internal/domain/email"
+	"github.com/streadway/amqp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAcknowledger counts acks and rejects of deliveries.
+type fakeAcknowledger struct {
+	acks    atomic.Int32
+	rejects atomic.Int32
+}
+
+func (a *fakeAcknowledger) Ack(tag uint64, multiple bool) error {
+	a.acks.Add(1)
+	return nil
+}
+
+func (a *fakeAcknowledger) Nack(tag uint64, multiple bool, requeue bool) error {
+	a.rejects.Add(1)
+	return nil
+}
+
+func (a *fakeAcknowledger) Reject(tag uint64, requeue bool) error {
+	a.rejects.Add(1)
+	return nil
+}
+
+func newTestDelivery(t *testing.T, ack amqp.Acknowledger, tag uint64) amqp.Delivery {
+	body, err := json.Marshal(email.QueueMessage{Type: email.EmailTypeWelcome})
+	require.NoError(t, err)
+	return amqp.Delivery{Acknowledger: ack, DeliveryTag: tag, Body: body}
+}
+
+func TestConnection_ConsumerConcurrency(t *testing.T) {
+	t.Run("should default to one message at a time", func(t *testing.T) {
+		conn := newConnection(ConnectionConfig{})
+
+		assert.Equal(t, DefaultPrefetch, conn.prefetch)
+		assert.Equal(t, DefaultConsumerWorkers, conn.consumerWorkers)
+	})
+
+	t.Run("should process up to prefetch messages concurrently", func(t *testing.T) {
+		const prefetch = 3
+
+		channel := newFakeChannel()
+		channel.deliveries = make(chan amqp.Delivery, prefetch*2)
+		conn := newConnection(ConnectionConfig{Prefetch: prefetch, ConsumerWorkers: prefetch})
+		conn.channel = channel
+
+		var inFlight, maxInFlight atomic.Int32
+		release := make(chan struct{})
+		handler := func(ctx context.Context, msg email.QueueMessage) error {
+			current := inFlight.Add(1)
+			for {
+				seen := maxInFlight.Load()
+				if current <= seen || maxInFlight.CompareAndSwap(seen, current) {
+					break
+				}
+			}
+			<-release
+			inFlight.Add(-1)
+			return nil
+		}
+
+		ack := &fakeAcknowledger{}
+		for i := 0; i < prefetch*2; i++ {
+			channel.deliveries <- newTestDelivery(t, ack, uint64(i+1))
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() {
+			done <- conn.consume(ctx, handler, DefaultEmailQueue)
+		}()
+
+		// Every worker picks a message and blocks in the handler
+		assert.Eventually(t, func() bool {
+			return inFlight.Load() == prefetch
+		}, time.Second, 5*time.Millisecond)
+		assert.Equal(t, int32(0), ack.acks.Load())
+
+		close(release)
+		assert.Eventually(t, func() bool {
+			return ack.acks.Load() == prefetch*2
+		}, time.Second, 5*time.Millisecond)
+
+		cancel()
+		require.NoError(t, <-done)
+
+		assert.Equal(t, int32(prefetch), maxInFlight.Load())
+		assert.Equal(t, int32(0), ack.rejects.Load())
+		assert.Equal(t, prefetch, channel.prefetch)
+	})
+
+	t.Run("should ack each message once even when the handler fails", func(t *testing.T) {
+		channel := newFakeChannel()
+		channel.deliveries = make(chan amqp.Delivery, 4)
+		channel.confirms = make(chan amqp.Confirmation, 4)
+		conn := newConnection(ConnectionConfig{Prefetch: 2, ConsumerWorkers: 2})
+		conn.channel = channel
+		conn.confirms = channel.confirms
+
+		var mu sync.Mutex
+		handled := 0
+		handler := func(ctx context.Context, msg email.QueueMessage) error {
+			mu.Lock()
+			defer mu.Unlock()
+			handled++
+			if handled%2 == 0 {
+				return assert.AnError
+			}
+			return nil
+		}
+
+		ack := &fakeAcknowledger{}
+		for i := 0; i < 3; i++ {
+			channel.deliveries <- newTestDelivery(t, ack, uint64(i+1))
+		}
+		channel.deliveries <- amqp.Delivery{Acknowledger: ack, DeliveryTag: 4, Body: []byte("not json")}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() {
+			done <- conn.consume(ctx, handler, DefaultEmailQueue)
+		}()
+
+		assert.Eventually(t, func() bool {
+			return ack.acks.Load()+ack.rejects.Load() == 4
+		}, time.Second, 5*time.Millisecond)
+
+		cancel()
+		require.NoError(t, <-done)
+
+		assert.Equal(t, int32(3), ack.acks.Load())
+		assert.Equal(t, int32(1), ack.rejects.Load()) // malformed message
+
+		// The failed message was requeued as a copy before its ack
+		published := channel.publishings()
+		require.Len(t, published, 1)
+		assert.Equal(t, int32(1), published[0].Headers[RetryCountHeader])
+	})
+
+	t.Run("should stop every worker when the channel closes", func(t *testing.T) {
+		channel := newFakeChannel()
+		channel.deliveries = make(chan amqp.Delivery)
+		conn := newConnection(ConnectionConfig{ConsumerWorkers: 4})
+		conn.channel = channel
+
+		done := make(chan error, 1)
+		go func() {
+			done <- conn.consume(context.Background(), func(context.Context, email.QueueMessage) error { return nil }, DefaultEmailQueue)
+		}()
+
+		close(channel.deliveries)
+
+		select {
+		case err := <-done:
+			assert.Error(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("consumer did not stop after the channel closed")
+		}
+	})
+	t.Run("should dead-letter a persistently failing message after max retries", func(t *testing.T) {
+		ack := &fakeAcknowledger{}
+		channel := newFakeChannel()
+		channel.deliveries = make(chan amqp.Delivery, MaxMessageRetries+1)
+		channel.confirms = make(chan amqp.Confirmation, MaxMessageRetries+1)
+		channel.redeliver = ack
+		conn := newConnection(ConnectionConfig{})
+		conn.channel = channel
+		conn.confirms = channel.confirms
+
+		var attempts atomic.Int32
+		handler := func(ctx context.Context, msg email.QueueMessage) error {
+			attempts.Add(1)
+			return assert.AnError
+		}
+
+		original := newTestDelivery(t, ack, 1)
+		original.RoutingKey = DefaultEmailQueue
+		channel.deliveries <- original
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() {
+			done <- conn.consume(ctx, handler, DefaultEmailQueue)
+		}()
+
+		assert.Eventually(t, func() bool {
+			return ack.rejects.Load() == 1
+		}, time.Second, 5*time.Millisecond)
+
+		cancel()
+		require.NoError(t, <-done)
+
+		assert.Equal(t, int32(MaxMessageRetries+1), attempts.Load())
+		assert.Equal(t, int32(MaxMessageRetries), ack.acks.Load())
+
+		published := channel.publishings()
+		require.Len(t, published, MaxMessageRetries)
+		for i, msg := range published {
+			assert.Equal(t, int32(i+1), msg.Headers[RetryCountHeader])
+			assert.Equal(t, original.Body, msg.Body)
+		}
+	})
+	t.Run("should finish the in-flight message before stopping", func(t *testing.T) {
+		channel := newFakeChannel()
+		channel.deliveries = make(chan amqp.Delivery, 2)
+		conn := newConnection(ConnectionConfig{})
+		conn.channel = channel
+
+		started := make(chan struct{})
+		release := make(chan struct{})
+		var completed, handlerCancelled atomic.Bool
+		handler := func(ctx context.Context, msg email.QueueMessage) error {
+			close(started)
+			<-release
+			handlerCancelled.Store(ctx.Err() != nil)
+			completed.Store(true)
+			return nil
+		}
+
+		ack := &fakeAcknowledger{}
+		channel.deliveries <- newTestDelivery(t, ack, 1)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() {
+			done <- conn.consume(ctx, handler, DefaultEmailQueue)
+		}()
+
+		<-started
+		cancel()
+
+		// The consumer stops receiving but waits for the handler
+		assert.Eventually(t, func() bool {
+			channel.mu.Lock()
+			defer channel.mu.Unlock()
+			return len(channel.cancelled) == 1
+		}, time.Second, 5*time.Millisecond)
+		select {
+		case <-done:
+			t.Fatal("consumer returned while a message was in flight")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		// A delivery arriving during shutdown is left for the broker to requeue
+		channel.deliveries <- newTestDelivery(t, ack, 2)
+		close(release)
+
+		select {
+		case err := <-done:
+			require.NoError(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("consumer did not stop after the in-flight message finished")
+		}
+
+		assert.True(t, completed.Load())
+		assert.False(t, handlerCancelled.Load())
+		assert.Equal(t, int32(1), ack.acks.Load())
+		assert.Len(t, channel.deliveries, 1)
+	})
+
+	t.Run("should stop waiting after the drain timeout", func(t *testing.T) {
+		channel := newFakeChannel()
+		channel.deliveries = make(chan amqp.Delivery, 1)
+		conn := newConnection(ConnectionConfig{DrainTimeout: 20 * time.Millisecond})
+		conn.channel = channel
+
+		started := make(chan struct{})
+		handlerCancelled := make(chan struct{})
+		handler := func(ctx context.Context, msg email.QueueMessage) error {
+			close(started)
+			<-ctx.Done()
+			close(handlerCancelled)
+			return ctx.Err()
+		}
+
+		channel.deliveries <- newTestDelivery(t, &fakeAcknowledger{}, 1)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() {
+			done <- conn.consume(ctx, handler, DefaultEmailQueue)
+		}()
+
+		<-started
+		cancel()
+
+		select {
+		case err := <-done:
+			assert.NoError(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("consumer did not give up after the drain timeout")
+		}
+
+		select {
+		case <-handlerCancelled:
+		case <-time.After(time.Second):
+			t.Fatal("handler context was not cancelled after the drain timeout")
+		}
+	})
+}
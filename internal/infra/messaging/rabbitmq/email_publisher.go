@@ -2,7 +2,9 @@ package rabbitmq
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
@@ -10,7 +12,18 @@ import (
 	"github.com/streadway/amqp"
 )
 
+var (
+	// ErrPublishNacked is returned when the broker rejects a published message.
+	ErrPublishNacked = errors.New("rabbitmq: message nacked by broker")
+	// ErrPublishConfirmTimeout is returned when the broker does not confirm in time.
+	ErrPublishConfirmTimeout = errors.New("rabbitmq: timed out waiting for publish confirm")
+)
+
 func (c *Connection) PublishWelcomeEmailMessage(message email.QueueMessage) error {
+	return c.PublishEmailMessage(message)
+}
+
+func (c *Connection) PublishEmailMessage(message email.QueueMessage) error {
 	if !c.IsConnected() {
 		return fmt.Errorf("rabbitmq: connection not available")
 	}
@@ -23,25 +36,99 @@ func (c *Connection) PublishWelcomeEmailMessage(message email.QueueMessage) erro
 
 	// Create AMQP message
 	amqpMessage := amqp.Publishing{
-		DeliveryMode: amqp.Persistent,
-		Timestamp:    time.Now(),
-		ContentType:  "application/json",
-		Body:         messageBody,
-		MessageId:    uuid.New().String(),
-	}
-
-	// Publish ONLY to email queue
-	err = c.channel.Publish(
-		"",                    // exchange (empty for direct queue)
-		"email_notifications", // routing key = queue name
-		false,                 // mandatory
-		false,                 // immediate
-		amqpMessage,
-	)
-	if err != nil {
+		DeliveryMode:  amqp.Persistent,
+		Timestamp:     time.Now(),
+		ContentType:   "application/json",
+		Body:          messageBody,
+		MessageId:     uuid.New().String(),
+		CorrelationId: message.RequestID,
+		Type:          string(message.Type),
+		Expiration:    strconv.FormatInt(c.messageTTL.Milliseconds(), 10),
+	}
+
+	// Rotear pelo tipo do email; no exchange padrão a routing key é o nome da fila
+	route := c.RouteFor(message.Type)
+	routingKey := route.RoutingKey
+	if c.exchange == "" {
+		routingKey = route.Queue
+	}
+
+	if err = c.publish(c.exchange, routingKey, amqpMessage); err != nil {
 		return fmt.Errorf("rabbitmq: failed to publish to email queue: %w", err)
 	}
 
-	fmt.Printf("Published welcome email to queue\n")
+	fmt.Printf("Published %s email to queue %s (request_id=%s)\n", message.Type, route.Queue, message.RequestID)
 	return nil
 }
+
+// publish sends msg as mandatory and waits for the broker confirm. Publishes
+// are serialized so each one can be matched to its delivery tag.
+func (c *Connection) publish(exchange, routingKey string, msg amqp.Publishing) error {
+	c.publishMu.Lock()
+	defer c.publishMu.Unlock()
+
+	err := c.channel.Publish(
+		exchange,   // exchange (empty for direct queue)
+		routingKey, // routing key
+		true,       // mandatory: unroutable messages are returned instead of dropped
+		false,      // immediate
+		msg,
+	)
+	if err != nil {
+		return err
+	}
+	c.publishSeq++
+
+	// Só considerar publicado após o ack do broker
+	if err := waitForConfirm(c.confirms, c.publishSeq, c.confirmTimeout); err != nil {
+		return err
+	}
+
+	// O broker também confirma mensagens sem fila, mas as devolve antes do ack
+	return checkReturned(c.returns, msg.MessageId)
+}
+
+// checkReturned reports the message with the given id as unroutable if the
+// broker returned it. Returns of earlier publishes are skipped.
+func checkReturned(returns <-chan amqp.Return, messageID string) error {
+	for {
+		select {
+		case returned, ok := <-returns:
+			if !ok {
+				return nil
+			}
+			if returned.MessageId != messageID {
+				continue
+			}
+			return fmt.Errorf("%w: no queue bound to exchange %q with routing key %q (%d %s)",
+				email.ErrMessageUnroutable, returned.Exchange, returned.RoutingKey, returned.ReplyCode, returned.ReplyText)
+		default:
+			return nil
+		}
+	}
+}
+
+// waitForConfirm blocks until the broker confirms the publish with the given
+// delivery tag. Late confirms of earlier publishes that timed out are skipped.
+func waitForConfirm(confirms <-chan amqp.Confirmation, deliveryTag uint64, timeout time.Duration) error {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		select {
+		case confirm, ok := <-confirms:
+			if !ok {
+				return fmt.Errorf("rabbitmq: confirm channel closed")
+			}
+			if confirm.DeliveryTag < deliveryTag {
+				continue
+			}
+			if !confirm.Ack {
+				return ErrPublishNacked
+			}
+			return nil
+		case <-timer.C:
+			return ErrPublishConfirmTimeout
+		}
+	}
+}
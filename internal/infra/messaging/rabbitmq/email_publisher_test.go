@@ -0,0 +1,104 @@
+package rabbitmq
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moura95/backend-challenge/internal/domain/email"
+	"github.com/streadway/amqp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitForConfirm(t *testing.T) {
+	t.Run("should succeed on ack", func(t *testing.T) {
+		confirms := make(chan amqp.Confirmation, 1)
+		confirms <- amqp.Confirmation{DeliveryTag: 1, Ack: true}
+
+		assert.NoError(t, waitForConfirm(confirms, 1, time.Second))
+	})
+
+	t.Run("should fail on nack", func(t *testing.T) {
+		confirms := make(chan amqp.Confirmation, 1)
+		confirms <- amqp.Confirmation{DeliveryTag: 1, Ack: false}
+
+		assert.ErrorIs(t, waitForConfirm(confirms, 1, time.Second), ErrPublishNacked)
+	})
+
+	t.Run("should time out without confirm", func(t *testing.T) {
+		confirms := make(chan amqp.Confirmation)
+
+		assert.ErrorIs(t, waitForConfirm(confirms, 1, 10*time.Millisecond), ErrPublishConfirmTimeout)
+	})
+
+	t.Run("should skip late confirms of earlier publishes", func(t *testing.T) {
+		confirms := make(chan amqp.Confirmation, 2)
+		confirms <- amqp.Confirmation{DeliveryTag: 1, Ack: false}
+		confirms <- amqp.Confirmation{DeliveryTag: 2, Ack: true}
+
+		assert.NoError(t, waitForConfirm(confirms, 2, time.Second))
+	})
+
+	t.Run("should fail when confirm channel is closed", func(t *testing.T) {
+		confirms := make(chan amqp.Confirmation)
+		close(confirms)
+
+		assert.Error(t, waitForConfirm(confirms, 1, time.Second))
+	})
+}
+
+func TestConnection_PublishMandatory(t *testing.T) {
+	setup := func(t *testing.T) (*Connection, *fakeChannel) {
+		channel := newFakeChannel()
+		channel.confirms = make(chan amqp.Confirmation, 2)
+		channel.returns = make(chan amqp.Return, 1)
+
+		conn := newConnection(ConnectionConfig{Exchange: "emails"})
+		conn.channel = channel
+		conn.confirms = channel.confirms
+		conn.returns = channel.returns
+		require.NoError(t, conn.setupQueues())
+
+		return conn, channel
+	}
+
+	t.Run("should publish to a bound routing key", func(t *testing.T) {
+		conn, _ := setup(t)
+
+		err := conn.publish("emails", DefaultEmailQueue, amqp.Publishing{MessageId: uuid.NewString()})
+		assert.NoError(t, err)
+	})
+
+	t.Run("should fail when the routing key has no queue", func(t *testing.T) {
+		conn, _ := setup(t)
+
+		err := conn.publish("emails", "missing.key", amqp.Publishing{MessageId: uuid.NewString()})
+		assert.ErrorIs(t, err, email.ErrMessageUnroutable)
+		assert.Contains(t, err.Error(), `routing key "missing.key"`)
+		assert.Contains(t, err.Error(), "NO_ROUTE")
+
+		// The return is consumed, so the next publish is not affected
+		assert.NoError(t, conn.publish("emails", DefaultEmailQueue, amqp.Publishing{MessageId: uuid.NewString()}))
+	})
+}
+
+func TestCheckReturned(t *testing.T) {
+	t.Run("should succeed without a return", func(t *testing.T) {
+		assert.NoError(t, checkReturned(make(chan amqp.Return, 1), "id"))
+		assert.NoError(t, checkReturned(nil, "id"))
+	})
+
+	t.Run("should skip returns of earlier publishes", func(t *testing.T) {
+		returns := make(chan amqp.Return, 1)
+		returns <- amqp.Return{MessageId: "earlier"}
+
+		assert.NoError(t, checkReturned(returns, "id"))
+		assert.Empty(t, returns)
+	})
+}
+
+func TestConnection_MessageTTL(t *testing.T) {
+	assert.Equal(t, DefaultMessageTTL, newConnection(ConnectionConfig{}).messageTTL)
+	assert.Equal(t, 10*time.Minute, newConnection(ConnectionConfig{MessageTTL: 10 * time.Minute}).messageTTL)
+}
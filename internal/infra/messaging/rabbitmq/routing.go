@@ -0,0 +1,168 @@
+package rabbitmq
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/moura95/backend-challenge/internal/domain/email"
+	"github.com/streadway/amqp"
+)
+
+// DefaultEmailQueue is the queue used when no queue is configured.
+const DefaultEmailQueue = "email_notifications"
+
+// DefaultDeadLetterExchange receives messages rejected without requeue.
+const DefaultDeadLetterExchange = "email_notifications.dlx"
+
+// DeadLetterQueue returns the queue holding dead-lettered messages of queue.
+func DeadLetterQueue(queue string) string {
+	return queue + ".dlq"
+}
+
+// Route is the queue an email type is consumed from and the routing key
+// used to publish it.
+type Route struct {
+	Queue string
+	// RoutingKey defaults to the queue name.
+	RoutingKey string
+}
+
+func (r Route) withDefaults(queue string) Route {
+	if r.Queue == "" {
+		r.Queue = queue
+	}
+	if r.RoutingKey == "" {
+		r.RoutingKey = r.Queue
+	}
+	return r
+}
+
+// RouteFor returns the route for an email type, falling back to the default route.
+func (c *Connection) RouteFor(emailType email.EmailType) Route {
+	if route, ok := c.routes[emailType]; ok {
+		return route
+	}
+	return c.defaultRoute
+}
+
+// Queues returns every distinct queue emails may be routed to, default first.
+func (c *Connection) Queues() []string {
+	queues := []string{c.defaultRoute.Queue}
+	seen := map[string]bool{c.defaultRoute.Queue: true}
+
+	for _, route := range c.routes {
+		if !seen[route.Queue] {
+			seen[route.Queue] = true
+			queues = append(queues, route.Queue)
+		}
+	}
+
+	return queues
+}
+
+func (c *Connection) setupQueues() error {
+	if c.exchange != "" {
+		err := c.channel.ExchangeDeclare(
+			c.exchange, // name
+			"direct",   // kind
+			true,       // durable
+			false,      // auto-deleted
+			false,      // internal
+			false,      // no-wait
+			nil,        // arguments
+		)
+		if err != nil {
+			return fmt.Errorf("failed to declare email exchange: %w", err)
+		}
+	}
+
+	err := c.channel.ExchangeDeclare(
+		c.deadLetterExchange, // name
+		"direct",             // kind
+		true,                 // durable
+		false,                // auto-deleted
+		false,                // internal
+		false,                // no-wait
+		nil,                  // arguments
+	)
+	if err != nil {
+		return fmt.Errorf("failed to declare dead-letter exchange: %w", err)
+	}
+
+	routes := []Route{c.defaultRoute}
+	for _, route := range c.routes {
+		routes = append(routes, route)
+	}
+
+	declared := make(map[string]bool)
+	for _, route := range routes {
+		if !declared[route.Queue] {
+			if err := c.setupQueue(route.Queue); err != nil {
+				return err
+			}
+			declared[route.Queue] = true
+		}
+
+		// No exchange padrão a routing key já é o nome da fila
+		if c.exchange == "" {
+			continue
+		}
+		if err := c.channel.QueueBind(route.Queue, route.RoutingKey, c.exchange, false, nil); err != nil {
+			return fmt.Errorf("failed to bind email queue %s: %w", route.Queue, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *Connection) setupQueue(queueName string) error {
+	// DLQ sem TTL, para o operador inspecionar as mensagens rejeitadas
+	deadLetterQueue := DeadLetterQueue(queueName)
+	_, err := c.channel.QueueDeclare(
+		deadLetterQueue, // name
+		true,            // durable
+		false,           // delete when unused
+		false,           // exclusive
+		false,           // no-wait
+		nil,             // arguments
+	)
+	if err != nil {
+		return fmt.Errorf("failed to declare dead-letter queue: %w", err)
+	}
+	if err = c.channel.QueueBind(deadLetterQueue, queueName, c.deadLetterExchange, false, nil); err != nil {
+		return fmt.Errorf("failed to bind dead-letter queue %s: %w", deadLetterQueue, err)
+	}
+
+	_, err = c.channel.QueueDeclare(
+		queueName, // name
+		true,      // durable
+		false,     // delete when unused
+		false,     // exclusive
+		false,     // no-wait
+		amqp.Table{
+			"x-message-ttl": 3600000, // 1 hour TTL
+			// Rejeitadas sem requeue (e expiradas) vão para a DLQ da fila
+			"x-dead-letter-exchange":    c.deadLetterExchange,
+			"x-dead-letter-routing-key": queueName,
+		},
+	)
+	if err != nil {
+		return queueDeclareError(queueName, err)
+	}
+
+	log.Printf("Email queue %s setup completed", queueName)
+	return nil
+}
+
+// queueDeclareError explica o PRECONDITION_FAILED de uma fila criada antes
+// da dead-letter queue, que o broker não deixa redeclarar com novos argumentos.
+func queueDeclareError(queueName string, err error) error {
+	var amqpErr *amqp.Error
+	if errors.As(err, &amqpErr) && amqpErr.Code == amqp.PreconditionFailed {
+		return fmt.Errorf("failed to declare email queue: %s already exists with other arguments "+
+			"(declared before the dead-letter setup); stop the app and run "+
+			"scripts/rabbitmq-migrate-email-queues.sh %s: %w", queueName, queueName, err)
+	}
+	return fmt.Errorf("failed to declare email queue: %w", err)
+}
@@ -0,0 +1,260 @@
+package rabbitmq
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/moura95/backend-challenge/internal/domain/email"
+	"github.com/streadway/amqp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeChannel records exchange and queue declarations, bindings, consumers
+// and publishes.
+type fakeChannel struct {
+	mu        sync.Mutex
+	exchanges []string
+	declared  []string
+	bindings  map[string]string // queue -> routing key
+	consumed  []string
+	prefetch  int
+	published []amqp.Publishing
+	queueArgs map[string]amqp.Table
+	cancelled []string
+
+	// deliveries, when set, is handed to every consumer
+	deliveries chan amqp.Delivery
+	// confirms, when set, receives an ack for every publish
+	confirms chan amqp.Confirmation
+	// returns, when set, receives mandatory publishes no queue is bound for
+	returns chan amqp.Return
+	// redeliver, when set, feeds every publish back into deliveries
+	redeliver amqp.Acknowledger
+	// declareErrs fails the declaration of the given queues
+	declareErrs map[string]error
+}
+
+func newFakeChannel() *fakeChannel {
+	return &fakeChannel{bindings: make(map[string]string), queueArgs: make(map[string]amqp.Table)}
+}
+
+func (f *fakeChannel) ExchangeDeclare(name, kind string, durable, autoDelete, internal, noWait bool, args amqp.Table) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.exchanges = append(f.exchanges, name)
+	return nil
+}
+
+func (f *fakeChannel) QueueDeclare(name string, durable, autoDelete, exclusive, noWait bool, args amqp.Table) (amqp.Queue, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.declareErrs[name]; err != nil {
+		return amqp.Queue{}, err
+	}
+	f.declared = append(f.declared, name)
+	f.queueArgs[name] = args
+	return amqp.Queue{Name: name}, nil
+}
+
+func (f *fakeChannel) QueueBind(name, key, exchange string, noWait bool, args amqp.Table) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.bindings[name] = key
+	return nil
+}
+
+func (f *fakeChannel) Publish(exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.published = append(f.published, msg)
+	// Como o broker, devolver antes do ack
+	if mandatory && f.returns != nil && !f.routable(exchange, key) {
+		f.returns <- amqp.Return{
+			ReplyCode:  amqp.NoRoute,
+			ReplyText:  "NO_ROUTE",
+			Exchange:   exchange,
+			RoutingKey: key,
+			MessageId:  msg.MessageId,
+		}
+	}
+	if f.confirms != nil {
+		f.confirms <- amqp.Confirmation{DeliveryTag: uint64(len(f.published)), Ack: true}
+	}
+	if f.redeliver != nil {
+		f.deliveries <- amqp.Delivery{
+			Acknowledger: f.redeliver,
+			DeliveryTag:  uint64(len(f.published)),
+			Exchange:     exchange,
+			RoutingKey:   key,
+			Headers:      msg.Headers,
+			Body:         msg.Body,
+		}
+	}
+	return nil
+}
+
+// routable reports whether a queue was declared (default exchange) or bound
+// for the routing key. Callers hold f.mu.
+func (f *fakeChannel) routable(exchange, key string) bool {
+	if exchange == "" {
+		for _, queue := range f.declared {
+			if queue == key {
+				return true
+			}
+		}
+		return false
+	}
+	for _, bound := range f.bindings {
+		if bound == key {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *fakeChannel) Consume(queue, consumer string, autoAck, exclusive, noLocal, noWait bool, args amqp.Table) (<-chan amqp.Delivery, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.consumed = append(f.consumed, queue)
+	if f.deliveries != nil {
+		return f.deliveries, nil
+	}
+	return make(chan amqp.Delivery), nil
+}
+
+func (f *fakeChannel) Qos(prefetchCount, prefetchSize int, global bool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.prefetch = prefetchCount
+	return nil
+}
+
+func (f *fakeChannel) Cancel(consumer string, noWait bool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.cancelled = append(f.cancelled, consumer)
+	return nil
+}
+
+func (f *fakeChannel) Close() error { return nil }
+
+func (f *fakeChannel) publishings() []amqp.Publishing {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]amqp.Publishing(nil), f.published...)
+}
+
+func (f *fakeChannel) consumedQueues() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.consumed...)
+}
+
+func TestConnection_Routing(t *testing.T) {
+	t.Run("should use default queue when none is configured", func(t *testing.T) {
+		conn := newConnection(ConnectionConfig{})
+
+		assert.Equal(t, []string{DefaultEmailQueue}, conn.Queues())
+		assert.Equal(t, Route{Queue: DefaultEmailQueue, RoutingKey: DefaultEmailQueue}, conn.RouteFor(email.EmailTypeWelcome))
+	})
+
+	t.Run("should declare and consume custom queue from config", func(t *testing.T) {
+		channel := newFakeChannel()
+		conn := newConnection(ConnectionConfig{DefaultRoute: Route{Queue: "custom_emails"}})
+		conn.channel = channel
+
+		require.NoError(t, conn.setupQueues())
+		assert.Equal(t, []string{"custom_emails.dlq", "custom_emails"}, channel.declared)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() {
+			done <- conn.consumeQueues(ctx, func(context.Context, email.QueueMessage) error { return nil }, conn.Queues())
+		}()
+
+		assert.Eventually(t, func() bool {
+			return len(channel.consumedQueues()) == 1
+		}, time.Second, 10*time.Millisecond)
+		assert.Equal(t, []string{"custom_emails"}, channel.consumedQueues())
+
+		cancel()
+		assert.NoError(t, <-done)
+	})
+
+	t.Run("should route email types to dedicated queues", func(t *testing.T) {
+		channel := newFakeChannel()
+		conn := newConnection(ConnectionConfig{
+			Exchange: "emails",
+			Routes: map[email.EmailType]Route{
+				email.EmailTypePasswordReset: {Queue: "email_priority", RoutingKey: "email.priority"},
+			},
+		})
+		conn.channel = channel
+
+		require.NoError(t, conn.setupQueues())
+
+		assert.Equal(t, []string{"emails", DefaultDeadLetterExchange}, channel.exchanges)
+		assert.ElementsMatch(t, []string{
+			DefaultEmailQueue, DeadLetterQueue(DefaultEmailQueue),
+			"email_priority", DeadLetterQueue("email_priority"),
+		}, channel.declared)
+		assert.Equal(t, "email.priority", channel.bindings["email_priority"])
+		assert.Equal(t, DefaultEmailQueue, channel.bindings[DefaultEmailQueue])
+
+		// Welcome emails stay on the default queue
+		assert.Equal(t, DefaultEmailQueue, conn.RouteFor(email.EmailTypeWelcome).Queue)
+		assert.Equal(t, "email_priority", conn.RouteFor(email.EmailTypePasswordReset).Queue)
+	})
+
+	t.Run("should ignore routes without a queue", func(t *testing.T) {
+		conn := newConnection(ConnectionConfig{
+			Routes: map[email.EmailType]Route{email.EmailTypePasswordReset: {}},
+		})
+
+		assert.Equal(t, []string{DefaultEmailQueue}, conn.Queues())
+		assert.Equal(t, DefaultEmailQueue, conn.RouteFor(email.EmailTypePasswordReset).Queue)
+	})
+	t.Run("should dead-letter every queue into its own DLQ", func(t *testing.T) {
+		channel := newFakeChannel()
+		conn := newConnection(ConnectionConfig{
+			DeadLetterExchange: "emails.dlx",
+			Routes: map[email.EmailType]Route{
+				email.EmailTypePasswordReset: {Queue: "email_priority"},
+			},
+		})
+		conn.channel = channel
+
+		require.NoError(t, conn.setupQueues())
+
+		assert.Equal(t, []string{"emails.dlx"}, channel.exchanges)
+		for _, queue := range []string{DefaultEmailQueue, "email_priority"} {
+			args := channel.queueArgs[queue]
+			assert.Equal(t, "emails.dlx", args["x-dead-letter-exchange"])
+			assert.Equal(t, queue, args["x-dead-letter-routing-key"])
+
+			// The DLQ keeps messages until an operator handles them
+			assert.Nil(t, channel.queueArgs[DeadLetterQueue(queue)])
+			assert.Equal(t, queue, channel.bindings[DeadLetterQueue(queue)])
+		}
+	})
+
+	t.Run("should point to the migration script when the queue predates the DLQ", func(t *testing.T) {
+		channel := newFakeChannel()
+		// What the broker answers when an existing queue only has x-message-ttl
+		channel.declareErrs = map[string]error{DefaultEmailQueue: &amqp.Error{
+			Code:   amqp.PreconditionFailed,
+			Reason: "PRECONDITION_FAILED - inequivalent arg 'x-dead-letter-exchange' for queue 'email_notifications'",
+		}}
+		conn := newConnection(ConnectionConfig{})
+		conn.channel = channel
+
+		err := conn.setupQueues()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "scripts/rabbitmq-migrate-email-queues.sh email_notifications")
+		assert.Contains(t, err.Error(), "inequivalent arg")
+	})
+}
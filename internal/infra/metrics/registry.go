@@ -0,0 +1,182 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultBuckets are upper bounds, in seconds, suited to request and
+// message processing latencies.
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Default is the process-wide registry served on GET /metrics.
+var Default = NewRegistry()
+
+// Registry keeps the metrics of the process and writes them in the
+// Prometheus text exposition format.
+type Registry struct {
+	mu         sync.Mutex
+	histograms map[string]*Histogram
+}
+
+func NewRegistry() *Registry {
+	return &Registry{histograms: make(map[string]*Histogram)}
+}
+
+// Histogram returns the histogram registered under name, creating it on the
+// first call. Observations are partitioned by the value of label; nil
+// buckets use DefaultBuckets.
+func (r *Registry) Histogram(name, help, label string, buckets []float64) *Histogram {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if histogram, ok := r.histograms[name]; ok {
+		return histogram
+	}
+
+	if buckets == nil {
+		buckets = DefaultBuckets
+	}
+	bounds := append([]float64(nil), buckets...)
+	sort.Float64s(bounds)
+
+	histogram := &Histogram{
+		name:   name,
+		help:   help,
+		label:  label,
+		bounds: bounds,
+		series: make(map[string]*histogramSeries),
+	}
+	r.histograms[name] = histogram
+	return histogram
+}
+
+// WriteText writes every metric in the Prometheus text format, sorted by
+// name and label value so the output is stable.
+func (r *Registry) WriteText(w io.Writer) error {
+	r.mu.Lock()
+	names := make([]string, 0, len(r.histograms))
+	for name := range r.histograms {
+		names = append(names, name)
+	}
+	histograms := r.histograms
+	r.mu.Unlock()
+
+	sort.Strings(names)
+	for _, name := range names {
+		if err := histograms[name].writeText(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Histogram counts observations into cumulative buckets per label value.
+type Histogram struct {
+	name   string
+	help   string
+	label  string
+	bounds []float64
+
+	mu     sync.Mutex
+	series map[string]*histogramSeries
+}
+
+type histogramSeries struct {
+	counts []uint64 // one per bound, not cumulative
+	count  uint64
+	sum    float64
+}
+
+// Snapshot is a point-in-time copy of one histogram series.
+type Snapshot struct {
+	Count uint64
+	Sum   float64
+	// Buckets maps each upper bound to the cumulative count of observations <= bound
+	Buckets map[float64]uint64
+}
+
+// Observe records value for the given label value.
+func (h *Histogram) Observe(labelValue string, value float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	series, ok := h.series[labelValue]
+	if !ok {
+		series = &histogramSeries{counts: make([]uint64, len(h.bounds))}
+		h.series[labelValue] = series
+	}
+
+	for i, bound := range h.bounds {
+		if value <= bound {
+			series.counts[i]++
+			break
+		}
+	}
+	series.count++
+	series.sum += value
+}
+
+// Snapshot returns the series of labelValue; ok is false when nothing was
+// observed for it.
+func (h *Histogram) Snapshot(labelValue string) (Snapshot, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	series, ok := h.series[labelValue]
+	if !ok {
+		return Snapshot{}, false
+	}
+
+	snapshot := Snapshot{Count: series.count, Sum: series.sum, Buckets: make(map[float64]uint64, len(h.bounds))}
+	var cumulative uint64
+	for i, bound := range h.bounds {
+		cumulative += series.counts[i]
+		snapshot.Buckets[bound] = cumulative
+	}
+	return snapshot, true
+}
+
+func (h *Histogram) writeText(w io.Writer) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# HELP %s %s\n", h.name, h.help)
+	fmt.Fprintf(&b, "# TYPE %s histogram\n", h.name)
+
+	labelValues := make([]string, 0, len(h.series))
+	for labelValue := range h.series {
+		labelValues = append(labelValues, labelValue)
+	}
+	sort.Strings(labelValues)
+
+	for _, labelValue := range labelValues {
+		series := h.series[labelValue]
+		label := fmt.Sprintf("%s=%q", h.label, labelValue)
+
+		var cumulative uint64
+		for i, bound := range h.bounds {
+			cumulative += series.counts[i]
+			fmt.Fprintf(&b, "%s_bucket{%s,le=\"%s\"} %d\n", h.name, label, formatFloat(bound), cumulative)
+		}
+		fmt.Fprintf(&b, "%s_bucket{%s,le=\"+Inf\"} %d\n", h.name, label, series.count)
+		fmt.Fprintf(&b, "%s_sum{%s} %s\n", h.name, label, formatFloat(series.sum))
+		fmt.Fprintf(&b, "%s_count{%s} %d\n", h.name, label, series.count)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func formatFloat(value float64) string {
+	if math.IsInf(value, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
@@ -0,0 +1,57 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistogram(t *testing.T) {
+	t.Run("should count observations into cumulative buckets per label", func(t *testing.T) {
+		registry := NewRegistry()
+		histogram := registry.Histogram("latency_seconds", "Latency.", "type", []float64{1, 0.1})
+
+		histogram.Observe("welcome", 0.05)
+		histogram.Observe("welcome", 0.5)
+		histogram.Observe("welcome", 3)
+		histogram.Observe("password_reset", 0.05)
+
+		snapshot, ok := histogram.Snapshot("welcome")
+		require.True(t, ok)
+		assert.Equal(t, uint64(3), snapshot.Count)
+		assert.InDelta(t, 3.55, snapshot.Sum, 1e-9)
+		assert.Equal(t, map[float64]uint64{0.1: 1, 1: 2}, snapshot.Buckets)
+
+		_, ok = histogram.Snapshot("verification")
+		assert.False(t, ok)
+	})
+
+	t.Run("should return the registered histogram for the same name", func(t *testing.T) {
+		registry := NewRegistry()
+
+		first := registry.Histogram("latency_seconds", "Latency.", "type", nil)
+		second := registry.Histogram("latency_seconds", "Other.", "kind", []float64{1})
+
+		assert.Same(t, first, second)
+	})
+
+	t.Run("should write the prometheus text format", func(t *testing.T) {
+		registry := NewRegistry()
+		histogram := registry.Histogram("latency_seconds", "Latency.", "type", []float64{0.1, 1})
+		histogram.Observe("welcome", 0.5)
+
+		var out strings.Builder
+		require.NoError(t, registry.WriteText(&out))
+
+		assert.Equal(t, `# HELP latency_seconds Latency.
+# TYPE latency_seconds histogram
+latency_seconds_bucket{type="welcome",le="0.1"} 0
+latency_seconds_bucket{type="welcome",le="1"} 1
+latency_seconds_bucket{type="welcome",le="+Inf"} 1
+latency_seconds_sum{type="welcome"} 0.5
+latency_seconds_count{type="welcome"} 1
+`, out.String())
+	})
+}
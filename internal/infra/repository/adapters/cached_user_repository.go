@@ -0,0 +1,253 @@
+package adapters
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moura95/backend-challenge/internal/domain/user"
+)
+
+// DefaultUserCacheSize is used when the cache is enabled without a size.
+const DefaultUserCacheSize = 1000
+
+type cachedUser struct {
+	id        uuid.UUID
+	user      *user.User
+	expiresAt time.Time
+}
+
+// cachedUserRepository guarda o resultado de GetByID em um LRU em memória
+// com TTL curto. Qualquer escrita no usuário invalida a entrada.
+type cachedUserRepository struct {
+	user.Repository
+
+	mu      sync.Mutex
+	entries map[uuid.UUID]*list.Element
+	order   *list.List // frente = mais recente
+	ttl     time.Duration
+	size    int
+	now     func() time.Time
+}
+
+// NewCachedUserRepository envolve inner com cache de GetByID. Tamanho não
+// positivo usa o padrão.
+func NewCachedUserRepository(inner user.Repository, ttl time.Duration, size int) user.Repository {
+	if size <= 0 {
+		size = DefaultUserCacheSize
+	}
+
+	return &cachedUserRepository{
+		Repository: inner,
+		entries:    make(map[uuid.UUID]*list.Element),
+		order:      list.New(),
+		ttl:        ttl,
+		size:       size,
+		now:        time.Now,
+	}
+}
+
+func (r *cachedUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*user.User, error) {
+	if cached, ok := r.get(id); ok {
+		return cached, nil
+	}
+
+	domainUser, err := r.Repository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	r.set(id, domainUser)
+	return cloneUser(domainUser), nil
+}
+
+func (r *cachedUserRepository) Update(ctx context.Context, domainUser *user.User) error {
+	defer r.invalidate(domainUser.ID)
+	return r.Repository.Update(ctx, domainUser)
+}
+
+func (r *cachedUserRepository) UpdatePassword(ctx context.Context, domainUser *user.User) error {
+	defer r.invalidate(domainUser.ID)
+	return r.Repository.UpdatePassword(ctx, domainUser)
+}
+
+func (r *cachedUserRepository) MarkEmailVerified(ctx context.Context, id uuid.UUID) error {
+	defer r.invalidate(id)
+	return r.Repository.MarkEmailVerified(ctx, id)
+}
+
+func (r *cachedUserRepository) UpdateRole(ctx context.Context, id uuid.UUID, role user.Role) error {
+	defer r.invalidate(id)
+	return r.Repository.UpdateRole(ctx, id, role)
+}
+
+func (r *cachedUserRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status user.Status) error {
+	defer r.invalidate(id)
+	return r.Repository.UpdateStatus(ctx, id, status)
+}
+
+func (r *cachedUserRepository) IncrementTokenVersion(ctx context.Context, id uuid.UUID) (int, error) {
+	defer r.invalidate(id)
+	return r.Repository.IncrementTokenVersion(ctx, id)
+}
+
+func (r *cachedUserRepository) UpdateLastLogin(ctx context.Context, id uuid.UUID, at time.Time) error {
+	defer r.invalidate(id)
+	return r.Repository.UpdateLastLogin(ctx, id, at)
+}
+
+func (r *cachedUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	defer r.invalidate(id)
+	return r.Repository.Delete(ctx, id)
+}
+
+func (r *cachedUserRepository) DeleteMany(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	defer func() {
+		for _, id := range ids {
+			r.invalidate(id)
+		}
+	}()
+	return r.Repository.DeleteMany(ctx, ids)
+}
+
+// txCachedUserRepository é o repositório de usuários de uma transação com o
+// cache habilitado: lê direto da transação e só invalida os usuários
+// alterados depois do commit, para ninguém guardar dados não confirmados.
+type txCachedUserRepository struct {
+	user.Repository
+
+	cache   *cachedUserRepository
+	written []uuid.UUID
+}
+
+func (r *cachedUserRepository) inTx(inner user.Repository) *txCachedUserRepository {
+	return &txCachedUserRepository{Repository: inner, cache: r}
+}
+
+func (r *txCachedUserRepository) Update(ctx context.Context, domainUser *user.User) error {
+	r.written = append(r.written, domainUser.ID)
+	return r.Repository.Update(ctx, domainUser)
+}
+
+func (r *txCachedUserRepository) UpdatePassword(ctx context.Context, domainUser *user.User) error {
+	r.written = append(r.written, domainUser.ID)
+	return r.Repository.UpdatePassword(ctx, domainUser)
+}
+
+func (r *txCachedUserRepository) MarkEmailVerified(ctx context.Context, id uuid.UUID) error {
+	r.written = append(r.written, id)
+	return r.Repository.MarkEmailVerified(ctx, id)
+}
+
+func (r *txCachedUserRepository) UpdateRole(ctx context.Context, id uuid.UUID, role user.Role) error {
+	r.written = append(r.written, id)
+	return r.Repository.UpdateRole(ctx, id, role)
+}
+
+func (r *txCachedUserRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status user.Status) error {
+	r.written = append(r.written, id)
+	return r.Repository.UpdateStatus(ctx, id, status)
+}
+
+func (r *txCachedUserRepository) IncrementTokenVersion(ctx context.Context, id uuid.UUID) (int, error) {
+	r.written = append(r.written, id)
+	return r.Repository.IncrementTokenVersion(ctx, id)
+}
+
+func (r *txCachedUserRepository) UpdateLastLogin(ctx context.Context, id uuid.UUID, at time.Time) error {
+	r.written = append(r.written, id)
+	return r.Repository.UpdateLastLogin(ctx, id, at)
+}
+
+func (r *txCachedUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.written = append(r.written, id)
+	return r.Repository.Delete(ctx, id)
+}
+
+func (r *txCachedUserRepository) DeleteMany(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	r.written = append(r.written, ids...)
+	return r.Repository.DeleteMany(ctx, ids)
+}
+
+// committed invalida no cache os usuários escritos pela transação.
+func (r *txCachedUserRepository) committed() {
+	for _, id := range r.written {
+		r.cache.invalidate(id)
+	}
+}
+
+func (r *cachedUserRepository) get(id uuid.UUID) (*user.User, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	elem, ok := r.entries[id]
+	if !ok {
+		return nil, false
+	}
+
+	entry := elem.Value.(*cachedUser)
+	if !r.now().Before(entry.expiresAt) {
+		r.order.Remove(elem)
+		delete(r.entries, id)
+		return nil, false
+	}
+
+	r.order.MoveToFront(elem)
+	return cloneUser(entry.user), true
+}
+
+func (r *cachedUserRepository) set(id uuid.UUID, domainUser *user.User) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry := &cachedUser{
+		id:        id,
+		user:      cloneUser(domainUser),
+		expiresAt: r.now().Add(r.ttl),
+	}
+
+	if elem, ok := r.entries[id]; ok {
+		elem.Value = entry
+		r.order.MoveToFront(elem)
+		return
+	}
+
+	r.entries[id] = r.order.PushFront(entry)
+
+	// Remove o menos usado quando passa do limite
+	if r.order.Len() > r.size {
+		oldest := r.order.Back()
+		r.order.Remove(oldest)
+		delete(r.entries, oldest.Value.(*cachedUser).id)
+	}
+}
+
+func (r *cachedUserRepository) invalidate(id uuid.UUID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if elem, ok := r.entries[id]; ok {
+		r.order.Remove(elem)
+		delete(r.entries, id)
+	}
+}
+
+// cloneUser evita que quem chama altere a cópia guardada no cache
+func cloneUser(u *user.User) *user.User {
+	clone := *u
+	if u.VerifiedAt != nil {
+		verifiedAt := *u.VerifiedAt
+		clone.VerifiedAt = &verifiedAt
+	}
+	if u.DeletedAt != nil {
+		deletedAt := *u.DeletedAt
+		clone.DeletedAt = &deletedAt
+	}
+	if u.LastLoginAt != nil {
+		lastLoginAt := *u.LastLoginAt
+		clone.LastLoginAt = &lastLoginAt
+	}
+	return &clone
+}
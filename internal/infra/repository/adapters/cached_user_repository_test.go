@@ -0,0 +1,318 @@
+package adapters
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	authUC "github.com/moura95/backend-challenge/internal/application/usecases/auth"
+	userUC "github.com/moura95/backend-challenge/internal/application/usecases/user"
+	"github.com/moura95/backend-challenge/internal/domain/token"
+	"github.com/moura95/backend-challenge/internal/domain/user"
+	"github.com/moura95/backend-challenge/internal/infra/security/jwt"
+)
+
+// countingUserRepository serves users from a map and counts GetByID calls
+type countingUserRepository struct {
+	user.Repository
+	users    map[uuid.UUID]*user.User
+	getCalls int
+}
+
+func newCountingUserRepository(users ...*user.User) *countingUserRepository {
+	repo := &countingUserRepository{users: make(map[uuid.UUID]*user.User)}
+	for _, u := range users {
+		repo.users[u.ID] = u
+	}
+	return repo
+}
+
+func (r *countingUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*user.User, error) {
+	r.getCalls++
+	u, ok := r.users[id]
+	if !ok {
+		return nil, user.ErrUserNotFound
+	}
+	clone := *u
+	return &clone, nil
+}
+
+func (r *countingUserRepository) Update(ctx context.Context, u *user.User) error {
+	r.users[u.ID] = u
+	return nil
+}
+
+func (r *countingUserRepository) IncrementTokenVersion(ctx context.Context, id uuid.UUID) (int, error) {
+	u, ok := r.users[id]
+	if !ok {
+		return 0, user.ErrUserNotFound
+	}
+	u.TokenVersion++
+	return u.TokenVersion, nil
+}
+
+func (r *countingUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	delete(r.users, id)
+	return nil
+}
+
+func (r *countingUserRepository) DeleteMany(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	var deleted []uuid.UUID
+	for _, id := range ids {
+		if _, ok := r.users[id]; ok {
+			delete(r.users, id)
+			deleted = append(deleted, id)
+		}
+	}
+	return deleted, nil
+}
+
+func newCachedRepoForTest(inner user.Repository, ttl time.Duration, size int) (*cachedUserRepository, *time.Time) {
+	current := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	repo := NewCachedUserRepository(inner, ttl, size).(*cachedUserRepository)
+	repo.now = func() time.Time { return current }
+	return repo, &current
+}
+
+func newCacheTestUser(name string) *user.User {
+	return &user.User{ID: uuid.New(), Name: name, Email: name + "@example.com", Role: user.RoleUser}
+}
+
+func TestCachedUserRepository_GetByID(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should serve second call within TTL from cache", func(t *testing.T) {
+		u := newCacheTestUser("john")
+		inner := newCountingUserRepository(u)
+		repo, _ := newCachedRepoForTest(inner, time.Minute, 10)
+
+		first, err := repo.GetByID(ctx, u.ID)
+		require.NoError(t, err)
+		second, err := repo.GetByID(ctx, u.ID)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, inner.getCalls)
+		assert.Equal(t, first, second)
+	})
+
+	t.Run("should reload after TTL expires", func(t *testing.T) {
+		u := newCacheTestUser("jane")
+		inner := newCountingUserRepository(u)
+		repo, now := newCachedRepoForTest(inner, time.Minute, 10)
+
+		_, err := repo.GetByID(ctx, u.ID)
+		require.NoError(t, err)
+
+		*now = now.Add(time.Minute)
+		_, err = repo.GetByID(ctx, u.ID)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, inner.getCalls)
+	})
+
+	t.Run("should not cache errors", func(t *testing.T) {
+		inner := newCountingUserRepository()
+		repo, _ := newCachedRepoForTest(inner, time.Minute, 10)
+
+		id := uuid.New()
+		_, err := repo.GetByID(ctx, id)
+		assert.ErrorIs(t, err, user.ErrUserNotFound)
+		_, err = repo.GetByID(ctx, id)
+		assert.ErrorIs(t, err, user.ErrUserNotFound)
+
+		assert.Equal(t, 2, inner.getCalls)
+	})
+
+	t.Run("should not expose the cached entry to callers", func(t *testing.T) {
+		u := newCacheTestUser("mutable")
+		inner := newCountingUserRepository(u)
+		repo, _ := newCachedRepoForTest(inner, time.Minute, 10)
+
+		first, err := repo.GetByID(ctx, u.ID)
+		require.NoError(t, err)
+		first.Name = "changed by caller"
+
+		second, err := repo.GetByID(ctx, u.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "mutable", second.Name)
+	})
+
+	t.Run("should evict least recently used entry", func(t *testing.T) {
+		a, b, c := newCacheTestUser("a"), newCacheTestUser("b"), newCacheTestUser("c")
+		inner := newCountingUserRepository(a, b, c)
+		repo, _ := newCachedRepoForTest(inner, time.Minute, 2)
+
+		for _, id := range []uuid.UUID{a.ID, b.ID, a.ID, c.ID} {
+			_, err := repo.GetByID(ctx, id)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, 3, inner.getCalls)
+
+		// b was the least recently used when c was added
+		_, err := repo.GetByID(ctx, a.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 3, inner.getCalls)
+
+		_, err = repo.GetByID(ctx, b.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 4, inner.getCalls)
+	})
+}
+
+func TestCachedUserRepository_Invalidation(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should invalidate on update", func(t *testing.T) {
+		u := newCacheTestUser("before")
+		inner := newCountingUserRepository(u)
+		repo, _ := newCachedRepoForTest(inner, time.Minute, 10)
+
+		_, err := repo.GetByID(ctx, u.ID)
+		require.NoError(t, err)
+
+		updated := *u
+		updated.Name = "after"
+		require.NoError(t, repo.Update(ctx, &updated))
+
+		got, err := repo.GetByID(ctx, u.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "after", got.Name)
+		assert.Equal(t, 2, inner.getCalls)
+	})
+
+	t.Run("should invalidate on delete", func(t *testing.T) {
+		u := newCacheTestUser("deleted")
+		inner := newCountingUserRepository(u)
+		repo, _ := newCachedRepoForTest(inner, time.Minute, 10)
+
+		_, err := repo.GetByID(ctx, u.ID)
+		require.NoError(t, err)
+
+		require.NoError(t, repo.Delete(ctx, u.ID))
+
+		_, err = repo.GetByID(ctx, u.ID)
+		assert.ErrorIs(t, err, user.ErrUserNotFound)
+		assert.Equal(t, 2, inner.getCalls)
+	})
+	t.Run("should invalidate on bulk delete", func(t *testing.T) {
+		deletedUser, keptUser := newCacheTestUser("bulk"), newCacheTestUser("kept")
+		inner := newCountingUserRepository(deletedUser, keptUser)
+		repo, _ := newCachedRepoForTest(inner, time.Minute, 10)
+
+		for _, u := range []*user.User{deletedUser, keptUser} {
+			_, err := repo.GetByID(ctx, u.ID)
+			require.NoError(t, err)
+		}
+
+		deleted, err := repo.DeleteMany(ctx, []uuid.UUID{deletedUser.ID})
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{deletedUser.ID}, deleted)
+
+		_, err = repo.GetByID(ctx, deletedUser.ID)
+		assert.ErrorIs(t, err, user.ErrUserNotFound)
+		_, err = repo.GetByID(ctx, keptUser.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 3, inner.getCalls)
+	})
+
+	t.Run("should invalidate on token version increment", func(t *testing.T) {
+		u := newCacheTestUser("revoked")
+		inner := newCountingUserRepository(u)
+		repo, _ := newCachedRepoForTest(inner, time.Minute, 10)
+
+		_, err := repo.GetByID(ctx, u.ID)
+		require.NoError(t, err)
+
+		version, err := repo.IncrementTokenVersion(ctx, u.ID)
+		require.NoError(t, err)
+
+		got, err := repo.GetByID(ctx, u.ID)
+		require.NoError(t, err)
+		assert.Equal(t, version, got.TokenVersion)
+		assert.Equal(t, 2, inner.getCalls)
+	})
+
+	t.Run("should invalidate a token version increment in a transaction after commit", func(t *testing.T) {
+		u := newCacheTestUser("txrevoked")
+		inner := newCountingUserRepository(u)
+		repo, _ := newCachedRepoForTest(inner, time.Minute, 10)
+
+		_, err := repo.GetByID(ctx, u.ID)
+		require.NoError(t, err)
+
+		txRepo := repo.inTx(inner)
+		version, err := txRepo.IncrementTokenVersion(ctx, u.ID)
+		require.NoError(t, err)
+		txRepo.committed()
+
+		got, err := repo.GetByID(ctx, u.ID)
+		require.NoError(t, err)
+		assert.Equal(t, version, got.TokenVersion)
+	})
+
+	t.Run("should invalidate transaction writes only after commit", func(t *testing.T) {
+		u := newCacheTestUser("tx")
+		inner := newCountingUserRepository(u)
+		repo, _ := newCachedRepoForTest(inner, time.Minute, 10)
+
+		_, err := repo.GetByID(ctx, u.ID)
+		require.NoError(t, err)
+
+		// Transaction reads bypass the cache and its writes go to the inner repository
+		txRepo := repo.inTx(inner)
+		txUser, err := txRepo.GetByID(ctx, u.ID)
+		require.NoError(t, err)
+		txUser.Name = "committed"
+		require.NoError(t, txRepo.Update(ctx, txUser))
+		assert.Equal(t, 2, inner.getCalls)
+
+		// Until commit other callers keep the cached copy
+		cached, err := repo.GetByID(ctx, u.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "tx", cached.Name)
+
+		txRepo.committed()
+
+		reloaded, err := repo.GetByID(ctx, u.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "committed", reloaded.Name)
+		assert.Equal(t, 3, inner.getCalls)
+	})
+}
+
+// notRevokedTokenRepository reports every token ID as not revoked, so only
+// the token version decides.
+type notRevokedTokenRepository struct {
+	token.Repository
+}
+
+func (notRevokedTokenRepository) IsRevoked(ctx context.Context, tokenID uuid.UUID) (bool, error) {
+	return false, nil
+}
+
+func TestCachedUserRepository_RevokeSessions(t *testing.T) {
+	ctx := context.Background()
+
+	u := newCacheTestUser("sessions")
+	repo, _ := newCachedRepoForTest(newCountingUserRepository(u), time.Minute, 10)
+
+	tokenMaker, err := jwt.NewPasetoMaker("12345678901234567890123456789012")
+	require.NoError(t, err)
+	oldToken, _, err := tokenMaker.CreateToken(u.ID, u.TokenVersion, time.Minute)
+	require.NoError(t, err)
+
+	verifyTokenUC := authUC.NewVerifyTokenUseCase(repo, notRevokedTokenRepository{}, tokenMaker)
+
+	// Warms the cache with the current token version
+	_, err = verifyTokenUC.Execute(ctx, oldToken)
+	require.NoError(t, err)
+
+	require.NoError(t, userUC.NewRevokeSessionsUseCase(repo).Execute(ctx, u.ID.String()))
+
+	_, err = verifyTokenUC.Execute(ctx, oldToken)
+	require.ErrorIs(t, err, token.ErrTokenRevoked)
+}
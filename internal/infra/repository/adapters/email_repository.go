@@ -4,40 +4,159 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
 	"github.com/moura95/backend-challenge/internal/domain/email"
 	"github.com/moura95/backend-challenge/internal/infra/repository/sqlc"
 )
 
 type emailRepository struct {
-	db *sqlc.Queries
+	db   *sqlc.Queries
+	conn *sqlx.DB // nil when bound to a transaction
 }
 
-func NewEmailRepository(db *sqlc.Queries) email.Repository {
+func NewEmailRepository(conn *sqlx.DB) email.Repository {
 	return &emailRepository{
-		db: db,
+		db:   sqlc.New(conn),
+		conn: conn,
 	}
 }
 
 func (r *emailRepository) Create(ctx context.Context, domainEmail *email.Email) error {
+	// Sem anexos (ou já dentro de uma transação) basta um INSERT
+	if len(domainEmail.Attachments) == 0 || r.conn == nil {
+		return r.create(ctx, r.db, domainEmail)
+	}
+
+	// Email e anexos na mesma transação: um email pendente nunca fica sem os anexos
+	tx, err := r.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("repository: create email failed: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := r.create(ctx, r.db.WithTx(tx), domainEmail); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("repository: create email failed: commit: %w", err)
+	}
+
+	return nil
+}
+
+func (r *emailRepository) create(ctx context.Context, queries *sqlc.Queries, domainEmail *email.Email) error {
 	params := sqlc.CreateEmailParams{
 		ToEmail:     domainEmail.To,
 		Subject:     domainEmail.Subject,
 		Body:        domainEmail.Body,
+		PlainBody:   domainEmail.PlainBody,
 		Type:        string(domainEmail.Type),
 		Status:      string(domainEmail.Status),
 		Attempts:    int32(domainEmail.Attempts),
 		MaxAttempts: int32(domainEmail.MaxAttempts),
+		// Colunas NOT NULL: lista vazia em vez de NULL
+		CcEmails:    append([]string{}, domainEmail.Cc...),
+		BccEmails:   append([]string{}, domainEmail.Bcc...),
+		DedupKey:    sql.NullString{String: domainEmail.DedupKey, Valid: domainEmail.DedupKey != ""},
+		ScheduledAt: nullTime(domainEmail.ScheduledAt),
 	}
 
-	sqlcEmail, err := r.db.CreateEmail(ctx, params)
+	sqlcEmail, err := queries.CreateEmail(ctx, params)
 	if err != nil {
 		return fmt.Errorf("repository: create email failed: %w", err)
 	}
 
+	return createAttachments(ctx, queries, domainEmail, sqlcEmail)
+}
+
+// CreateIfNotExists serializa as criações com a mesma chave por um advisory
+// lock da transação: duas chamadas simultâneas não passam ambas pelo NOT EXISTS.
+func (r *emailRepository) CreateIfNotExists(ctx context.Context, domainEmail *email.Email, window time.Duration) (bool, error) {
+	if domainEmail.DedupKey == "" {
+		domainEmail.DedupKey = email.DedupKey(domainEmail.Type, domainEmail.To)
+	}
+
+	// Já dentro de uma transação o lock vale até o commit dela
+	if r.conn == nil {
+		return r.createIfNotExists(ctx, r.db, domainEmail, window)
+	}
+
+	tx, err := r.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("repository: create email if not exists failed: %w", err)
+	}
+	defer tx.Rollback()
+
+	created, err := r.createIfNotExists(ctx, r.db.WithTx(tx), domainEmail, window)
+	if err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("repository: create email if not exists failed: commit: %w", err)
+	}
+
+	return created, nil
+}
+
+func (r *emailRepository) createIfNotExists(ctx context.Context, queries *sqlc.Queries, domainEmail *email.Email, window time.Duration) (bool, error) {
+	if err := queries.LockEmailDedupKey(ctx, domainEmail.DedupKey); err != nil {
+		return false, fmt.Errorf("repository: create email if not exists failed: lock: %w", err)
+	}
+
+	sqlcEmail, err := queries.CreateEmailIfNotExists(ctx, sqlc.CreateEmailIfNotExistsParams{
+		ToEmail:       domainEmail.To,
+		Subject:       domainEmail.Subject,
+		Body:          domainEmail.Body,
+		PlainBody:     domainEmail.PlainBody,
+		Type:          string(domainEmail.Type),
+		Status:        string(domainEmail.Status),
+		Attempts:      int32(domainEmail.Attempts),
+		MaxAttempts:   int32(domainEmail.MaxAttempts),
+		CcEmails:      append([]string{}, domainEmail.Cc...),
+		BccEmails:     append([]string{}, domainEmail.Bcc...),
+		ScheduledAt:   nullTime(domainEmail.ScheduledAt),
+		DedupKey:      domainEmail.DedupKey,
+		WindowSeconds: window.Seconds(),
+	})
+	if err != nil {
+		// Nenhuma linha inserida: já existe um email com a chave dentro da janela
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, fmt.Errorf("repository: create email if not exists failed: %w", err)
+	}
+
+	if err := createAttachments(ctx, queries, domainEmail, sqlcEmail); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// createAttachments grava os anexos do email recém-criado e preenche os
+// campos gerados pelo banco.
+func createAttachments(ctx context.Context, queries *sqlc.Queries, domainEmail *email.Email, sqlcEmail sqlc.Email) error {
+	for _, attachment := range domainEmail.Attachments {
+		err := queries.CreateEmailAttachment(ctx, sqlc.CreateEmailAttachmentParams{
+			EmailUuid:   sqlcEmail.Uuid,
+			Filename:    attachment.Filename,
+			ContentType: attachment.ContentType,
+			Content:     attachment.Content,
+		})
+		if err != nil {
+			return fmt.Errorf("repository: create email attachment failed: %w", err)
+		}
+	}
+
 	domainEmail.ID = sqlcEmail.Uuid
 	domainEmail.CreatedAt = sqlcEmail.CreatedAt
+	domainEmail.NextAttemptAt = sqlcEmail.NextAttemptAt
 
 	return nil
 }
@@ -46,12 +165,17 @@ func (r *emailRepository) GetByID(ctx context.Context, id uuid.UUID) (*email.Ema
 	sqlcEmail, err := r.db.GetEmailByID(ctx, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("repository: get email by id failed: email not found")
+			return nil, fmt.Errorf("repository: get email by id failed: %w", email.ErrEmailNotFound)
 		}
 		return nil, fmt.Errorf("repository: get email by id failed: %w", err)
 	}
 
-	return sqlcEmailToDomain(sqlcEmail), nil
+	domainEmail := sqlcEmailToDomain(sqlcEmail)
+	if err := loadAttachments(ctx, r.db, domainEmail); err != nil {
+		return nil, err
+	}
+
+	return domainEmail, nil
 }
 
 func (r *emailRepository) Update(ctx context.Context, domainEmail *email.Email) error {
@@ -81,10 +205,17 @@ func (r *emailRepository) Update(ctx context.Context, domainEmail *email.Email)
 		}
 	}
 
+	if !domainEmail.NextAttemptAt.IsZero() {
+		params.NextAttemptAt = sql.NullTime{
+			Time:  domainEmail.NextAttemptAt,
+			Valid: true,
+		}
+	}
+
 	err := r.db.UpdateEmail(ctx, params)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return fmt.Errorf("repository: update email failed: email not found")
+			return fmt.Errorf("repository: update email failed: %w", email.ErrEmailNotFound)
 		}
 		return fmt.Errorf("repository: update email failed: %w", err)
 	}
@@ -92,6 +223,47 @@ func (r *emailRepository) Update(ctx context.Context, domainEmail *email.Email)
 	return nil
 }
 
+func (r *emailRepository) LockForProcessing(ctx context.Context, id uuid.UUID, fn func(*email.Email, email.Repository) error) error {
+	if r.conn == nil {
+		return fmt.Errorf("repository: lock email failed: already inside a transaction")
+	}
+
+	tx, err := r.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("repository: lock email failed: %w", err)
+	}
+	defer tx.Rollback()
+
+	txQueries := r.db.WithTx(tx)
+
+	sqlcEmail, err := txQueries.LockEmailForProcessing(ctx, id)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			return fmt.Errorf("repository: lock email failed: %w", err)
+		}
+		// Sem linha: ou não existe, ou outro worker está com o lock
+		if _, getErr := r.db.GetEmailByID(ctx, id); getErr == sql.ErrNoRows {
+			return fmt.Errorf("repository: lock email failed: %w", email.ErrEmailNotFound)
+		}
+		return email.ErrEmailLocked
+	}
+
+	domainEmail := sqlcEmailToDomain(sqlcEmail)
+	if err := loadAttachments(ctx, txQueries, domainEmail); err != nil {
+		return err
+	}
+
+	if err := fn(domainEmail, &emailRepository{db: txQueries}); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("repository: lock email failed: commit: %w", err)
+	}
+
+	return nil
+}
+
 func (r *emailRepository) GetPendingEmails(ctx context.Context, limit int) ([]*email.Email, error) {
 	if limit <= 0 {
 		limit = 10
@@ -110,17 +282,211 @@ func (r *emailRepository) GetPendingEmails(ctx context.Context, limit int) ([]*e
 	return emails, nil
 }
 
+func (r *emailRepository) CountPending(ctx context.Context) (int, error) {
+	count, err := r.db.CountPendingEmails(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("repository: count pending emails failed: %w", err)
+	}
+
+	return int(count), nil
+}
+
+func (r *emailRepository) GetByRecipient(ctx context.Context, to string) ([]*email.Email, error) {
+	sqlcEmails, err := r.db.GetEmailsByRecipient(ctx, to)
+	if err != nil {
+		return nil, fmt.Errorf("repository: get emails by recipient failed: %w", err)
+	}
+
+	emails := make([]*email.Email, len(sqlcEmails))
+	for i, sqlcEmail := range sqlcEmails {
+		emails[i] = sqlcEmailToDomain(sqlcEmail)
+	}
+
+	return emails, nil
+}
+
+func (r *emailRepository) ListByRecipient(ctx context.Context, to string, params email.RecipientListParams) ([]*email.Email, int, error) {
+	if params.Page <= 0 {
+		params.Page = 1
+	}
+	if params.PageSize <= 0 {
+		params.PageSize = 10
+	}
+
+	rows, err := r.db.ListEmailsByRecipient(ctx, sqlc.ListEmailsByRecipientParams{
+		IncludeBody: params.IncludeBody,
+		ToEmail:     to,
+		Limit:       int32(params.PageSize),
+		Offset:      int32((params.Page - 1) * params.PageSize),
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("repository: list emails by recipient failed: %w", err)
+	}
+
+	total, err := r.db.CountEmailsByRecipient(ctx, to)
+	if err != nil {
+		return nil, 0, fmt.Errorf("repository: count emails by recipient failed: %w", err)
+	}
+
+	emails := make([]*email.Email, len(rows))
+	for i, row := range rows {
+		emails[i] = &email.Email{
+			ID:        row.Uuid,
+			To:        row.ToEmail,
+			Subject:   row.Subject,
+			Body:      row.Body,
+			Type:      email.EmailType(row.Type),
+			Status:    email.Status(row.Status),
+			CreatedAt: row.CreatedAt,
+		}
+		if row.SentAt.Valid {
+			emails[i].SentAt = &row.SentAt.Time
+		}
+	}
+
+	return emails, int(total), nil
+}
+
+func (r *emailRepository) ReclaimStaleSending(ctx context.Context, olderThan time.Duration) (int64, error) {
+	reclaimed, err := r.db.ReclaimStaleSendingEmails(ctx, time.Now().Add(-olderThan))
+	if err != nil {
+		return 0, fmt.Errorf("repository: reclaim stale sending emails failed: %w", err)
+	}
+
+	return reclaimed, nil
+}
+
+func (r *emailRepository) Stats(ctx context.Context) (*email.DeliveryStats, error) {
+	row, err := r.db.GetEmailStats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("repository: email stats failed: %w", err)
+	}
+
+	return &email.DeliveryStats{
+		Pending: int(row.Pending),
+		Sent:    int(row.Sent),
+		Failed:  int(row.Failed),
+	}, nil
+}
+
+func (r *emailRepository) Search(ctx context.Context, params email.SearchParams) ([]*email.Email, int, error) {
+	if params.Page <= 0 {
+		params.Page = 1
+	}
+	if params.PageSize <= 0 {
+		params.PageSize = 10
+	}
+
+	filters := sqlc.CountSearchEmailsParams{
+		// % e _ digitados pelo admin são literais, não curingas do ILIKE
+		ToEmail:       sql.NullString{String: escapeLike(params.To), Valid: params.To != ""},
+		Status:        sql.NullString{String: string(params.Status), Valid: params.Status != ""},
+		Type:          sql.NullString{String: string(params.Type), Valid: params.Type != ""},
+		CreatedAfter:  nullTime(params.CreatedAfter),
+		CreatedBefore: nullTime(params.CreatedBefore),
+	}
+
+	rows, err := r.db.SearchEmails(ctx, sqlc.SearchEmailsParams{
+		ToEmail:       filters.ToEmail,
+		Status:        filters.Status,
+		Type:          filters.Type,
+		CreatedAfter:  filters.CreatedAfter,
+		CreatedBefore: filters.CreatedBefore,
+		Limit:         int32(params.PageSize),
+		Offset:        int32((params.Page - 1) * params.PageSize),
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("repository: search emails failed: %w", err)
+	}
+
+	total, err := r.db.CountSearchEmails(ctx, filters)
+	if err != nil {
+		return nil, 0, fmt.Errorf("repository: count emails failed: %w", err)
+	}
+
+	emails := make([]*email.Email, len(rows))
+	for i, row := range rows {
+		emails[i] = &email.Email{
+			ID:          row.Uuid,
+			To:          row.ToEmail,
+			Subject:     row.Subject,
+			Type:        email.EmailType(row.Type),
+			Status:      email.Status(row.Status),
+			Attempts:    int(row.Attempts),
+			MaxAttempts: int(row.MaxAttempts),
+			CreatedAt:   row.CreatedAt,
+		}
+		if row.ErrorMsg.Valid {
+			emails[i].ErrorMsg = row.ErrorMsg.String
+		}
+		if row.SentAt.Valid {
+			emails[i].SentAt = &row.SentAt.Time
+		}
+	}
+
+	return emails, int(total), nil
+}
+
+// escapeLike escapa os curingas de LIKE (o escape padrão do Postgres é \).
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
+
+// nullTime converte um horário opcional; as colunas de data de emails são TIMESTAMPTZ.
+func nullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
+
+// loadAttachments preenche os anexos do email; as listagens não os carregam.
+func loadAttachments(ctx context.Context, queries *sqlc.Queries, domainEmail *email.Email) error {
+	rows, err := queries.ListEmailAttachments(ctx, domainEmail.ID)
+	if err != nil {
+		return fmt.Errorf("repository: list email attachments failed: %w", err)
+	}
+
+	for _, row := range rows {
+		domainEmail.Attachments = append(domainEmail.Attachments, email.Attachment{
+			Filename:    row.Filename,
+			ContentType: row.ContentType,
+			Content:     row.Content,
+		})
+	}
+
+	return nil
+}
+
 func sqlcEmailToDomain(sqlcEmail sqlc.Email) *email.Email {
 	domainEmail := &email.Email{
-		ID:          sqlcEmail.Uuid,
-		To:          sqlcEmail.ToEmail,
-		Subject:     sqlcEmail.Subject,
-		Body:        sqlcEmail.Body,
-		Type:        email.EmailType(sqlcEmail.Type),
-		Status:      email.Status(sqlcEmail.Status),
-		Attempts:    int(sqlcEmail.Attempts),
-		MaxAttempts: int(sqlcEmail.MaxAttempts),
-		CreatedAt:   sqlcEmail.CreatedAt,
+		ID:            sqlcEmail.Uuid,
+		To:            sqlcEmail.ToEmail,
+		Subject:       sqlcEmail.Subject,
+		Body:          sqlcEmail.Body,
+		PlainBody:     sqlcEmail.PlainBody,
+		Type:          email.EmailType(sqlcEmail.Type),
+		Status:        email.Status(sqlcEmail.Status),
+		Attempts:      int(sqlcEmail.Attempts),
+		MaxAttempts:   int(sqlcEmail.MaxAttempts),
+		CreatedAt:     sqlcEmail.CreatedAt,
+		NextAttemptAt: sqlcEmail.NextAttemptAt,
+	}
+
+	if len(sqlcEmail.CcEmails) > 0 {
+		domainEmail.Cc = sqlcEmail.CcEmails
+	}
+
+	if len(sqlcEmail.BccEmails) > 0 {
+		domainEmail.Bcc = sqlcEmail.BccEmails
+	}
+
+	if sqlcEmail.DedupKey.Valid {
+		domainEmail.DedupKey = sqlcEmail.DedupKey.String
+	}
+
+	if sqlcEmail.ScheduledAt.Valid {
+		domainEmail.ScheduledAt = &sqlcEmail.ScheduledAt.Time
 	}
 
 	if sqlcEmail.ErrorMsg.Valid {
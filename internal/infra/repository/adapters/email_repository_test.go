@@ -2,6 +2,7 @@ package adapters
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -15,7 +16,6 @@ import (
 	"github.com/testcontainers/testcontainers-go/wait"
 
 	"github.com/moura95/backend-challenge/internal/domain/email"
-	"github.com/moura95/backend-challenge/internal/infra/repository/sqlc"
 )
 
 func setupEmailTestDB(t *testing.T) *testDB {
@@ -75,7 +75,24 @@ func runEmailMigrations(db *sqlx.DB) error {
 		error_msg    TEXT,
 		sent_at      TIMESTAMPTZ,
 		created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
-		updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		plain_body   TEXT NOT NULL DEFAULT '',
+		next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		cc_emails    TEXT[] NOT NULL DEFAULT '{}',
+		bcc_emails   TEXT[] NOT NULL DEFAULT '{}',
+		dedup_key    VARCHAR(255),
+		scheduled_at TIMESTAMPTZ
+	);
+	
+	-- Email attachments table
+	CREATE TABLE IF NOT EXISTS email_attachments (
+		id           BIGSERIAL PRIMARY KEY,
+		email_uuid   UUID NOT NULL,
+		filename     VARCHAR(255) NOT NULL,
+		content_type VARCHAR(255) NOT NULL,
+		content      BYTEA NOT NULL,
+		created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		FOREIGN KEY (email_uuid) REFERENCES emails(uuid) ON DELETE CASCADE
 	);
 	
 	CREATE INDEX IF NOT EXISTS idx_emails_status ON emails(status);
@@ -107,8 +124,7 @@ func TestEmailRepository_Create(t *testing.T) {
 	defer testDB.cleanup()
 
 	// Setup repository
-	queries := sqlc.New(testDB.db)
-	repo := NewEmailRepository(queries)
+	repo := NewEmailRepository(testDB.db)
 
 	t.Run("should create email successfully", func(t *testing.T) {
 		ctx := context.Background()
@@ -169,12 +185,91 @@ func TestEmailRepository_Create(t *testing.T) {
 	})
 }
 
+func TestEmailRepository_CreateIfNotExists(t *testing.T) {
+	testDB := setupEmailTestDB(t)
+	defer testDB.cleanup()
+
+	repo := NewEmailRepository(testDB.db)
+	ctx := context.Background()
+
+	newWelcome := func(address string) *email.Email {
+		welcomeEmail, err := email.NewWelcomeEmail(email.WelcomeEmailData{
+			UserID:    uuid.New().String(),
+			UserName:  "John Doe",
+			UserEmail: address,
+		})
+		require.NoError(t, err)
+		return welcomeEmail
+	}
+
+	countRows := func(key string) int {
+		var count int
+		require.NoError(t, testDB.db.Get(&count, "SELECT COUNT(*) FROM emails WHERE dedup_key = $1", key))
+		return count
+	}
+
+	t.Run("should persist one row for two welcome emails in quick succession", func(t *testing.T) {
+		first := newWelcome("dedup@example.com")
+		created, err := repo.CreateIfNotExists(ctx, first, time.Minute)
+		require.NoError(t, err)
+		assert.True(t, created)
+		assert.NotEqual(t, uuid.Nil, first.ID)
+
+		second := newWelcome("Dedup@Example.com")
+		created, err = repo.CreateIfNotExists(ctx, second, time.Minute)
+		require.NoError(t, err)
+		assert.False(t, created)
+		assert.Equal(t, uuid.Nil, second.ID)
+
+		assert.Equal(t, 1, countRows(email.DedupKey(email.EmailTypeWelcome, "dedup@example.com")))
+
+		stored, err := repo.GetByID(ctx, first.ID)
+		require.NoError(t, err)
+		assert.Equal(t, first.DedupKey, stored.DedupKey)
+	})
+
+	t.Run("should create again once the window has passed", func(t *testing.T) {
+		first := newWelcome("window@example.com")
+		created, err := repo.CreateIfNotExists(ctx, first, time.Minute)
+		require.NoError(t, err)
+		require.True(t, created)
+
+		_, err = testDB.db.Exec("UPDATE emails SET created_at = NOW() - INTERVAL '2 minutes' WHERE uuid = $1", first.ID)
+		require.NoError(t, err)
+
+		created, err = repo.CreateIfNotExists(ctx, newWelcome("window@example.com"), time.Minute)
+		require.NoError(t, err)
+		assert.True(t, created)
+		assert.Equal(t, 2, countRows(first.DedupKey))
+	})
+
+	t.Run("should create only one row under concurrent calls", func(t *testing.T) {
+		const callers = 5
+		results := make(chan bool, callers)
+		for i := 0; i < callers; i++ {
+			go func(welcomeEmail *email.Email) {
+				created, err := repo.CreateIfNotExists(ctx, welcomeEmail, time.Minute)
+				assert.NoError(t, err)
+				results <- created
+			}(newWelcome("race@example.com"))
+		}
+
+		createdCount := 0
+		for i := 0; i < callers; i++ {
+			if <-results {
+				createdCount++
+			}
+		}
+		assert.Equal(t, 1, createdCount)
+		assert.Equal(t, 1, countRows(email.DedupKey(email.EmailTypeWelcome, "race@example.com")))
+	})
+}
+
 func TestEmailRepository_GetByID(t *testing.T) {
 	testDB := setupEmailTestDB(t)
 	defer testDB.cleanup()
 
-	queries := sqlc.New(testDB.db)
-	repo := NewEmailRepository(queries)
+	repo := NewEmailRepository(testDB.db)
 	ctx := context.Background()
 
 	// Create test email
@@ -198,6 +293,51 @@ func TestEmailRepository_GetByID(t *testing.T) {
 		assert.Equal(t, testEmail.MaxAttempts, foundEmail.MaxAttempts)
 	})
 
+	t.Run("should round-trip cc and bcc recipients", func(t *testing.T) {
+		notification := createTestEmail()
+		notification.Type = email.EmailTypeNotification
+		notification.Cc = []string{"ops@example.com", "lead@example.com"}
+		notification.Bcc = []string{"audit@example.com"}
+		require.NoError(t, repo.Create(ctx, notification))
+
+		foundEmail, err := repo.GetByID(ctx, notification.ID)
+
+		require.NoError(t, err)
+		assert.Equal(t, notification.Cc, foundEmail.Cc)
+		assert.Equal(t, notification.Bcc, foundEmail.Bcc)
+
+		// Emails without copies come back with nil lists
+		plainEmail, err := repo.GetByID(ctx, testEmail.ID)
+		require.NoError(t, err)
+		assert.Nil(t, plainEmail.Cc)
+		assert.Nil(t, plainEmail.Bcc)
+	})
+
+	t.Run("should round-trip attachments in order", func(t *testing.T) {
+		notification := createTestEmail()
+		notification.Type = email.EmailTypeNotification
+		notification.Attachments = []email.Attachment{
+			{Filename: "report.txt", ContentType: "text/plain", Content: []byte("monthly report")},
+			{Filename: "data.bin", ContentType: "application/octet-stream", Content: []byte{0x00, 0xff}},
+		}
+		require.NoError(t, repo.Create(ctx, notification))
+
+		foundEmail, err := repo.GetByID(ctx, notification.ID)
+		require.NoError(t, err)
+		assert.Equal(t, notification.Attachments, foundEmail.Attachments)
+
+		// The processing lock sees them too
+		err = repo.LockForProcessing(ctx, notification.ID, func(locked *email.Email, _ email.Repository) error {
+			assert.Equal(t, notification.Attachments, locked.Attachments)
+			return nil
+		})
+		require.NoError(t, err)
+
+		plainEmail, err := repo.GetByID(ctx, testEmail.ID)
+		require.NoError(t, err)
+		assert.Nil(t, plainEmail.Attachments)
+	})
+
 	t.Run("should return error for non-existent ID", func(t *testing.T) {
 		// Execute
 		nonExistentID := uuid.New()
@@ -213,8 +353,7 @@ func TestEmailRepository_Update(t *testing.T) {
 	testDB := setupEmailTestDB(t)
 	defer testDB.cleanup()
 
-	queries := sqlc.New(testDB.db)
-	repo := NewEmailRepository(queries)
+	repo := NewEmailRepository(testDB.db)
 	ctx := context.Background()
 
 	// Create test email
@@ -291,12 +430,238 @@ func TestEmailRepository_Update(t *testing.T) {
 
 }
 
+func TestEmailRepository_GetByRecipient(t *testing.T) {
+	testDB := setupEmailTestDB(t)
+	defer testDB.cleanup()
+
+	repo := NewEmailRepository(testDB.db)
+	ctx := context.Background()
+
+	t.Run("should return only emails to the recipient, oldest first", func(t *testing.T) {
+		first := createTestEmail()
+		first.To = "recipient@example.com"
+		first.Subject = "First"
+		require.NoError(t, repo.Create(ctx, first))
+
+		second := createTestEmail()
+		second.To = "recipient@example.com"
+		second.Subject = "Second"
+		require.NoError(t, repo.Create(ctx, second))
+
+		other := createTestEmail()
+		other.To = "someone-else@example.com"
+		require.NoError(t, repo.Create(ctx, other))
+
+		emails, err := repo.GetByRecipient(ctx, "recipient@example.com")
+
+		require.NoError(t, err)
+		require.Len(t, emails, 2)
+		assert.Equal(t, "First", emails[0].Subject)
+		assert.Equal(t, "Second", emails[1].Subject)
+	})
+
+	t.Run("should return empty list for unknown recipient", func(t *testing.T) {
+		emails, err := repo.GetByRecipient(ctx, "nobody@example.com")
+
+		require.NoError(t, err)
+		assert.Empty(t, emails)
+	})
+}
+
+func TestEmailRepository_ListByRecipient(t *testing.T) {
+	testDB := setupEmailTestDB(t)
+	defer testDB.cleanup()
+
+	repo := NewEmailRepository(testDB.db)
+	ctx := context.Background()
+
+	for _, subject := range []string{"First", "Second", "Third"} {
+		e := createTestEmail()
+		e.To = "paged@example.com"
+		e.Subject = subject
+		require.NoError(t, repo.Create(ctx, e))
+	}
+	other := createTestEmail()
+	other.To = "someone-else@example.com"
+	require.NoError(t, repo.Create(ctx, other))
+
+	t.Run("should page newest first without bodies", func(t *testing.T) {
+		emails, total, err := repo.ListByRecipient(ctx, "paged@example.com", email.RecipientListParams{Page: 1, PageSize: 2})
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, total)
+		require.Len(t, emails, 2)
+		assert.Equal(t, "Third", emails[0].Subject)
+		assert.Equal(t, "Second", emails[1].Subject)
+		assert.Empty(t, emails[0].Body)
+
+		emails, _, err = repo.ListByRecipient(ctx, "paged@example.com", email.RecipientListParams{Page: 2, PageSize: 2})
+		require.NoError(t, err)
+		require.Len(t, emails, 1)
+		assert.Equal(t, "First", emails[0].Subject)
+	})
+
+	t.Run("should include bodies when requested", func(t *testing.T) {
+		emails, _, err := repo.ListByRecipient(ctx, "paged@example.com", email.RecipientListParams{PageSize: 1, IncludeBody: true})
+
+		require.NoError(t, err)
+		require.Len(t, emails, 1)
+		assert.NotEmpty(t, emails[0].Body)
+	})
+}
+
+func TestEmailRepository_Search(t *testing.T) {
+	testDB := setupEmailTestDB(t)
+	defer testDB.cleanup()
+
+	repo := NewEmailRepository(testDB.db)
+	ctx := context.Background()
+
+	seed := func(to string, status email.Status, emailType email.EmailType) *email.Email {
+		e := createTestEmail()
+		e.To = to
+		e.Type = emailType
+		require.NoError(t, repo.Create(ctx, e))
+		if status != email.StatusPending {
+			e.Status = status
+			e.Attempts = 1
+			require.NoError(t, repo.Update(ctx, e))
+		}
+		return e
+	}
+
+	failed := seed("alice@support.example.com", email.StatusFailed, email.EmailTypeWelcome)
+	seed("alice@other.example.com", email.StatusSent, email.EmailTypeNotification)
+	seed("bob@support.example.com", email.StatusPending, email.EmailTypeWelcome)
+	seed("a_b@example.com", email.StatusPending, email.EmailTypeNotification)
+
+	t.Run("should filter by status", func(t *testing.T) {
+		emails, total, err := repo.Search(ctx, email.SearchParams{Status: email.StatusFailed})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		require.Len(t, emails, 1)
+		assert.Equal(t, failed.ID, emails[0].ID)
+		assert.Empty(t, emails[0].Body)
+	})
+
+	t.Run("should match recipient substrings case-insensitively", func(t *testing.T) {
+		emails, total, err := repo.Search(ctx, email.SearchParams{To: "SUPPORT.example"})
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, total)
+		for _, e := range emails {
+			assert.Contains(t, e.To, "@support.example.com")
+		}
+	})
+
+	t.Run("should combine filters", func(t *testing.T) {
+		emails, total, err := repo.Search(ctx, email.SearchParams{To: "alice", Type: email.EmailTypeNotification})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		require.Len(t, emails, 1)
+		assert.Equal(t, "alice@other.example.com", emails[0].To)
+	})
+
+	t.Run("should treat like wildcards literally", func(t *testing.T) {
+		emails, total, err := repo.Search(ctx, email.SearchParams{To: "a_b"})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		require.Len(t, emails, 1)
+		assert.Equal(t, "a_b@example.com", emails[0].To)
+
+		_, total, err = repo.Search(ctx, email.SearchParams{To: "%"})
+		require.NoError(t, err)
+		assert.Equal(t, 0, total)
+	})
+
+	t.Run("should page results", func(t *testing.T) {
+		emails, total, err := repo.Search(ctx, email.SearchParams{Page: 2, PageSize: 3})
+
+		require.NoError(t, err)
+		assert.Equal(t, 4, total)
+		assert.Len(t, emails, 1)
+	})
+
+	t.Run("should count every filtered match beyond the page", func(t *testing.T) {
+		emails, total, err := repo.Search(ctx, email.SearchParams{Status: email.StatusPending, Page: 1, PageSize: 1})
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, total)
+		require.Len(t, emails, 1)
+		assert.Equal(t, email.StatusPending, emails[0].Status)
+	})
+}
+
+func TestEmailRepository_LockForProcessing(t *testing.T) {
+	testDB := setupEmailTestDB(t)
+	defer testDB.cleanup()
+
+	repo := NewEmailRepository(testDB.db)
+	ctx := context.Background()
+
+	t.Run("should commit updates made inside the lock", func(t *testing.T) {
+		testEmail := createTestEmail()
+		require.NoError(t, repo.Create(ctx, testEmail))
+
+		err := repo.LockForProcessing(ctx, testEmail.ID, func(locked *email.Email, txRepo email.Repository) error {
+			locked.MarkAsSent()
+			return txRepo.Update(ctx, locked)
+		})
+		require.NoError(t, err)
+
+		updated, err := repo.GetByID(ctx, testEmail.ID)
+		require.NoError(t, err)
+		assert.Equal(t, email.StatusSent, updated.Status)
+	})
+
+	t.Run("should roll back when fn fails", func(t *testing.T) {
+		testEmail := createTestEmail()
+		require.NoError(t, repo.Create(ctx, testEmail))
+
+		err := repo.LockForProcessing(ctx, testEmail.ID, func(locked *email.Email, txRepo email.Repository) error {
+			locked.MarkAsSent()
+			require.NoError(t, txRepo.Update(ctx, locked))
+			return errors.New("boom")
+		})
+		assert.EqualError(t, err, "boom")
+
+		updated, err := repo.GetByID(ctx, testEmail.ID)
+		require.NoError(t, err)
+		assert.Equal(t, email.StatusPending, updated.Status)
+	})
+
+	t.Run("should report locked email while another worker holds it", func(t *testing.T) {
+		testEmail := createTestEmail()
+		require.NoError(t, repo.Create(ctx, testEmail))
+
+		var innerErr error
+		err := repo.LockForProcessing(ctx, testEmail.ID, func(*email.Email, email.Repository) error {
+			innerErr = repo.LockForProcessing(ctx, testEmail.ID, func(*email.Email, email.Repository) error {
+				return nil
+			})
+			return nil
+		})
+		require.NoError(t, err)
+		assert.ErrorIs(t, innerErr, email.ErrEmailLocked)
+	})
+
+	t.Run("should return error for non-existent ID", func(t *testing.T) {
+		err := repo.LockForProcessing(ctx, uuid.New(), func(*email.Email, email.Repository) error {
+			return nil
+		})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "email not found")
+	})
+}
+
 func TestEmailRepository_Integration_EmailWorkflow(t *testing.T) {
 	testDB := setupEmailTestDB(t)
 	defer testDB.cleanup()
 
-	queries := sqlc.New(testDB.db)
-	repo := NewEmailRepository(queries)
+	repo := NewEmailRepository(testDB.db)
 	ctx := context.Background()
 
 	t.Run("complete email workflow", func(t *testing.T) {
@@ -348,3 +713,118 @@ func TestEmailRepository_Integration_EmailWorkflow(t *testing.T) {
 		assert.Empty(t, remainingPending)
 	})
 }
+
+func TestEmailRepository_Integration_ScheduledEmails(t *testing.T) {
+	testDB := setupEmailTestDB(t)
+	defer testDB.cleanup()
+
+	repo := NewEmailRepository(testDB.db)
+	ctx := context.Background()
+
+	t.Run("scheduled email is not pending until its time", func(t *testing.T) {
+		scheduled, err := email.NewNotificationEmail("scheduled@example.com", "Later", "Sent in one hour")
+		require.NoError(t, err)
+		scheduled.Schedule(time.Now().Add(time.Hour))
+		require.NoError(t, repo.Create(ctx, scheduled))
+
+		immediate, err := email.NewNotificationEmail("now@example.com", "Now", "Sent right away")
+		require.NoError(t, err)
+		require.NoError(t, repo.Create(ctx, immediate))
+
+		pending, err := repo.GetPendingEmails(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		assert.Equal(t, immediate.ID, pending[0].ID)
+
+		stored, err := repo.GetByID(ctx, scheduled.ID)
+		require.NoError(t, err)
+		require.NotNil(t, stored.ScheduledAt)
+		assert.WithinDuration(t, *scheduled.ScheduledAt, *stored.ScheduledAt, time.Second)
+
+		// Quando o horário chega o email passa a ser processado
+		_, err = testDB.db.Exec("UPDATE emails SET scheduled_at = NOW() - INTERVAL '1 minute', next_attempt_at = NOW() - INTERVAL '1 minute' WHERE uuid = $1", scheduled.ID)
+		require.NoError(t, err)
+
+		pending, err = repo.GetPendingEmails(ctx, 10)
+		require.NoError(t, err)
+		assert.Len(t, pending, 2)
+	})
+}
+
+func TestEmailRepository_ReclaimStaleSending(t *testing.T) {
+	testDB := setupEmailTestDB(t)
+	defer testDB.cleanup()
+
+	repo := NewEmailRepository(testDB.db)
+	ctx := context.Background()
+
+	claim := func(t *testing.T, to string) *email.Email {
+		e, err := email.NewNotificationEmail(to, "Subject", "Body")
+		require.NoError(t, err)
+		require.NoError(t, repo.Create(ctx, e))
+		require.NoError(t, e.MarkAsSending())
+		require.NoError(t, repo.Update(ctx, e))
+		return e
+	}
+
+	t.Run("returns emails stuck in sending to pending", func(t *testing.T) {
+		stale := claim(t, "stale@example.com")
+		fresh := claim(t, "fresh@example.com")
+		_, err := testDB.db.Exec("UPDATE emails SET updated_at = NOW() - INTERVAL '1 hour' WHERE uuid = $1", stale.ID)
+		require.NoError(t, err)
+
+		// Em envio, nenhum dos dois é listado como pendente
+		pending, err := repo.GetPendingEmails(ctx, 10)
+		require.NoError(t, err)
+		assert.Empty(t, pending)
+
+		reclaimed, err := repo.ReclaimStaleSending(ctx, 10*time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), reclaimed)
+
+		stored, err := repo.GetByID(ctx, stale.ID)
+		require.NoError(t, err)
+		assert.Equal(t, email.StatusPending, stored.Status)
+		assert.Equal(t, 0, stored.Attempts)
+
+		stored, err = repo.GetByID(ctx, fresh.ID)
+		require.NoError(t, err)
+		assert.Equal(t, email.StatusSending, stored.Status)
+
+		pending, err = repo.GetPendingEmails(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		assert.Equal(t, stale.ID, pending[0].ID)
+	})
+}
+
+func TestEmailRepository_CountPending(t *testing.T) {
+	testDB := setupEmailTestDB(t)
+	defer testDB.cleanup()
+
+	repo := NewEmailRepository(testDB.db)
+	ctx := context.Background()
+
+	t.Run("counts only pending emails that are due", func(t *testing.T) {
+		for _, to := range []string{"backlog1@example.com", "backlog2@example.com", "backlog3@example.com"} {
+			pending, err := email.NewNotificationEmail(to, "Subject", "Body")
+			require.NoError(t, err)
+			require.NoError(t, repo.Create(ctx, pending))
+		}
+
+		sent, err := email.NewNotificationEmail("sent@example.com", "Subject", "Body")
+		require.NoError(t, err)
+		require.NoError(t, repo.Create(ctx, sent))
+		sent.MarkAsSent()
+		require.NoError(t, repo.Update(ctx, sent))
+
+		scheduled, err := email.NewNotificationEmail("later@example.com", "Subject", "Body")
+		require.NoError(t, err)
+		scheduled.Schedule(time.Now().Add(time.Hour))
+		require.NoError(t, repo.Create(ctx, scheduled))
+
+		count, err := repo.CountPending(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+	})
+}
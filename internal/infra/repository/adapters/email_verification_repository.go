@@ -0,0 +1,61 @@
+package adapters
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moura95/backend-challenge/internal/domain/token"
+	"github.com/moura95/backend-challenge/internal/infra/repository/sqlc"
+)
+
+type emailVerificationRepository struct {
+	db *sqlc.Queries
+}
+
+func NewEmailVerificationRepository(db *sqlc.Queries) token.EmailVerificationRepository {
+	return &emailVerificationRepository{
+		db: db,
+	}
+}
+
+func (r *emailVerificationRepository) Create(ctx context.Context, tokenHash string, userID uuid.UUID, expiresAt time.Time) error {
+	params := sqlc.CreateEmailVerificationTokenParams{
+		TokenHash: tokenHash,
+		UserUuid:  userID,
+		ExpiresAt: expiresAt,
+	}
+
+	err := r.db.CreateEmailVerificationToken(ctx, params)
+	if err != nil {
+		return fmt.Errorf("repository: create email verification token failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *emailVerificationRepository) Consume(ctx context.Context, tokenHash string) (uuid.UUID, error) {
+	userID, err := r.db.ConsumeEmailVerificationToken(ctx, tokenHash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return uuid.Nil, fmt.Errorf("repository: consume email verification token failed: token not found")
+		}
+		return uuid.Nil, fmt.Errorf("repository: consume email verification token failed: %w", err)
+	}
+
+	return userID, nil
+}
+
+func (r *emailVerificationRepository) GetUserID(ctx context.Context, tokenHash string) (uuid.UUID, error) {
+	userID, err := r.db.GetEmailVerificationTokenUser(ctx, tokenHash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return uuid.Nil, fmt.Errorf("repository: get email verification token failed: token not found")
+		}
+		return uuid.Nil, fmt.Errorf("repository: get email verification token failed: %w", err)
+	}
+
+	return userID, nil
+}
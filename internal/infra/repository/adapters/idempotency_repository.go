@@ -0,0 +1,62 @@
+package adapters
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moura95/backend-challenge/internal/domain/token"
+	"github.com/moura95/backend-challenge/internal/infra/repository/sqlc"
+)
+
+type idempotencyRepository struct {
+	db *sqlc.Queries
+}
+
+func NewIdempotencyRepository(db *sqlc.Queries) token.IdempotencyRepository {
+	return &idempotencyRepository{
+		db: db,
+	}
+}
+
+func (r *idempotencyRepository) Create(ctx context.Context, key string, userID uuid.UUID, expiresAt time.Time) error {
+	params := sqlc.CreateIdempotencyKeyParams{
+		IdempotencyKey: key,
+		UserUuid:       userID,
+		ExpiresAt:      expiresAt,
+	}
+
+	rows, err := r.db.CreateIdempotencyKey(ctx, params)
+	if err != nil {
+		return fmt.Errorf("repository: create idempotency key failed: %w", err)
+	}
+	// Nenhuma linha: a chave existe e ainda não expirou
+	if rows == 0 {
+		return fmt.Errorf("repository: create idempotency key failed: %w", token.ErrIdempotencyKeyExists)
+	}
+
+	return nil
+}
+
+func (r *idempotencyRepository) Get(ctx context.Context, key string) (uuid.UUID, error) {
+	row, err := r.db.GetIdempotencyKey(ctx, key)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return uuid.Nil, fmt.Errorf("repository: get idempotency key failed: %w", token.ErrIdempotencyKeyNotFound)
+		}
+		return uuid.Nil, fmt.Errorf("repository: get idempotency key failed: %w", err)
+	}
+
+	return row.UserUuid, nil
+}
+
+func (r *idempotencyRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	deleted, err := r.db.DeleteExpiredIdempotencyKeys(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("repository: delete expired idempotency keys failed: %w", err)
+	}
+
+	return deleted, nil
+}
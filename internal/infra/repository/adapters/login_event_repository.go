@@ -0,0 +1,67 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/moura95/backend-challenge/internal/domain/user"
+	"github.com/moura95/backend-challenge/internal/infra/repository/sqlc"
+)
+
+type loginEventRepository struct {
+	db *sqlc.Queries
+}
+
+func NewLoginEventRepository(db *sqlc.Queries) user.LoginEventRepository {
+	return &loginEventRepository{
+		db: db,
+	}
+}
+
+func (r *loginEventRepository) Create(ctx context.Context, event *user.LoginEvent) error {
+	params := sqlc.CreateLoginEventParams{
+		Email:     event.Email,
+		IpAddress: event.IPAddress,
+		UserAgent: event.UserAgent,
+		Success:   event.Success,
+	}
+	if event.UserID != nil {
+		params.UserUuid = uuid.NullUUID{UUID: *event.UserID, Valid: true}
+	}
+
+	if err := r.db.CreateLoginEvent(ctx, params); err != nil {
+		return fmt.Errorf("repository: create login event failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *loginEventRepository) ListByUser(ctx context.Context, userID uuid.UUID, limit int) ([]*user.LoginEvent, error) {
+	rows, err := r.db.ListLoginEventsByUser(ctx, sqlc.ListLoginEventsByUserParams{
+		UserUuid: uuid.NullUUID{UUID: userID, Valid: true},
+		Limit:    int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repository: list login events failed: %w", err)
+	}
+
+	events := make([]*user.LoginEvent, 0, len(rows))
+	for _, row := range rows {
+		event := &user.LoginEvent{
+			ID:        row.ID,
+			Email:     row.Email,
+			IPAddress: row.IpAddress,
+			UserAgent: row.UserAgent,
+			Success:   row.Success,
+			CreatedAt: row.CreatedAt,
+		}
+		if row.UserUuid.Valid {
+			id := row.UserUuid.UUID
+			event.UserID = &id
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
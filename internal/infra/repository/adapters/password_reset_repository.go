@@ -0,0 +1,51 @@
+package adapters
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moura95/backend-challenge/internal/domain/token"
+	"github.com/moura95/backend-challenge/internal/infra/repository/sqlc"
+)
+
+type passwordResetRepository struct {
+	db *sqlc.Queries
+}
+
+func NewPasswordResetRepository(db *sqlc.Queries) token.PasswordResetRepository {
+	return &passwordResetRepository{
+		db: db,
+	}
+}
+
+func (r *passwordResetRepository) Create(ctx context.Context, tokenHash string, userID uuid.UUID, expiresAt time.Time) error {
+	params := sqlc.CreatePasswordResetTokenParams{
+		TokenHash: tokenHash,
+		UserUuid:  userID,
+		ExpiresAt: expiresAt,
+	}
+
+	err := r.db.CreatePasswordResetToken(ctx, params)
+	if err != nil {
+		return fmt.Errorf("repository: create password reset token failed: %w", err)
+	}
+
+	return nil
+}
+
+// Consume marca o token como usado e retorna o dono. Tokens já usados ou
+// expirados não são encontrados, garantindo uso único.
+func (r *passwordResetRepository) Consume(ctx context.Context, tokenHash string) (uuid.UUID, error) {
+	userID, err := r.db.ConsumePasswordResetToken(ctx, tokenHash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return uuid.Nil, fmt.Errorf("repository: consume password reset token failed: token not found")
+		}
+		return uuid.Nil, fmt.Errorf("repository: consume password reset token failed: %w", err)
+	}
+
+	return userID, nil
+}
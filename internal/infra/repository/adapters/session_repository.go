@@ -0,0 +1,94 @@
+package adapters
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/moura95/backend-challenge/internal/domain/token"
+	"github.com/moura95/backend-challenge/internal/infra/repository/sqlc"
+)
+
+type sessionRepository struct {
+	db *sqlc.Queries
+}
+
+func NewSessionRepository(db *sqlc.Queries) token.SessionRepository {
+	return &sessionRepository{
+		db: db,
+	}
+}
+
+// Create grava a sessão; a coluna refresh_token guarda só o ID do refresh
+// token, nunca o token em si.
+func (r *sessionRepository) Create(ctx context.Context, session *token.Session) error {
+	created, err := r.db.CreateSession(ctx, sqlc.CreateSessionParams{
+		Uuid:         session.ID,
+		UserUuid:     session.UserID,
+		RefreshToken: session.RefreshTokenID.String(),
+		UserAgent:    session.UserAgent,
+		ClientIp:     session.IPAddress,
+		IsBlocked:    session.Revoked,
+		ExpiresAt:    session.ExpiresAt,
+	})
+	if err != nil {
+		return fmt.Errorf("repository: create session failed: %w", err)
+	}
+
+	session.IssuedAt = created.CreatedAt
+	return nil
+}
+
+func (r *sessionRepository) ListActive(ctx context.Context, userID uuid.UUID) ([]*token.Session, error) {
+	rows, err := r.db.ListActiveSessionsByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("repository: list sessions failed: %w", err)
+	}
+
+	sessions := make([]*token.Session, 0, len(rows))
+	for _, row := range rows {
+		sessions = append(sessions, sqlcSessionToDomain(row))
+	}
+
+	return sessions, nil
+}
+
+func (r *sessionRepository) Revoke(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*token.Session, error) {
+	row, err := r.db.RevokeSession(ctx, sqlc.RevokeSessionParams{
+		Uuid:     id,
+		UserUuid: userID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("repository: revoke session failed: %w", token.ErrSessionNotFound)
+		}
+		return nil, fmt.Errorf("repository: revoke session failed: %w", err)
+	}
+
+	return sqlcSessionToDomain(row), nil
+}
+
+func (r *sessionRepository) RevokeAll(ctx context.Context, userID uuid.UUID) error {
+	if err := r.db.RevokeUserSessions(ctx, userID); err != nil {
+		return fmt.Errorf("repository: revoke sessions failed: %w", err)
+	}
+
+	return nil
+}
+
+func sqlcSessionToDomain(row sqlc.UserSession) *token.Session {
+	// IDs inválidos (linhas antigas) ficam como uuid.Nil
+	refreshTokenID, _ := uuid.Parse(row.RefreshToken)
+
+	return &token.Session{
+		ID:             row.Uuid,
+		UserID:         row.UserUuid,
+		RefreshTokenID: refreshTokenID,
+		UserAgent:      row.UserAgent,
+		IPAddress:      row.ClientIp,
+		IssuedAt:       row.CreatedAt,
+		ExpiresAt:      row.ExpiresAt,
+		Revoked:        row.IsBlocked,
+	}
+}
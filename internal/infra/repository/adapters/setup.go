@@ -1,22 +1,89 @@
 package adapters
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/jmoiron/sqlx"
 	"github.com/moura95/backend-challenge/internal/domain/email"
+	"github.com/moura95/backend-challenge/internal/domain/token"
 	"github.com/moura95/backend-challenge/internal/domain/user"
 	"github.com/moura95/backend-challenge/internal/infra/repository/sqlc"
 )
 
 type Repositories struct {
-	User  user.Repository
-	Email email.Repository
+	User              user.Repository
+	Email             email.Repository
+	Token             token.Repository
+	PasswordReset     token.PasswordResetRepository
+	EmailVerification token.EmailVerificationRepository
+	Idempotency       token.IdempotencyRepository
+	LoginEvent        user.LoginEventRepository
+	Session           token.SessionRepository
+
+	db *sqlx.DB
 }
 
 func NewRepositories(db *sqlx.DB) *Repositories {
 	queries := sqlc.New(db)
 
 	return &Repositories{
-		User:  NewUserRepository(queries),
-		Email: NewEmailRepository(queries),
+		User:              NewUserRepository(queries),
+		Email:             NewEmailRepository(db),
+		Token:             NewTokenRepository(queries),
+		PasswordReset:     NewPasswordResetRepository(queries),
+		EmailVerification: NewEmailVerificationRepository(queries),
+		Idempotency:       NewIdempotencyRepository(queries),
+		LoginEvent:        NewLoginEventRepository(queries),
+		Session:           NewSessionRepository(queries),
+		db:                db,
+	}
+}
+
+// WithTx runs fn with repositories bound to a single transaction. The
+// transaction commits when fn returns nil and rolls back otherwise.
+func (r *Repositories) WithTx(ctx context.Context, fn func(tx *Repositories) error) error {
+	if r.db == nil {
+		return fmt.Errorf("repository: transaction failed: already inside a transaction")
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("repository: transaction failed: %w", err)
+	}
+	defer tx.Rollback()
+
+	queries := sqlc.New(tx)
+	txRepos := &Repositories{
+		User:              NewUserRepository(queries),
+		Email:             &emailRepository{db: queries},
+		Token:             NewTokenRepository(queries),
+		PasswordReset:     NewPasswordResetRepository(queries),
+		EmailVerification: NewEmailVerificationRepository(queries),
+		Idempotency:       NewIdempotencyRepository(queries),
+		LoginEvent:        NewLoginEventRepository(queries),
+		Session:           NewSessionRepository(queries),
 	}
+
+	// Com cache de usuários, as escritas da transação o invalidam após o commit
+	cachedUsers, _ := r.User.(*cachedUserRepository)
+	var txUsers *txCachedUserRepository
+	if cachedUsers != nil {
+		txUsers = cachedUsers.inTx(txRepos.User)
+		txRepos.User = txUsers
+	}
+
+	if err := fn(txRepos); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("repository: transaction failed: commit: %w", err)
+	}
+
+	if txUsers != nil {
+		txUsers.committed()
+	}
+
+	return nil
 }
@@ -0,0 +1,54 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moura95/backend-challenge/internal/domain/token"
+	"github.com/moura95/backend-challenge/internal/infra/repository/sqlc"
+)
+
+type tokenRepository struct {
+	db *sqlc.Queries
+}
+
+func NewTokenRepository(db *sqlc.Queries) token.Repository {
+	return &tokenRepository{
+		db: db,
+	}
+}
+
+func (r *tokenRepository) Revoke(ctx context.Context, tokenID uuid.UUID, userID uuid.UUID, expiresAt time.Time) error {
+	params := sqlc.RevokeTokenParams{
+		TokenUuid: tokenID,
+		UserUuid:  userID,
+		ExpiresAt: expiresAt,
+	}
+
+	err := r.db.RevokeToken(ctx, params)
+	if err != nil {
+		return fmt.Errorf("repository: revoke token failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *tokenRepository) IsRevoked(ctx context.Context, tokenID uuid.UUID) (bool, error) {
+	revoked, err := r.db.IsTokenRevoked(ctx, tokenID)
+	if err != nil {
+		return false, fmt.Errorf("repository: check token revoked failed: %w", err)
+	}
+
+	return revoked, nil
+}
+
+func (r *tokenRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	deleted, err := r.db.DeleteExpiredRevokedTokens(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("repository: delete expired tokens failed: %w", err)
+	}
+
+	return deleted, nil
+}
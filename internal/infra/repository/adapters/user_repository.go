@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/moura95/backend-challenge/internal/domain/user"
@@ -31,14 +32,16 @@ func (r *userRepository) Create(ctx context.Context, domainUser *user.User) erro
 	sqlcUser, err := r.db.CreateUser(ctx, params)
 	if err != nil {
 		if strings.Contains(err.Error(), "duplicate key") || strings.Contains(err.Error(), "UNIQUE constraint") {
-			return fmt.Errorf("repository: create user failed: email already exists")
+			return fmt.Errorf("repository: create user failed: %w", user.ErrEmailExists)
 		}
 		return fmt.Errorf("repository: create user failed: %w", err)
 	}
 
 	domainUser.ID = sqlcUser.Uuid
+	domainUser.Role = user.Role(sqlcUser.Role)
 	domainUser.CreatedAt = sqlcUser.CreatedAt
 	domainUser.UpdatedAt = sqlcUser.UpdatedAt
+	domainUser.Version = int(sqlcUser.Version)
 
 	return nil
 }
@@ -47,7 +50,7 @@ func (r *userRepository) GetByID(ctx context.Context, id uuid.UUID) (*user.User,
 	sqlcUser, err := r.db.GetUserByID(ctx, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("repository: get user by id failed: user not found")
+			return nil, fmt.Errorf("repository: get user by id failed: %w", user.ErrUserNotFound)
 		}
 		return nil, fmt.Errorf("repository: get user by id failed: %w", err)
 	}
@@ -55,11 +58,38 @@ func (r *userRepository) GetByID(ctx context.Context, id uuid.UUID) (*user.User,
 	return sqlcUserToDomain(sqlcUser), nil
 }
 
+func (r *userRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*user.User, error) {
+	if len(ids) == 0 {
+		return []*user.User{}, nil
+	}
+
+	sqlcUsers, err := r.db.GetUsersByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("repository: get users by ids failed: %w", err)
+	}
+
+	// ANY($1) não garante ordem: devolver na ordem dos IDs pedidos
+	byID := make(map[uuid.UUID]*user.User, len(sqlcUsers))
+	for _, sqlcUser := range sqlcUsers {
+		byID[sqlcUser.Uuid] = sqlcUserToDomain(sqlcUser)
+	}
+
+	users := make([]*user.User, 0, len(byID))
+	for _, id := range ids {
+		if found, ok := byID[id]; ok {
+			users = append(users, found)
+			delete(byID, id)
+		}
+	}
+
+	return users, nil
+}
+
 func (r *userRepository) GetByEmail(ctx context.Context, email string) (*user.User, error) {
 	sqlcUser, err := r.db.GetUserByEmail(ctx, email)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("repository: get user by email failed: user not found")
+			return nil, fmt.Errorf("repository: get user by email failed: %w", user.ErrUserNotFound)
 		}
 		return nil, fmt.Errorf("repository: get user by email failed: %w", err)
 	}
@@ -78,34 +108,130 @@ func (r *userRepository) Update(ctx context.Context, domainUser *user.User) erro
 			String: domainUser.Email,
 			Valid:  domainUser.Email != "",
 		},
+		Version: int32(domainUser.Version),
 	}
 
-	err := r.db.UpdateUserByUUID(ctx, params)
+	rows, err := r.db.UpdateUserByUUID(ctx, params)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return fmt.Errorf("repository: update user failed: user not found")
-		}
 		if strings.Contains(err.Error(), "duplicate key") || strings.Contains(err.Error(), "UNIQUE constraint") {
-			return fmt.Errorf("repository: update user failed: email already exists")
+			return fmt.Errorf("repository: update user failed: %w", user.ErrEmailExists)
 		}
 		return fmt.Errorf("repository: update user failed: %w", err)
 	}
 
+	if rows == 0 {
+		// Nenhuma linha: ou o usuário não existe, ou a versão ficou obsoleta
+		if _, err := r.db.GetUserByID(ctx, domainUser.ID); err == sql.ErrNoRows {
+			return fmt.Errorf("repository: update user failed: %w", user.ErrUserNotFound)
+		}
+		return fmt.Errorf("repository: update user failed: %w", user.ErrConflict)
+	}
+
+	domainUser.Version++
 	return nil
 }
 
-func (r *userRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	_, err := r.db.RemoveUserByID(ctx, id)
+func (r *userRepository) UpdatePassword(ctx context.Context, domainUser *user.User) error {
+	params := sqlc.UpdateUserPasswordParams{
+		Uuid:     domainUser.ID,
+		Password: domainUser.Password,
+	}
+
+	err := r.db.UpdateUserPassword(ctx, params)
+	if err != nil {
+		return fmt.Errorf("repository: update user password failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *userRepository) MarkEmailVerified(ctx context.Context, id uuid.UUID) error {
+	err := r.db.MarkUserEmailVerified(ctx, id)
+	if err != nil {
+		return fmt.Errorf("repository: mark email verified failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *userRepository) UpdateRole(ctx context.Context, id uuid.UUID, role user.Role) error {
+	params := sqlc.UpdateUserRoleParams{
+		Uuid: id,
+		Role: string(role),
+	}
+
+	err := r.db.UpdateUserRole(ctx, params)
+	if err != nil {
+		return fmt.Errorf("repository: update user role failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *userRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status user.Status) error {
+	rows, err := r.db.UpdateUserStatus(ctx, sqlc.UpdateUserStatusParams{
+		Uuid:   id,
+		Status: string(status),
+	})
+	if err != nil {
+		return fmt.Errorf("repository: update user status failed: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("repository: update user status failed: %w", user.ErrUserNotFound)
+	}
+
+	return nil
+}
+
+func (r *userRepository) IncrementTokenVersion(ctx context.Context, id uuid.UUID) (int, error) {
+	version, err := r.db.IncrementUserTokenVersion(ctx, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return fmt.Errorf("repository: delete user failed: user not found")
+			return 0, fmt.Errorf("repository: increment token version failed: %w", user.ErrUserNotFound)
 		}
+		return 0, fmt.Errorf("repository: increment token version failed: %w", err)
+	}
+
+	return int(version), nil
+}
+
+func (r *userRepository) UpdateLastLogin(ctx context.Context, id uuid.UUID, at time.Time) error {
+	err := r.db.UpdateUserLastLogin(ctx, sqlc.UpdateUserLastLoginParams{
+		Uuid:        id,
+		LastLoginAt: sql.NullTime{Time: at.UTC(), Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("repository: update last login failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *userRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	rows, err := r.db.SoftDeleteUser(ctx, id)
+	if err != nil {
 		return fmt.Errorf("repository: delete user failed: %w", err)
 	}
+	if rows == 0 {
+		return fmt.Errorf("repository: delete user failed: %w", user.ErrUserNotFound)
+	}
 
 	return nil
 }
 
+func (r *userRepository) DeleteMany(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	if len(ids) == 0 {
+		return []uuid.UUID{}, nil
+	}
+
+	deleted, err := r.db.SoftDeleteUsers(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("repository: delete users failed: %w", err)
+	}
+
+	return deleted, nil
+}
+
 func (r *userRepository) List(ctx context.Context, params user.ListParams) ([]*user.User, int, error) {
 	if params.Page <= 0 {
 		params.Page = 1
@@ -114,17 +240,59 @@ func (r *userRepository) List(ctx context.Context, params user.ListParams) ([]*u
 		params.PageSize = 10
 	}
 
+	if params.SortBy == "" {
+		params.SortBy = user.SortByCreatedAt
+	}
+	if params.SortOrder == "" {
+		params.SortOrder = user.SortOrderDesc
+	}
+
 	offset := (params.Page - 1) * params.PageSize
 
 	listParams := sqlc.ListUsersParams{
-		Search: sql.NullString{String: params.Search, Valid: params.Search != ""},
-		Limit:  sql.NullInt32{Int32: int32(params.PageSize), Valid: true},
-		Offset: sql.NullInt32{Int32: int32(offset), Valid: true},
+		Search:         sql.NullString{String: params.Search, Valid: params.Search != ""},
+		IncludeDeleted: params.IncludeDeleted,
+		CreatedAfter:   nullTimeUTC(params.CreatedAfter),
+		CreatedBefore:  nullTimeUTC(params.CreatedBefore),
+		SortBy:         params.SortBy,
+		SortOrder:      params.SortOrder,
+		Limit:          sql.NullInt32{Int32: int32(params.PageSize), Valid: true},
+		Offset:         sql.NullInt32{Int32: int32(offset), Valid: true},
+	}
+
+	var sqlcUsers []sqlc.ListUsersRow
+	if params.After != nil {
+		rows, err := r.db.ListUsersAfterCursor(ctx, sqlc.ListUsersAfterCursorParams{
+			Search:          listParams.Search,
+			IncludeDeleted:  listParams.IncludeDeleted,
+			CreatedAfter:    listParams.CreatedAfter,
+			CreatedBefore:   listParams.CreatedBefore,
+			CursorCreatedAt: params.After.CreatedAt,
+			CursorUuid:      params.After.ID,
+			Limit:           int32(params.PageSize),
+		})
+		if err != nil {
+			return nil, 0, fmt.Errorf("repository: list users failed: %w", err)
+		}
+		for _, row := range rows {
+			sqlcUsers = append(sqlcUsers, sqlc.ListUsersRow(row))
+		}
+	} else {
+		rows, err := r.db.ListUsers(ctx, listParams)
+		if err != nil {
+			return nil, 0, fmt.Errorf("repository: list users failed: %w", err)
+		}
+		sqlcUsers = rows
 	}
 
-	sqlcUsers, err := r.db.ListUsers(ctx, listParams)
+	total, err := r.db.CountUsers(ctx, sqlc.CountUsersParams{
+		Search:         listParams.Search,
+		IncludeDeleted: listParams.IncludeDeleted,
+		CreatedAfter:   listParams.CreatedAfter,
+		CreatedBefore:  listParams.CreatedBefore,
+	})
 	if err != nil {
-		return nil, 0, fmt.Errorf("repository: list users failed: %w", err)
+		return nil, 0, fmt.Errorf("repository: count users failed: %w", err)
 	}
 
 	users := make([]*user.User, len(sqlcUsers))
@@ -132,7 +300,7 @@ func (r *userRepository) List(ctx context.Context, params user.ListParams) ([]*u
 		users[i] = listRowToDomain(sqlcUser)
 	}
 
-	return users, len(users), nil
+	return users, int(total), nil
 }
 
 func (r *userRepository) EmailExists(ctx context.Context, email string) (bool, error) {
@@ -144,24 +312,78 @@ func (r *userRepository) EmailExists(ctx context.Context, email string) (bool, e
 	return exists, nil
 }
 
+func (r *userRepository) Stats(ctx context.Context) (*user.SignupStats, error) {
+	row, err := r.db.GetUserStats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("repository: user stats failed: %w", err)
+	}
+
+	return &user.SignupStats{
+		Total:          int(row.Total),
+		CreatedLast24h: int(row.CreatedLast24h),
+		CreatedLast7d:  int(row.CreatedLast7d),
+		CreatedLast30d: int(row.CreatedLast30d),
+	}, nil
+}
+
+// nullTimeUTC converts an optional filter to UTC, matching users.created_at
+// (TIMESTAMP without time zone, written by NOW() in UTC).
+func nullTimeUTC(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: t.UTC(), Valid: true}
+}
+
 func sqlcUserToDomain(sqlcUser sqlc.User) *user.User {
-	return &user.User{
+	domainUser := &user.User{
 		ID:        sqlcUser.Uuid,
 		Name:      sqlcUser.Name,
 		Email:     sqlcUser.Email,
 		Password:  sqlcUser.Password,
+		Role:      user.Role(sqlcUser.Role),
+		Status:    user.Status(sqlcUser.Status),
 		CreatedAt: sqlcUser.CreatedAt,
 		UpdatedAt: sqlcUser.UpdatedAt,
+		Version:   int(sqlcUser.Version),
+
+		TokenVersion: int(sqlcUser.TokenVersion),
 	}
+
+	if sqlcUser.VerifiedAt.Valid {
+		domainUser.VerifiedAt = &sqlcUser.VerifiedAt.Time
+	}
+
+	if sqlcUser.DeletedAt.Valid {
+		domainUser.DeletedAt = &sqlcUser.DeletedAt.Time
+	}
+
+	if sqlcUser.LastLoginAt.Valid {
+		domainUser.LastLoginAt = &sqlcUser.LastLoginAt.Time
+	}
+
+	return domainUser
 }
 
 func listRowToDomain(row sqlc.ListUsersRow) *user.User {
-	return &user.User{
+	domainUser := &user.User{
 		ID:        row.Uuid,
 		Name:      row.Name,
 		Email:     row.Email,
 		Password:  "", // Password não vem na listagem por segurança
+		Role:      user.Role(row.Role),
+		Status:    user.Status(row.Status),
 		CreatedAt: row.CreatedAt,
 		UpdatedAt: row.UpdatedAt,
 	}
+
+	if row.DeletedAt.Valid {
+		domainUser.DeletedAt = &row.DeletedAt.Time
+	}
+
+	if row.LastLoginAt.Valid {
+		domainUser.LastLoginAt = &row.LastLoginAt.Time
+	}
+
+	return domainUser
 }
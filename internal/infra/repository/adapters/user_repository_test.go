@@ -2,9 +2,11 @@ package adapters
 
 import (
 	"context"
+	"database/sql"
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
@@ -73,6 +75,13 @@ func runMigrations(db *sqlx.DB) error {
 		name         VARCHAR(255) NOT NULL,
 		email        VARCHAR(100) NOT NULL UNIQUE,
 		password     TEXT NOT NULL,
+		verified_at  TIMESTAMPTZ,
+		role         VARCHAR(20) NOT NULL DEFAULT 'user',
+		deleted_at   TIMESTAMPTZ,
+		version      INTEGER NOT NULL DEFAULT 1,
+		status       VARCHAR(20) NOT NULL DEFAULT 'active',
+		token_version INTEGER NOT NULL DEFAULT 0,
+		last_login_at TIMESTAMPTZ,
 		created_at   TIMESTAMP NOT NULL DEFAULT NOW(),
 		updated_at   TIMESTAMP NOT NULL DEFAULT NOW()
 	);
@@ -169,6 +178,144 @@ func TestUserRepository_GetByID(t *testing.T) {
 
 }
 
+func TestUserRepository_GetByIDs(t *testing.T) {
+	testDB := setupTestDB(t)
+	defer testDB.cleanup()
+
+	queries := sqlc.New(testDB.db)
+	repo := NewUserRepository(queries)
+	ctx := context.Background()
+
+	// Create five users, fetch three of them
+	created := make([]*user.User, 5)
+	for i := range created {
+		created[i] = &user.User{
+			Name:     "User",
+			Email:    uuid.NewString() + "@example.com",
+			Password: "hashedpassword123",
+		}
+		require.NoError(t, repo.Create(ctx, created[i]))
+	}
+
+	t.Run("should return exactly the requested users in request order", func(t *testing.T) {
+		ids := []uuid.UUID{created[3].ID, created[0].ID, created[4].ID}
+
+		found, err := repo.GetByIDs(ctx, ids)
+
+		require.NoError(t, err)
+		require.Len(t, found, 3)
+		for i, id := range ids {
+			assert.Equal(t, id, found[i].ID)
+		}
+		assert.Equal(t, created[3].Email, found[0].Email)
+	})
+
+	t.Run("should skip unknown and deleted IDs and ignore duplicates", func(t *testing.T) {
+		require.NoError(t, repo.Delete(ctx, created[1].ID))
+
+		found, err := repo.GetByIDs(ctx, []uuid.UUID{created[2].ID, uuid.New(), created[1].ID, created[2].ID})
+
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		assert.Equal(t, created[2].ID, found[0].ID)
+	})
+
+	t.Run("should return an empty slice for no IDs", func(t *testing.T) {
+		found, err := repo.GetByIDs(ctx, nil)
+
+		require.NoError(t, err)
+		assert.Empty(t, found)
+	})
+}
+
+func TestUserRepository_UpdateStatus(t *testing.T) {
+	testDB := setupTestDB(t)
+	defer testDB.cleanup()
+
+	queries := sqlc.New(testDB.db)
+	repo := NewUserRepository(queries)
+	ctx := context.Background()
+
+	testUser := &user.User{
+		Name:     "John Doe",
+		Email:    "status@example.com",
+		Password: "hashedpassword123",
+	}
+	require.NoError(t, repo.Create(ctx, testUser))
+
+	t.Run("should default to active", func(t *testing.T) {
+		foundUser, err := repo.GetByID(ctx, testUser.ID)
+
+		require.NoError(t, err)
+		assert.Equal(t, user.StatusActive, foundUser.Status)
+	})
+
+	t.Run("should suspend and reactivate", func(t *testing.T) {
+		require.NoError(t, repo.UpdateStatus(ctx, testUser.ID, user.StatusSuspended))
+		foundUser, err := repo.GetByEmail(ctx, testUser.Email)
+		require.NoError(t, err)
+		assert.Equal(t, user.StatusSuspended, foundUser.Status)
+
+		require.NoError(t, repo.UpdateStatus(ctx, testUser.ID, user.StatusActive))
+		foundUser, err = repo.GetByID(ctx, testUser.ID)
+		require.NoError(t, err)
+		assert.Equal(t, user.StatusActive, foundUser.Status)
+	})
+
+	t.Run("should return not found for unknown user", func(t *testing.T) {
+		err := repo.UpdateStatus(ctx, uuid.New(), user.StatusSuspended)
+		assert.ErrorIs(t, err, user.ErrUserNotFound)
+	})
+}
+
+func TestUserRepository_UpdateLastLogin(t *testing.T) {
+	testDB := setupTestDB(t)
+	defer testDB.cleanup()
+
+	queries := sqlc.New(testDB.db)
+	repo := NewUserRepository(queries)
+	ctx := context.Background()
+
+	testUser := &user.User{
+		Name:     "John Doe",
+		Email:    "lastlogin@example.com",
+		Password: "hashedpassword123",
+	}
+	require.NoError(t, repo.Create(ctx, testUser))
+
+	t.Run("should be empty before the first sign in", func(t *testing.T) {
+		foundUser, err := repo.GetByID(ctx, testUser.ID)
+
+		require.NoError(t, err)
+		assert.Nil(t, foundUser.LastLoginAt)
+	})
+
+	t.Run("should store and advance the last login", func(t *testing.T) {
+		first := time.Now().Add(-time.Minute)
+		require.NoError(t, repo.UpdateLastLogin(ctx, testUser.ID, first))
+		foundUser, err := repo.GetByID(ctx, testUser.ID)
+		require.NoError(t, err)
+		require.NotNil(t, foundUser.LastLoginAt)
+		assert.WithinDuration(t, first, *foundUser.LastLoginAt, time.Millisecond)
+
+		second := time.Now()
+		require.NoError(t, repo.UpdateLastLogin(ctx, testUser.ID, second))
+		foundUser, err = repo.GetByEmail(ctx, testUser.Email)
+		require.NoError(t, err)
+		assert.WithinDuration(t, second, *foundUser.LastLoginAt, time.Millisecond)
+	})
+
+	t.Run("should ignore an older time written late", func(t *testing.T) {
+		latest := time.Now()
+		require.NoError(t, repo.UpdateLastLogin(ctx, testUser.ID, latest))
+		require.NoError(t, repo.UpdateLastLogin(ctx, testUser.ID, latest.Add(-time.Hour)))
+
+		foundUser, err := repo.GetByID(ctx, testUser.ID)
+		require.NoError(t, err)
+		assert.WithinDuration(t, latest, *foundUser.LastLoginAt, time.Millisecond)
+	})
+}
+
 func TestUserRepository_GetByEmail(t *testing.T) {
 	testDB := setupTestDB(t)
 	defer testDB.cleanup()
@@ -239,6 +386,51 @@ func TestUserRepository_Update(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, "John Updated", updatedUser.Name)
 		assert.Equal(t, "john.updated@example.com", updatedUser.Email)
+		assert.Equal(t, testUser.Version, updatedUser.Version)
+	})
+
+	t.Run("should reject update with stale version", func(t *testing.T) {
+		// Two copies loaded at the same version
+		first, err := repo.GetByID(ctx, testUser.ID)
+		require.NoError(t, err)
+		second, err := repo.GetByID(ctx, testUser.ID)
+		require.NoError(t, err)
+
+		first.Name = "First Writer"
+		require.NoError(t, repo.Update(ctx, first))
+
+		second.Name = "Second Writer"
+		err = repo.Update(ctx, second)
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, user.ErrConflict)
+		assert.Contains(t, err.Error(), "user was modified concurrently")
+
+		stored, err := repo.GetByID(ctx, testUser.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "First Writer", stored.Name)
+	})
+
+	t.Run("should accept update with fresh version", func(t *testing.T) {
+		fresh, err := repo.GetByID(ctx, testUser.ID)
+		require.NoError(t, err)
+
+		fresh.Name = "Fresh Writer"
+		err = repo.Update(ctx, fresh)
+
+		require.NoError(t, err)
+		stored, err := repo.GetByID(ctx, testUser.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Fresh Writer", stored.Name)
+		assert.Equal(t, fresh.Version, stored.Version)
+	})
+
+	t.Run("should return not found for missing user", func(t *testing.T) {
+		missing := &user.User{ID: uuid.New(), Name: "Ghost", Version: 1}
+
+		err := repo.Update(ctx, missing)
+
+		assert.ErrorIs(t, err, user.ErrUserNotFound)
 	})
 }
 
@@ -271,6 +463,89 @@ func TestUserRepository_Delete(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "user not found")
 	})
+
+	t.Run("should keep row with deleted_at set", func(t *testing.T) {
+		var deletedAt sql.NullTime
+		err := testDB.db.Get(&deletedAt, "SELECT deleted_at FROM users WHERE uuid = $1", testUser.ID)
+		require.NoError(t, err)
+		assert.True(t, deletedAt.Valid)
+	})
+
+	t.Run("should hide soft-deleted user from lookups", func(t *testing.T) {
+		_, err := repo.GetByEmail(ctx, testUser.Email)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "user not found")
+
+		exists, err := repo.EmailExists(ctx, testUser.Email)
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("should list soft-deleted user only when requested", func(t *testing.T) {
+		users, _, err := repo.List(ctx, user.ListParams{Page: 1, PageSize: 10})
+		require.NoError(t, err)
+		for _, u := range users {
+			assert.NotEqual(t, testUser.ID, u.ID)
+		}
+
+		users, _, err = repo.List(ctx, user.ListParams{Page: 1, PageSize: 10, IncludeDeleted: true})
+		require.NoError(t, err)
+
+		var found *user.User
+		for _, u := range users {
+			if u.ID == testUser.ID {
+				found = u
+			}
+		}
+		require.NotNil(t, found)
+		assert.True(t, found.IsDeleted())
+	})
+
+	t.Run("should fail deleting already deleted user", func(t *testing.T) {
+		err := repo.Delete(ctx, testUser.ID)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "user not found")
+	})
+}
+
+func TestUserRepository_DeleteMany(t *testing.T) {
+	testDB := setupTestDB(t)
+	defer testDB.cleanup()
+
+	queries := sqlc.New(testDB.db)
+	repo := NewUserRepository(queries)
+	ctx := context.Background()
+
+	var users []*user.User
+	for _, address := range []string{"spam1@example.com", "spam2@example.com", "keep@example.com"} {
+		u := &user.User{Name: "User", Email: address, Password: "hashedpassword123"}
+		require.NoError(t, repo.Create(ctx, u))
+		users = append(users, u)
+	}
+
+	t.Run("should soft-delete only the listed users", func(t *testing.T) {
+		unknown := uuid.New()
+
+		deleted, err := repo.DeleteMany(ctx, []uuid.UUID{users[0].ID, unknown, users[1].ID})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []uuid.UUID{users[0].ID, users[1].ID}, deleted)
+
+		for _, u := range users[:2] {
+			_, err := repo.GetByID(ctx, u.ID)
+			assert.ErrorIs(t, err, user.ErrUserNotFound)
+		}
+
+		kept, err := repo.GetByID(ctx, users[2].ID)
+		require.NoError(t, err)
+		assert.False(t, kept.IsDeleted())
+	})
+
+	t.Run("should skip users already deleted", func(t *testing.T) {
+		deleted, err := repo.DeleteMany(ctx, []uuid.UUID{users[0].ID})
+		require.NoError(t, err)
+		assert.Empty(t, deleted)
+	})
 }
 
 func TestUserRepository_EmailExists(t *testing.T) {
@@ -8,24 +8,90 @@ package sqlc
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
+const countEmailsByRecipient = `-- name: CountEmailsByRecipient :one
+SELECT COUNT(*)
+FROM emails
+WHERE to_email = $1
+`
+
+func (q *Queries) CountEmailsByRecipient(ctx context.Context, toEmail string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countEmailsByRecipient, toEmail)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countPendingEmails = `-- name: CountPendingEmails :one
+SELECT COUNT(*)
+FROM emails
+WHERE status = 'pending'
+  AND next_attempt_at <= NOW()
+  AND (scheduled_at IS NULL OR scheduled_at <= NOW())
+`
+
+func (q *Queries) CountPendingEmails(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countPendingEmails)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countSearchEmails = `-- name: CountSearchEmails :one
+SELECT COUNT(*)
+FROM emails
+WHERE ($1::text IS NULL OR to_email ILIKE '%' || $1::text || '%')
+  AND ($2::text IS NULL OR status = $2::text)
+  AND ($3::text IS NULL OR type = $3::text)
+  AND ($4::timestamptz IS NULL OR created_at >= $4::timestamptz)
+  AND ($5::timestamptz IS NULL OR created_at <= $5::timestamptz)
+`
+
+type CountSearchEmailsParams struct {
+	ToEmail       sql.NullString
+	Status        sql.NullString
+	Type          sql.NullString
+	CreatedAfter  sql.NullTime
+	CreatedBefore sql.NullTime
+}
+
+func (q *Queries) CountSearchEmails(ctx context.Context, arg CountSearchEmailsParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countSearchEmails,
+		arg.ToEmail,
+		arg.Status,
+		arg.Type,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createEmail = `-- name: CreateEmail :one
-INSERT INTO emails (to_email, subject, body, type, status, attempts, max_attempts)
-VALUES ($1, $2, $3, $4, $5, $6, $7)
-RETURNING uuid, to_email, subject, body, type, status, attempts, max_attempts, error_msg, sent_at, created_at, updated_at
+INSERT INTO emails (to_email, subject, body, plain_body, type, status, attempts, max_attempts, cc_emails, bcc_emails, dedup_key, scheduled_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+RETURNING uuid, to_email, subject, body, type, status, attempts, max_attempts, error_msg, sent_at, created_at, updated_at, plain_body, next_attempt_at, cc_emails, bcc_emails, dedup_key, scheduled_at
 `
 
 type CreateEmailParams struct {
 	ToEmail     string
 	Subject     string
 	Body        string
+	PlainBody   string
 	Type        string
 	Status      string
 	Attempts    int32
 	MaxAttempts int32
+	CcEmails    []string
+	BccEmails   []string
+	DedupKey    sql.NullString
+	ScheduledAt sql.NullTime
 }
 
 func (q *Queries) CreateEmail(ctx context.Context, arg CreateEmailParams) (Email, error) {
@@ -33,10 +99,83 @@ func (q *Queries) CreateEmail(ctx context.Context, arg CreateEmailParams) (Email
 		arg.ToEmail,
 		arg.Subject,
 		arg.Body,
+		arg.PlainBody,
+		arg.Type,
+		arg.Status,
+		arg.Attempts,
+		arg.MaxAttempts,
+		pq.Array(arg.CcEmails),
+		pq.Array(arg.BccEmails),
+		arg.DedupKey,
+		arg.ScheduledAt,
+	)
+	var i Email
+	err := row.Scan(
+		&i.Uuid,
+		&i.ToEmail,
+		&i.Subject,
+		&i.Body,
+		&i.Type,
+		&i.Status,
+		&i.Attempts,
+		&i.MaxAttempts,
+		&i.ErrorMsg,
+		&i.SentAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.PlainBody,
+		&i.NextAttemptAt,
+		pq.Array(&i.CcEmails),
+		pq.Array(&i.BccEmails),
+		&i.DedupKey,
+		&i.ScheduledAt,
+	)
+	return i, err
+}
+
+const createEmailIfNotExists = `-- name: CreateEmailIfNotExists :one
+INSERT INTO emails (to_email, subject, body, plain_body, type, status, attempts, max_attempts, cc_emails, bcc_emails, scheduled_at, dedup_key)
+SELECT $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12::varchar
+WHERE NOT EXISTS (
+    SELECT 1
+    FROM emails
+    WHERE dedup_key = $12::varchar
+      AND created_at > NOW() - make_interval(secs => $13::float8)
+)
+RETURNING uuid, to_email, subject, body, type, status, attempts, max_attempts, error_msg, sent_at, created_at, updated_at, plain_body, next_attempt_at, cc_emails, bcc_emails, dedup_key, scheduled_at
+`
+
+type CreateEmailIfNotExistsParams struct {
+	ToEmail       string
+	Subject       string
+	Body          string
+	PlainBody     string
+	Type          string
+	Status        string
+	Attempts      int32
+	MaxAttempts   int32
+	CcEmails      []string
+	BccEmails     []string
+	ScheduledAt   sql.NullTime
+	DedupKey      string
+	WindowSeconds float64
+}
+
+func (q *Queries) CreateEmailIfNotExists(ctx context.Context, arg CreateEmailIfNotExistsParams) (Email, error) {
+	row := q.db.QueryRowContext(ctx, createEmailIfNotExists,
+		arg.ToEmail,
+		arg.Subject,
+		arg.Body,
+		arg.PlainBody,
 		arg.Type,
 		arg.Status,
 		arg.Attempts,
 		arg.MaxAttempts,
+		pq.Array(arg.CcEmails),
+		pq.Array(arg.BccEmails),
+		arg.ScheduledAt,
+		arg.DedupKey,
+		arg.WindowSeconds,
 	)
 	var i Email
 	err := row.Scan(
@@ -52,12 +191,18 @@ func (q *Queries) CreateEmail(ctx context.Context, arg CreateEmailParams) (Email
 		&i.SentAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.PlainBody,
+		&i.NextAttemptAt,
+		pq.Array(&i.CcEmails),
+		pq.Array(&i.BccEmails),
+		&i.DedupKey,
+		&i.ScheduledAt,
 	)
 	return i, err
 }
 
 const getEmailByID = `-- name: GetEmailByID :one
-SELECT uuid, to_email, subject, body, type, status, attempts, max_attempts, error_msg, sent_at, created_at, updated_at
+SELECT uuid, to_email, subject, body, type, status, attempts, max_attempts, error_msg, sent_at, created_at, updated_at, plain_body, next_attempt_at, cc_emails, bcc_emails, dedup_key, scheduled_at
 FROM emails
 WHERE uuid = $1
 `
@@ -78,15 +223,96 @@ func (q *Queries) GetEmailByID(ctx context.Context, argUuid uuid.UUID) (Email, e
 		&i.SentAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.PlainBody,
+		&i.NextAttemptAt,
+		pq.Array(&i.CcEmails),
+		pq.Array(&i.BccEmails),
+		&i.DedupKey,
+		&i.ScheduledAt,
 	)
 	return i, err
 }
 
+const getEmailStats = `-- name: GetEmailStats :one
+SELECT COUNT(*) FILTER (WHERE status = 'pending') AS pending,
+       COUNT(*) FILTER (WHERE status = 'sent')    AS sent,
+       COUNT(*) FILTER (WHERE status = 'failed')  AS failed
+FROM emails
+`
+
+type GetEmailStatsRow struct {
+	Pending int64
+	Sent    int64
+	Failed  int64
+}
+
+func (q *Queries) GetEmailStats(ctx context.Context) (GetEmailStatsRow, error) {
+	row := q.db.QueryRowContext(ctx, getEmailStats)
+	var i GetEmailStatsRow
+	err := row.Scan(
+		&i.Pending,
+		&i.Sent,
+		&i.Failed,
+	)
+	return i, err
+}
+
+const getEmailsByRecipient = `-- name: GetEmailsByRecipient :many
+SELECT uuid, to_email, subject, body, type, status, attempts, max_attempts, error_msg, sent_at, created_at, updated_at, plain_body, next_attempt_at, cc_emails, bcc_emails, dedup_key, scheduled_at
+FROM emails
+WHERE to_email = $1
+ORDER BY created_at ASC
+`
+
+func (q *Queries) GetEmailsByRecipient(ctx context.Context, toEmail string) ([]Email, error) {
+	rows, err := q.db.QueryContext(ctx, getEmailsByRecipient, toEmail)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Email
+	for rows.Next() {
+		var i Email
+		if err := rows.Scan(
+			&i.Uuid,
+			&i.ToEmail,
+			&i.Subject,
+			&i.Body,
+			&i.Type,
+			&i.Status,
+			&i.Attempts,
+			&i.MaxAttempts,
+			&i.ErrorMsg,
+			&i.SentAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.PlainBody,
+			&i.NextAttemptAt,
+			pq.Array(&i.CcEmails),
+			pq.Array(&i.BccEmails),
+			&i.DedupKey,
+			&i.ScheduledAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getPendingEmails = `-- name: GetPendingEmails :many
-SELECT uuid, to_email, subject, body, type, status, attempts, max_attempts, error_msg, sent_at, created_at, updated_at
+SELECT uuid, to_email, subject, body, type, status, attempts, max_attempts, error_msg, sent_at, created_at, updated_at, plain_body, next_attempt_at, cc_emails, bcc_emails, dedup_key, scheduled_at
 FROM emails
 WHERE status = 'pending'
-ORDER BY created_at ASC
+  AND next_attempt_at <= NOW()
+  AND (scheduled_at IS NULL OR scheduled_at <= NOW())
+ORDER BY next_attempt_at ASC, created_at ASC
 LIMIT $1
 `
 
@@ -112,6 +338,213 @@ func (q *Queries) GetPendingEmails(ctx context.Context, limit int32) ([]Email, e
 			&i.SentAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.PlainBody,
+			&i.NextAttemptAt,
+			pq.Array(&i.CcEmails),
+			pq.Array(&i.BccEmails),
+			&i.DedupKey,
+			&i.ScheduledAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEmailsByRecipient = `-- name: ListEmailsByRecipient :many
+SELECT uuid, to_email, subject, type, status, sent_at, created_at,
+       (CASE WHEN $1::bool THEN body ELSE '' END)::text AS body
+FROM emails
+WHERE to_email = $2
+ORDER BY created_at DESC, uuid DESC
+LIMIT $3::int
+    OFFSET $4::int
+`
+
+type ListEmailsByRecipientParams struct {
+	IncludeBody bool
+	ToEmail     string
+	Limit       int32
+	Offset      int32
+}
+
+type ListEmailsByRecipientRow struct {
+	Uuid      uuid.UUID
+	ToEmail   string
+	Subject   string
+	Type      string
+	Status    string
+	SentAt    sql.NullTime
+	CreatedAt time.Time
+	Body      string
+}
+
+func (q *Queries) ListEmailsByRecipient(ctx context.Context, arg ListEmailsByRecipientParams) ([]ListEmailsByRecipientRow, error) {
+	rows, err := q.db.QueryContext(ctx, listEmailsByRecipient,
+		arg.IncludeBody,
+		arg.ToEmail,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListEmailsByRecipientRow
+	for rows.Next() {
+		var i ListEmailsByRecipientRow
+		if err := rows.Scan(
+			&i.Uuid,
+			&i.ToEmail,
+			&i.Subject,
+			&i.Type,
+			&i.Status,
+			&i.SentAt,
+			&i.CreatedAt,
+			&i.Body,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockEmailDedupKey = `-- name: LockEmailDedupKey :exec
+SELECT pg_advisory_xact_lock(hashtext($1::varchar))
+`
+
+func (q *Queries) LockEmailDedupKey(ctx context.Context, dedupKey string) error {
+	_, err := q.db.ExecContext(ctx, lockEmailDedupKey, dedupKey)
+	return err
+}
+
+const lockEmailForProcessing = `-- name: LockEmailForProcessing :one
+SELECT uuid, to_email, subject, body, type, status, attempts, max_attempts, error_msg, sent_at, created_at, updated_at, plain_body, next_attempt_at, cc_emails, bcc_emails, dedup_key, scheduled_at
+FROM emails
+WHERE uuid = $1
+FOR UPDATE SKIP LOCKED
+`
+
+func (q *Queries) LockEmailForProcessing(ctx context.Context, argUuid uuid.UUID) (Email, error) {
+	row := q.db.QueryRowContext(ctx, lockEmailForProcessing, argUuid)
+	var i Email
+	err := row.Scan(
+		&i.Uuid,
+		&i.ToEmail,
+		&i.Subject,
+		&i.Body,
+		&i.Type,
+		&i.Status,
+		&i.Attempts,
+		&i.MaxAttempts,
+		&i.ErrorMsg,
+		&i.SentAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.PlainBody,
+		&i.NextAttemptAt,
+		pq.Array(&i.CcEmails),
+		pq.Array(&i.BccEmails),
+		&i.DedupKey,
+		&i.ScheduledAt,
+	)
+	return i, err
+}
+
+const reclaimStaleSendingEmails = `-- name: ReclaimStaleSendingEmails :execrows
+UPDATE emails
+SET status     = 'pending',
+    updated_at = NOW()
+WHERE status = 'sending'
+  AND updated_at < $1::timestamptz
+`
+
+func (q *Queries) ReclaimStaleSendingEmails(ctx context.Context, staleBefore time.Time) (int64, error) {
+	result, err := q.db.ExecContext(ctx, reclaimStaleSendingEmails, staleBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const searchEmails = `-- name: SearchEmails :many
+SELECT uuid, to_email, subject, type, status, attempts, max_attempts, error_msg, sent_at, created_at
+FROM emails
+WHERE ($1::text IS NULL OR to_email ILIKE '%' || $1::text || '%')
+  AND ($2::text IS NULL OR status = $2::text)
+  AND ($3::text IS NULL OR type = $3::text)
+  AND ($4::timestamptz IS NULL OR created_at >= $4::timestamptz)
+  AND ($5::timestamptz IS NULL OR created_at <= $5::timestamptz)
+ORDER BY created_at DESC, uuid DESC
+LIMIT $6::int
+    OFFSET $7::int
+`
+
+type SearchEmailsParams struct {
+	ToEmail       sql.NullString
+	Status        sql.NullString
+	Type          sql.NullString
+	CreatedAfter  sql.NullTime
+	CreatedBefore sql.NullTime
+	Limit         int32
+	Offset        int32
+}
+
+type SearchEmailsRow struct {
+	Uuid        uuid.UUID
+	ToEmail     string
+	Subject     string
+	Type        string
+	Status      string
+	Attempts    int32
+	MaxAttempts int32
+	ErrorMsg    sql.NullString
+	SentAt      sql.NullTime
+	CreatedAt   time.Time
+}
+
+func (q *Queries) SearchEmails(ctx context.Context, arg SearchEmailsParams) ([]SearchEmailsRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchEmails,
+		arg.ToEmail,
+		arg.Status,
+		arg.Type,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SearchEmailsRow
+	for rows.Next() {
+		var i SearchEmailsRow
+		if err := rows.Scan(
+			&i.Uuid,
+			&i.ToEmail,
+			&i.Subject,
+			&i.Type,
+			&i.Status,
+			&i.Attempts,
+			&i.MaxAttempts,
+			&i.ErrorMsg,
+			&i.SentAt,
+			&i.CreatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -133,16 +566,18 @@ SET
     attempts = COALESCE($3, attempts),
     error_msg = COALESCE($4, error_msg),
     sent_at = COALESCE($5, sent_at),
+    next_attempt_at = COALESCE($6, next_attempt_at),
     updated_at = NOW()
 WHERE uuid = $1
 `
 
 type UpdateEmailParams struct {
-	Uuid     uuid.UUID
-	Status   sql.NullString
-	Attempts sql.NullInt32
-	ErrorMsg sql.NullString
-	SentAt   sql.NullTime
+	Uuid          uuid.UUID
+	Status        sql.NullString
+	Attempts      sql.NullInt32
+	ErrorMsg      sql.NullString
+	SentAt        sql.NullTime
+	NextAttemptAt sql.NullTime
 }
 
 func (q *Queries) UpdateEmail(ctx context.Context, arg UpdateEmailParams) error {
@@ -152,6 +587,7 @@ func (q *Queries) UpdateEmail(ctx context.Context, arg UpdateEmailParams) error
 		arg.Attempts,
 		arg.ErrorMsg,
 		arg.SentAt,
+		arg.NextAttemptAt,
 	)
 	return err
 }
@@ -12,27 +12,89 @@ import (
 )
 
 type Email struct {
-	Uuid        uuid.UUID
-	ToEmail     string
-	Subject     string
-	Body        string
-	Type        string
-	Status      string
-	Attempts    int32
-	MaxAttempts int32
-	ErrorMsg    sql.NullString
-	SentAt      sql.NullTime
+	Uuid          uuid.UUID
+	ToEmail       string
+	Subject       string
+	Body          string
+	Type          string
+	Status        string
+	Attempts      int32
+	MaxAttempts   int32
+	ErrorMsg      sql.NullString
+	SentAt        sql.NullTime
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	PlainBody     string
+	NextAttemptAt time.Time
+	CcEmails      []string
+	BccEmails     []string
+	DedupKey      sql.NullString
+	ScheduledAt   sql.NullTime
+}
+
+type EmailAttachment struct {
+	ID          int64
+	EmailUuid   uuid.UUID
+	Filename    string
+	ContentType string
+	Content     []byte
 	CreatedAt   time.Time
-	UpdatedAt   time.Time
 }
 
-type User struct {
-	Uuid      uuid.UUID
-	Name      string
+type EmailVerificationToken struct {
+	TokenHash string
+	UserUuid  uuid.UUID
+	ExpiresAt time.Time
+	UsedAt    sql.NullTime
+	CreatedAt time.Time
+}
+
+type IdempotencyKey struct {
+	IdempotencyKey string
+	UserUuid       uuid.UUID
+	ExpiresAt      time.Time
+	CreatedAt      time.Time
+}
+
+type LoginEvent struct {
+	ID        int64
+	UserUuid  uuid.NullUUID
 	Email     string
-	Password  string
+	IpAddress string
+	UserAgent string
+	Success   bool
+	CreatedAt time.Time
+}
+
+type PasswordResetToken struct {
+	TokenHash string
+	UserUuid  uuid.UUID
+	ExpiresAt time.Time
+	UsedAt    sql.NullTime
 	CreatedAt time.Time
-	UpdatedAt time.Time
+}
+
+type RevokedToken struct {
+	TokenUuid uuid.UUID
+	UserUuid  uuid.UUID
+	ExpiresAt time.Time
+	RevokedAt time.Time
+}
+
+type User struct {
+	Uuid         uuid.UUID
+	Name         string
+	Email        string
+	Password     string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	VerifiedAt   sql.NullTime
+	Role         string
+	DeletedAt    sql.NullTime
+	Version      int32
+	Status       string
+	TokenVersion int32
+	LastLoginAt  sql.NullTime
 }
 
 type UserSession struct {
@@ -79,3 +79,85 @@ func (q *Queries) GetSessionByID(ctx context.Context, argUuid uuid.UUID) (UserSe
 	)
 	return i, err
 }
+
+const listActiveSessionsByUser = `-- name: ListActiveSessionsByUser :many
+SELECT uuid, user_uuid, refresh_token, user_agent, client_ip, is_blocked, expires_at, created_at
+FROM user_sessions
+WHERE user_uuid = $1
+  AND is_blocked = false
+  AND expires_at > NOW()
+ORDER BY created_at DESC
+`
+
+func (q *Queries) ListActiveSessionsByUser(ctx context.Context, userUuid uuid.UUID) ([]UserSession, error) {
+	rows, err := q.db.QueryContext(ctx, listActiveSessionsByUser, userUuid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []UserSession
+	for rows.Next() {
+		var i UserSession
+		if err := rows.Scan(
+			&i.Uuid,
+			&i.UserUuid,
+			&i.RefreshToken,
+			&i.UserAgent,
+			&i.ClientIp,
+			&i.IsBlocked,
+			&i.ExpiresAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const revokeSession = `-- name: RevokeSession :one
+UPDATE user_sessions
+SET is_blocked = true
+WHERE uuid = $1
+  AND user_uuid = $2
+RETURNING uuid, user_uuid, refresh_token, user_agent, client_ip, is_blocked, expires_at, created_at
+`
+
+type RevokeSessionParams struct {
+	Uuid     uuid.UUID
+	UserUuid uuid.UUID
+}
+
+func (q *Queries) RevokeSession(ctx context.Context, arg RevokeSessionParams) (UserSession, error) {
+	row := q.db.QueryRowContext(ctx, revokeSession, arg.Uuid, arg.UserUuid)
+	var i UserSession
+	err := row.Scan(
+		&i.Uuid,
+		&i.UserUuid,
+		&i.RefreshToken,
+		&i.UserAgent,
+		&i.ClientIp,
+		&i.IsBlocked,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const revokeUserSessions = `-- name: RevokeUserSessions :exec
+UPDATE user_sessions
+SET is_blocked = true
+WHERE user_uuid = $1
+  AND is_blocked = false
+`
+
+func (q *Queries) RevokeUserSessions(ctx context.Context, userUuid uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, revokeUserSessions, userUuid)
+	return err
+}
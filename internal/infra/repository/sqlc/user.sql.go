@@ -11,12 +11,47 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
+const countUsers = `-- name: CountUsers :one
+SELECT COUNT(*)
+FROM users
+WHERE
+    CASE
+        WHEN $1::text IS NOT NULL THEN
+            (name ILIKE '%' || $1::text || '%' OR
+             email ILIKE '%' || $1::text || '%')
+        ELSE TRUE
+        END
+  AND ($2::bool OR deleted_at IS NULL)
+  AND ($3::timestamp IS NULL OR created_at >= $3::timestamp)
+  AND ($4::timestamp IS NULL OR created_at <= $4::timestamp)
+`
+
+type CountUsersParams struct {
+	Search         sql.NullString
+	IncludeDeleted bool
+	CreatedAfter   sql.NullTime
+	CreatedBefore  sql.NullTime
+}
+
+func (q *Queries) CountUsers(ctx context.Context, arg CountUsersParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countUsers,
+		arg.Search,
+		arg.IncludeDeleted,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createUser = `-- name: CreateUser :one
 INSERT INTO users (email, password, name)
 VALUES ($1, $2, $3)
-RETURNING uuid, name, email, password, created_at, updated_at
+RETURNING uuid, name, email, password, created_at, updated_at, verified_at, role, deleted_at, version, status, token_version, last_login_at
 `
 
 type CreateUserParams struct {
@@ -35,12 +70,19 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.Password,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.VerifiedAt,
+		&i.Role,
+		&i.DeletedAt,
+		&i.Version,
+		&i.Status,
+		&i.TokenVersion,
+		&i.LastLoginAt,
 	)
 	return i, err
 }
 
 const emailExists = `-- name: EmailExists :one
-SELECT EXISTS(SELECT 1 FROM users WHERE email = $1)
+SELECT EXISTS(SELECT 1 FROM users WHERE LOWER(email) = LOWER($1) AND deleted_at IS NULL)
 `
 
 func (q *Queries) EmailExists(ctx context.Context, email string) (bool, error) {
@@ -51,9 +93,10 @@ func (q *Queries) EmailExists(ctx context.Context, email string) (bool, error) {
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT uuid, name, email, password, created_at, updated_at
+SELECT uuid, name, email, password, created_at, updated_at, verified_at, role, deleted_at, version, status, token_version, last_login_at
 FROM users
-WHERE email = $1
+WHERE LOWER(email) = LOWER($1)
+  AND deleted_at IS NULL
 `
 
 func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error) {
@@ -66,14 +109,22 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.Password,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.VerifiedAt,
+		&i.Role,
+		&i.DeletedAt,
+		&i.Version,
+		&i.Status,
+		&i.TokenVersion,
+		&i.LastLoginAt,
 	)
 	return i, err
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT uuid, name, email, password, created_at, updated_at
+SELECT uuid, name, email, password, created_at, updated_at, verified_at, role, deleted_at, version, status, token_version, last_login_at
 FROM users
 WHERE users.uuid = $1
+  AND deleted_at IS NULL
 `
 
 func (q *Queries) GetUserByID(ctx context.Context, argUuid uuid.UUID) (User, error) {
@@ -86,6 +137,13 @@ func (q *Queries) GetUserByID(ctx context.Context, argUuid uuid.UUID) (User, err
 		&i.Password,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.VerifiedAt,
+		&i.Role,
+		&i.DeletedAt,
+		&i.Version,
+		&i.Status,
+		&i.TokenVersion,
+		&i.LastLoginAt,
 	)
 	return i, err
 }
@@ -103,8 +161,96 @@ func (q *Queries) GetUserPasswordByID(ctx context.Context, argUuid uuid.UUID) (s
 	return password, err
 }
 
+const getUserStats = `-- name: GetUserStats :one
+SELECT COUNT(*)                                                          AS total,
+       COUNT(*) FILTER (WHERE created_at >= NOW() - INTERVAL '24 hours') AS created_last_24h,
+       COUNT(*) FILTER (WHERE created_at >= NOW() - INTERVAL '7 days')   AS created_last_7d,
+       COUNT(*) FILTER (WHERE created_at >= NOW() - INTERVAL '30 days')  AS created_last_30d
+FROM users
+WHERE deleted_at IS NULL
+`
+
+type GetUserStatsRow struct {
+	Total          int64
+	CreatedLast24h int64
+	CreatedLast7d  int64
+	CreatedLast30d int64
+}
+
+func (q *Queries) GetUserStats(ctx context.Context) (GetUserStatsRow, error) {
+	row := q.db.QueryRowContext(ctx, getUserStats)
+	var i GetUserStatsRow
+	err := row.Scan(
+		&i.Total,
+		&i.CreatedLast24h,
+		&i.CreatedLast7d,
+		&i.CreatedLast30d,
+	)
+	return i, err
+}
+
+const getUsersByIDs = `-- name: GetUsersByIDs :many
+SELECT uuid, name, email, password, created_at, updated_at, verified_at, role, deleted_at, version, status, token_version, last_login_at
+FROM users
+WHERE uuid = ANY($1::uuid[])
+  AND deleted_at IS NULL
+`
+
+func (q *Queries) GetUsersByIDs(ctx context.Context, ids []uuid.UUID) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, getUsersByIDs, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.Uuid,
+			&i.Name,
+			&i.Email,
+			&i.Password,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.VerifiedAt,
+			&i.Role,
+			&i.DeletedAt,
+			&i.Version,
+			&i.Status,
+			&i.TokenVersion,
+			&i.LastLoginAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const incrementUserTokenVersion = `-- name: IncrementUserTokenVersion :one
+UPDATE users
+SET token_version = token_version + 1,
+    updated_at    = NOW()
+WHERE uuid = $1
+  AND deleted_at IS NULL
+RETURNING token_version
+`
+
+func (q *Queries) IncrementUserTokenVersion(ctx context.Context, argUuid uuid.UUID) (int32, error) {
+	row := q.db.QueryRowContext(ctx, incrementUserTokenVersion, argUuid)
+	var token_version int32
+	err := row.Scan(&token_version)
+	return token_version, err
+}
+
 const listUsers = `-- name: ListUsers :many
-SELECT uuid, name, email, created_at, updated_at
+SELECT uuid, name, email, role, status, created_at, updated_at, deleted_at, last_login_at
 FROM users
 WHERE
     CASE
@@ -113,27 +259,55 @@ WHERE
              email ILIKE '%' || $1::text || '%')
         ELSE TRUE
         END
-ORDER BY created_at DESC
-LIMIT $3::int
-    OFFSET $2::int
+  AND ($2::bool OR deleted_at IS NULL)
+  AND ($3::timestamp IS NULL OR created_at >= $3::timestamp)
+  AND ($4::timestamp IS NULL OR created_at <= $4::timestamp)
+ORDER BY
+    CASE WHEN $5::text = 'name' AND $6::text = 'asc' THEN name END ASC,
+    CASE WHEN $5::text = 'name' AND $6::text = 'desc' THEN name END DESC,
+    CASE WHEN $5::text = 'email' AND $6::text = 'asc' THEN email END ASC,
+    CASE WHEN $5::text = 'email' AND $6::text = 'desc' THEN email END DESC,
+    CASE WHEN $5::text = 'created_at' AND $6::text = 'asc' THEN created_at END ASC,
+    created_at DESC,
+    uuid DESC
+LIMIT $8::int
+    OFFSET $7::int
 `
 
 type ListUsersParams struct {
-	Search sql.NullString
-	Offset sql.NullInt32
-	Limit  sql.NullInt32
+	Search         sql.NullString
+	IncludeDeleted bool
+	CreatedAfter   sql.NullTime
+	CreatedBefore  sql.NullTime
+	SortBy         string
+	SortOrder      string
+	Offset         sql.NullInt32
+	Limit          sql.NullInt32
 }
 
 type ListUsersRow struct {
-	Uuid      uuid.UUID
-	Name      string
-	Email     string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	Uuid        uuid.UUID
+	Name        string
+	Email       string
+	Role        string
+	Status      string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   sql.NullTime
+	LastLoginAt sql.NullTime
 }
 
 func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]ListUsersRow, error) {
-	rows, err := q.db.QueryContext(ctx, listUsers, arg.Search, arg.Offset, arg.Limit)
+	rows, err := q.db.QueryContext(ctx, listUsers,
+		arg.Search,
+		arg.IncludeDeleted,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+		arg.SortBy,
+		arg.SortOrder,
+		arg.Offset,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -145,8 +319,93 @@ func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]ListUse
 			&i.Uuid,
 			&i.Name,
 			&i.Email,
+			&i.Role,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.LastLoginAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUsersAfterCursor = `-- name: ListUsersAfterCursor :many
+SELECT uuid, name, email, role, status, created_at, updated_at, deleted_at, last_login_at
+FROM users
+WHERE
+    CASE
+        WHEN $1::text IS NOT NULL THEN
+            (name ILIKE '%' || $1::text || '%' OR
+             email ILIKE '%' || $1::text || '%')
+        ELSE TRUE
+        END
+  AND ($2::bool OR deleted_at IS NULL)
+  AND ($3::timestamp IS NULL OR created_at >= $3::timestamp)
+  AND ($4::timestamp IS NULL OR created_at <= $4::timestamp)
+  AND (created_at, uuid) < ($5::timestamp, $6::uuid)
+ORDER BY created_at DESC, uuid DESC
+LIMIT $7::int
+`
+
+type ListUsersAfterCursorParams struct {
+	Search          sql.NullString
+	IncludeDeleted  bool
+	CreatedAfter    sql.NullTime
+	CreatedBefore   sql.NullTime
+	CursorCreatedAt time.Time
+	CursorUuid      uuid.UUID
+	Limit           int32
+}
+
+type ListUsersAfterCursorRow struct {
+	Uuid        uuid.UUID
+	Name        string
+	Email       string
+	Role        string
+	Status      string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   sql.NullTime
+	LastLoginAt sql.NullTime
+}
+
+func (q *Queries) ListUsersAfterCursor(ctx context.Context, arg ListUsersAfterCursorParams) ([]ListUsersAfterCursorRow, error) {
+	rows, err := q.db.QueryContext(ctx, listUsersAfterCursor,
+		arg.Search,
+		arg.IncludeDeleted,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+		arg.CursorCreatedAt,
+		arg.CursorUuid,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListUsersAfterCursorRow
+	for rows.Next() {
+		var i ListUsersAfterCursorRow
+		if err := rows.Scan(
+			&i.Uuid,
+			&i.Name,
+			&i.Email,
+			&i.Role,
+			&i.Status,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.LastLoginAt,
 		); err != nil {
 			return nil, err
 		}
@@ -161,11 +420,23 @@ func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]ListUse
 	return items, nil
 }
 
+const markUserEmailVerified = `-- name: MarkUserEmailVerified :exec
+UPDATE users
+SET verified_at = COALESCE(verified_at, NOW()),
+    updated_at  = NOW()
+WHERE uuid = $1
+`
+
+func (q *Queries) MarkUserEmailVerified(ctx context.Context, argUuid uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, markUserEmailVerified, argUuid)
+	return err
+}
+
 const removeUserByID = `-- name: RemoveUserByID :one
 DELETE
 FROM users
 WHERE uuid = $1
-RETURNING uuid, name, email, password, created_at, updated_at
+RETURNING uuid, name, email, password, created_at, updated_at, verified_at, role, deleted_at, version, status, token_version, last_login_at
 `
 
 func (q *Queries) RemoveUserByID(ctx context.Context, argUuid uuid.UUID) (User, error) {
@@ -178,26 +449,164 @@ func (q *Queries) RemoveUserByID(ctx context.Context, argUuid uuid.UUID) (User,
 		&i.Password,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.VerifiedAt,
+		&i.Role,
+		&i.DeletedAt,
+		&i.Version,
+		&i.Status,
+		&i.TokenVersion,
+		&i.LastLoginAt,
 	)
 	return i, err
 }
 
-const updateUserByUUID = `-- name: UpdateUserByUUID :exec
+const softDeleteUser = `-- name: SoftDeleteUser :execrows
+UPDATE users
+SET deleted_at = NOW(),
+    updated_at = NOW()
+WHERE uuid = $1
+  AND deleted_at IS NULL
+`
+
+func (q *Queries) SoftDeleteUser(ctx context.Context, argUuid uuid.UUID) (int64, error) {
+	result, err := q.db.ExecContext(ctx, softDeleteUser, argUuid)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const softDeleteUsers = `-- name: SoftDeleteUsers :many
+UPDATE users
+SET deleted_at = NOW(),
+    updated_at = NOW()
+WHERE uuid = ANY($1::uuid[])
+  AND deleted_at IS NULL
+RETURNING uuid
+`
+
+func (q *Queries) SoftDeleteUsers(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, softDeleteUsers, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var uuid uuid.UUID
+		if err := rows.Scan(&uuid); err != nil {
+			return nil, err
+		}
+		items = append(items, uuid)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateUserByUUID = `-- name: UpdateUserByUUID :execrows
 UPDATE users
 SET
     name   = COALESCE($2, name),
     email = COALESCE($3, email),
+    version = version + 1,
     updated_at = NOW()
 WHERE uuid = $1
+  AND version = $4
 `
 
 type UpdateUserByUUIDParams struct {
-	Uuid  uuid.UUID
-	Name  sql.NullString
-	Email sql.NullString
+	Uuid    uuid.UUID
+	Name    sql.NullString
+	Email   sql.NullString
+	Version int32
+}
+
+func (q *Queries) UpdateUserByUUID(ctx context.Context, arg UpdateUserByUUIDParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateUserByUUID,
+		arg.Uuid,
+		arg.Name,
+		arg.Email,
+		arg.Version,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
-func (q *Queries) UpdateUserByUUID(ctx context.Context, arg UpdateUserByUUIDParams) error {
-	_, err := q.db.ExecContext(ctx, updateUserByUUID, arg.Uuid, arg.Name, arg.Email)
+const updateUserLastLogin = `-- name: UpdateUserLastLogin :exec
+UPDATE users
+SET last_login_at = $2
+WHERE uuid = $1
+  AND (last_login_at IS NULL OR last_login_at < $2)
+`
+
+type UpdateUserLastLoginParams struct {
+	Uuid        uuid.UUID
+	LastLoginAt sql.NullTime
+}
+
+func (q *Queries) UpdateUserLastLogin(ctx context.Context, arg UpdateUserLastLoginParams) error {
+	_, err := q.db.ExecContext(ctx, updateUserLastLogin, arg.Uuid, arg.LastLoginAt)
 	return err
 }
+
+const updateUserPassword = `-- name: UpdateUserPassword :exec
+UPDATE users
+SET password   = $2,
+    updated_at = NOW()
+WHERE uuid = $1
+`
+
+type UpdateUserPasswordParams struct {
+	Uuid     uuid.UUID
+	Password string
+}
+
+func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error {
+	_, err := q.db.ExecContext(ctx, updateUserPassword, arg.Uuid, arg.Password)
+	return err
+}
+
+const updateUserRole = `-- name: UpdateUserRole :exec
+UPDATE users
+SET role       = $2,
+    updated_at = NOW()
+WHERE uuid = $1
+`
+
+type UpdateUserRoleParams struct {
+	Uuid uuid.UUID
+	Role string
+}
+
+func (q *Queries) UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) error {
+	_, err := q.db.ExecContext(ctx, updateUserRole, arg.Uuid, arg.Role)
+	return err
+}
+
+const updateUserStatus = `-- name: UpdateUserStatus :execrows
+UPDATE users
+SET status     = $2,
+    updated_at = NOW()
+WHERE uuid = $1
+  AND deleted_at IS NULL
+`
+
+type UpdateUserStatusParams struct {
+	Uuid   uuid.UUID
+	Status string
+}
+
+func (q *Queries) UpdateUserStatus(ctx context.Context, arg UpdateUserStatusParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateUserStatus, arg.Uuid, arg.Status)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
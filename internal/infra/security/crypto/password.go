@@ -1,13 +1,44 @@
 package crypto
 
 import (
+	"errors"
 	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"golang.org/x/crypto/bcrypt"
 )
 
+const DefaultBcryptCost = bcrypt.DefaultCost
+
+// bcryptCost é configurado uma única vez na inicialização (BCRYPT_COST).
+var bcryptCost = DefaultBcryptCost
+
+// SetBcryptCost define o custo usado por HashPassword. Zero mantém o padrão;
+// valores fora do intervalo aceito pelo bcrypt (4–31) são rejeitados e o
+// custo volta para o padrão.
+func SetBcryptCost(cost int) error {
+	if cost == 0 {
+		bcryptCost = DefaultBcryptCost
+		return nil
+	}
+
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		bcryptCost = DefaultBcryptCost
+		return fmt.Errorf("bcrypt cost must be between %d and %d, got %d", bcrypt.MinCost, bcrypt.MaxCost, cost)
+	}
+
+	bcryptCost = cost
+	return nil
+}
+
+func BcryptCost() int {
+	return bcryptCost
+}
+
 func HashPassword(password string) (string, error) {
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcryptCost)
 	if err != nil {
 		return "", fmt.Errorf("failed to hash password: %w", err)
 	}
@@ -18,13 +49,115 @@ func CheckPassword(password string, hashedPassword string) error {
 	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
 }
 
-func ValidatePasswordStrength(password string) error {
-	if len(password) < 6 {
-		return fmt.Errorf("password must be at least 6 characters long")
+// NeedsRehash reports whether hashedPassword was generated with a lower cost
+// than the configured one. Hashes that cannot be parsed are left alone.
+func NeedsRehash(hashedPassword string) bool {
+	cost, err := bcrypt.Cost([]byte(hashedPassword))
+	if err != nil {
+		return false
+	}
+	return cost < bcryptCost
+}
+
+// DefaultPasswordMinLength is the minimum length when PASSWORD_MIN_LENGTH is not set.
+const DefaultPasswordMinLength = 6
+
+// PasswordPolicy lists the rules a new password must satisfy. The zero value
+// of each Require* flag disables that rule.
+type PasswordPolicy struct {
+	MinLength      int
+	RequireDigit   bool
+	RequireUpper   bool
+	RequireLower   bool
+	RequireSpecial bool
+}
+
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{MinLength: DefaultPasswordMinLength}
+}
+
+// passwordPolicy é configurada uma única vez na inicialização (PASSWORD_*).
+var passwordPolicy = DefaultPasswordPolicy()
+
+// SetPasswordPolicy define a política usada por ValidatePassword. MinLength
+// zero mantém o mínimo padrão; valores negativos são rejeitados e a política
+// volta para o padrão.
+func SetPasswordPolicy(policy PasswordPolicy) error {
+	if policy.MinLength < 0 {
+		passwordPolicy = DefaultPasswordPolicy()
+		return fmt.Errorf("password min length must not be negative, got %d", policy.MinLength)
+	}
+	if policy.MinLength == 0 {
+		policy.MinLength = DefaultPasswordMinLength
+	}
+
+	passwordPolicy = policy
+	return nil
+}
+
+func CurrentPasswordPolicy() PasswordPolicy {
+	return passwordPolicy
+}
+
+// ValidatePassword checks password against the configured policy, used by
+// signup, change password and password reset alike.
+func ValidatePassword(password string) error {
+	return passwordPolicy.Validate(password)
+}
+
+// Validate reports every unmet rule, not just the first one, so the client
+// can fix the password in a single attempt.
+func (p PasswordPolicy) Validate(password string) error {
+	var hasDigit, hasUpper, hasLower, hasSpecial bool
+	for _, r := range password {
+		switch {
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			hasSpecial = true
+		}
 	}
 
-	// Adicione mais validações se quiser
-	// hasUpper, hasLower, hasNumber, hasSpecial := false, false, false, false
+	var violations []string
+	if utf8.RuneCountInString(password) < p.MinLength {
+		violations = append(violations, fmt.Sprintf("password must be at least %d characters long", p.MinLength))
+	}
+	if p.RequireDigit && !hasDigit {
+		violations = append(violations, "password must contain at least one digit")
+	}
+	if p.RequireUpper && !hasUpper {
+		violations = append(violations, "password must contain at least one uppercase letter")
+	}
+	if p.RequireLower && !hasLower {
+		violations = append(violations, "password must contain at least one lowercase letter")
+	}
+	if p.RequireSpecial && !hasSpecial {
+		violations = append(violations, "password must contain at least one special character")
+	}
 
+	if len(violations) > 0 {
+		return errors.New(strings.Join(violations, "; "))
+	}
 	return nil
 }
+
+// GenerateTemporaryPassword returns a random password of at least length
+// characters that satisfies the configured policy. It is meant for accounts
+// whose owner sets a real password afterwards (e.g. imported users).
+func GenerateTemporaryPassword(length int) (string, error) {
+	if length < passwordPolicy.MinLength {
+		length = passwordPolicy.MinLength
+	}
+
+	random, err := GenerateRandomString(length)
+	if err != nil {
+		return "", err
+	}
+
+	// Hex só tem dígitos e minúsculas: garantir uma letra de cada classe
+	return random + "Aa1!", nil
+}
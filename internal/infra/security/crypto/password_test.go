@@ -0,0 +1,148 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestSetBcryptCost(t *testing.T) {
+	defer SetBcryptCost(DefaultBcryptCost)
+
+	t.Run("should accept costs within bcrypt range", func(t *testing.T) {
+		require.NoError(t, SetBcryptCost(bcrypt.MinCost))
+		assert.Equal(t, bcrypt.MinCost, BcryptCost())
+
+		require.NoError(t, SetBcryptCost(12))
+		assert.Equal(t, 12, BcryptCost())
+	})
+
+	t.Run("should fall back to default when zero", func(t *testing.T) {
+		require.NoError(t, SetBcryptCost(0))
+		assert.Equal(t, DefaultBcryptCost, BcryptCost())
+	})
+
+	t.Run("should reject out of range costs and use default", func(t *testing.T) {
+		for _, cost := range []int{-1, 3, 32} {
+			require.NoError(t, SetBcryptCost(bcrypt.MinCost))
+
+			err := SetBcryptCost(cost)
+
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "bcrypt cost must be between 4 and 31")
+			assert.Equal(t, DefaultBcryptCost, BcryptCost())
+		}
+	})
+
+	t.Run("should hash with configured cost", func(t *testing.T) {
+		require.NoError(t, SetBcryptCost(5))
+
+		hash, err := HashPassword("password123")
+		require.NoError(t, err)
+
+		cost, err := bcrypt.Cost([]byte(hash))
+		require.NoError(t, err)
+		assert.Equal(t, 5, cost)
+		assert.NoError(t, CheckPassword("password123", hash))
+	})
+}
+
+func TestNeedsRehash(t *testing.T) {
+	defer SetBcryptCost(DefaultBcryptCost)
+
+	require.NoError(t, SetBcryptCost(5))
+	hash, err := HashPassword("password123")
+	require.NoError(t, err)
+
+	assert.False(t, NeedsRehash(hash))
+
+	require.NoError(t, SetBcryptCost(6))
+	assert.True(t, NeedsRehash(hash))
+
+	require.NoError(t, SetBcryptCost(bcrypt.MinCost))
+	assert.False(t, NeedsRehash(hash))
+
+	assert.False(t, NeedsRehash("not-a-bcrypt-hash"))
+}
+
+func TestPasswordPolicy_Validate(t *testing.T) {
+	t.Run("default policy only enforces six characters", func(t *testing.T) {
+		policy := DefaultPasswordPolicy()
+
+		assert.NoError(t, policy.Validate("abcdef"))
+		err := policy.Validate("abc")
+		require.Error(t, err)
+		assert.Equal(t, "password must be at least 6 characters long", err.Error())
+	})
+
+	testCases := []struct {
+		name     string
+		policy   PasswordPolicy
+		valid    string
+		invalid  string
+		expected string
+	}{
+		{"min length", PasswordPolicy{MinLength: 10}, "abcdefghij", "abcdefghi", "password must be at least 10 characters long"},
+		{"digit", PasswordPolicy{MinLength: 1, RequireDigit: true}, "abc1", "abcd", "password must contain at least one digit"},
+		{"uppercase", PasswordPolicy{MinLength: 1, RequireUpper: true}, "abcD", "abcd", "password must contain at least one uppercase letter"},
+		{"lowercase", PasswordPolicy{MinLength: 1, RequireLower: true}, "ABCd", "ABCD", "password must contain at least one lowercase letter"},
+		{"special", PasswordPolicy{MinLength: 1, RequireSpecial: true}, "abc!", "abc1", "password must contain at least one special character"},
+	}
+	for _, tc := range testCases {
+		t.Run("enforces "+tc.name+" on its own", func(t *testing.T) {
+			assert.NoError(t, tc.policy.Validate(tc.valid))
+
+			err := tc.policy.Validate(tc.invalid)
+			require.Error(t, err)
+			assert.Equal(t, tc.expected, err.Error())
+		})
+	}
+
+	t.Run("reports every unmet rule", func(t *testing.T) {
+		policy := PasswordPolicy{MinLength: 8, RequireDigit: true, RequireUpper: true, RequireLower: true, RequireSpecial: true}
+
+		err := policy.Validate("abc")
+		require.Error(t, err)
+		assert.Equal(t, "password must be at least 8 characters long; "+
+			"password must contain at least one digit; "+
+			"password must contain at least one uppercase letter; "+
+			"password must contain at least one special character", err.Error())
+
+		assert.NoError(t, policy.Validate("Abcdef1!"))
+	})
+}
+
+func TestSetPasswordPolicy(t *testing.T) {
+	defer SetPasswordPolicy(DefaultPasswordPolicy())
+
+	t.Run("applies the configured policy to ValidatePassword", func(t *testing.T) {
+		require.NoError(t, SetPasswordPolicy(PasswordPolicy{MinLength: 8, RequireUpper: true}))
+
+		assert.Error(t, ValidatePassword("abcdefgh"))
+		assert.NoError(t, ValidatePassword("Abcdefgh"))
+	})
+
+	t.Run("zero min length keeps the default", func(t *testing.T) {
+		require.NoError(t, SetPasswordPolicy(PasswordPolicy{RequireDigit: true}))
+
+		assert.Equal(t, DefaultPasswordMinLength, CurrentPasswordPolicy().MinLength)
+		assert.True(t, CurrentPasswordPolicy().RequireDigit)
+	})
+
+	t.Run("rejects a negative min length and uses the default", func(t *testing.T) {
+		err := SetPasswordPolicy(PasswordPolicy{MinLength: -1, RequireDigit: true})
+
+		assert.Error(t, err)
+		assert.Equal(t, DefaultPasswordPolicy(), CurrentPasswordPolicy())
+	})
+
+	t.Run("temporary passwords satisfy the strictest policy", func(t *testing.T) {
+		require.NoError(t, SetPasswordPolicy(PasswordPolicy{MinLength: 40, RequireDigit: true, RequireUpper: true, RequireLower: true, RequireSpecial: true}))
+
+		password, err := GenerateTemporaryPassword(32)
+		require.NoError(t, err)
+		assert.NoError(t, ValidatePassword(password))
+	})
+}
@@ -3,6 +3,7 @@ package jwt
 import "errors"
 
 var (
-	ErrInvalidToken = errors.New("token is invalid")
-	ErrExpiredToken = errors.New("token has expired")
+	ErrInvalidToken       = errors.New("token is invalid")
+	ErrExpiredToken       = errors.New("token has expired")
+	ErrSigningUnavailable = errors.New("token signing requires a private key")
 )
@@ -7,6 +7,7 @@ import (
 )
 
 type Maker interface {
-	CreateToken(userID uuid.UUID, duration time.Duration) (string, Payload, error)
+	CreateToken(userID uuid.UUID, tokenVersion int, duration time.Duration, opts ...PayloadOption) (string, Payload, error)
+	CreateRefreshToken(userID uuid.UUID, tokenVersion int, duration time.Duration, opts ...PayloadOption) (string, Payload, error)
 	VerifyToken(token string) (*Payload, error)
 }
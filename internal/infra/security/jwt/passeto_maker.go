@@ -25,12 +25,25 @@ func NewPasetoMaker(symmetricKey string) (Maker, error) {
 	return maker, nil
 }
 
-func (maker *PasetoMaker) CreateToken(userID uuid.UUID, duration time.Duration) (string, Payload, error) {
-	payload, err := NewPayload(userID, duration)
+func (maker *PasetoMaker) CreateToken(userID uuid.UUID, tokenVersion int, duration time.Duration, opts ...PayloadOption) (string, Payload, error) {
+	payload, err := NewPayload(userID, tokenVersion, duration, opts...)
 	if err != nil {
-		return "", *payload, err
+		return "", Payload{}, err
 	}
 
+	return maker.encrypt(payload)
+}
+
+func (maker *PasetoMaker) CreateRefreshToken(userID uuid.UUID, tokenVersion int, duration time.Duration, opts ...PayloadOption) (string, Payload, error) {
+	payload, err := NewRefreshPayload(userID, tokenVersion, duration, opts...)
+	if err != nil {
+		return "", Payload{}, err
+	}
+
+	return maker.encrypt(payload)
+}
+
+func (maker *PasetoMaker) encrypt(payload *Payload) (string, Payload, error) {
 	tokenStr, err := maker.paseto.Encrypt(maker.symmetricKey, payload, nil)
 	return tokenStr, *payload, err
 }
@@ -63,7 +63,7 @@ func TestPasetoMaker_CreateToken(t *testing.T) {
 		userID := uuid.New()
 		duration := time.Hour
 
-		tokenString, payload, err := maker.CreateToken(userID, duration)
+		tokenString, payload, err := maker.CreateToken(userID, 0, duration)
 
 		require.NoError(t, err)
 		assert.NotEmpty(t, tokenString)
@@ -73,14 +73,23 @@ func TestPasetoMaker_CreateToken(t *testing.T) {
 		assert.True(t, payload.IssuedAt.Before(time.Now().Add(time.Second)))
 	})
 
+	t.Run("should carry the token version", func(t *testing.T) {
+		tokenString, _, err := maker.CreateToken(uuid.New(), 3, time.Hour)
+		require.NoError(t, err)
+
+		payload, err := maker.VerifyToken(tokenString)
+		require.NoError(t, err)
+		assert.Equal(t, 3, payload.TokenVersion)
+	})
+
 	t.Run("should create different tokens for same user", func(t *testing.T) {
 		userID := uuid.New()
 		duration := time.Hour
 
-		token1, payload1, err := maker.CreateToken(userID, duration)
+		token1, payload1, err := maker.CreateToken(userID, 0, duration)
 		require.NoError(t, err)
 
-		token2, payload2, err := maker.CreateToken(userID, duration)
+		token2, payload2, err := maker.CreateToken(userID, 0, duration)
 		require.NoError(t, err)
 
 		// Tokens should be different
@@ -95,12 +104,12 @@ func TestPasetoMaker_CreateToken(t *testing.T) {
 
 		// Short duration
 		shortDuration := 5 * time.Minute
-		tokenShort, payloadShort, err := maker.CreateToken(userID, shortDuration)
+		tokenShort, payloadShort, err := maker.CreateToken(userID, 0, shortDuration)
 		require.NoError(t, err)
 
 		// Long duration
 		longDuration := 24 * time.Hour
-		tokenLong, payloadLong, err := maker.CreateToken(userID, longDuration)
+		tokenLong, payloadLong, err := maker.CreateToken(userID, 0, longDuration)
 		require.NoError(t, err)
 
 		assert.NotEqual(t, tokenShort, tokenLong)
@@ -111,7 +120,7 @@ func TestPasetoMaker_CreateToken(t *testing.T) {
 		userID := uuid.New()
 		duration := time.Duration(0)
 
-		tokenString, payload, err := maker.CreateToken(userID, duration)
+		tokenString, payload, err := maker.CreateToken(userID, 0, duration)
 
 		require.NoError(t, err)
 		assert.NotEmpty(t, tokenString)
@@ -123,7 +132,7 @@ func TestPasetoMaker_CreateToken(t *testing.T) {
 		userID := uuid.New()
 		duration := -time.Hour
 
-		tokenString, payload, err := maker.CreateToken(userID, duration)
+		tokenString, payload, err := maker.CreateToken(userID, 0, duration)
 
 		require.NoError(t, err)
 		assert.NotEmpty(t, tokenString)
@@ -132,6 +141,47 @@ func TestPasetoMaker_CreateToken(t *testing.T) {
 	})
 }
 
+func TestPasetoMaker_CreateRefreshToken(t *testing.T) {
+	validKey := "12345678901234567890123456789012"
+	maker, err := NewPasetoMaker(validKey)
+	require.NoError(t, err)
+
+	t.Run("should create refresh token with refresh type", func(t *testing.T) {
+		userID := uuid.New()
+
+		tokenString, payload, err := maker.CreateRefreshToken(userID, 0, 7*24*time.Hour)
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, tokenString)
+		assert.Equal(t, userID.String(), payload.UserUUID)
+		assert.Equal(t, TokenTypeRefresh, payload.TokenType)
+		assert.True(t, payload.IsRefresh())
+
+		verifiedPayload, err := maker.VerifyToken(tokenString)
+		require.NoError(t, err)
+		assert.Equal(t, TokenTypeRefresh, verifiedPayload.TokenType)
+	})
+
+	t.Run("access and refresh tokens should have distinct types", func(t *testing.T) {
+		userID := uuid.New()
+
+		accessToken, _, err := maker.CreateToken(userID, 0, time.Hour)
+		require.NoError(t, err)
+
+		refreshToken, _, err := maker.CreateRefreshToken(userID, 0, time.Hour)
+		require.NoError(t, err)
+
+		accessPayload, err := maker.VerifyToken(accessToken)
+		require.NoError(t, err)
+		refreshPayload, err := maker.VerifyToken(refreshToken)
+		require.NoError(t, err)
+
+		assert.Equal(t, TokenTypeAccess, accessPayload.TokenType)
+		assert.False(t, accessPayload.IsRefresh())
+		assert.Equal(t, TokenTypeRefresh, refreshPayload.TokenType)
+	})
+}
+
 func TestPasetoMaker_VerifyToken(t *testing.T) {
 	validKey := "12345678901234567890123456789012"
 	maker, err := NewPasetoMaker(validKey)
@@ -142,7 +192,7 @@ func TestPasetoMaker_VerifyToken(t *testing.T) {
 		duration := time.Hour
 
 		// Create token
-		tokenString, originalPayload, err := maker.CreateToken(userID, duration)
+		tokenString, originalPayload, err := maker.CreateToken(userID, 0, duration)
 		require.NoError(t, err)
 
 		// Verify token
@@ -179,7 +229,7 @@ func TestPasetoMaker_VerifyToken(t *testing.T) {
 		duration := -time.Hour // Expired 1 hour ago
 
 		// Create expired token
-		tokenString, _, err := maker.CreateToken(userID, duration)
+		tokenString, _, err := maker.CreateToken(userID, 0, duration)
 		require.NoError(t, err)
 
 		// Try to verify expired token
@@ -194,7 +244,7 @@ func TestPasetoMaker_VerifyToken(t *testing.T) {
 		// Create token with first maker
 		userID := uuid.New()
 		duration := time.Hour
-		tokenString, _, err := maker.CreateToken(userID, duration)
+		tokenString, _, err := maker.CreateToken(userID, 0, duration)
 		require.NoError(t, err)
 
 		// Create second maker with different key
@@ -222,7 +272,7 @@ func TestPasetoMaker_TokenLifecycle(t *testing.T) {
 		duration := 2 * time.Second // Short duration for testing
 
 		// 1. Create token
-		tokenString, originalPayload, err := maker.CreateToken(userID, duration)
+		tokenString, originalPayload, err := maker.CreateToken(userID, 0, duration)
 		require.NoError(t, err)
 		assert.NotEmpty(t, tokenString)
 
@@ -257,11 +307,11 @@ func TestPasetoMaker_Security(t *testing.T) {
 		duration := time.Hour
 
 		// Create token with maker1
-		token1, _, err := maker1.CreateToken(userID, duration)
+		token1, _, err := maker1.CreateToken(userID, 0, duration)
 		require.NoError(t, err)
 
 		// Create token with maker2
-		token2, _, err := maker2.CreateToken(userID, duration)
+		token2, _, err := maker2.CreateToken(userID, 0, duration)
 		require.NoError(t, err)
 
 		// Tokens should be different
@@ -290,7 +340,7 @@ func TestPasetoMaker_EdgeCases(t *testing.T) {
 		var nilUUID uuid.UUID
 		duration := time.Hour
 
-		tokenString, payload, err := maker.CreateToken(nilUUID, duration)
+		tokenString, payload, err := maker.CreateToken(nilUUID, 0, duration)
 
 		require.NoError(t, err)
 		assert.NotEmpty(t, tokenString)
@@ -301,7 +351,7 @@ func TestPasetoMaker_EdgeCases(t *testing.T) {
 		userID := uuid.New()
 		duration := 100 * 365 * 24 * time.Hour // 100 years
 
-		tokenString, payload, err := maker.CreateToken(userID, duration)
+		tokenString, payload, err := maker.CreateToken(userID, 0, duration)
 
 		require.NoError(t, err)
 		assert.NotEmpty(t, tokenString)
@@ -322,7 +372,7 @@ func TestPasetoMaker_Performance(t *testing.T) {
 
 		// Create 1000 tokens
 		for i := 0; i < 1000; i++ {
-			_, _, err := maker.CreateToken(userID, duration)
+			_, _, err := maker.CreateToken(userID, 0, duration)
 			require.NoError(t, err)
 		}
 
@@ -338,7 +388,7 @@ func TestPasetoMaker_Performance(t *testing.T) {
 		duration := time.Hour
 
 		// Create a token
-		tokenString, _, err := maker.CreateToken(userID, duration)
+		tokenString, _, err := maker.CreateToken(userID, 0, duration)
 		require.NoError(t, err)
 
 		start := time.Now()
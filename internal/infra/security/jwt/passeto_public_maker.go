@@ -0,0 +1,95 @@
+package jwt
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/o1egl/paseto"
+)
+
+// PasetoPublicMaker issues v2.public tokens signed with an Ed25519 private
+// key. Verifying only needs the public key, so a resource server can check
+// tokens without holding the signing secret.
+type PasetoPublicMaker struct {
+	paseto     *paseto.V2
+	privateKey ed25519.PrivateKey
+	publicKey  ed25519.PublicKey
+}
+
+// NewPasetoPublicMaker builds a maker from hex-encoded Ed25519 keys. An empty
+// private key gives a verify-only maker whose Create methods return
+// ErrSigningUnavailable.
+func NewPasetoPublicMaker(privateKey, publicKey string) (Maker, error) {
+	pub, err := hex.DecodeString(publicKey)
+	if err != nil || len(pub) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid public key: must be %d hex-encoded bytes", ed25519.PublicKeySize)
+	}
+
+	maker := &PasetoPublicMaker{
+		paseto:    paseto.NewV2(),
+		publicKey: ed25519.PublicKey(pub),
+	}
+
+	if privateKey == "" {
+		return maker, nil
+	}
+
+	priv, err := hex.DecodeString(privateKey)
+	if err != nil || len(priv) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("invalid private key: must be %d hex-encoded bytes", ed25519.PrivateKeySize)
+	}
+	// Uma chave privada de outro par geraria tokens que o próprio maker rejeita
+	if !bytes.Equal(ed25519.PrivateKey(priv).Public().(ed25519.PublicKey), maker.publicKey) {
+		return nil, fmt.Errorf("private key does not match public key")
+	}
+	maker.privateKey = ed25519.PrivateKey(priv)
+
+	return maker, nil
+}
+
+func (maker *PasetoPublicMaker) CreateToken(userID uuid.UUID, tokenVersion int, duration time.Duration, opts ...PayloadOption) (string, Payload, error) {
+	payload, err := NewPayload(userID, tokenVersion, duration, opts...)
+	if err != nil {
+		return "", Payload{}, err
+	}
+
+	return maker.sign(payload)
+}
+
+func (maker *PasetoPublicMaker) CreateRefreshToken(userID uuid.UUID, tokenVersion int, duration time.Duration, opts ...PayloadOption) (string, Payload, error) {
+	payload, err := NewRefreshPayload(userID, tokenVersion, duration, opts...)
+	if err != nil {
+		return "", Payload{}, err
+	}
+
+	return maker.sign(payload)
+}
+
+func (maker *PasetoPublicMaker) sign(payload *Payload) (string, Payload, error) {
+	if maker.privateKey == nil {
+		return "", Payload{}, ErrSigningUnavailable
+	}
+
+	tokenStr, err := maker.paseto.Sign(maker.privateKey, payload, nil)
+	return tokenStr, *payload, err
+}
+
+func (maker *PasetoPublicMaker) VerifyToken(tokenStr string) (*Payload, error) {
+	payload := &Payload{}
+
+	err := maker.paseto.Verify(tokenStr, maker.publicKey, payload, nil)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	err = payload.Valid()
+	if err != nil {
+		return nil, err
+	}
+
+	return payload, nil
+}
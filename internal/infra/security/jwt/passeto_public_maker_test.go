@@ -0,0 +1,153 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func generateKeyPair(t *testing.T) (string, string) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	return hex.EncodeToString(privateKey), hex.EncodeToString(publicKey)
+}
+
+func TestNewPasetoPublicMaker(t *testing.T) {
+	privateKey, publicKey := generateKeyPair(t)
+
+	t.Run("should create maker with a key pair", func(t *testing.T) {
+		maker, err := NewPasetoPublicMaker(privateKey, publicKey)
+
+		require.NoError(t, err)
+		assert.IsType(t, &PasetoPublicMaker{}, maker)
+	})
+
+	t.Run("should create verify-only maker without private key", func(t *testing.T) {
+		maker, err := NewPasetoPublicMaker("", publicKey)
+		require.NoError(t, err)
+
+		_, _, err = maker.CreateToken(uuid.New(), 0, time.Minute)
+		assert.ErrorIs(t, err, ErrSigningUnavailable)
+		_, _, err = maker.CreateRefreshToken(uuid.New(), 0, time.Minute)
+		assert.ErrorIs(t, err, ErrSigningUnavailable)
+	})
+
+	t.Run("should fail with invalid public key", func(t *testing.T) {
+		for _, key := range []string{"", "not-hex", publicKey[:10]} {
+			maker, err := NewPasetoPublicMaker(privateKey, key)
+
+			assert.Error(t, err)
+			assert.Nil(t, maker)
+			assert.Contains(t, err.Error(), "invalid public key")
+		}
+	})
+
+	t.Run("should fail with invalid private key", func(t *testing.T) {
+		maker, err := NewPasetoPublicMaker(privateKey[:64], publicKey)
+
+		assert.Error(t, err)
+		assert.Nil(t, maker)
+		assert.Contains(t, err.Error(), "invalid private key")
+	})
+
+	t.Run("should fail with mismatched key pair", func(t *testing.T) {
+		otherPrivateKey, _ := generateKeyPair(t)
+
+		maker, err := NewPasetoPublicMaker(otherPrivateKey, publicKey)
+
+		assert.Error(t, err)
+		assert.Nil(t, maker)
+		assert.Contains(t, err.Error(), "does not match")
+	})
+}
+
+func TestPasetoPublicMaker_VerifyToken(t *testing.T) {
+	privateKey, publicKey := generateKeyPair(t)
+	issuer, err := NewPasetoPublicMaker(privateKey, publicKey)
+	require.NoError(t, err)
+	verifier, err := NewPasetoPublicMaker("", publicKey)
+	require.NoError(t, err)
+
+	t.Run("should verify with only the public key", func(t *testing.T) {
+		userID := uuid.New()
+		token, created, err := issuer.CreateToken(userID, 0, time.Minute)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(token, "v2.public."))
+
+		payload, err := verifier.VerifyToken(token)
+
+		require.NoError(t, err)
+		assert.Equal(t, created.UUID, payload.UUID)
+		assert.Equal(t, userID.String(), payload.UserUUID)
+		assert.Equal(t, TokenTypeAccess, payload.TokenType)
+	})
+
+	t.Run("should keep refresh token type", func(t *testing.T) {
+		token, _, err := issuer.CreateRefreshToken(uuid.New(), 0, time.Minute)
+		require.NoError(t, err)
+
+		payload, err := verifier.VerifyToken(token)
+
+		require.NoError(t, err)
+		assert.Equal(t, TokenTypeRefresh, payload.TokenType)
+	})
+
+	t.Run("should reject tampered token", func(t *testing.T) {
+		token, _, err := issuer.CreateToken(uuid.New(), 0, time.Minute)
+		require.NoError(t, err)
+
+		// Trocar um caractere no meio do corpo invalida a assinatura
+		i := len("v2.public.") + 10
+		replacement := byte('A')
+		if token[i] == replacement {
+			replacement = 'B'
+		}
+		tampered := token[:i] + string(replacement) + token[i+1:]
+
+		payload, err := verifier.VerifyToken(tampered)
+
+		assert.ErrorIs(t, err, ErrInvalidToken)
+		assert.Nil(t, payload)
+	})
+
+	t.Run("should reject token signed by another key", func(t *testing.T) {
+		otherPrivateKey, otherPublicKey := generateKeyPair(t)
+		other, err := NewPasetoPublicMaker(otherPrivateKey, otherPublicKey)
+		require.NoError(t, err)
+		token, _, err := other.CreateToken(uuid.New(), 0, time.Minute)
+		require.NoError(t, err)
+
+		payload, err := verifier.VerifyToken(token)
+
+		assert.ErrorIs(t, err, ErrInvalidToken)
+		assert.Nil(t, payload)
+	})
+
+	t.Run("should reject local token", func(t *testing.T) {
+		local, err := NewPasetoMaker("12345678901234567890123456789012")
+		require.NoError(t, err)
+		token, _, err := local.CreateToken(uuid.New(), 0, time.Minute)
+		require.NoError(t, err)
+
+		payload, err := verifier.VerifyToken(token)
+
+		assert.ErrorIs(t, err, ErrInvalidToken)
+		assert.Nil(t, payload)
+	})
+
+	t.Run("should reject expired token", func(t *testing.T) {
+		token, _, err := issuer.CreateToken(uuid.New(), 0, -time.Minute)
+		require.NoError(t, err)
+
+		payload, err := verifier.VerifyToken(token)
+
+		assert.ErrorIs(t, err, ErrExpiredToken)
+		assert.Nil(t, payload)
+	})
+}
@@ -6,14 +6,44 @@ import (
 	"github.com/google/uuid"
 )
 
+const (
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+)
+
 type Payload struct {
 	UUID      string    `json:"uuid"`
 	UserUUID  string    `json:"user_uuid"`
+	TokenType string    `json:"token_type"`
 	IssuedAt  time.Time `json:"issued_at"`
 	ExpiredAt time.Time `json:"expired_at"`
+	// TokenVersion must match the user's current version; tokens issued
+	// before the user revoked all sessions carry an older one
+	TokenVersion int `json:"token_version"`
+	// SessionID links refresh tokens and refreshed access tokens to the
+	// sign-in that produced them; see SessionUUID
+	SessionID string `json:"session_id,omitempty"`
 }
 
-func NewPayload(userUUID uuid.UUID, duration time.Duration) (*Payload, error) {
+// PayloadOption sets an optional claim on a new payload.
+type PayloadOption func(*Payload)
+
+// WithSessionID binds the token to a sign-in session.
+func WithSessionID(sessionID uuid.UUID) PayloadOption {
+	return func(payload *Payload) {
+		payload.SessionID = sessionID.String()
+	}
+}
+
+func NewPayload(userUUID uuid.UUID, tokenVersion int, duration time.Duration, opts ...PayloadOption) (*Payload, error) {
+	return newPayload(userUUID, TokenTypeAccess, tokenVersion, duration, opts)
+}
+
+func NewRefreshPayload(userUUID uuid.UUID, tokenVersion int, duration time.Duration, opts ...PayloadOption) (*Payload, error) {
+	return newPayload(userUUID, TokenTypeRefresh, tokenVersion, duration, opts)
+}
+
+func newPayload(userUUID uuid.UUID, tokenType string, tokenVersion int, duration time.Duration, opts []PayloadOption) (*Payload, error) {
 	tokenID, err := uuid.NewRandom()
 	if err != nil {
 		return nil, err
@@ -22,8 +52,14 @@ func NewPayload(userUUID uuid.UUID, duration time.Duration) (*Payload, error) {
 	payload := &Payload{
 		UUID:      tokenID.String(),
 		UserUUID:  userUUID.String(),
+		TokenType: tokenType,
 		IssuedAt:  time.Now(),
 		ExpiredAt: time.Now().Add(duration),
+
+		TokenVersion: tokenVersion,
+	}
+	for _, opt := range opts {
+		opt(payload)
 	}
 
 	return payload, nil
@@ -35,3 +71,16 @@ func (payload *Payload) Valid() error {
 	}
 	return nil
 }
+
+func (payload *Payload) IsRefresh() bool {
+	return payload.TokenType == TokenTypeRefresh
+}
+
+// SessionUUID returns the sign-in session of the token: its SessionID claim,
+// or, for the access token issued at sign-in, the token's own UUID.
+func (payload *Payload) SessionUUID() string {
+	if payload.SessionID != "" {
+		return payload.SessionID
+	}
+	return payload.UUID
+}
@@ -0,0 +1,24 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// LoginLimiter controla tentativas de login malsucedidas por chave (email).
+// A implementação em memória pode ser trocada por uma compartilhada (ex.: Redis).
+type LoginLimiter interface {
+	// IsLocked informa se a chave está bloqueada e por quanto tempo ainda.
+	IsLocked(ctx context.Context, key string) (bool, time.Duration, error)
+	// RegisterFailure contabiliza uma falha e bloqueia a chave ao atingir o limite.
+	RegisterFailure(ctx context.Context, key string) error
+	// Reset zera o contador da chave (login bem-sucedido).
+	Reset(ctx context.Context, key string) error
+}
+
+// RateLimiter limita a taxa de requisições por chave (ex.: IP do cliente).
+type RateLimiter interface {
+	// Allow consome uma unidade da chave; quando não há saldo informa quanto
+	// tempo falta até a próxima requisição ser aceita.
+	Allow(key string) (bool, time.Duration)
+}
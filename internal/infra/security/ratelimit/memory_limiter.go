@@ -0,0 +1,116 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultMaxAttempts   = 5
+	DefaultLockoutWindow = 15 * time.Minute
+)
+
+type attempts struct {
+	failures    int
+	lastFailure time.Time
+	lockedUntil time.Time
+}
+
+type MemoryLoginLimiter struct {
+	mu          sync.Mutex
+	entries     map[string]*attempts
+	maxAttempts int
+	window      time.Duration
+	lastPrune   time.Time
+	now         func() time.Time
+}
+
+// NewMemoryLoginLimiter bloqueia a chave por window após maxAttempts falhas
+// consecutivas. Valores não positivos usam os padrões.
+func NewMemoryLoginLimiter(maxAttempts int, window time.Duration) *MemoryLoginLimiter {
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultMaxAttempts
+	}
+	if window <= 0 {
+		window = DefaultLockoutWindow
+	}
+
+	return &MemoryLoginLimiter{
+		entries:     make(map[string]*attempts),
+		maxAttempts: maxAttempts,
+		window:      window,
+		now:         time.Now,
+	}
+}
+
+func (l *MemoryLoginLimiter) IsLocked(ctx context.Context, key string) (bool, time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry, ok := l.entries[key]
+	if !ok {
+		return false, 0, nil
+	}
+
+	now := l.now()
+	if entry.lockedUntil.After(now) {
+		return true, entry.lockedUntil.Sub(now), nil
+	}
+
+	// Bloqueio expirado: começa do zero
+	if !entry.lockedUntil.IsZero() {
+		delete(l.entries, key)
+	}
+
+	return false, 0, nil
+}
+
+func (l *MemoryLoginLimiter) RegisterFailure(ctx context.Context, key string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.pruneExpired(now)
+
+	entry, ok := l.entries[key]
+	if !ok || now.Sub(entry.lastFailure) > l.window {
+		entry = &attempts{}
+		l.entries[key] = entry
+	}
+
+	if entry.lockedUntil.After(now) {
+		return nil
+	}
+
+	entry.failures++
+	entry.lastFailure = now
+	if entry.failures >= l.maxAttempts {
+		entry.lockedUntil = now.Add(l.window)
+	}
+
+	return nil
+}
+
+func (l *MemoryLoginLimiter) Reset(ctx context.Context, key string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.entries, key)
+	return nil
+}
+
+// pruneExpired remove entradas antigas (no máximo uma vez por janela) para o
+// mapa não crescer sem limite.
+func (l *MemoryLoginLimiter) pruneExpired(now time.Time) {
+	if now.Sub(l.lastPrune) < l.window {
+		return
+	}
+	l.lastPrune = now
+
+	for key, entry := range l.entries {
+		if entry.lockedUntil.Before(now) && now.Sub(entry.lastFailure) > l.window {
+			delete(l.entries, key)
+		}
+	}
+}
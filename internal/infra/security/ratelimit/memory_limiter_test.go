@@ -0,0 +1,114 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClock struct {
+	current time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.current
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.current = c.current.Add(d)
+}
+
+func newTestLimiter(maxAttempts int, window time.Duration) (*MemoryLoginLimiter, *fakeClock) {
+	clock := &fakeClock{current: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	limiter := NewMemoryLoginLimiter(maxAttempts, window)
+	limiter.now = clock.Now
+	return limiter, clock
+}
+
+func TestMemoryLoginLimiter(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should lock after max consecutive failures", func(t *testing.T) {
+		limiter, _ := newTestLimiter(5, 15*time.Minute)
+
+		for i := 0; i < 4; i++ {
+			require.NoError(t, limiter.RegisterFailure(ctx, "john@example.com"))
+			locked, _, err := limiter.IsLocked(ctx, "john@example.com")
+			require.NoError(t, err)
+			assert.False(t, locked, "should not lock after %d failures", i+1)
+		}
+
+		require.NoError(t, limiter.RegisterFailure(ctx, "john@example.com"))
+		locked, remaining, err := limiter.IsLocked(ctx, "john@example.com")
+		require.NoError(t, err)
+		assert.True(t, locked)
+		assert.Equal(t, 15*time.Minute, remaining)
+	})
+
+	t.Run("should unlock after window elapses", func(t *testing.T) {
+		limiter, clock := newTestLimiter(3, time.Minute)
+
+		for i := 0; i < 3; i++ {
+			require.NoError(t, limiter.RegisterFailure(ctx, "john@example.com"))
+		}
+
+		clock.Advance(59 * time.Second)
+		locked, _, _ := limiter.IsLocked(ctx, "john@example.com")
+		assert.True(t, locked)
+
+		clock.Advance(time.Second)
+		locked, _, _ = limiter.IsLocked(ctx, "john@example.com")
+		assert.False(t, locked)
+
+		// Counter starts over after the lockout
+		require.NoError(t, limiter.RegisterFailure(ctx, "john@example.com"))
+		locked, _, _ = limiter.IsLocked(ctx, "john@example.com")
+		assert.False(t, locked)
+	})
+
+	t.Run("should reset counter on success", func(t *testing.T) {
+		limiter, _ := newTestLimiter(3, time.Minute)
+
+		require.NoError(t, limiter.RegisterFailure(ctx, "john@example.com"))
+		require.NoError(t, limiter.RegisterFailure(ctx, "john@example.com"))
+		require.NoError(t, limiter.Reset(ctx, "john@example.com"))
+		require.NoError(t, limiter.RegisterFailure(ctx, "john@example.com"))
+
+		locked, _, _ := limiter.IsLocked(ctx, "john@example.com")
+		assert.False(t, locked)
+	})
+
+	t.Run("should forget old failures outside the window", func(t *testing.T) {
+		limiter, clock := newTestLimiter(3, time.Minute)
+
+		require.NoError(t, limiter.RegisterFailure(ctx, "john@example.com"))
+		require.NoError(t, limiter.RegisterFailure(ctx, "john@example.com"))
+		clock.Advance(2 * time.Minute)
+		require.NoError(t, limiter.RegisterFailure(ctx, "john@example.com"))
+
+		locked, _, _ := limiter.IsLocked(ctx, "john@example.com")
+		assert.False(t, locked)
+	})
+
+	t.Run("should track keys independently", func(t *testing.T) {
+		limiter, _ := newTestLimiter(2, time.Minute)
+
+		require.NoError(t, limiter.RegisterFailure(ctx, "john@example.com"))
+		require.NoError(t, limiter.RegisterFailure(ctx, "john@example.com"))
+
+		locked, _, _ := limiter.IsLocked(ctx, "john@example.com")
+		assert.True(t, locked)
+		locked, _, _ = limiter.IsLocked(ctx, "jane@example.com")
+		assert.False(t, locked)
+	})
+
+	t.Run("should use defaults for non positive settings", func(t *testing.T) {
+		limiter := NewMemoryLoginLimiter(0, 0)
+
+		assert.Equal(t, DefaultMaxAttempts, limiter.maxAttempts)
+		assert.Equal(t, DefaultLockoutWindow, limiter.window)
+	})
+}
@@ -0,0 +1,94 @@
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultRequestsPerSecond = 5
+	DefaultBurst             = 10
+)
+
+type bucket struct {
+	tokens     float64
+	lastRefill time.Time
+}
+
+// TokenBucketLimiter mantém um balde por chave em memória: cada requisição
+// consome um token e os tokens voltam a rate por segundo, até burst.
+type TokenBucketLimiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	rate      float64
+	burst     float64
+	lastPrune time.Time
+	now       func() time.Time
+}
+
+// NewTokenBucketLimiter aceita burst requisições seguidas por chave e depois
+// rate por segundo. Valores não positivos usam os padrões.
+func NewTokenBucketLimiter(rate float64, burst int) *TokenBucketLimiter {
+	if rate <= 0 {
+		rate = DefaultRequestsPerSecond
+	}
+	if burst <= 0 {
+		burst = DefaultBurst
+	}
+
+	return &TokenBucketLimiter{
+		buckets: make(map[string]*bucket),
+		rate:    rate,
+		burst:   float64(burst),
+		now:     time.Now,
+	}
+}
+
+// WithClock troca o relógio usado para repor os tokens (testes).
+func (l *TokenBucketLimiter) WithClock(now func() time.Time) *TokenBucketLimiter {
+	l.now = now
+	return l
+}
+
+func (l *TokenBucketLimiter) Allow(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.pruneFull(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: l.burst, lastRefill: now}
+		l.buckets[key] = b
+	}
+
+	if elapsed := now.Sub(b.lastRefill); elapsed > 0 {
+		b.tokens = math.Min(l.burst, b.tokens+elapsed.Seconds()*l.rate)
+		b.lastRefill = now
+	}
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+
+	missing := (1 - b.tokens) / l.rate
+	return false, time.Duration(math.Ceil(missing * float64(time.Second)))
+}
+
+// pruneFull remove baldes que já estariam cheios (no máximo uma vez por
+// minuto): recriá-los dá o mesmo resultado e o mapa não cresce sem limite.
+func (l *TokenBucketLimiter) pruneFull(now time.Time) {
+	if now.Sub(l.lastPrune) < time.Minute {
+		return
+	}
+	l.lastPrune = now
+
+	for key, b := range l.buckets {
+		if b.tokens+now.Sub(b.lastRefill).Seconds()*l.rate >= l.burst {
+			delete(l.buckets, key)
+		}
+	}
+}
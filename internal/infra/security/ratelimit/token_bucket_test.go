@@ -0,0 +1,85 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestBucketLimiter(rate float64, burst int) (*TokenBucketLimiter, *fakeClock) {
+	clock := &fakeClock{current: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	return NewTokenBucketLimiter(rate, burst).WithClock(clock.Now), clock
+}
+
+func TestTokenBucketLimiter(t *testing.T) {
+	t.Run("should allow the burst and then reject", func(t *testing.T) {
+		limiter, _ := newTestBucketLimiter(1, 3)
+
+		for i := 0; i < 3; i++ {
+			allowed, _ := limiter.Allow("10.0.0.1")
+			assert.True(t, allowed, "request %d should be allowed", i+1)
+		}
+
+		allowed, retryAfter := limiter.Allow("10.0.0.1")
+		assert.False(t, allowed)
+		assert.Equal(t, time.Second, retryAfter)
+	})
+
+	t.Run("should refill at the configured rate", func(t *testing.T) {
+		limiter, clock := newTestBucketLimiter(2, 1)
+
+		allowed, _ := limiter.Allow("10.0.0.1")
+		assert.True(t, allowed)
+
+		allowed, retryAfter := limiter.Allow("10.0.0.1")
+		assert.False(t, allowed)
+		assert.Equal(t, 500*time.Millisecond, retryAfter)
+
+		clock.Advance(500 * time.Millisecond)
+		allowed, _ = limiter.Allow("10.0.0.1")
+		assert.True(t, allowed)
+	})
+
+	t.Run("should not refill above the burst", func(t *testing.T) {
+		limiter, clock := newTestBucketLimiter(1, 2)
+
+		clock.Advance(time.Hour)
+		for i := 0; i < 2; i++ {
+			allowed, _ := limiter.Allow("10.0.0.1")
+			assert.True(t, allowed)
+		}
+		allowed, _ := limiter.Allow("10.0.0.1")
+		assert.False(t, allowed)
+	})
+
+	t.Run("should keep a bucket per key", func(t *testing.T) {
+		limiter, _ := newTestBucketLimiter(1, 1)
+
+		allowed, _ := limiter.Allow("10.0.0.1")
+		assert.True(t, allowed)
+		allowed, _ = limiter.Allow("10.0.0.1")
+		assert.False(t, allowed)
+
+		allowed, _ = limiter.Allow("10.0.0.2")
+		assert.True(t, allowed)
+	})
+
+	t.Run("should prune buckets that are full again", func(t *testing.T) {
+		limiter, clock := newTestBucketLimiter(1, 1)
+
+		limiter.Allow("10.0.0.1")
+		clock.Advance(2 * time.Minute)
+		limiter.Allow("10.0.0.2")
+
+		assert.Len(t, limiter.buckets, 1)
+		assert.Contains(t, limiter.buckets, "10.0.0.2")
+	})
+
+	t.Run("should use defaults for non positive values", func(t *testing.T) {
+		limiter := NewTokenBucketLimiter(0, -1)
+
+		assert.Equal(t, float64(DefaultRequestsPerSecond), limiter.rate)
+		assert.Equal(t, float64(DefaultBurst), limiter.burst)
+	})
+}
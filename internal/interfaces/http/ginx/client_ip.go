@@ -0,0 +1,11 @@
+package ginx
+
+import "github.com/gin-gonic/gin"
+
+// ClientIP is the client address used by rate limiting, request logs and the
+// login audit. X-Forwarded-For is honored only when the connection comes from
+// a proxy passed to router.SetTrustedProxies (TRUSTED_PROXIES); otherwise it
+// is the connection address.
+func ClientIP(c *gin.Context) string {
+	return c.ClientIP()
+}
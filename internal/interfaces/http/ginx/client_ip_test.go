@@ -0,0 +1,59 @@
+package ginx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientIP(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(t *testing.T, trustedProxies []string) *gin.Engine {
+		router := gin.New()
+		require.NoError(t, router.SetTrustedProxies(trustedProxies))
+		router.GET("/ip", func(c *gin.Context) {
+			c.String(http.StatusOK, ClientIP(c))
+		})
+		return router
+	}
+
+	resolve := func(router *gin.Engine, remoteAddr, forwardedFor string) string {
+		req := httptest.NewRequest("GET", "/ip", nil)
+		req.RemoteAddr = remoteAddr
+		if forwardedFor != "" {
+			req.Header.Set("X-Forwarded-For", forwardedFor)
+		}
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder.Body.String()
+	}
+
+	t.Run("should honor X-Forwarded-For from a trusted proxy", func(t *testing.T) {
+		router := newRouter(t, []string{"10.0.0.0/8"})
+
+		assert.Equal(t, "203.0.113.7", resolve(router, "10.1.2.3:4567", "203.0.113.7"))
+	})
+
+	t.Run("should skip trusted hops in the forwarded chain", func(t *testing.T) {
+		router := newRouter(t, []string{"10.0.0.0/8"})
+
+		assert.Equal(t, "203.0.113.7", resolve(router, "10.1.2.3:4567", "198.51.100.1, 203.0.113.7, 10.9.9.9"))
+	})
+
+	t.Run("should ignore X-Forwarded-For from an untrusted client", func(t *testing.T) {
+		router := newRouter(t, []string{"10.0.0.0/8"})
+
+		assert.Equal(t, "198.51.100.9", resolve(router, "198.51.100.9:4567", "203.0.113.7"))
+	})
+
+	t.Run("should use the connection address without trusted proxies", func(t *testing.T) {
+		router := newRouter(t, nil)
+
+		assert.Equal(t, "10.1.2.3", resolve(router, "10.1.2.3:4567", "203.0.113.7"))
+	})
+}
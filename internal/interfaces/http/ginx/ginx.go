@@ -1,12 +1,24 @@
 package ginx
 
 import (
+	"errors"
+	"fmt"
+	"net/http"
+
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
 )
 
+// ErrBodyTooLarge is returned by ParseJSON when the body exceeds the limit
+// set by the body limit middleware.
+var ErrBodyTooLarge = errors.New("request body too large")
+
 func ParseJSON(c *gin.Context, obj interface{}) error {
 	if err := c.ShouldBindJSON(obj); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return fmt.Errorf("%w: limit is %d bytes", ErrBodyTooLarge, maxBytesErr.Limit)
+		}
 		return err
 	}
 	return nil
@@ -33,8 +45,10 @@ func ParseUri(c *gin.Context, obj interface{}) error {
 }
 
 type Response struct {
-	Error interface{} `json:"error"`
-	Data  interface{} `json:"data"`
+	Error  interface{}       `json:"error"`
+	Code   string            `json:"code,omitempty"`
+	Fields map[string]string `json:"fields,omitempty"`
+	Data   interface{}       `json:"data"`
 }
 
 func SuccessResponse(data interface{}) Response {
@@ -50,3 +64,24 @@ func ErrorResponse(error string) Response {
 		Error: error,
 	}
 }
+
+// ErrorResponseWithCode keeps the human readable message and adds a
+// machine readable code clients can switch on.
+func ErrorResponseWithCode(error string, code string) Response {
+	return Response{
+		Data:  "",
+		Error: error,
+		Code:  code,
+	}
+}
+
+// ValidationErrorResponse reports the message together with the
+// per-field validation failures.
+func ValidationErrorResponse(error string, code string, fields map[string]string) Response {
+	return Response{
+		Data:   "",
+		Error:  error,
+		Code:   code,
+		Fields: fields,
+	}
+}
@@ -0,0 +1,137 @@
+package ginx
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIVersion identifies the shape of a response body.
+type APIVersion int
+
+const (
+	// APIVersion1 is the Response envelope ({error, code, fields, data}).
+	APIVersion1 APIVersion = 1
+
+	// DefaultAPIVersion is served when the client does not ask for a version.
+	DefaultAPIVersion = APIVersion1
+)
+
+// jsonContentType is what c.JSON sends when no version was asked for.
+const jsonContentType = "application/json; charset=utf-8"
+
+// ErrNotAcceptable is returned by Negotiate when no media type in Accept can
+// be served, e.g. a response version the API does not have.
+var ErrNotAcceptable = errors.New("no acceptable response version")
+
+// application/vnd.backend.v2+json -> 2
+var vendorMediaTypePattern = regexp.MustCompile(`^application/vnd\.backend\.v([0-9]+)\+json$`)
+
+// VendorMediaType is the media type clients send in Accept to pin a version.
+func VendorMediaType(version APIVersion) string {
+	return fmt.Sprintf("application/vnd.backend.v%d+json", version)
+}
+
+// Serializers builds the response body of each supported version.
+type Serializers map[APIVersion]func() any
+
+// Negotiation is the outcome of matching Accept against the supported versions.
+type Negotiation struct {
+	Version APIVersion
+	// Vendor media type when the client pinned the version, plain JSON otherwise
+	ContentType string
+}
+
+// Negotiate escolhe a versão da resposta pelo header Accept, na ordem de
+// preferência (q) do cliente. Sem Accept, ou com application/json ou
+// curingas, vale DefaultAPIVersion; versões não suportadas e tipos que não
+// são JSON são ignorados, e se nada sobrar retorna ErrNotAcceptable.
+func Negotiate(c *gin.Context, supported ...APIVersion) (Negotiation, error) {
+	accept := strings.TrimSpace(c.GetHeader("Accept"))
+	if accept == "" {
+		return Negotiation{Version: DefaultAPIVersion, ContentType: jsonContentType}, nil
+	}
+
+	for _, mediaRange := range parseAccept(accept) {
+		switch mediaRange {
+		case "*/*", "application/*", "application/json":
+			return Negotiation{Version: DefaultAPIVersion, ContentType: jsonContentType}, nil
+		}
+
+		match := vendorMediaTypePattern.FindStringSubmatch(mediaRange)
+		if match == nil {
+			continue
+		}
+		number, err := strconv.Atoi(match[1])
+		if err != nil {
+			continue
+		}
+		for _, version := range supported {
+			if APIVersion(number) == version {
+				return Negotiation{Version: version, ContentType: VendorMediaType(version)}, nil
+			}
+		}
+	}
+
+	return Negotiation{}, fmt.Errorf("%w: %s", ErrNotAcceptable, accept)
+}
+
+// Render escreve o corpo da versão negociada com o Content-Type correspondente.
+func (n Negotiation) Render(c *gin.Context, status int, serializers Serializers) {
+	serialize, ok := serializers[n.Version]
+	if !ok {
+		// Negotiate só devolve versões suportadas: é um erro de programação
+		c.JSON(http.StatusInternalServerError, ErrorResponse(fmt.Sprintf("ginx: no serializer for API version %d", n.Version)))
+		return
+	}
+
+	// A mesma URL muda de corpo conforme o Accept: caches precisam saber
+	c.Header("Vary", "Accept")
+	c.Header("Content-Type", n.ContentType)
+	c.JSON(status, serialize())
+}
+
+// parseAccept devolve os media ranges do Accept, em minúsculas e ordenados
+// por q decrescente (empates mantêm a ordem do header); q=0 é descartado.
+func parseAccept(accept string) []string {
+	type weighted struct {
+		mediaRange string
+		quality    float64
+	}
+
+	var ranges []weighted
+	for _, part := range strings.Split(accept, ",") {
+		mediaRange, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			quality, err = strconv.ParseFloat(q, 64)
+			if err != nil || quality < 0 || quality > 1 {
+				continue
+			}
+		}
+		if quality == 0 {
+			continue
+		}
+
+		ranges = append(ranges, weighted{mediaRange: strings.ToLower(mediaRange), quality: quality})
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].quality > ranges[j].quality })
+
+	mediaRanges := make([]string, len(ranges))
+	for i, r := range ranges {
+		mediaRanges[i] = r.mediaRange
+	}
+	return mediaRanges
+}
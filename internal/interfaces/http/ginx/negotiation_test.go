@@ -0,0 +1,92 @@
+package ginx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegotiate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	negotiate := func(accept string, supported ...APIVersion) (Negotiation, error) {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest("GET", "/", nil)
+		if accept != "" {
+			c.Request.Header.Set("Accept", accept)
+		}
+		return Negotiate(c, supported...)
+	}
+
+	defaults := []string{"", "application/json", "*/*", "application/*", "text/html, application/json;q=0.9"}
+	for _, accept := range defaults {
+		t.Run("default version for "+accept, func(t *testing.T) {
+			negotiation, err := negotiate(accept, APIVersion1)
+			require.NoError(t, err)
+			assert.Equal(t, DefaultAPIVersion, negotiation.Version)
+			assert.Equal(t, "application/json; charset=utf-8", negotiation.ContentType)
+		})
+	}
+
+	t.Run("vendor media type pins the version", func(t *testing.T) {
+		negotiation, err := negotiate("application/vnd.backend.v1+json", APIVersion1)
+		require.NoError(t, err)
+		assert.Equal(t, APIVersion1, negotiation.Version)
+		assert.Equal(t, "application/vnd.backend.v1+json", negotiation.ContentType)
+	})
+
+	t.Run("highest quality supported version wins", func(t *testing.T) {
+		negotiation, err := negotiate("application/vnd.backend.v1+json;q=0.5, application/vnd.backend.v2+json", APIVersion1, 2)
+		require.NoError(t, err)
+		assert.Equal(t, APIVersion(2), negotiation.Version)
+	})
+
+	t.Run("unsupported version falls back to the next acceptable range", func(t *testing.T) {
+		negotiation, err := negotiate("application/vnd.backend.v2+json, application/vnd.backend.v1+json;q=0.8", APIVersion1)
+		require.NoError(t, err)
+		assert.Equal(t, APIVersion1, negotiation.Version)
+	})
+
+	unacceptable := []string{"application/vnd.backend.v2+json", "text/html", "application/json;q=0"}
+	for _, accept := range unacceptable {
+		t.Run("not acceptable for "+accept, func(t *testing.T) {
+			_, err := negotiate(accept, APIVersion1)
+			assert.ErrorIs(t, err, ErrNotAcceptable)
+		})
+	}
+}
+
+func TestNegotiation_Render(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	serializers := Serializers{
+		APIVersion1: func() any { return SuccessResponse("v1") },
+		2:           func() any { return map[string]string{"value": "v2"} },
+	}
+
+	render := func(negotiation Negotiation) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(recorder)
+		negotiation.Render(c, http.StatusOK, serializers)
+		return recorder
+	}
+
+	t.Run("selects the serializer of the negotiated version", func(t *testing.T) {
+		recorder := render(Negotiation{Version: 2, ContentType: VendorMediaType(2)})
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.JSONEq(t, `{"value":"v2"}`, recorder.Body.String())
+		assert.Equal(t, "application/vnd.backend.v2+json", recorder.Header().Get("Content-Type"))
+		assert.Equal(t, "Accept", recorder.Header().Get("Vary"))
+	})
+
+	t.Run("version without serializer is a server error", func(t *testing.T) {
+		recorder := render(Negotiation{Version: 3, ContentType: VendorMediaType(3)})
+
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	})
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	userUC "github.com/moura95/backend-challenge/internal/application/usecases/user"
+	"github.com/moura95/backend-challenge/internal/interfaces/http/ginx"
+	"github.com/moura95/backend-challenge/internal/interfaces/http/middlewares"
+)
+
+type AccountEmailsHandler struct {
+	listUserEmailsUseCase *userUC.ListUserEmailsUseCase
+}
+
+func NewAccountEmailsHandler(listUserEmailsUC *userUC.ListUserEmailsUseCase) *AccountEmailsHandler {
+	return &AccountEmailsHandler{
+		listUserEmailsUseCase: listUserEmailsUC,
+	}
+}
+
+// @Summary List my emails
+// @Description List the emails sent to the current user's address, newest first. Bodies are only returned with include_body=true
+// @Tags user
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size, capped at MAX_PAGE_SIZE" default(10)
+// @Param include_body query bool false "Include the HTML body of each email" default(false)
+// @Success 200 {object} ginx.Response{data=github_com_moura95_backend-challenge_internal_application_usecases_user.ListUserEmailsResponse}
+// @Header 200 {int} X-Total-Count "Total emails sent to the user"
+// @Header 200 {int} X-Page "Current page"
+// @Header 200 {int} X-Page-Size "Effective page size"
+// @Header 200 {string} Link "RFC 5988 links: first, prev, next, last"
+// @Failure 400 {object} ginx.Response
+// @Failure 401 {object} ginx.Response
+// @Router /account/emails [get]
+func (h *AccountEmailsHandler) ListEmails(c *gin.Context) {
+	userID, exists := middlewares.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ginx.ErrorResponse("handler: list emails failed: user not authenticated"))
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size")) // zero uses the configured default
+	includeBody, _ := strconv.ParseBool(c.DefaultQuery("include_body", "false"))
+
+	result, err := h.listUserEmailsUseCase.Execute(c.Request.Context(), userUC.ListUserEmailsRequest{
+		UserID:      userID,
+		Page:        page,
+		PageSize:    pageSize,
+		IncludeBody: includeBody,
+	})
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		c.JSON(statusCode, errorResponse(fmt.Sprintf("handler: list emails failed: %v", err), err))
+		return
+	}
+
+	setPaginationHeaders(c, result.Total, result.Page, result.PageSize)
+	c.JSON(http.StatusOK, ginx.SuccessResponse(result))
+}
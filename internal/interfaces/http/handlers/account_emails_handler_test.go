@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	userUC "github.com/moura95/backend-challenge/internal/application/usecases/user"
+	"github.com/moura95/backend-challenge/internal/interfaces/http/ginx"
+)
+
+func TestAccountEmailsHandler_ListEmails(t *testing.T) {
+	server := setupUserHandlerTest(t)
+	defer server.cleanup()
+
+	aliceToken, _ := createUserAndGetToken(t, server, "Alice", "alice@example.com", "password123")
+	createUserAndGetToken(t, server, "Bob", "bob@example.com", "password123")
+
+	listEmails := func(t *testing.T, path string) userUC.ListUserEmailsResponse {
+		recorder := makeAuthenticatedRequest(t, server, "GET", path, aliceToken, nil)
+		require.Equal(t, http.StatusOK, recorder.Code)
+
+		var response ginx.Response
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		data, err := json.Marshal(response.Data)
+		require.NoError(t, err)
+
+		var result userUC.ListUserEmailsResponse
+		require.NoError(t, json.Unmarshal(data, &result))
+		return result
+	}
+
+	t.Run("should list the user's welcome email but not another user's", func(t *testing.T) {
+		result := listEmails(t, "/api/account/emails")
+
+		require.Equal(t, 1, result.Total)
+		require.Len(t, result.Emails, 1)
+		assert.Equal(t, "welcome", result.Emails[0].Type)
+		assert.Equal(t, "pending", result.Emails[0].Status)
+		assert.NotEmpty(t, result.Emails[0].Subject)
+		assert.Empty(t, result.Emails[0].Body)
+
+		// Nada do email de boas-vindas do Bob aparece na listagem
+		recorder := makeAuthenticatedRequest(t, server, "GET", "/api/account/emails", aliceToken, nil)
+		assert.NotContains(t, recorder.Body.String(), "Bob")
+	})
+
+	t.Run("should include the body only when requested", func(t *testing.T) {
+		result := listEmails(t, "/api/account/emails?include_body=true")
+
+		require.Len(t, result.Emails, 1)
+		assert.Contains(t, result.Emails[0].Body, "Alice")
+	})
+
+	t.Run("should require authentication", func(t *testing.T) {
+		recorder := makeAuthenticatedRequest(t, server, "GET", "/api/account/emails", "invalid-token", nil)
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	})
+}
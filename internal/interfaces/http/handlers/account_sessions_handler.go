@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	userUC "github.com/moura95/backend-challenge/internal/application/usecases/user"
+	"github.com/moura95/backend-challenge/internal/interfaces/http/ginx"
+	"github.com/moura95/backend-challenge/internal/interfaces/http/middlewares"
+)
+
+type AccountSessionsHandler struct {
+	revokeSessionsUseCase *userUC.RevokeSessionsUseCase
+	listSessionsUseCase   *userUC.ListSessionsUseCase
+	revokeSessionUseCase  *userUC.RevokeSessionUseCase
+}
+
+func NewAccountSessionsHandler(
+	revokeSessionsUC *userUC.RevokeSessionsUseCase,
+	listSessionsUC *userUC.ListSessionsUseCase,
+	revokeSessionUC *userUC.RevokeSessionUseCase,
+) *AccountSessionsHandler {
+	return &AccountSessionsHandler{
+		revokeSessionsUseCase: revokeSessionsUC,
+		listSessionsUseCase:   listSessionsUC,
+		revokeSessionUseCase:  revokeSessionUC,
+	}
+}
+
+// @Summary List my sessions
+// @Description List the current user's active sign-in sessions, newest first. The session of the token used in the request is marked as current
+// @Tags user
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} ginx.Response{data=github_com_moura95_backend-challenge_internal_application_usecases_user.ListSessionsResponse}
+// @Failure 401 {object} ginx.Response
+// @Router /users/me/sessions [get]
+func (h *AccountSessionsHandler) ListSessions(c *gin.Context) {
+	userID, exists := middlewares.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ginx.ErrorResponse("handler: list sessions failed: user not authenticated"))
+		return
+	}
+
+	var currentSessionID string
+	if payload, ok := middlewares.GetTokenPayloadFromContext(c); ok {
+		currentSessionID = payload.SessionUUID()
+	}
+
+	result, err := h.listSessionsUseCase.Execute(c.Request.Context(), userID, currentSessionID)
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		c.JSON(statusCode, errorResponse(fmt.Sprintf("handler: list sessions failed: %v", err), err))
+		return
+	}
+
+	c.JSON(http.StatusOK, ginx.SuccessResponse(result))
+}
+
+// @Summary Revoke a session
+// @Description Revoke one of the current user's sessions: its access token, refresh token and every token refreshed from them stop working
+// @Tags user
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Session ID"
+// @Success 200 {object} ginx.Response
+// @Failure 401 {object} ginx.Response
+// @Failure 404 {object} ginx.Response
+// @Router /account/sessions/{id} [delete]
+func (h *AccountSessionsHandler) RevokeSession(c *gin.Context) {
+	userID, exists := middlewares.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ginx.ErrorResponse("handler: revoke session failed: user not authenticated"))
+		return
+	}
+
+	if err := h.revokeSessionUseCase.Execute(c.Request.Context(), userID, c.Param("id")); err != nil {
+		statusCode := getStatusCodeFromError(err)
+		c.JSON(statusCode, errorResponse(fmt.Sprintf("handler: revoke session failed: %v", err), err))
+		return
+	}
+
+	c.JSON(http.StatusOK, ginx.SuccessResponse("session revoked"))
+}
+
+// @Summary Revoke all sessions
+// @Description Invalidate every access and refresh token issued to the current user, including the one used for this request. Sign in again to get a new token
+// @Tags user
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} ginx.Response
+// @Failure 401 {object} ginx.Response
+// @Router /account/revoke-sessions [post]
+func (h *AccountSessionsHandler) RevokeSessions(c *gin.Context) {
+	userID, exists := middlewares.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ginx.ErrorResponse("handler: revoke sessions failed: user not authenticated"))
+		return
+	}
+
+	if err := h.revokeSessionsUseCase.Execute(c.Request.Context(), userID); err != nil {
+		statusCode := getStatusCodeFromError(err)
+		c.JSON(statusCode, errorResponse(fmt.Sprintf("handler: revoke sessions failed: %v", err), err))
+		return
+	}
+
+	// O cookie passou a carregar um token inválido
+	if _, err := c.Cookie(middlewares.AccessTokenCookieName); err == nil {
+		setAccessTokenCookie(c, "", -1)
+	}
+
+	c.JSON(http.StatusOK, ginx.SuccessResponse("all sessions revoked"))
+}
@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	authUC "github.com/moura95/backend-challenge/internal/application/usecases/auth"
+	userUC "github.com/moura95/backend-challenge/internal/application/usecases/user"
+	"github.com/moura95/backend-challenge/internal/domain/token"
+	"github.com/moura95/backend-challenge/internal/domain/user"
+	"github.com/moura95/backend-challenge/internal/infra/security/jwt"
+	"github.com/moura95/backend-challenge/internal/interfaces/http/ginx"
+	"github.com/moura95/backend-challenge/internal/interfaces/http/middlewares"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sessionsUserRepository serves a single user and bumps its token version.
+type sessionsUserRepository struct {
+	cookieUserRepository
+}
+
+func (r *sessionsUserRepository) IncrementTokenVersion(ctx context.Context, id uuid.UUID) (int, error) {
+	if id != r.user.ID {
+		return 0, user.ErrUserNotFound
+	}
+	r.user.TokenVersion++
+	return r.user.TokenVersion, nil
+}
+
+// memorySessionRepository keeps sessions in memory, in creation order.
+type memorySessionRepository struct {
+	sessions []*token.Session
+}
+
+func (r *memorySessionRepository) Create(ctx context.Context, session *token.Session) error {
+	session.IssuedAt = time.Now()
+	r.sessions = append(r.sessions, session)
+	return nil
+}
+
+func (r *memorySessionRepository) ListActive(ctx context.Context, userID uuid.UUID) ([]*token.Session, error) {
+	var active []*token.Session
+	for i := len(r.sessions) - 1; i >= 0; i-- {
+		session := r.sessions[i]
+		if session.UserID == userID && !session.Revoked && session.ExpiresAt.After(time.Now()) {
+			active = append(active, session)
+		}
+	}
+	return active, nil
+}
+
+func (r *memorySessionRepository) Revoke(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*token.Session, error) {
+	for _, session := range r.sessions {
+		if session.ID == id && session.UserID == userID {
+			session.Revoked = true
+			return session, nil
+		}
+	}
+	return nil, token.ErrSessionNotFound
+}
+
+func (r *memorySessionRepository) RevokeAll(ctx context.Context, userID uuid.UUID) error {
+	for _, session := range r.sessions {
+		if session.UserID == userID {
+			session.Revoked = true
+		}
+	}
+	return nil
+}
+
+func TestAccountSessionsHandler_Sessions(t *testing.T) {
+	existing, err := user.NewUser("John Doe", "devices@example.com", "password123")
+	require.NoError(t, err)
+
+	tokenMaker, err := jwt.NewPasetoMaker("12345678901234567890123456789012")
+	require.NoError(t, err)
+
+	userRepo := &sessionsUserRepository{cookieUserRepository{user: existing}}
+	tokenRepo := &cookieTokenRepository{revoked: map[uuid.UUID]bool{}}
+	sessionRepo := &memorySessionRepository{}
+	verifyTokenUC := authUC.NewVerifyTokenUseCase(userRepo, tokenRepo, tokenMaker)
+	signInUC := authUC.NewSignInUseCase(userRepo, tokenMaker).WithSessions(sessionRepo)
+	refreshTokenUC := authUC.NewRefreshTokenUseCase(userRepo, tokenRepo, tokenMaker)
+	handler := NewAccountSessionsHandler(
+		userUC.NewRevokeSessionsUseCase(userRepo).WithSessions(sessionRepo),
+		userUC.NewListSessionsUseCase(sessionRepo),
+		userUC.NewRevokeSessionUseCase(sessionRepo, tokenRepo),
+	)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/users/me/sessions", middlewares.AuthMiddleware(verifyTokenUC), handler.ListSessions)
+	router.DELETE("/account/sessions/:id", middlewares.AuthMiddleware(verifyTokenUC), handler.RevokeSession)
+
+	serve := func(method, path, accessToken string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder
+	}
+	listSessions := func(accessToken string) []userUC.SessionResponse {
+		recorder := serve("GET", "/users/me/sessions", accessToken)
+		require.Equal(t, http.StatusOK, recorder.Code)
+
+		var response ginx.Response
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		data, err := json.Marshal(response.Data)
+		require.NoError(t, err)
+
+		var result userUC.ListSessionsResponse
+		require.NoError(t, json.Unmarshal(data, &result))
+		return result.Sessions
+	}
+	signIn := func(userAgent string) *authUC.SignInResponse {
+		result, err := signInUC.Execute(context.Background(), authUC.SignInRequest{
+			Email:     "devices@example.com",
+			Password:  "password123",
+			IPAddress: "203.0.113.7",
+			UserAgent: userAgent,
+		})
+		require.NoError(t, err)
+		return result
+	}
+
+	laptop := signIn("laptop")
+	phone := signIn("phone")
+
+	// As duas sessões aparecem, a mais recente primeiro
+	sessions := listSessions(laptop.Token)
+	require.Len(t, sessions, 2)
+	assert.Equal(t, "phone", sessions[0].UserAgent)
+	assert.Equal(t, "laptop", sessions[1].UserAgent)
+	assert.Equal(t, "203.0.113.7", sessions[1].IPAddress)
+	assert.False(t, sessions[0].Current)
+	assert.True(t, sessions[1].Current)
+	assert.True(t, sessions[1].ExpiresAt.After(sessions[1].IssuedAt))
+
+	// Um access token renovado pertence à mesma sessão
+	refreshed, err := refreshTokenUC.Execute(context.Background(), authUC.RefreshTokenRequest{RefreshToken: phone.RefreshToken})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, serve("GET", "/users/me/sessions", refreshed.Token).Code)
+
+	// Revogar a sessão do telefone a partir do laptop
+	require.Equal(t, http.StatusOK, serve("DELETE", "/account/sessions/"+sessions[0].ID, laptop.Token).Code)
+
+	assert.Equal(t, http.StatusUnauthorized, serve("GET", "/users/me/sessions", phone.Token).Code)
+	assert.Equal(t, http.StatusUnauthorized, serve("GET", "/users/me/sessions", refreshed.Token).Code)
+	_, err = refreshTokenUC.Execute(context.Background(), authUC.RefreshTokenRequest{RefreshToken: phone.RefreshToken})
+	assert.ErrorIs(t, err, token.ErrRefreshTokenRevoked)
+
+	// O laptop continua funcionando e vê só a própria sessão
+	sessions = listSessions(laptop.Token)
+	require.Len(t, sessions, 1)
+	assert.Equal(t, "laptop", sessions[0].UserAgent)
+
+	t.Run("unknown or foreign session is not found", func(t *testing.T) {
+		recorder := serve("DELETE", "/account/sessions/"+uuid.NewString(), laptop.Token)
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+
+		recorder = serve("DELETE", "/account/sessions/not-a-uuid", laptop.Token)
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+}
+
+func TestAccountSessionsHandler_RevokeSessions(t *testing.T) {
+	existing, err := user.NewUser("John Doe", "sessions@example.com", "password123")
+	require.NoError(t, err)
+
+	tokenMaker, err := jwt.NewPasetoMaker("12345678901234567890123456789012")
+	require.NoError(t, err)
+
+	userRepo := &sessionsUserRepository{cookieUserRepository{user: existing}}
+	verifyTokenUC := authUC.NewVerifyTokenUseCase(userRepo, &cookieTokenRepository{revoked: map[uuid.UUID]bool{}}, tokenMaker)
+	signInUC := authUC.NewSignInUseCase(userRepo, tokenMaker)
+	handler := NewAccountSessionsHandler(userUC.NewRevokeSessionsUseCase(userRepo), nil, nil)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/account/revoke-sessions", middlewares.AuthMiddleware(verifyTokenUC), handler.RevokeSessions)
+	router.GET("/account", middlewares.AuthMiddleware(verifyTokenUC), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	request := func(method, path, token string) int {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder.Code
+	}
+	signIn := func() string {
+		result, err := signInUC.Execute(context.Background(), authUC.SignInRequest{
+			Email:    "sessions@example.com",
+			Password: "password123",
+		})
+		require.NoError(t, err)
+		return result.Token
+	}
+
+	oldToken := signIn()
+	otherDeviceToken := signIn()
+	require.Equal(t, http.StatusOK, request("GET", "/account", oldToken))
+
+	require.Equal(t, http.StatusOK, request("POST", "/account/revoke-sessions", oldToken))
+	assert.Equal(t, 1, existing.TokenVersion)
+
+	// Todos os tokens anteriores deixam de valer, inclusive o usado na revogação
+	assert.Equal(t, http.StatusUnauthorized, request("GET", "/account", oldToken))
+	assert.Equal(t, http.StatusUnauthorized, request("GET", "/account", otherDeviceToken))
+
+	// Um novo login recebe a versão atual
+	assert.Equal(t, http.StatusOK, request("GET", "/account", signIn()))
+}
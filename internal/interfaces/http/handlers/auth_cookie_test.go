@@ -0,0 +1,220 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	authUC "github.com/moura95/backend-challenge/internal/application/usecases/auth"
+	"github.com/moura95/backend-challenge/internal/domain/user"
+	"github.com/moura95/backend-challenge/internal/infra/security/jwt"
+	"github.com/moura95/backend-challenge/internal/interfaces/http/ginx"
+	"github.com/moura95/backend-challenge/internal/interfaces/http/middlewares"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// cookieUserRepository serves a single user by email and ID.
+type cookieUserRepository struct {
+	user.Repository
+	user *user.User
+}
+
+func (r *cookieUserRepository) GetByEmail(ctx context.Context, email string) (*user.User, error) {
+	if email != r.user.Email {
+		return nil, user.ErrUserNotFound
+	}
+	return r.user, nil
+}
+
+func (r *cookieUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*user.User, error) {
+	if id != r.user.ID {
+		return nil, user.ErrUserNotFound
+	}
+	return r.user, nil
+}
+
+// UpdateLastLogin is called in the background by sign-in; the user already
+// carries the time, so nothing needs storing.
+func (r *cookieUserRepository) UpdateLastLogin(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return nil
+}
+
+// cookieTokenRepository keeps revoked token IDs in memory.
+type cookieTokenRepository struct {
+	revoked map[uuid.UUID]bool
+}
+
+func (r *cookieTokenRepository) Revoke(ctx context.Context, tokenID uuid.UUID, userID uuid.UUID, expiresAt time.Time) error {
+	r.revoked[tokenID] = true
+	return nil
+}
+
+func (r *cookieTokenRepository) IsRevoked(ctx context.Context, tokenID uuid.UUID) (bool, error) {
+	return r.revoked[tokenID], nil
+}
+
+func (r *cookieTokenRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	return 0, nil
+}
+
+func setupAuthCookieRouter(t *testing.T, cookieMode bool) *gin.Engine {
+	t.Helper()
+
+	existing, err := user.NewUser("John Doe", "cookie@example.com", "password123")
+	require.NoError(t, err)
+
+	tokenMaker, err := jwt.NewPasetoMaker("12345678901234567890123456789012")
+	require.NoError(t, err)
+
+	userRepo := &cookieUserRepository{user: existing}
+	tokenRepo := &cookieTokenRepository{revoked: map[uuid.UUID]bool{}}
+	verifyTokenUC := authUC.NewVerifyTokenUseCase(userRepo, tokenRepo, tokenMaker)
+
+	handler := NewAuthHandler(
+		nil,
+		authUC.NewSignInUseCase(userRepo, tokenMaker),
+		verifyTokenUC,
+		nil,
+		authUC.NewLogoutUseCase(tokenRepo, tokenMaker),
+		nil,
+		nil,
+		nil,
+	).WithTokenCookie(cookieMode)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/auth/signin", handler.SignIn)
+	router.POST("/auth/logout", middlewares.AuthMiddleware(verifyTokenUC), handler.Logout)
+	router.GET("/account", middlewares.AuthMiddleware(verifyTokenUC), func(c *gin.Context) {
+		userID, _ := middlewares.GetUserIDFromContext(c)
+		c.JSON(http.StatusOK, ginx.SuccessResponse(userID))
+	})
+
+	return router
+}
+
+func signInForCookieTest(t *testing.T, router *gin.Engine, query string) (*httptest.ResponseRecorder, AuthResponse) {
+	t.Helper()
+
+	body, err := json.Marshal(authUC.SignInRequest{Email: "cookie@example.com", Password: "password123"})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("POST", "/auth/signin"+query, bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var response ginx.Response
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	data, err := json.Marshal(response.Data)
+	require.NoError(t, err)
+
+	var authResponse AuthResponse
+	require.NoError(t, json.Unmarshal(data, &authResponse))
+
+	return recorder, authResponse
+}
+
+func accessTokenCookie(recorder *httptest.ResponseRecorder) *http.Cookie {
+	for _, cookie := range recorder.Result().Cookies() {
+		if cookie.Name == middlewares.AccessTokenCookieName {
+			return cookie
+		}
+	}
+	return nil
+}
+
+func TestAuthHandler_SignInCookieMode(t *testing.T) {
+	t.Run("header mode returns the token in the body without a cookie", func(t *testing.T) {
+		router := setupAuthCookieRouter(t, false)
+
+		recorder, authResponse := signInForCookieTest(t, router, "")
+		assert.NotEmpty(t, authResponse.Token)
+		assert.NotEmpty(t, authResponse.RefreshToken)
+		assert.Nil(t, accessTokenCookie(recorder))
+
+		req := httptest.NewRequest("GET", "/account", nil)
+		req.Header.Set("Authorization", "Bearer "+authResponse.Token)
+		accountRecorder := httptest.NewRecorder()
+		router.ServeHTTP(accountRecorder, req)
+		assert.Equal(t, http.StatusOK, accountRecorder.Code)
+	})
+
+	t.Run("cookie query sets a secure HttpOnly cookie and omits the token", func(t *testing.T) {
+		router := setupAuthCookieRouter(t, false)
+
+		recorder, authResponse := signInForCookieTest(t, router, "?cookie=true")
+		assert.Empty(t, authResponse.Token)
+		assert.NotEmpty(t, authResponse.RefreshToken)
+		assert.NotContains(t, recorder.Body.String(), `"token"`)
+
+		cookie := accessTokenCookie(recorder)
+		require.NotNil(t, cookie)
+		assert.NotEmpty(t, cookie.Value)
+		assert.True(t, cookie.HttpOnly)
+		assert.True(t, cookie.Secure)
+		assert.Equal(t, http.SameSiteStrictMode, cookie.SameSite)
+		assert.Equal(t, "/", cookie.Path)
+		assert.InDelta(t, (24 * time.Hour).Seconds(), cookie.MaxAge, 5)
+
+		// AuthMiddleware aceita o cookie quando não há header Authorization
+		req := httptest.NewRequest("GET", "/account", nil)
+		req.AddCookie(cookie)
+		accountRecorder := httptest.NewRecorder()
+		router.ServeHTTP(accountRecorder, req)
+		assert.Equal(t, http.StatusOK, accountRecorder.Code)
+	})
+
+	t.Run("configured cookie mode is the default and can be turned off per request", func(t *testing.T) {
+		router := setupAuthCookieRouter(t, true)
+
+		recorder, authResponse := signInForCookieTest(t, router, "")
+		assert.Empty(t, authResponse.Token)
+		assert.NotNil(t, accessTokenCookie(recorder))
+
+		recorder, authResponse = signInForCookieTest(t, router, "?cookie=false")
+		assert.NotEmpty(t, authResponse.Token)
+		assert.Nil(t, accessTokenCookie(recorder))
+	})
+
+	t.Run("logout revokes the cookie token and clears the cookie", func(t *testing.T) {
+		router := setupAuthCookieRouter(t, true)
+
+		recorder, _ := signInForCookieTest(t, router, "")
+		cookie := accessTokenCookie(recorder)
+		require.NotNil(t, cookie)
+
+		req := httptest.NewRequest("POST", "/auth/logout", nil)
+		req.AddCookie(cookie)
+		logoutRecorder := httptest.NewRecorder()
+		router.ServeHTTP(logoutRecorder, req)
+		require.Equal(t, http.StatusOK, logoutRecorder.Code)
+
+		cleared := accessTokenCookie(logoutRecorder)
+		require.NotNil(t, cleared)
+		assert.Empty(t, cleared.Value)
+		assert.Negative(t, cleared.MaxAge)
+
+		req = httptest.NewRequest("GET", "/account", nil)
+		req.AddCookie(cookie)
+		accountRecorder := httptest.NewRecorder()
+		router.ServeHTTP(accountRecorder, req)
+		assert.Equal(t, http.StatusUnauthorized, accountRecorder.Code)
+	})
+
+	t.Run("without header or cookie the request is rejected", func(t *testing.T) {
+		router := setupAuthCookieRouter(t, true)
+
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest("GET", "/account", nil))
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	})
+}
@@ -1,61 +1,124 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	adminUC "github.com/moura95/backend-challenge/internal/application/usecases/admin"
 	authUC "github.com/moura95/backend-challenge/internal/application/usecases/auth"
+	emailUC "github.com/moura95/backend-challenge/internal/application/usecases/email"
+	"github.com/moura95/backend-challenge/internal/domain/email"
+	"github.com/moura95/backend-challenge/internal/domain/token"
 	"github.com/moura95/backend-challenge/internal/domain/user"
 	"github.com/moura95/backend-challenge/internal/interfaces/http/ginx"
+	"github.com/moura95/backend-challenge/internal/interfaces/http/middlewares"
+)
+
+// IdempotencyKeyHeader lets clients retry a signup safely
+const (
+	IdempotencyKeyHeader    = "Idempotency-Key"
+	maxIdempotencyKeyLength = 255
 )
 
 type AuthHandler struct {
-	signUpUseCase      *authUC.SignUpUseCase
-	signInUseCase      *authUC.SignInUseCase
-	verifyTokenUseCase *authUC.VerifyTokenUseCase
+	signUpUseCase               *authUC.SignUpUseCase
+	signInUseCase               *authUC.SignInUseCase
+	verifyTokenUseCase          *authUC.VerifyTokenUseCase
+	refreshTokenUseCase         *authUC.RefreshTokenUseCase
+	logoutUseCase               *authUC.LogoutUseCase
+	requestPasswordResetUseCase *authUC.RequestPasswordResetUseCase
+	resetPasswordUseCase        *authUC.ResetPasswordUseCase
+	verifyEmailUseCase          *authUC.VerifyEmailUseCase
+	resendVerificationUseCase   *authUC.ResendVerificationUseCase
+
+	// Modo cookie: o access token vai num cookie HttpOnly em vez do corpo
+	tokenCookie bool
 }
 
 type AuthResponse struct {
-	User  user.UserResponse `json:"user"`
-	Token string            `json:"token,omitempty"`
+	User         user.UserResponse `json:"user"`
+	Token        string            `json:"token,omitempty"`
+	RefreshToken string            `json:"refresh_token,omitempty"`
+}
+
+// WhoAmIResponse holds the claims of the authenticated access token.
+type WhoAmIResponse struct {
+	UserUUID  string    `json:"user_uuid"`
+	IssuedAt  time.Time `json:"issued_at"`
+	ExpiredAt time.Time `json:"expired_at"`
 }
 
 func NewAuthHandler(
 	signUpUC *authUC.SignUpUseCase,
 	signInUC *authUC.SignInUseCase,
 	verifyTokenUC *authUC.VerifyTokenUseCase,
+	refreshTokenUC *authUC.RefreshTokenUseCase,
+	logoutUC *authUC.LogoutUseCase,
+	requestPasswordResetUC *authUC.RequestPasswordResetUseCase,
+	resetPasswordUC *authUC.ResetPasswordUseCase,
+	verifyEmailUC *authUC.VerifyEmailUseCase,
 ) *AuthHandler {
 	return &AuthHandler{
-		signUpUseCase:      signUpUC,
-		signInUseCase:      signInUC,
-		verifyTokenUseCase: verifyTokenUC,
+		signUpUseCase:               signUpUC,
+		signInUseCase:               signInUC,
+		verifyTokenUseCase:          verifyTokenUC,
+		refreshTokenUseCase:         refreshTokenUC,
+		logoutUseCase:               logoutUC,
+		requestPasswordResetUseCase: requestPasswordResetUC,
+		resetPasswordUseCase:        resetPasswordUC,
+		verifyEmailUseCase:          verifyEmailUC,
 	}
 }
 
+// WithResendVerification enables POST /auth/verification/resend.
+func (h *AuthHandler) WithResendVerification(uc *authUC.ResendVerificationUseCase) *AuthHandler {
+	h.resendVerificationUseCase = uc
+	return h
+}
+
+// WithTokenCookie makes signin return the access token as an HttpOnly cookie
+// by default; clients can still choose per request with ?cookie=.
+func (h *AuthHandler) WithTokenCookie(enabled bool) *AuthHandler {
+	h.tokenCookie = enabled
+	return h
+}
+
 // @Summary Sign up a new user
 // @Description Create a new user account
 // @Tags auth
 // @Accept json
 // @Produce json
 // @Param request body github_com_moura95_backend-challenge_internal_application_usecases_auth.SignUpRequest true "Sign up request"
+// @Param Idempotency-Key header string false "Retrying with the same key returns the original response instead of 409"
 // @Success 201 {object} ginx.Response{data=internal_interfaces_http_handlers.AuthResponse}
 // @Failure 400 {object} ginx.Response
 // @Failure 409 {object} ginx.Response
+// @Failure 422 {object} ginx.Response
+// @Failure 503 {object} ginx.Response "Signups temporarily disabled"
 // @Router /auth/signup [post]
 func (h *AuthHandler) SignUp(c *gin.Context) {
 	var req authUC.SignUpRequest
 
 	if err := ginx.ParseJSON(c, &req); err != nil {
-		c.JSON(http.StatusBadRequest, ginx.ErrorResponse("handler: signup failed: invalid request format"))
+		c.JSON(bindErrorResponse("handler: signup failed", err))
+		return
+	}
+
+	req.IdempotencyKey = c.GetHeader(IdempotencyKeyHeader)
+	if len(req.IdempotencyKey) > maxIdempotencyKeyLength {
+		c.JSON(http.StatusBadRequest, ginx.ErrorResponse(fmt.Sprintf("handler: signup failed: %s must be at most %d characters", IdempotencyKeyHeader, maxIdempotencyKeyLength)))
 		return
 	}
 
 	result, err := h.signUpUseCase.Execute(c.Request.Context(), req)
 	if err != nil {
 		statusCode := getStatusCodeFromError(err)
-		c.JSON(statusCode, ginx.ErrorResponse(fmt.Sprintf("handler: signup failed: %v", err)))
+		c.JSON(statusCode, errorResponse(fmt.Sprintf("handler: signup failed: %v", err), err))
 		return
 	}
 
@@ -72,22 +135,84 @@ func (h *AuthHandler) SignUp(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param request body github_com_moura95_backend-challenge_internal_application_usecases_auth.SignInRequest true "Sign in request"
+// @Param cookie query bool false "Return the access token as an HttpOnly cookie instead of in the body"
 // @Success 200 {object} ginx.Response{data=internal_interfaces_http_handlers.AuthResponse}
 // @Failure 400 {object} ginx.Response
 // @Failure 401 {object} ginx.Response
+// @Failure 403 {object} ginx.Response
+// @Failure 429 {object} ginx.Response
 // @Router /auth/signin [post]
 func (h *AuthHandler) SignIn(c *gin.Context) {
 	var req authUC.SignInRequest
 
 	if err := ginx.ParseJSON(c, &req); err != nil {
-		c.JSON(http.StatusBadRequest, ginx.ErrorResponse("handler: signin failed: invalid request format"))
+		c.JSON(bindErrorResponse("handler: signin failed", err))
 		return
 	}
+	// X-Forwarded-For só vale quando vem de um proxy confiável
+	req.IPAddress = ginx.ClientIP(c)
+	req.UserAgent = c.Request.UserAgent()
 
 	result, err := h.signInUseCase.Execute(c.Request.Context(), req)
 	if err != nil {
 		statusCode := getStatusCodeFromError(err)
-		c.JSON(statusCode, ginx.ErrorResponse(fmt.Sprintf("handler: signin failed: %v", err)))
+		c.JSON(statusCode, errorResponse(fmt.Sprintf("handler: signin failed: %v", err), err))
+		return
+	}
+
+	response := AuthResponse{
+		User:         result.User.ToResponse(),
+		Token:        result.Token,
+		RefreshToken: result.RefreshToken,
+	}
+
+	// Em modo cookie o token não aparece no corpo (nem em logs de resposta)
+	if h.useTokenCookie(c) {
+		setAccessTokenCookie(c, result.Token, int(time.Until(result.ExpiresAt).Seconds()))
+		response.Token = ""
+	}
+
+	c.JSON(http.StatusOK, ginx.SuccessResponse(response))
+}
+
+// useTokenCookie honours ?cookie=true|false and falls back to the configured mode.
+func (h *AuthHandler) useTokenCookie(c *gin.Context) bool {
+	if value, ok := c.GetQuery("cookie"); ok {
+		if enabled, err := strconv.ParseBool(value); err == nil {
+			return enabled
+		}
+	}
+	return h.tokenCookie
+}
+
+// setAccessTokenCookie grava (ou, com maxAge negativo, remove) o cookie do access token.
+func setAccessTokenCookie(c *gin.Context, token string, maxAge int) {
+	c.SetSameSite(http.SameSiteStrictMode)
+	c.SetCookie(middlewares.AccessTokenCookieName, token, maxAge, "/", "", true, true)
+}
+
+// @Summary Refresh access token
+// @Description Exchange a valid refresh token for a new access token
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body github_com_moura95_backend-challenge_internal_application_usecases_auth.RefreshTokenRequest true "Refresh token request"
+// @Success 200 {object} ginx.Response{data=internal_interfaces_http_handlers.AuthResponse}
+// @Failure 400 {object} ginx.Response
+// @Failure 401 {object} ginx.Response
+// @Router /auth/refresh [post]
+func (h *AuthHandler) RefreshToken(c *gin.Context) {
+	var req authUC.RefreshTokenRequest
+
+	if err := ginx.ParseJSON(c, &req); err != nil {
+		c.JSON(bindErrorResponse("handler: refresh token failed", err))
+		return
+	}
+
+	result, err := h.refreshTokenUseCase.Execute(c.Request.Context(), req)
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		c.JSON(statusCode, errorResponse(fmt.Sprintf("handler: refresh token failed: %v", err), err))
 		return
 	}
 
@@ -99,29 +224,308 @@ func (h *AuthHandler) SignIn(c *gin.Context) {
 	c.JSON(http.StatusOK, ginx.SuccessResponse(response))
 }
 
+// @Summary Logout user
+// @Description Revoke the current access token (and optionally a refresh token)
+// @Tags auth
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body github_com_moura95_backend-challenge_internal_application_usecases_auth.LogoutRequest false "Logout request"
+// @Success 200 {object} ginx.Response
+// @Failure 400 {object} ginx.Response
+// @Failure 401 {object} ginx.Response
+// @Router /auth/logout [post]
+func (h *AuthHandler) Logout(c *gin.Context) {
+	var req authUC.LogoutRequest
+
+	if c.Request.ContentLength > 0 {
+		if err := ginx.ParseJSON(c, &req); err != nil {
+			c.JSON(bindErrorResponse("handler: logout failed", err))
+			return
+		}
+	}
+
+	accessToken, exists := middlewares.GetAccessTokenFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ginx.ErrorResponse("handler: logout failed: user not authenticated"))
+		return
+	}
+	req.Token = accessToken
+
+	err := h.logoutUseCase.Execute(c.Request.Context(), req)
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		c.JSON(statusCode, errorResponse(fmt.Sprintf("handler: logout failed: %v", err), err))
+		return
+	}
+
+	// Token revogado: remover também o cookie, se a sessão veio dele
+	if _, err := c.Cookie(middlewares.AccessTokenCookieName); err == nil {
+		setAccessTokenCookie(c, "", -1)
+	}
+
+	c.JSON(http.StatusOK, ginx.SuccessResponse("logged out"))
+}
+
+// @Summary Current token claims
+// @Description Return the user ID and validity of the access token, without loading the user profile
+// @Tags auth
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} ginx.Response{data=internal_interfaces_http_handlers.WhoAmIResponse}
+// @Failure 401 {object} ginx.Response
+// @Router /auth/whoami [get]
+func (h *AuthHandler) WhoAmI(c *gin.Context) {
+	payload, exists := middlewares.GetTokenPayloadFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ginx.ErrorResponse("handler: whoami failed: user not authenticated"))
+		return
+	}
+
+	c.JSON(http.StatusOK, ginx.SuccessResponse(WhoAmIResponse{
+		UserUUID:  payload.UserUUID,
+		IssuedAt:  payload.IssuedAt,
+		ExpiredAt: payload.ExpiredAt,
+	}))
+}
+
+// @Summary Request password reset
+// @Description Send a password reset link to the given email. Always returns 200 to avoid user enumeration
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body github_com_moura95_backend-challenge_internal_application_usecases_auth.RequestPasswordResetRequest true "Password reset request"
+// @Success 200 {object} ginx.Response
+// @Failure 400 {object} ginx.Response
+// @Router /auth/password-reset/request [post]
+func (h *AuthHandler) RequestPasswordReset(c *gin.Context) {
+	var req authUC.RequestPasswordResetRequest
+
+	if err := ginx.ParseJSON(c, &req); err != nil {
+		c.JSON(bindErrorResponse("handler: request password reset failed", err))
+		return
+	}
+
+	err := h.requestPasswordResetUseCase.Execute(c.Request.Context(), req)
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		c.JSON(statusCode, errorResponse(fmt.Sprintf("handler: request password reset failed: %v", err), err))
+		return
+	}
+
+	c.JSON(http.StatusOK, ginx.SuccessResponse("if the email is registered, a reset link has been sent"))
+}
+
+// @Summary Confirm password reset
+// @Description Set a new password using a reset token received by email
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body github_com_moura95_backend-challenge_internal_application_usecases_auth.ResetPasswordRequest true "Password reset confirmation"
+// @Success 200 {object} ginx.Response
+// @Failure 400 {object} ginx.Response
+// @Router /auth/password-reset/confirm [post]
+func (h *AuthHandler) ConfirmPasswordReset(c *gin.Context) {
+	var req authUC.ResetPasswordRequest
+
+	if err := ginx.ParseJSON(c, &req); err != nil {
+		c.JSON(bindErrorResponse("handler: reset password failed", err))
+		return
+	}
+
+	err := h.resetPasswordUseCase.Execute(c.Request.Context(), req)
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		c.JSON(statusCode, errorResponse(fmt.Sprintf("handler: reset password failed: %v", err), err))
+		return
+	}
+
+	c.JSON(http.StatusOK, ginx.SuccessResponse("password updated"))
+}
+
+// @Summary Verify email
+// @Description Confirm the user's email address using the token received by email. Opening a link again after the account was verified returns 200 "email already verified"
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body github_com_moura95_backend-challenge_internal_application_usecases_auth.VerifyEmailRequest true "Verify email request"
+// @Success 200 {object} ginx.Response
+// @Failure 400 {object} ginx.Response
+// @Router /auth/verify-email [post]
+func (h *AuthHandler) VerifyEmail(c *gin.Context) {
+	var req authUC.VerifyEmailRequest
+
+	if err := ginx.ParseJSON(c, &req); err != nil {
+		c.JSON(bindErrorResponse("handler: verify email failed", err))
+		return
+	}
+
+	result, err := h.verifyEmailUseCase.Execute(c.Request.Context(), req)
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		c.JSON(statusCode, errorResponse(fmt.Sprintf("handler: verify email failed: %v", err), err))
+		return
+	}
+
+	if result.AlreadyVerified {
+		c.JSON(http.StatusOK, ginx.SuccessResponse("email already verified"))
+		return
+	}
+	c.JSON(http.StatusOK, ginx.SuccessResponse("email verified"))
+}
+
+// @Summary Resend verification email
+// @Description Queue a new verification link when the account exists and is not verified yet. Always returns 200 to avoid user enumeration; requests for the same email within the cooldown send nothing
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body github_com_moura95_backend-challenge_internal_application_usecases_auth.ResendVerificationRequest true "Resend verification request"
+// @Success 200 {object} ginx.Response
+// @Failure 400 {object} ginx.Response
+// @Router /auth/verification/resend [post]
+func (h *AuthHandler) ResendVerification(c *gin.Context) {
+	var req authUC.ResendVerificationRequest
+
+	if err := ginx.ParseJSON(c, &req); err != nil {
+		c.JSON(bindErrorResponse("handler: resend verification failed", err))
+		return
+	}
+
+	err := h.resendVerificationUseCase.Execute(c.Request.Context(), req)
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		c.JSON(statusCode, errorResponse(fmt.Sprintf("handler: resend verification failed: %v", err), err))
+		return
+	}
+
+	c.JSON(http.StatusOK, ginx.SuccessResponse("if the email is registered and not verified, a verification link has been sent"))
+}
+
 func (h *AuthHandler) VerifyToken(c *gin.Context, token string) (*user.User, error) {
 	return h.verifyTokenUseCase.Execute(c.Request.Context(), token)
 }
 
+// Error codes returned in the "code" field of error responses
+const (
+	ErrorCodeEmailExists         = "email_exists"
+	ErrorCodeConflict            = "conflict"
+	ErrorCodeTooManyAttempts     = "too_many_attempts"
+	ErrorCodeEmailNotVerified    = "email_not_verified"
+	ErrorCodeAccountSuspended    = "account_suspended"
+	ErrorCodeEmailNotFound       = "email_not_found"
+	ErrorCodeInvalidCredentials  = "invalid_credentials"
+	ErrorCodeUserNotFound        = "user_not_found"
+	ErrorCodeInvalidRefreshToken = "invalid_refresh_token"
+	ErrorCodeRefreshTokenExpired = "refresh_token_expired"
+	ErrorCodeTokenRevoked        = "token_revoked"
+	ErrorCodeSessionNotFound     = "session_not_found"
+	ErrorCodeIncorrectPassword   = "incorrect_password"
+	ErrorCodeUnauthorized        = "unauthorized"
+	ErrorCodeValidation          = "validation_error"
+	ErrorCodeBodyTooLarge        = "body_too_large"
+	ErrorCodeIdempotencyReused   = "idempotency_key_reused"
+	ErrorCodeInternal            = "internal_error"
+	ErrorCodeRouteNotFound       = "route_not_found"
+	ErrorCodeMethodNotAllowed    = "method_not_allowed"
+	ErrorCodeNotAcceptable       = "not_acceptable"
+	ErrorCodeSignupsDisabled     = "signups_disabled"
+)
+
+// Typed domain errors, checked in order with errors.Is
+var domainErrors = []struct {
+	err    error
+	status int
+	code   string
+}{
+	{user.ErrEmailExists, http.StatusConflict, ErrorCodeEmailExists},
+	{user.ErrConflict, http.StatusConflict, ErrorCodeConflict},
+	{user.ErrTooManyAttempts, http.StatusTooManyRequests, ErrorCodeTooManyAttempts},
+	{user.ErrEmailNotVerified, http.StatusForbidden, ErrorCodeEmailNotVerified},
+	{user.ErrAccountSuspended, http.StatusForbidden, ErrorCodeAccountSuspended},
+	{email.ErrEmailNotFound, http.StatusNotFound, ErrorCodeEmailNotFound},
+	{email.ErrBodyTooLarge, http.StatusBadRequest, ErrorCodeValidation},
+	{user.ErrInvalidCredentials, http.StatusUnauthorized, ErrorCodeInvalidCredentials},
+	{user.ErrUserNotFound, http.StatusUnauthorized, ErrorCodeUserNotFound},
+	{token.ErrInvalidRefreshToken, http.StatusUnauthorized, ErrorCodeInvalidRefreshToken},
+	{token.ErrRefreshTokenExpired, http.StatusUnauthorized, ErrorCodeRefreshTokenExpired},
+	{token.ErrRefreshTokenRevoked, http.StatusUnauthorized, ErrorCodeTokenRevoked},
+	{token.ErrTokenRevoked, http.StatusUnauthorized, ErrorCodeTokenRevoked},
+	{token.ErrSessionNotFound, http.StatusNotFound, ErrorCodeSessionNotFound},
+	{user.ErrIncorrectPassword, http.StatusUnauthorized, ErrorCodeIncorrectPassword},
+	{token.ErrIdempotencyKeyReused, http.StatusUnprocessableEntity, ErrorCodeIdempotencyReused},
+	{authUC.ErrSignupsDisabled, http.StatusServiceUnavailable, ErrorCodeSignupsDisabled},
+	{adminUC.ErrImportBatchTooLarge, http.StatusBadRequest, ErrorCodeValidation},
+	{adminUC.ErrBulkDeleteBatchTooLarge, http.StatusBadRequest, ErrorCodeValidation},
+	{emailUC.ErrPreviewTypeNotSupported, http.StatusBadRequest, ErrorCodeValidation},
+}
+
+// errorResponse builds the error body for a use case failure, including
+// per-field messages when the error is a validation error.
+func errorResponse(message string, err error) ginx.Response {
+	var validationErr *user.ValidationError
+	if errors.As(err, &validationErr) {
+		return ginx.ValidationErrorResponse(message, ErrorCodeValidation, validationErr.Fields)
+	}
+	return ginx.ErrorResponseWithCode(message, getErrorCodeFromError(err))
+}
+
+// bindErrorResponse reports a body that could not be parsed: 413 when it
+// exceeded the size limit, 400 otherwise.
+func bindErrorResponse(prefix string, err error) (int, ginx.Response) {
+	if errors.Is(err, ginx.ErrBodyTooLarge) {
+		return http.StatusRequestEntityTooLarge, ginx.ErrorResponseWithCode(prefix+": request body too large", ErrorCodeBodyTooLarge)
+	}
+	return http.StatusBadRequest, ginx.ErrorResponse(prefix + ": invalid request format")
+}
+
+// negotiateVersion escolhe a versão da resposta pelo Accept; quando nenhuma
+// das suportadas serve, responde 406 e retorna false.
+func negotiateVersion(c *gin.Context, prefix string, supported ...ginx.APIVersion) (ginx.Negotiation, bool) {
+	negotiation, err := ginx.Negotiate(c, supported...)
+	if err != nil {
+		c.JSON(http.StatusNotAcceptable, ginx.ErrorResponseWithCode(fmt.Sprintf("%s: %v", prefix, err), ErrorCodeNotAcceptable))
+		return ginx.Negotiation{}, false
+	}
+	return negotiation, true
+}
+
 func getStatusCodeFromError(err error) int {
-	errMsg := err.Error()
+	status, _ := classifyError(err)
+	return status
+}
+
+func getErrorCodeFromError(err error) string {
+	_, code := classifyError(err)
+	return code
+}
 
-	if strings.Contains(errMsg, "email already exists") {
-		return http.StatusConflict
+func classifyError(err error) (int, string) {
+	for _, d := range domainErrors {
+		if errors.Is(err, d.err) {
+			return d.status, d.code
+		}
 	}
 
-	if strings.Contains(errMsg, "invalid credentials") ||
-		strings.Contains(errMsg, "user not found") ||
-		strings.Contains(errMsg, "email is required") ||
-		strings.Contains(errMsg, "password is required") {
-		return http.StatusUnauthorized
+	var validationErr *user.ValidationError
+	if errors.As(err, &validationErr) {
+		return http.StatusBadRequest, ErrorCodeValidation
+	}
+
+	// Demais erros de validação ainda são mensagens simples
+	errMsg := err.Error()
+
+	if strings.Contains(errMsg, "email is required") ||
+		strings.Contains(errMsg, "password is required") ||
+		strings.Contains(errMsg, "refresh token is required") {
+		return http.StatusUnauthorized, ErrorCodeUnauthorized
 	}
 
 	if strings.Contains(errMsg, "invalid") ||
 		strings.Contains(errMsg, "required") ||
-		strings.Contains(errMsg, "format") {
-		return http.StatusBadRequest
+		strings.Contains(errMsg, "format") ||
+		strings.Contains(errMsg, "password must") {
+		return http.StatusBadRequest, ErrorCodeValidation
 	}
 
-	return http.StatusInternalServerError
+	return http.StatusInternalServerError, ErrorCodeInternal
 }
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
@@ -21,9 +22,13 @@ import (
 	"github.com/testcontainers/testcontainers-go/wait"
 
 	authUC "github.com/moura95/backend-challenge/internal/application/usecases/auth"
+	"github.com/moura95/backend-challenge/internal/domain/email"
+	"github.com/moura95/backend-challenge/internal/domain/token"
+	"github.com/moura95/backend-challenge/internal/domain/user"
 	"github.com/moura95/backend-challenge/internal/infra/repository/adapters"
 	"github.com/moura95/backend-challenge/internal/infra/security/jwt"
 	"github.com/moura95/backend-challenge/internal/interfaces/http/ginx"
+	"github.com/moura95/backend-challenge/internal/interfaces/http/middlewares"
 )
 
 type authHandlerTestServer struct {
@@ -71,22 +76,42 @@ func setupAuthHandlerTest(t *testing.T) *authHandlerTestServer {
 	require.NoError(t, err)
 
 	// Setup use cases
-	signUpUC := authUC.NewSignUpUseCase(repos.User, repos.Email, tokenMaker, nil)
+	signUpUC := authUC.NewSignUpUseCase(repos.User, repos.Email, tokenMaker, nil).
+		WithIdempotency(repos.Idempotency, time.Hour)
 	signInUC := authUC.NewSignInUseCase(repos.User, tokenMaker)
-	verifyTokenUC := authUC.NewVerifyTokenUseCase(repos.User, tokenMaker)
+	verifyTokenUC := authUC.NewVerifyTokenUseCase(repos.User, repos.Token, tokenMaker)
+	refreshTokenUC := authUC.NewRefreshTokenUseCase(repos.User, repos.Token, tokenMaker)
+	logoutUC := authUC.NewLogoutUseCase(repos.Token, tokenMaker)
+	requestPasswordResetUC := authUC.NewRequestPasswordResetUseCase(repos.User, repos.Email, repos.PasswordReset, nil, "")
+	resetPasswordUC := authUC.NewResetPasswordUseCase(repos.User, repos.PasswordReset)
+	verifyEmailUC := authUC.NewVerifyEmailUseCase(repos.User, repos.EmailVerification)
 
 	// Setup handler
-	handler := NewAuthHandler(signUpUC, signInUC, verifyTokenUC)
+	handler := NewAuthHandler(
+		signUpUC,
+		signInUC,
+		verifyTokenUC,
+		refreshTokenUC,
+		logoutUC,
+		requestPasswordResetUC,
+		resetPasswordUC,
+		verifyEmailUC,
+	)
 
 	// Setup Gin router
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
 
 	// Setup routes
-	auth := router.Group("/auth")
+	auth := router.Group("/auth", middlewares.BodyLimit(testMaxBodyBytes), middlewares.RequireJSON())
 	{
 		auth.POST("/signup", handler.SignUp)
 		auth.POST("/signin", handler.SignIn)
+		auth.POST("/refresh", handler.RefreshToken)
+		auth.POST("/logout", middlewares.AuthMiddleware(verifyTokenUC), handler.Logout)
+		auth.POST("/password-reset/request", handler.RequestPasswordReset)
+		auth.POST("/password-reset/confirm", handler.ConfirmPasswordReset)
+		auth.POST("/verify-email", handler.VerifyEmail)
 	}
 
 	cleanup := func() {
@@ -114,6 +139,13 @@ func runAuthHandlerMigrations(db *sqlx.DB) error {
 		name         VARCHAR(255) NOT NULL,
 		email        VARCHAR(100) NOT NULL UNIQUE,
 		password     TEXT NOT NULL,
+		verified_at  TIMESTAMPTZ,
+		role         VARCHAR(20) NOT NULL DEFAULT 'user',
+		deleted_at   TIMESTAMPTZ,
+		version      INTEGER NOT NULL DEFAULT 1,
+		status       VARCHAR(20) NOT NULL DEFAULT 'active',
+		token_version INTEGER NOT NULL DEFAULT 0,
+		last_login_at TIMESTAMPTZ,
 		created_at   TIMESTAMP NOT NULL DEFAULT NOW(),
 		updated_at   TIMESTAMP NOT NULL DEFAULT NOW()
 	);
@@ -131,7 +163,58 @@ func runAuthHandlerMigrations(db *sqlx.DB) error {
 		error_msg    TEXT,
 		sent_at      TIMESTAMPTZ,
 		created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
-		updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		plain_body   TEXT NOT NULL DEFAULT '',
+		next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		cc_emails    TEXT[] NOT NULL DEFAULT '{}',
+		bcc_emails   TEXT[] NOT NULL DEFAULT '{}',
+		dedup_key    VARCHAR(255),
+		scheduled_at TIMESTAMPTZ
+	);
+	
+	-- Email attachments table
+	CREATE TABLE IF NOT EXISTS email_attachments (
+		id           BIGSERIAL PRIMARY KEY,
+		email_uuid   UUID NOT NULL,
+		filename     VARCHAR(255) NOT NULL,
+		content_type VARCHAR(255) NOT NULL,
+		content      BYTEA NOT NULL,
+		created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		FOREIGN KEY (email_uuid) REFERENCES emails(uuid) ON DELETE CASCADE
+	);
+	
+	-- Revoked tokens table
+	CREATE TABLE IF NOT EXISTS revoked_tokens (
+		token_uuid   UUID PRIMARY KEY,
+		user_uuid    UUID NOT NULL,
+		expires_at   TIMESTAMPTZ NOT NULL,
+		revoked_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	);
+	
+	-- Password reset tokens table
+	CREATE TABLE IF NOT EXISTS password_reset_tokens (
+		token_hash   TEXT PRIMARY KEY,
+		user_uuid    UUID NOT NULL REFERENCES users(uuid) ON DELETE CASCADE,
+		expires_at   TIMESTAMPTZ NOT NULL,
+		used_at      TIMESTAMPTZ,
+		created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	);
+	
+	-- Email verification tokens table
+	CREATE TABLE IF NOT EXISTS email_verification_tokens (
+		token_hash   TEXT PRIMARY KEY,
+		user_uuid    UUID NOT NULL REFERENCES users(uuid) ON DELETE CASCADE,
+		expires_at   TIMESTAMPTZ NOT NULL,
+		used_at      TIMESTAMPTZ,
+		created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	);
+	
+	-- Signup idempotency keys table
+	CREATE TABLE IF NOT EXISTS idempotency_keys (
+		idempotency_key VARCHAR(255) PRIMARY KEY,
+		user_uuid       UUID NOT NULL REFERENCES users(uuid) ON DELETE CASCADE,
+		expires_at      TIMESTAMPTZ NOT NULL,
+		created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
 	);
 	
 	-- Indexes
@@ -143,6 +226,9 @@ func runAuthHandlerMigrations(db *sqlx.DB) error {
 	return err
 }
 
+// Body limit used by the test router, small enough to exceed cheaply
+const testMaxBodyBytes = 4 << 10
+
 func TestAuthHandler_SignUp(t *testing.T) {
 	server := setupAuthHandlerTest(t)
 	defer server.cleanup()
@@ -321,6 +407,128 @@ func TestAuthHandler_SignUp(t *testing.T) {
 	})
 }
 
+func TestAuthHandler_SignUpIdempotency(t *testing.T) {
+	server := setupAuthHandlerTest(t)
+	defer server.cleanup()
+
+	signup := func(key string, body authUC.SignUpRequest) *httptest.ResponseRecorder {
+		requestBody, err := json.Marshal(body)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest("POST", "/auth/signup", bytes.NewBuffer(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		if key != "" {
+			req.Header.Set(IdempotencyKeyHeader, key)
+		}
+		recorder := httptest.NewRecorder()
+		server.router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	t.Run("should return the original response when retried with the same key", func(t *testing.T) {
+		body := authUC.SignUpRequest{Name: "Retry User", Email: "retry@example.com", Password: "password123"}
+
+		first := signup("retry-key-1", body)
+		second := signup("retry-key-1", body)
+
+		assert.Equal(t, http.StatusCreated, first.Code)
+		assert.Equal(t, http.StatusCreated, second.Code)
+		assert.JSONEq(t, first.Body.String(), second.Body.String())
+
+		var count int
+		err := server.db.Get(&count, "SELECT COUNT(*) FROM users WHERE email = $1", "retry@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("should still conflict without a key", func(t *testing.T) {
+		body := authUC.SignUpRequest{Name: "No Key", Email: "nokey@example.com", Password: "password123"}
+
+		assert.Equal(t, http.StatusCreated, signup("", body).Code)
+		assert.Equal(t, http.StatusConflict, signup("", body).Code)
+	})
+
+	t.Run("should reject a key reused for another email", func(t *testing.T) {
+		first := signup("shared-key", authUC.SignUpRequest{Name: "First", Email: "first-key@example.com", Password: "password123"})
+		require.Equal(t, http.StatusCreated, first.Code)
+
+		second := signup("shared-key", authUC.SignUpRequest{Name: "Second", Email: "second-key@example.com", Password: "password123"})
+
+		assert.Equal(t, http.StatusUnprocessableEntity, second.Code)
+		var response ginx.Response
+		require.NoError(t, json.Unmarshal(second.Body.Bytes(), &response))
+		assert.Equal(t, ErrorCodeIdempotencyReused, response.Code)
+	})
+
+	t.Run("should reject an oversized key", func(t *testing.T) {
+		body := authUC.SignUpRequest{Name: "Long Key", Email: "longkey@example.com", Password: "password123"}
+
+		recorder := signup(strings.Repeat("k", 256), body)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+}
+
+func TestAuthHandler_BodyLimit(t *testing.T) {
+	server := setupAuthHandlerTest(t)
+	defer server.cleanup()
+
+	oversized, err := json.Marshal(authUC.SignUpRequest{
+		Name:     strings.Repeat("a", testMaxBodyBytes),
+		Email:    "huge@example.com",
+		Password: "password123",
+	})
+	require.NoError(t, err)
+
+	t.Run("should reject body over the limit with 413", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/auth/signup", bytes.NewBuffer(oversized))
+		req.Header.Set("Content-Type", "application/json")
+		recorder := httptest.NewRecorder()
+
+		server.router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+	})
+
+	t.Run("should reject body over the limit without content length", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/auth/signup", bytes.NewBuffer(oversized))
+		req.Header.Set("Content-Type", "application/json")
+		req.ContentLength = -1 // chunked: only the reader can enforce the limit
+		recorder := httptest.NewRecorder()
+
+		server.router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+
+		var response ginx.Response
+		err := json.Unmarshal(recorder.Body.Bytes(), &response)
+		require.NoError(t, err)
+		assert.Equal(t, ErrorCodeBodyTooLarge, response.Code)
+
+		var count int
+		err = server.db.Get(&count, "SELECT COUNT(*) FROM users WHERE email = $1", "huge@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+	})
+
+	t.Run("should accept a normal signup", func(t *testing.T) {
+		requestBody, err := json.Marshal(authUC.SignUpRequest{
+			Name:     "Normal Size",
+			Email:    "normalsize@example.com",
+			Password: "password123",
+		})
+		require.NoError(t, err)
+
+		req := httptest.NewRequest("POST", "/auth/signup", bytes.NewBuffer(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		recorder := httptest.NewRecorder()
+
+		server.router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusCreated, recorder.Code)
+	})
+}
+
 func TestAuthHandler_SignIn(t *testing.T) {
 	server := setupAuthHandlerTest(t)
 	defer server.cleanup()
@@ -385,7 +593,8 @@ func TestAuthHandler_SignIn(t *testing.T) {
 		assert.Equal(t, "John Doe", authResponse.User.Name)
 		assert.Equal(t, "signin@example.com", authResponse.User.Email)
 		assert.NotEmpty(t, authResponse.User.ID)
-		assert.NotEmpty(t, authResponse.Token) // Token should be present in signin
+		assert.NotEmpty(t, authResponse.Token)        // Token should be present in signin
+		assert.NotEmpty(t, authResponse.RefreshToken) // Refresh token should be present in signin
 	})
 
 	t.Run("should fail with invalid email", func(t *testing.T) {
@@ -496,30 +705,431 @@ func TestAuthHandler_SignIn(t *testing.T) {
 	})
 }
 
+func TestAuthHandler_RefreshToken(t *testing.T) {
+	server := setupAuthHandlerTest(t)
+	defer server.cleanup()
+
+	// Helper function to create a user and sign in
+	signUpAndSignIn := func(name, email, password string) AuthResponse {
+		signupRequest := authUC.SignUpRequest{
+			Name:     name,
+			Email:    email,
+			Password: password,
+		}
+
+		requestBody, err := json.Marshal(signupRequest)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest("POST", "/auth/signup", bytes.NewBuffer(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		recorder := httptest.NewRecorder()
+
+		server.router.ServeHTTP(recorder, req)
+		require.Equal(t, http.StatusCreated, recorder.Code)
+
+		signinRequest := authUC.SignInRequest{
+			Email:    email,
+			Password: password,
+		}
+
+		requestBody, err = json.Marshal(signinRequest)
+		require.NoError(t, err)
+
+		req = httptest.NewRequest("POST", "/auth/signin", bytes.NewBuffer(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		recorder = httptest.NewRecorder()
+
+		server.router.ServeHTTP(recorder, req)
+		require.Equal(t, http.StatusOK, recorder.Code)
+
+		var response ginx.Response
+		err = json.Unmarshal(recorder.Body.Bytes(), &response)
+		require.NoError(t, err)
+
+		responseData, err := json.Marshal(response.Data)
+		require.NoError(t, err)
+
+		var authResponse AuthResponse
+		err = json.Unmarshal(responseData, &authResponse)
+		require.NoError(t, err)
+
+		return authResponse
+	}
+
+	makeRefreshRequest := func(refreshToken string) *httptest.ResponseRecorder {
+		requestBody, err := json.Marshal(authUC.RefreshTokenRequest{RefreshToken: refreshToken})
+		require.NoError(t, err)
+
+		req := httptest.NewRequest("POST", "/auth/refresh", bytes.NewBuffer(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		recorder := httptest.NewRecorder()
+
+		server.router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	t.Run("should refresh access token successfully", func(t *testing.T) {
+		signin := signUpAndSignIn("Refresh User", "refresh@example.com", "password123")
+		require.NotEmpty(t, signin.RefreshToken)
+
+		recorder := makeRefreshRequest(signin.RefreshToken)
+
+		// Assert HTTP response
+		assert.Equal(t, http.StatusOK, recorder.Code)
+
+		var response ginx.Response
+		err := json.Unmarshal(recorder.Body.Bytes(), &response)
+		require.NoError(t, err)
+
+		assert.Empty(t, response.Error)
+
+		responseData, err := json.Marshal(response.Data)
+		require.NoError(t, err)
+
+		var authResponse AuthResponse
+		err = json.Unmarshal(responseData, &authResponse)
+		require.NoError(t, err)
+
+		assert.NotEmpty(t, authResponse.Token)
+		assert.Empty(t, authResponse.RefreshToken)
+		assert.Equal(t, "refresh@example.com", authResponse.User.Email)
+
+		// New access token must be usable
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest("GET", "/", nil)
+
+		user, err := server.handler.VerifyToken(c, authResponse.Token)
+		require.NoError(t, err)
+		assert.Equal(t, signin.User.ID, user.ID.String())
+	})
+
+	t.Run("should reject access token on refresh endpoint", func(t *testing.T) {
+		signin := signUpAndSignIn("Access User", "access@example.com", "password123")
+
+		recorder := makeRefreshRequest(signin.Token)
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+
+		var response ginx.Response
+		err := json.Unmarshal(recorder.Body.Bytes(), &response)
+		require.NoError(t, err)
+		assert.Contains(t, response.Error, "invalid refresh token")
+	})
+
+	t.Run("should reject refresh token used as access token", func(t *testing.T) {
+		signin := signUpAndSignIn("Misuse User", "misuse@example.com", "password123")
+
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest("GET", "/", nil)
+
+		user, err := server.handler.VerifyToken(c, signin.RefreshToken)
+		assert.Error(t, err)
+		assert.Nil(t, user)
+	})
+
+	t.Run("should fail with expired refresh token", func(t *testing.T) {
+		signin := signUpAndSignIn("Expired User", "expired@example.com", "password123")
+
+		tokenMaker, err := jwt.NewPasetoMaker("12345678901234567890123456789012")
+		require.NoError(t, err)
+
+		userID, err := uuid.Parse(signin.User.ID)
+		require.NoError(t, err)
+
+		expiredToken, _, err := tokenMaker.CreateRefreshToken(userID, 0, -time.Hour)
+		require.NoError(t, err)
+
+		recorder := makeRefreshRequest(expiredToken)
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+
+		var response ginx.Response
+		err = json.Unmarshal(recorder.Body.Bytes(), &response)
+		require.NoError(t, err)
+		assert.Contains(t, response.Error, "refresh token has expired")
+	})
+
+	t.Run("should fail with invalid JSON", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/auth/refresh", strings.NewReader("invalid json"))
+		req.Header.Set("Content-Type", "application/json")
+		recorder := httptest.NewRecorder()
+
+		server.router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+}
+
+func TestAuthHandler_Logout(t *testing.T) {
+	server := setupAuthHandlerTest(t)
+	defer server.cleanup()
+
+	// Helper function to create a user and sign in
+	signUpAndSignIn := func(name, email, password string) AuthResponse {
+		signupRequest := authUC.SignUpRequest{
+			Name:     name,
+			Email:    email,
+			Password: password,
+		}
+
+		requestBody, err := json.Marshal(signupRequest)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest("POST", "/auth/signup", bytes.NewBuffer(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		recorder := httptest.NewRecorder()
+
+		server.router.ServeHTTP(recorder, req)
+		require.Equal(t, http.StatusCreated, recorder.Code)
+
+		signinRequest := authUC.SignInRequest{
+			Email:    email,
+			Password: password,
+		}
+
+		requestBody, err = json.Marshal(signinRequest)
+		require.NoError(t, err)
+
+		req = httptest.NewRequest("POST", "/auth/signin", bytes.NewBuffer(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		recorder = httptest.NewRecorder()
+
+		server.router.ServeHTTP(recorder, req)
+		require.Equal(t, http.StatusOK, recorder.Code)
+
+		var response ginx.Response
+		err = json.Unmarshal(recorder.Body.Bytes(), &response)
+		require.NoError(t, err)
+
+		responseData, err := json.Marshal(response.Data)
+		require.NoError(t, err)
+
+		var authResponse AuthResponse
+		err = json.Unmarshal(responseData, &authResponse)
+		require.NoError(t, err)
+
+		return authResponse
+	}
+
+	makeLogoutRequest := func(token string, body []byte) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/auth/logout", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		recorder := httptest.NewRecorder()
+
+		server.router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	t.Run("should logout and reject the token afterwards", func(t *testing.T) {
+		signin := signUpAndSignIn("Logout User", "logout@example.com", "password123")
+
+		recorder := makeLogoutRequest(signin.Token, nil)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+
+		// Reusing the same token must fail
+		recorder = makeLogoutRequest(signin.Token, nil)
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	})
+
+	t.Run("should revoke refresh token when provided", func(t *testing.T) {
+		signin := signUpAndSignIn("Logout Refresh", "logout-refresh@example.com", "password123")
+
+		requestBody, err := json.Marshal(authUC.LogoutRequest{RefreshToken: signin.RefreshToken})
+		require.NoError(t, err)
+
+		recorder := makeLogoutRequest(signin.Token, requestBody)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+
+		// Refresh token can no longer be exchanged
+		requestBody, err = json.Marshal(authUC.RefreshTokenRequest{RefreshToken: signin.RefreshToken})
+		require.NoError(t, err)
+
+		req := httptest.NewRequest("POST", "/auth/refresh", bytes.NewBuffer(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		recorder = httptest.NewRecorder()
+
+		server.router.ServeHTTP(recorder, req)
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	})
+
+	t.Run("should fail without authorization header", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/auth/logout", nil)
+		recorder := httptest.NewRecorder()
+
+		server.router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	})
+}
+
+func TestAuthHandler_PasswordReset(t *testing.T) {
+	server := setupAuthHandlerTest(t)
+	defer server.cleanup()
+
+	postJSON := func(path string, body interface{}) *httptest.ResponseRecorder {
+		requestBody, err := json.Marshal(body)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest("POST", path, bytes.NewBuffer(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		recorder := httptest.NewRecorder()
+
+		server.router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	t.Run("should reset password and allow signin with the new one", func(t *testing.T) {
+		recorder := postJSON("/auth/signup", authUC.SignUpRequest{
+			Name:     "Reset User",
+			Email:    "reset@example.com",
+			Password: "oldpassword",
+		})
+		require.Equal(t, http.StatusCreated, recorder.Code)
+
+		recorder = postJSON("/auth/password-reset/request", authUC.RequestPasswordResetRequest{Email: "reset@example.com"})
+		assert.Equal(t, http.StatusOK, recorder.Code)
+
+		// Read the token from the persisted email
+		var body string
+		err := server.db.Get(&body, "SELECT body FROM emails WHERE to_email = $1 AND type = 'password_reset'", "reset@example.com")
+		require.NoError(t, err)
+
+		idx := strings.Index(body, "token=")
+		require.NotEqual(t, -1, idx)
+		resetToken := body[idx+len("token=") : idx+len("token=")+64]
+
+		recorder = postJSON("/auth/password-reset/confirm", authUC.ResetPasswordRequest{
+			Token:       resetToken,
+			NewPassword: "newpassword",
+		})
+		assert.Equal(t, http.StatusOK, recorder.Code)
+
+		recorder = postJSON("/auth/signin", authUC.SignInRequest{Email: "reset@example.com", Password: "newpassword"})
+		assert.Equal(t, http.StatusOK, recorder.Code)
+
+		recorder = postJSON("/auth/signin", authUC.SignInRequest{Email: "reset@example.com", Password: "oldpassword"})
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	})
+
+	t.Run("should return 200 for unknown email", func(t *testing.T) {
+		recorder := postJSON("/auth/password-reset/request", authUC.RequestPasswordResetRequest{Email: "unknown@example.com"})
+		assert.Equal(t, http.StatusOK, recorder.Code)
+	})
+
+	t.Run("should return 400 for invalid token", func(t *testing.T) {
+		recorder := postJSON("/auth/password-reset/confirm", authUC.ResetPasswordRequest{
+			Token:       "invalid",
+			NewPassword: "newpassword",
+		})
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+
+	t.Run("should return 400 for invalid request", func(t *testing.T) {
+		recorder := postJSON("/auth/password-reset/request", map[string]string{"email": "not-an-email"})
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+}
+
+func TestAuthHandler_VerifyEmail(t *testing.T) {
+	server := setupAuthHandlerTest(t)
+	defer server.cleanup()
+
+	t.Run("should return 400 for invalid verification token", func(t *testing.T) {
+		requestBody, err := json.Marshal(authUC.VerifyEmailRequest{Token: "invalid"})
+		require.NoError(t, err)
+
+		req := httptest.NewRequest("POST", "/auth/verify-email", bytes.NewBuffer(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		recorder := httptest.NewRecorder()
+
+		server.router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+
+	t.Run("should return 400 for missing token", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/auth/verify-email", strings.NewReader("{}"))
+		req.Header.Set("Content-Type", "application/json")
+		recorder := httptest.NewRecorder()
+
+		server.router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+}
+
 func TestAuthHandler_ErrorMapping(t *testing.T) {
-	t.Run("should map errors correctly", func(t *testing.T) {
+	t.Run("should map typed domain errors", func(t *testing.T) {
+		testCases := []struct {
+			err            error
+			expectedStatus int
+			expectedCode   string
+		}{
+			{user.ErrEmailExists, http.StatusConflict, ErrorCodeEmailExists},
+			{user.ErrConflict, http.StatusConflict, ErrorCodeConflict},
+			{user.ErrInvalidCredentials, http.StatusUnauthorized, ErrorCodeInvalidCredentials},
+			{user.ErrUserNotFound, http.StatusUnauthorized, ErrorCodeUserNotFound},
+			{token.ErrInvalidRefreshToken, http.StatusUnauthorized, ErrorCodeInvalidRefreshToken},
+			{token.ErrRefreshTokenExpired, http.StatusUnauthorized, ErrorCodeRefreshTokenExpired},
+			{token.ErrRefreshTokenRevoked, http.StatusUnauthorized, ErrorCodeTokenRevoked},
+			{token.ErrTokenRevoked, http.StatusUnauthorized, ErrorCodeTokenRevoked},
+			{user.ErrEmailNotVerified, http.StatusForbidden, ErrorCodeEmailNotVerified},
+			{user.ErrTooManyAttempts, http.StatusTooManyRequests, ErrorCodeTooManyAttempts},
+			{user.ErrIncorrectPassword, http.StatusUnauthorized, ErrorCodeIncorrectPassword},
+			{email.ErrEmailNotFound, http.StatusNotFound, ErrorCodeEmailNotFound},
+			{token.ErrIdempotencyKeyReused, http.StatusUnprocessableEntity, ErrorCodeIdempotencyReused},
+		}
+
+		for _, tc := range testCases {
+			err := fmt.Errorf("usecase: something failed: %w", tc.err)
+			assert.Equal(t, tc.expectedStatus, getStatusCodeFromError(err),
+				"Error '%v' should map to status %d", tc.err, tc.expectedStatus)
+			assert.Equal(t, tc.expectedCode, getErrorCodeFromError(err),
+				"Error '%v' should map to code %s", tc.err, tc.expectedCode)
+		}
+	})
+
+	t.Run("should map plain validation errors", func(t *testing.T) {
 		testCases := []struct {
 			errorMessage   string
 			expectedStatus int
-			description    string
+			expectedCode   string
 		}{
-			{"email already exists", http.StatusConflict, "duplicate email"},
-			{"invalid credentials", http.StatusUnauthorized, "auth failure"},
-			{"user not found", http.StatusUnauthorized, "user missing"},
-			{"email is required", http.StatusUnauthorized, "missing email"},
-			{"password is required", http.StatusUnauthorized, "missing password"},
-			{"invalid email format", http.StatusBadRequest, "bad format"},
-			{"name is required", http.StatusBadRequest, "validation error"},
-			{"some other error", http.StatusInternalServerError, "generic error"},
+			{"email is required", http.StatusUnauthorized, ErrorCodeUnauthorized},
+			{"password is required", http.StatusUnauthorized, ErrorCodeUnauthorized},
+			{"password must be at least 6 characters long", http.StatusBadRequest, ErrorCodeValidation},
+			{"password must contain at least one digit", http.StatusBadRequest, ErrorCodeValidation},
+			{"invalid email format", http.StatusBadRequest, ErrorCodeValidation},
+			{"name is required", http.StatusBadRequest, ErrorCodeValidation},
+			{"some other error", http.StatusInternalServerError, ErrorCodeInternal},
 		}
 
 		for _, tc := range testCases {
 			err := fmt.Errorf("%s", tc.errorMessage)
-			statusCode := getStatusCodeFromError(err)
-			assert.Equal(t, tc.expectedStatus, statusCode,
+			assert.Equal(t, tc.expectedStatus, getStatusCodeFromError(err),
 				"Error '%s' should map to status %d", tc.errorMessage, tc.expectedStatus)
+			assert.Equal(t, tc.expectedCode, getErrorCodeFromError(err),
+				"Error '%s' should map to code %s", tc.errorMessage, tc.expectedCode)
 		}
 	})
+
+	t.Run("should map validation errors with fields", func(t *testing.T) {
+		validationErr := user.NewValidationError()
+		validationErr.Add(user.FieldName, fmt.Errorf("name must be at least 2 characters long"))
+		err := fmt.Errorf("usecase: signup failed: %w", validationErr)
+
+		assert.Equal(t, http.StatusBadRequest, getStatusCodeFromError(err))
+
+		response := errorResponse("handler: signup failed", err)
+		assert.Equal(t, ErrorCodeValidation, response.Code)
+		assert.Equal(t, "name must be at least 2 characters long", response.Fields[user.FieldName])
+	})
+
+	t.Run("should not rely on message text for typed errors", func(t *testing.T) {
+		err := fmt.Errorf("email already exists")
+		assert.NotEqual(t, ErrorCodeEmailExists, getErrorCodeFromError(err))
+	})
 }
 
 func TestAuthHandler_Integration_CompleteFlow(t *testing.T) {
@@ -629,8 +1239,51 @@ func TestAuthHandler_ContentType(t *testing.T) {
 
 		server.router.ServeHTTP(recorder, req)
 
-		// Should fail with bad request
-		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		// Rejected before the body is parsed
+		assert.Equal(t, http.StatusUnsupportedMediaType, recorder.Code)
+	})
+
+	t.Run("should reject valid json sent as text/plain", func(t *testing.T) {
+		signupRequest := authUC.SignUpRequest{
+			Name:     "Plain Text",
+			Email:    "plaintext@example.com",
+			Password: "password123",
+		}
+
+		requestBody, err := json.Marshal(signupRequest)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest("POST", "/auth/signup", bytes.NewBuffer(requestBody))
+		req.Header.Set("Content-Type", "text/plain")
+		recorder := httptest.NewRecorder()
+
+		server.router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, recorder.Code)
+
+		var count int
+		err = server.db.Get(&count, "SELECT COUNT(*) FROM users WHERE email = $1", "plaintext@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+	})
+
+	t.Run("should accept json with charset", func(t *testing.T) {
+		signupRequest := authUC.SignUpRequest{
+			Name:     "Charset",
+			Email:    "charset@example.com",
+			Password: "password123",
+		}
+
+		requestBody, err := json.Marshal(signupRequest)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest("POST", "/auth/signup", bytes.NewBuffer(requestBody))
+		req.Header.Set("Content-Type", "application/json; charset=utf-8")
+		recorder := httptest.NewRecorder()
+
+		server.router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusCreated, recorder.Code)
 	})
 }
 
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	authUC "github.com/moura95/backend-challenge/internal/application/usecases/auth"
+	userUC "github.com/moura95/backend-challenge/internal/application/usecases/user"
+	"github.com/moura95/backend-challenge/internal/domain/user"
+	"github.com/moura95/backend-challenge/internal/infra/security/jwt"
+	"github.com/moura95/backend-challenge/internal/interfaces/http/ginx"
+	"github.com/moura95/backend-challenge/internal/interfaces/http/middlewares"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// signupUserRepository keeps users in memory and counts the signup queries
+// (EmailExists and Create) it receives.
+type signupUserRepository struct {
+	user.Repository
+	users         map[string]*user.User
+	signupQueries int
+}
+
+func (r *signupUserRepository) EmailExists(ctx context.Context, address string) (bool, error) {
+	r.signupQueries++
+	_, ok := r.users[address]
+	return ok, nil
+}
+
+func (r *signupUserRepository) Create(ctx context.Context, u *user.User) error {
+	r.signupQueries++
+	r.users[u.Email] = u
+	return nil
+}
+
+func (r *signupUserRepository) GetByEmail(ctx context.Context, address string) (*user.User, error) {
+	found, ok := r.users[address]
+	if !ok {
+		return nil, user.ErrUserNotFound
+	}
+	stored := *found
+	return &stored, nil
+}
+
+func (r *signupUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*user.User, error) {
+	for _, found := range r.users {
+		if found.ID == id {
+			stored := *found
+			return &stored, nil
+		}
+	}
+	return nil, user.ErrUserNotFound
+}
+
+func (r *signupUserRepository) UpdateLastLogin(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return nil
+}
+
+func TestAuthHandler_SignUpDisabled(t *testing.T) {
+	setup := func(t *testing.T, disabled bool) (*gin.Engine, *signupUserRepository) {
+		existing, err := user.NewUser("John Doe", "existing@example.com", "password123")
+		require.NoError(t, err)
+
+		tokenMaker, err := jwt.NewPasetoMaker("12345678901234567890123456789012")
+		require.NoError(t, err)
+
+		userRepo := &signupUserRepository{users: map[string]*user.User{existing.Email: existing}}
+		verifyTokenUC := authUC.NewVerifyTokenUseCase(userRepo, &cookieTokenRepository{revoked: map[uuid.UUID]bool{}}, tokenMaker)
+		// Sem email de boas-vindas, o cadastro só precisa do repositório de usuários
+		signUpUC := authUC.NewSignUpUseCase(userRepo, nil, tokenMaker, nil).
+			WithWelcomeEmail(false).
+			WithSignupsDisabled(disabled)
+
+		authHandler := NewAuthHandler(signUpUC, authUC.NewSignInUseCase(userRepo, tokenMaker), verifyTokenUC, nil, nil, nil, nil, nil)
+		userHandler := NewUserHandler(userUC.NewGetUserProfileUseCase(userRepo), nil, nil, nil, nil, nil)
+
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		router.POST("/auth/signup", authHandler.SignUp)
+		router.POST("/auth/signin", authHandler.SignIn)
+		router.GET("/account/me", middlewares.AuthMiddleware(verifyTokenUC), userHandler.GetProfile)
+
+		return router, userRepo
+	}
+
+	post := func(router *gin.Engine, path string, body any) *httptest.ResponseRecorder {
+		payload, _ := json.Marshal(body)
+		req := httptest.NewRequest("POST", path, bytes.NewBuffer(payload))
+		req.Header.Set("Content-Type", "application/json")
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	signUp := authUC.SignUpRequest{Name: "Jane Doe", Email: "jane@example.com", Password: "password123"}
+
+	t.Run("disabled signup returns 503 without touching the repository", func(t *testing.T) {
+		router, userRepo := setup(t, true)
+
+		recorder := post(router, "/auth/signup", signUp)
+
+		require.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+		var response ginx.Response
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		assert.Equal(t, ErrorCodeSignupsDisabled, response.Code)
+		assert.Contains(t, response.Error, "signups temporarily disabled")
+
+		assert.NotContains(t, userRepo.users, "jane@example.com")
+		assert.Zero(t, userRepo.signupQueries)
+	})
+
+	t.Run("sign in and profile keep working while signup is disabled", func(t *testing.T) {
+		router, _ := setup(t, true)
+
+		recorder := post(router, "/auth/signin", authUC.SignInRequest{Email: "existing@example.com", Password: "password123"})
+		require.Equal(t, http.StatusOK, recorder.Code)
+
+		var response struct {
+			Data AuthResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		require.NotEmpty(t, response.Data.Token)
+
+		req := httptest.NewRequest("GET", "/account/me", nil)
+		req.Header.Set("Authorization", "Bearer "+response.Data.Token)
+		profile := httptest.NewRecorder()
+		router.ServeHTTP(profile, req)
+		assert.Equal(t, http.StatusOK, profile.Code)
+		assert.Contains(t, profile.Body.String(), "existing@example.com")
+	})
+
+	t.Run("enabled signup creates the user", func(t *testing.T) {
+		router, userRepo := setup(t, false)
+
+		recorder := post(router, "/auth/signup", signUp)
+
+		require.Equal(t, http.StatusCreated, recorder.Code)
+		assert.Contains(t, userRepo.users, "jane@example.com")
+		assert.Positive(t, userRepo.signupQueries)
+	})
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	authUC "github.com/moura95/backend-challenge/internal/application/usecases/auth"
+	"github.com/moura95/backend-challenge/internal/domain/user"
+	"github.com/moura95/backend-challenge/internal/infra/security/jwt"
+	"github.com/moura95/backend-challenge/internal/interfaces/http/ginx"
+	"github.com/moura95/backend-challenge/internal/interfaces/http/middlewares"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthHandler_WhoAmI(t *testing.T) {
+	existing, err := user.NewUser("John Doe", "whoami@example.com", "password123")
+	require.NoError(t, err)
+
+	tokenMaker, err := jwt.NewPasetoMaker("12345678901234567890123456789012")
+	require.NoError(t, err)
+
+	verifyTokenUC := authUC.NewVerifyTokenUseCase(
+		&cookieUserRepository{user: existing},
+		&cookieTokenRepository{revoked: map[uuid.UUID]bool{}},
+		tokenMaker,
+	)
+	handler := NewAuthHandler(nil, nil, verifyTokenUC, nil, nil, nil, nil, nil)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/auth/whoami", middlewares.AuthMiddleware(verifyTokenUC), handler.WhoAmI)
+
+	whoami := func(token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/auth/whoami", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	t.Run("returns the claims of a valid token", func(t *testing.T) {
+		token, payload, err := tokenMaker.CreateToken(existing.ID, 0, time.Hour)
+		require.NoError(t, err)
+
+		recorder := whoami(token)
+		require.Equal(t, http.StatusOK, recorder.Code)
+
+		var response ginx.Response
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		data, err := json.Marshal(response.Data)
+		require.NoError(t, err)
+
+		var claims WhoAmIResponse
+		require.NoError(t, json.Unmarshal(data, &claims))
+		assert.Equal(t, existing.ID.String(), claims.UserUUID)
+		assert.WithinDuration(t, payload.IssuedAt, claims.IssuedAt, time.Second)
+		assert.WithinDuration(t, payload.ExpiredAt, claims.ExpiredAt, time.Second)
+	})
+
+	t.Run("rejects an expired token", func(t *testing.T) {
+		token, _, err := tokenMaker.CreateToken(existing.ID, 0, -time.Minute)
+		require.NoError(t, err)
+
+		recorder := whoami(token)
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	})
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	emailDomain "github.com/moura95/backend-challenge/internal/domain/email"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailConsumerHandler_Dispatch(t *testing.T) {
+	const digestType emailDomain.EmailType = "digest"
+
+	t.Run("registered type runs its validator then its handler", func(t *testing.T) {
+		var calls []string
+		handler := NewEmailConsumerHandler(nil).WithMessageType(digestType,
+			func(message emailDomain.QueueMessage) error {
+				calls = append(calls, "validate")
+				return nil
+			},
+			func(ctx context.Context, message emailDomain.QueueMessage) error {
+				calls = append(calls, "handle")
+				return nil
+			})
+
+		err := handler.HandleEmailMessage(context.Background(), emailDomain.QueueMessage{EmailID: uuid.New(), Type: digestType})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"validate", "handle"}, calls)
+	})
+
+	t.Run("validator failure skips the handler", func(t *testing.T) {
+		handled := false
+		handler := NewEmailConsumerHandler(nil).WithMessageType(digestType,
+			func(message emailDomain.QueueMessage) error {
+				return errors.New("digest period is required")
+			},
+			func(ctx context.Context, message emailDomain.QueueMessage) error {
+				handled = true
+				return nil
+			})
+
+		err := handler.HandleEmailMessage(context.Background(), emailDomain.QueueMessage{EmailID: uuid.New(), Type: digestType})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "digest period is required")
+		assert.False(t, handled)
+	})
+
+	t.Run("handler error is returned for a retry", func(t *testing.T) {
+		handler := NewEmailConsumerHandler(nil).WithMessageType(digestType, nil,
+			func(ctx context.Context, message emailDomain.QueueMessage) error {
+				return errors.New("digest store unavailable")
+			})
+
+		err := handler.HandleEmailMessage(context.Background(), emailDomain.QueueMessage{EmailID: uuid.New(), Type: digestType})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "digest store unavailable")
+	})
+
+	t.Run("unregistered type is unsupported", func(t *testing.T) {
+		handler := NewEmailConsumerHandler(nil)
+
+		err := handler.HandleEmailMessage(context.Background(), emailDomain.QueueMessage{EmailID: uuid.New(), Type: digestType})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported message type")
+	})
+
+	t.Run("notification without data is rejected before processing", func(t *testing.T) {
+		// Sem use case: a validação precisa barrar a mensagem antes dele
+		handler := NewEmailConsumerHandler(nil)
+
+		err := handler.HandleEmailMessage(context.Background(), emailDomain.QueueMessage{EmailID: uuid.New(), Type: emailDomain.EmailTypeNotification})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "notification data is required")
+	})
+}
@@ -6,28 +6,103 @@ import (
 
 	"github.com/moura95/backend-challenge/internal/application/usecases/email"
 	emailDomain "github.com/moura95/backend-challenge/internal/domain/email"
+	"github.com/moura95/backend-challenge/internal/infra/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// MessageValidator rejects a queue message before it is handled, e.g. when
+// data its type requires is missing.
+type MessageValidator func(message emailDomain.QueueMessage) error
+
+// messageRoute is how one email type is validated and handled.
+type messageRoute struct {
+	validate MessageValidator
+	handle   emailDomain.MessageHandler
+}
+
 type EmailConsumerHandler struct {
 	processEmailUC *email.ProcessEmailQueueUseCase
+
+	// Tabela de despacho: um tipo sem entrada é rejeitado como não suportado
+	routes map[emailDomain.EmailType]messageRoute
 }
 
 func NewEmailConsumerHandler(processEmailUC *email.ProcessEmailQueueUseCase) *EmailConsumerHandler {
-	return &EmailConsumerHandler{
+	h := &EmailConsumerHandler{
 		processEmailUC: processEmailUC,
+		routes:         make(map[emailDomain.EmailType]messageRoute),
 	}
+
+	// Os tipos embutidos são enviados pelo use case a partir do email gravado
+	h.WithMessageType(emailDomain.EmailTypeWelcome, nil, h.processEmail)
+	h.WithMessageType(emailDomain.EmailTypePasswordReset, nil, h.processEmail)
+	h.WithMessageType(emailDomain.EmailTypeVerification, nil, h.processEmail)
+	h.WithMessageType(emailDomain.EmailTypeNotification, validateNotificationMessage, h.processEmail)
+
+	return h
+}
+
+// WithMessageType registra (ou substitui) o validador e o handler de um tipo
+// de email; validate pode ser nil quando o tipo não exige dados extras.
+func (h *EmailConsumerHandler) WithMessageType(emailType emailDomain.EmailType, validate MessageValidator, handle emailDomain.MessageHandler) *EmailConsumerHandler {
+	h.routes[emailType] = messageRoute{validate: validate, handle: handle}
+	return h
 }
 
 func (h *EmailConsumerHandler) HandleEmailMessage(ctx context.Context, message emailDomain.QueueMessage) error {
-	fmt.Printf("Processing email message: %s for user %s\n",
-		message.Type, message.Data.UserEmail)
+	ctx, span := tracing.Start(ctx, "EmailConsumerHandler.HandleEmailMessage",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(attribute.String("email.type", string(message.Type))))
+	err := h.handleEmailMessage(ctx, message)
+	tracing.End(span, err)
+	return err
+}
+
+func (h *EmailConsumerHandler) handleEmailMessage(ctx context.Context, message emailDomain.QueueMessage) error {
+	fmt.Printf("Processing email message: %s for user %s (request_id=%s)\n",
+		message.Type, message.Recipient(), message.RequestID)
 
-	// Processar a mensagem usando o use case
-	err := h.processEmailUC.Execute(ctx, message)
+	// 1. Validar conforme o tipo registrado
+	route, err := h.validateMessage(message)
 	if err != nil {
 		return fmt.Errorf("failed to process email message: %w", err)
 	}
 
-	fmt.Printf("Email message processed successfully for user %s\n", message.Data.UserEmail)
+	// 2. Despachar para o handler do tipo
+	if err := route.handle(ctx, message); err != nil {
+		return fmt.Errorf("failed to process email message: %w", err)
+	}
+
+	fmt.Printf("Email message processed successfully for user %s\n", message.Recipient())
+	return nil
+}
+
+// validateMessage busca a rota do tipo e roda seu validador.
+func (h *EmailConsumerHandler) validateMessage(message emailDomain.QueueMessage) (messageRoute, error) {
+	route, ok := h.routes[message.Type]
+	if !ok {
+		return messageRoute{}, fmt.Errorf("unsupported message type: %q", message.Type)
+	}
+
+	if route.validate != nil {
+		if err := route.validate(message); err != nil {
+			return messageRoute{}, err
+		}
+	}
+
+	return route, nil
+}
+
+// processEmail envia o email gravado referenciado pela mensagem.
+func (h *EmailConsumerHandler) processEmail(ctx context.Context, message emailDomain.QueueMessage) error {
+	return h.processEmailUC.Execute(ctx, message)
+}
+
+// validateNotificationMessage exige os dados enviados pelo chamador da notificação.
+func validateNotificationMessage(message emailDomain.QueueMessage) error {
+	if message.Notification == nil {
+		return fmt.Errorf("notification data is required")
+	}
 	return nil
 }
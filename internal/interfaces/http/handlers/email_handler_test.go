@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"testing"
@@ -90,7 +91,24 @@ func runEmailConsumerMigrations(db *sqlx.DB) error {
 		error_msg    TEXT,
 		sent_at      TIMESTAMPTZ,
 		created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
-		updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		plain_body   TEXT NOT NULL DEFAULT '',
+		next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		cc_emails    TEXT[] NOT NULL DEFAULT '{}',
+		bcc_emails   TEXT[] NOT NULL DEFAULT '{}',
+		dedup_key    VARCHAR(255),
+		scheduled_at TIMESTAMPTZ
+	);
+	
+	-- Email attachments table
+	CREATE TABLE IF NOT EXISTS email_attachments (
+		id           BIGSERIAL PRIMARY KEY,
+		email_uuid   UUID NOT NULL,
+		filename     VARCHAR(255) NOT NULL,
+		content_type VARCHAR(255) NOT NULL,
+		content      BYTEA NOT NULL,
+		created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		FOREIGN KEY (email_uuid) REFERENCES emails(uuid) ON DELETE CASCADE
 	);
 	
 	-- Indexes
@@ -144,6 +162,20 @@ func createTestEmailForConsumer(t *testing.T, server *emailConsumerTestServer, t
 	return testEmail
 }
 
+// jsonQueuePublisher stores published messages as JSON, like the broker would.
+type jsonQueuePublisher struct {
+	bodies [][]byte
+}
+
+func (p *jsonQueuePublisher) PublishEmailMessage(message emailDomain.QueueMessage) error {
+	body, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	p.bodies = append(p.bodies, body)
+	return nil
+}
+
 func TestEmailConsumerHandler_HandleEmailMessage(t *testing.T) {
 	server := setupEmailConsumerTest(t)
 	defer server.cleanup()
@@ -187,6 +219,63 @@ func TestEmailConsumerHandler_HandleEmailMessage(t *testing.T) {
 		assert.Equal(t, "sent", status)
 	})
 
+	t.Run("should round-trip notification email through the queue and send it", func(t *testing.T) {
+		// Publisher that serializes messages as they would travel through the broker
+		queue := &jsonQueuePublisher{}
+		sendUC := emailUC.NewSendNotificationEmailUseCase(server.repos.Email, queue)
+
+		result, err := sendUC.Execute(ctx, emailUC.SendNotificationEmailRequest{
+			To:      "notify@example.com",
+			Subject: "Your report is ready",
+			Body:    "<p>Download it from your dashboard.</p>",
+		})
+		require.NoError(t, err)
+		require.Len(t, queue.bodies, 1)
+
+		var message emailDomain.QueueMessage
+		require.NoError(t, json.Unmarshal(queue.bodies[0], &message))
+		assert.Equal(t, emailDomain.EmailTypeNotification, message.Type)
+		assert.Equal(t, result.EmailID, message.EmailID.String())
+		require.NotNil(t, message.Notification)
+
+		// Setup mock email service expecting the caller-supplied content
+		mockEmailService := new(MockEmailService)
+		mockEmailService.On("SendEmailAuto", ctx, mock.MatchedBy(func(e *emailDomain.Email) bool {
+			return e.To == "notify@example.com" &&
+				e.Subject == "Your report is ready" &&
+				e.Body == "<p>Download it from your dashboard.</p>"
+		})).Return(nil)
+
+		processEmailUC := emailUC.NewProcessEmailQueueUseCase(server.repos.Email, mockEmailService)
+		handler := NewEmailConsumerHandler(processEmailUC)
+
+		// Execute
+		err = handler.HandleEmailMessage(ctx, message)
+
+		// Assert
+		require.NoError(t, err)
+		mockEmailService.AssertExpectations(t)
+
+		var status string
+		err = server.db.Get(&status, "SELECT status FROM emails WHERE uuid = $1", message.EmailID)
+		require.NoError(t, err)
+		assert.Equal(t, "sent", status)
+	})
+
+	t.Run("should reject notification message without data", func(t *testing.T) {
+		mockEmailService := new(MockEmailService)
+		handler := NewEmailConsumerHandler(emailUC.NewProcessEmailQueueUseCase(server.repos.Email, mockEmailService))
+
+		err := handler.HandleEmailMessage(ctx, emailDomain.QueueMessage{
+			EmailID: uuid.New(),
+			Type:    emailDomain.EmailTypeNotification,
+		})
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "notification data is required")
+		mockEmailService.AssertNotCalled(t, "SendEmailAuto")
+	})
+
 	t.Run("should handle email send failure", func(t *testing.T) {
 		// Create test email in database
 		testEmail := createTestEmailForConsumer(t, server, "fail@example.com", "Fail Subject")
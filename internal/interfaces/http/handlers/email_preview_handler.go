@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	emailUC "github.com/moura95/backend-challenge/internal/application/usecases/email"
+)
+
+type EmailPreviewHandler struct {
+	previewEmailUseCase *emailUC.PreviewEmailUseCase
+}
+
+func NewEmailPreviewHandler(previewEmailUC *emailUC.PreviewEmailUseCase) *EmailPreviewHandler {
+	return &EmailPreviewHandler{
+		previewEmailUseCase: previewEmailUC,
+	}
+}
+
+// @Summary Preview an email body
+// @Description Render the HTML body of a welcome, password_reset or verification email for the given name, without storing or sending it (admin only)
+// @Tags admin
+// @Security BearerAuth
+// @Produce html
+// @Param type query string true "Email type (welcome, password_reset, verification)"
+// @Param name query string true "Recipient name used in the template"
+// @Success 200 {string} string "Rendered HTML body"
+// @Failure 400 {object} ginx.Response
+// @Failure 401 {object} ginx.Response
+// @Failure 403 {object} ginx.Response
+// @Router /admin/emails/preview [get]
+func (h *EmailPreviewHandler) PreviewEmail(c *gin.Context) {
+	preview, err := h.previewEmailUseCase.Execute(c.Request.Context(), emailUC.PreviewEmailRequest{
+		Type: c.Query("type"),
+		Name: c.Query("name"),
+	})
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		c.JSON(statusCode, errorResponse(fmt.Sprintf("handler: preview email failed: %v", err), err))
+		return
+	}
+
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(preview.Body))
+}
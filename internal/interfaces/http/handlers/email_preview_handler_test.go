@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailPreviewHandler_PreviewEmail(t *testing.T) {
+	server := setupUserHandlerTest(t)
+	defer server.cleanup()
+
+	adminToken, _ := createUserAndGetToken(t, server, "Admin User", "admin@example.com", "password123")
+	promoteToAdmin(t, server, "admin@example.com")
+
+	countEmails := func() int {
+		var count int
+		require.NoError(t, server.db.Get(&count, "SELECT COUNT(*) FROM emails"))
+		return count
+	}
+
+	t.Run("should render welcome HTML without storing an email", func(t *testing.T) {
+		before := countEmails()
+
+		recorder := makeAuthenticatedRequest(t, server, "GET", "/api/admin/emails/preview?type=welcome&name=Maria%20Preview", adminToken, nil)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Contains(t, recorder.Header().Get("Content-Type"), "text/html")
+		assert.Contains(t, recorder.Body.String(), "<html")
+		assert.Contains(t, recorder.Body.String(), "Maria Preview")
+
+		assert.Equal(t, before, countEmails())
+	})
+
+	t.Run("should reject unsupported types", func(t *testing.T) {
+		recorder := makeAuthenticatedRequest(t, server, "GET", "/api/admin/emails/preview?type=notification&name=Maria", adminToken, nil)
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+
+	t.Run("should forbid regular users", func(t *testing.T) {
+		token, _ := createUserAndGetToken(t, server, "Regular User", "regular@example.com", "password123")
+
+		recorder := makeAuthenticatedRequest(t, server, "GET", "/api/admin/emails/preview?type=welcome&name=Maria", token, nil)
+		assert.Equal(t, http.StatusForbidden, recorder.Code)
+	})
+}
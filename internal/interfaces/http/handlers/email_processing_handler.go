@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	emailUC "github.com/moura95/backend-challenge/internal/application/usecases/email"
+	"github.com/moura95/backend-challenge/internal/interfaces/http/ginx"
+)
+
+type EmailProcessingHandler struct {
+	processEmailUseCase *emailUC.ProcessEmailQueueUseCase
+}
+
+func NewEmailProcessingHandler(processEmailUC *emailUC.ProcessEmailQueueUseCase) *EmailProcessingHandler {
+	return &EmailProcessingHandler{
+		processEmailUseCase: processEmailUC,
+	}
+}
+
+// @Summary Process pending emails now
+// @Description Send the pending emails that are due right away instead of waiting for the background loop (admin only)
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Param limit query int false "Maximum emails to process (default 50, max 500)"
+// @Success 200 {object} ginx.Response{data=github_com_moura95_backend-challenge_internal_application_usecases_email.ProcessPendingResult}
+// @Failure 400 {object} ginx.Response
+// @Failure 401 {object} ginx.Response
+// @Failure 403 {object} ginx.Response
+// @Router /admin/emails/process [post]
+func (h *EmailProcessingHandler) ProcessPendingEmails(c *gin.Context) {
+	limit := 0 // zero uses the use case default
+	if value := c.Query("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 {
+			err = fmt.Errorf("limit must be a positive integer")
+			c.JSON(http.StatusBadRequest, errorResponse(fmt.Sprintf("handler: process pending emails failed: %v", err), err))
+			return
+		}
+		limit = parsed
+	}
+
+	result, err := h.processEmailUseCase.ProcessPendingEmails(c.Request.Context(), limit)
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		c.JSON(statusCode, errorResponse(fmt.Sprintf("handler: process pending emails failed: %v", err), err))
+		return
+	}
+
+	c.JSON(http.StatusOK, ginx.SuccessResponse(result))
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	emailUC "github.com/moura95/backend-challenge/internal/application/usecases/email"
+	"github.com/moura95/backend-challenge/internal/interfaces/http/ginx"
+)
+
+func TestEmailProcessingHandler_ProcessPendingEmails(t *testing.T) {
+	server := setupUserHandlerTest(t)
+	defer server.cleanup()
+
+	// Signup queues a pending welcome email for every user
+	adminToken, _ := createUserAndGetToken(t, server, "Admin User", "admin@example.com", "password123")
+	promoteToAdmin(t, server, "admin@example.com")
+
+	decodeResult := func(t *testing.T, body []byte) emailUC.ProcessPendingResult {
+		var response ginx.Response
+		require.NoError(t, json.Unmarshal(body, &response))
+
+		data, err := json.Marshal(response.Data)
+		require.NoError(t, err)
+
+		var result emailUC.ProcessPendingResult
+		require.NoError(t, json.Unmarshal(data, &result))
+		return result
+	}
+
+	t.Run("should send pending emails and report the counts", func(t *testing.T) {
+		createUserAndGetToken(t, server, "Flush User", "flush@example.com", "password123")
+		createUserAndGetToken(t, server, "Broken User", "undeliverable@example.com", "password123")
+
+		recorder := makeAuthenticatedRequest(t, server, "POST", "/api/admin/emails/process", adminToken, nil)
+		require.Equal(t, http.StatusOK, recorder.Code)
+
+		result := decodeResult(t, recorder.Body.Bytes())
+		assert.Equal(t, emailUC.ProcessPendingResult{Processed: 3, Sent: 2, Failed: 1}, result)
+
+		var statuses []string
+		err := server.db.Select(&statuses, "SELECT status FROM emails WHERE to_email IN ($1, $2) ORDER BY to_email", "flush@example.com", "undeliverable@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"sent", "pending"}, statuses)
+
+		// The failed email waits for its backoff, so nothing is due now
+		recorder = makeAuthenticatedRequest(t, server, "POST", "/api/admin/emails/process", adminToken, nil)
+		require.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, emailUC.ProcessPendingResult{}, decodeResult(t, recorder.Body.Bytes()))
+	})
+
+	t.Run("should honour the limit", func(t *testing.T) {
+		createUserAndGetToken(t, server, "Limit One", "limit1@example.com", "password123")
+		createUserAndGetToken(t, server, "Limit Two", "limit2@example.com", "password123")
+
+		recorder := makeAuthenticatedRequest(t, server, "POST", "/api/admin/emails/process?limit=1", adminToken, nil)
+		require.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, emailUC.ProcessPendingResult{Processed: 1, Sent: 1}, decodeResult(t, recorder.Body.Bytes()))
+	})
+
+	t.Run("should reject an invalid limit", func(t *testing.T) {
+		for _, limit := range []string{"0", "-1", "abc"} {
+			recorder := makeAuthenticatedRequest(t, server, "POST", "/api/admin/emails/process?limit="+limit, adminToken, nil)
+			assert.Equal(t, http.StatusBadRequest, recorder.Code, limit)
+		}
+	})
+
+	t.Run("should forbid regular users", func(t *testing.T) {
+		token, _ := createUserAndGetToken(t, server, "Regular User", "regular@example.com", "password123")
+
+		recorder := makeAuthenticatedRequest(t, server, "POST", "/api/admin/emails/process", token, nil)
+		assert.Equal(t, http.StatusForbidden, recorder.Code)
+	})
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	emailUC "github.com/moura95/backend-challenge/internal/application/usecases/email"
+	"github.com/moura95/backend-challenge/internal/interfaces/http/ginx"
+)
+
+type EmailSearchHandler struct {
+	searchEmailsUseCase *emailUC.SearchEmailsUseCase
+}
+
+func NewEmailSearchHandler(searchEmailsUC *emailUC.SearchEmailsUseCase) *EmailSearchHandler {
+	return &EmailSearchHandler{
+		searchEmailsUseCase: searchEmailsUC,
+	}
+}
+
+// @Summary Search emails
+// @Description Find emails by recipient substring, status, type and creation window, newest first (admin only)
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Param to query string false "Recipient address substring (case-insensitive)"
+// @Param status query string false "Delivery status" Enums(pending, sending, sent, failed)
+// @Param type query string false "Email type" Enums(welcome, password_reset, verification, notification)
+// @Param created_after query string false "Only emails created at or after this instant (RFC3339)"
+// @Param created_before query string false "Only emails created at or before this instant (RFC3339)"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size, capped at MAX_PAGE_SIZE" default(10)
+// @Success 200 {object} ginx.Response{data=github_com_moura95_backend-challenge_internal_application_usecases_email.SearchEmailsResponse}
+// @Header 200 {int} X-Total-Count "Total emails matching the filters"
+// @Header 200 {int} X-Page "Current page"
+// @Header 200 {int} X-Page-Size "Effective page size"
+// @Header 200 {string} Link "RFC 5988 links: first, prev, next, last"
+// @Failure 400 {object} ginx.Response
+// @Failure 401 {object} ginx.Response
+// @Failure 403 {object} ginx.Response
+// @Router /admin/emails [get]
+func (h *EmailSearchHandler) SearchEmails(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size")) // zero uses the configured default
+
+	createdAfter, err := parseTimeQuery(c, "created_after")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ginx.ErrorResponse(fmt.Sprintf("handler: search emails failed: %v", err)))
+		return
+	}
+	createdBefore, err := parseTimeQuery(c, "created_before")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ginx.ErrorResponse(fmt.Sprintf("handler: search emails failed: %v", err)))
+		return
+	}
+
+	result, err := h.searchEmailsUseCase.Execute(c.Request.Context(), emailUC.SearchEmailsRequest{
+		To:            c.Query("to"),
+		Status:        c.Query("status"),
+		Type:          c.Query("type"),
+		CreatedAfter:  createdAfter,
+		CreatedBefore: createdBefore,
+		Page:          page,
+		PageSize:      pageSize,
+	})
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		c.JSON(statusCode, errorResponse(fmt.Sprintf("handler: search emails failed: %v", err), err))
+		return
+	}
+
+	setPaginationHeaders(c, result.Total, result.Page, result.PageSize)
+	c.JSON(http.StatusOK, ginx.SuccessResponse(result))
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	emailUC "github.com/moura95/backend-challenge/internal/application/usecases/email"
+	emailDomain "github.com/moura95/backend-challenge/internal/domain/email"
+	"github.com/moura95/backend-challenge/internal/interfaces/http/ginx"
+)
+
+func TestEmailSearchHandler_SearchEmails(t *testing.T) {
+	server := setupUserHandlerTest(t)
+	defer server.cleanup()
+
+	adminToken, _ := createUserAndGetToken(t, server, "Admin User", "admin@example.com", "password123")
+	promoteToAdmin(t, server, "admin@example.com")
+
+	ctx := context.Background()
+	for _, to := range []string{"triage-one@example.com", "triage-two@example.com", "other@example.com"} {
+		notification, err := emailDomain.NewNotificationEmail(to, "Support", "<p>Body</p>")
+		require.NoError(t, err)
+		require.NoError(t, server.repos.Email.Create(ctx, notification))
+
+		if to == "triage-two@example.com" {
+			notification.Status = emailDomain.StatusFailed
+			notification.ErrorMsg = "mailbox full"
+			require.NoError(t, server.repos.Email.Update(ctx, notification))
+		}
+	}
+
+	search := func(t *testing.T, query string) emailUC.SearchEmailsResponse {
+		recorder := makeAuthenticatedRequest(t, server, "GET", "/api/admin/emails"+query, adminToken, nil)
+		require.Equal(t, http.StatusOK, recorder.Code)
+
+		var response ginx.Response
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		data, err := json.Marshal(response.Data)
+		require.NoError(t, err)
+
+		var result emailUC.SearchEmailsResponse
+		require.NoError(t, json.Unmarshal(data, &result))
+		return result
+	}
+
+	t.Run("should return only failed emails", func(t *testing.T) {
+		result := search(t, "?status=failed")
+
+		require.Equal(t, 1, result.Total)
+		require.Len(t, result.Emails, 1)
+		assert.Equal(t, "triage-two@example.com", result.Emails[0].To)
+		assert.Equal(t, "failed", result.Emails[0].Status)
+		assert.Equal(t, "mailbox full", result.Emails[0].LastError)
+	})
+
+	t.Run("should return only recipients containing the substring", func(t *testing.T) {
+		result := search(t, "?to=triage")
+
+		assert.Equal(t, 2, result.Total)
+		require.Len(t, result.Emails, 2)
+		for _, e := range result.Emails {
+			assert.Contains(t, e.To, "triage")
+		}
+	})
+
+	t.Run("should paginate with the standard headers", func(t *testing.T) {
+		recorder := makeAuthenticatedRequest(t, server, "GET", "/api/admin/emails?type=notification&page_size=2", adminToken, nil)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "3", recorder.Header().Get("X-Total-Count"))
+		assert.Equal(t, "2", recorder.Header().Get("X-Page-Size"))
+
+		// The total counts every match while the page holds page_size emails
+		result := search(t, "?type=notification&page_size=2")
+		assert.Equal(t, 3, result.Total)
+		assert.Equal(t, 1, result.Page)
+		assert.Equal(t, 2, result.PageSize)
+		assert.Len(t, result.Emails, 2)
+	})
+
+	t.Run("should reject unknown statuses", func(t *testing.T) {
+		recorder := makeAuthenticatedRequest(t, server, "GET", "/api/admin/emails?status=bounced", adminToken, nil)
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+
+	t.Run("should forbid regular users", func(t *testing.T) {
+		token, _ := createUserAndGetToken(t, server, "Regular User", "regular@example.com", "password123")
+
+		recorder := makeAuthenticatedRequest(t, server, "GET", "/api/admin/emails", token, nil)
+		assert.Equal(t, http.StatusForbidden, recorder.Code)
+	})
+}
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	emailUC "github.com/moura95/backend-challenge/internal/application/usecases/email"
+	"github.com/moura95/backend-challenge/internal/interfaces/http/ginx"
+)
+
+type EmailStatusHandler struct {
+	getEmailStatusUseCase *emailUC.GetEmailStatusUseCase
+}
+
+func NewEmailStatusHandler(getEmailStatusUC *emailUC.GetEmailStatusUseCase) *EmailStatusHandler {
+	return &EmailStatusHandler{
+		getEmailStatusUseCase: getEmailStatusUC,
+	}
+}
+
+// @Summary Get email delivery status
+// @Description Get the delivery status of a queued email (admin only)
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Email ID"
+// @Success 200 {object} ginx.Response{data=github_com_moura95_backend-challenge_internal_application_usecases_email.EmailStatusResponse}
+// @Failure 400 {object} ginx.Response
+// @Failure 401 {object} ginx.Response
+// @Failure 403 {object} ginx.Response
+// @Failure 404 {object} ginx.Response
+// @Router /admin/emails/{id} [get]
+func (h *EmailStatusHandler) GetEmailStatus(c *gin.Context) {
+	status, err := h.getEmailStatusUseCase.Execute(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		c.JSON(statusCode, errorResponse(fmt.Sprintf("handler: get email status failed: %v", err), err))
+		return
+	}
+
+	c.JSON(http.StatusOK, ginx.SuccessResponse(status))
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	emailUC "github.com/moura95/backend-challenge/internal/application/usecases/email"
+	emailDomain "github.com/moura95/backend-challenge/internal/domain/email"
+	"github.com/moura95/backend-challenge/internal/interfaces/http/ginx"
+)
+
+func TestEmailStatusHandler_GetEmailStatus(t *testing.T) {
+	server := setupUserHandlerTest(t)
+	defer server.cleanup()
+
+	ctx := context.Background()
+
+	adminToken, _ := createUserAndGetToken(t, server, "Admin User", "admin@example.com", "password123")
+	promoteToAdmin(t, server, "admin@example.com")
+
+	t.Run("should report sent welcome email after processing", func(t *testing.T) {
+		// Signup queues a welcome email
+		createUserAndGetToken(t, server, "Welcome User", "welcome@example.com", "password123")
+
+		var emailID uuid.UUID
+		err := server.db.Get(&emailID, "SELECT uuid FROM emails WHERE to_email = $1 AND type = 'welcome'", "welcome@example.com")
+		require.NoError(t, err)
+
+		// Process it
+		mockEmailService := new(MockEmailService)
+		mockEmailService.On("SendEmailAuto", ctx, mock.AnythingOfType("*email.Email")).Return(nil)
+
+		processEmailUC := emailUC.NewProcessEmailQueueUseCase(server.repos.Email, mockEmailService)
+		err = processEmailUC.Execute(ctx, emailDomain.QueueMessage{EmailID: emailID, Type: emailDomain.EmailTypeWelcome})
+		require.NoError(t, err)
+
+		// Query status
+		recorder := makeAuthenticatedRequest(t, server, "GET", "/api/admin/emails/"+emailID.String(), adminToken, nil)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+
+		var response ginx.Response
+		err = json.Unmarshal(recorder.Body.Bytes(), &response)
+		require.NoError(t, err)
+
+		statusData, err := json.Marshal(response.Data)
+		require.NoError(t, err)
+
+		var status emailUC.EmailStatusResponse
+		err = json.Unmarshal(statusData, &status)
+		require.NoError(t, err)
+
+		assert.Equal(t, emailID.String(), status.EmailID)
+		assert.Equal(t, "sent", status.Status)
+		assert.Equal(t, 0, status.Attempts)
+		assert.Empty(t, status.LastError)
+		assert.NotNil(t, status.SentAt)
+	})
+
+	t.Run("should return 404 for unknown email", func(t *testing.T) {
+		recorder := makeAuthenticatedRequest(t, server, "GET", "/api/admin/emails/"+uuid.New().String(), adminToken, nil)
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+
+		var response ginx.Response
+		err := json.Unmarshal(recorder.Body.Bytes(), &response)
+		require.NoError(t, err)
+		assert.Contains(t, response.Error, "email not found")
+	})
+
+	t.Run("should return 400 for invalid email ID", func(t *testing.T) {
+		recorder := makeAuthenticatedRequest(t, server, "GET", "/api/admin/emails/not-a-uuid", adminToken, nil)
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+
+	t.Run("should forbid regular users", func(t *testing.T) {
+		token, _ := createUserAndGetToken(t, server, "Regular User", "regular@example.com", "password123")
+
+		recorder := makeAuthenticatedRequest(t, server, "GET", "/api/admin/emails/"+uuid.New().String(), token, nil)
+		assert.Equal(t, http.StatusForbidden, recorder.Code)
+	})
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	emailUC "github.com/moura95/backend-challenge/internal/application/usecases/email"
+	emailDomain "github.com/moura95/backend-challenge/internal/domain/email"
+	"github.com/moura95/backend-challenge/internal/infra/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// lockingEmailRepository keeps emails in memory and runs LockForProcessing
+// without a transaction.
+type lockingEmailRepository struct {
+	emailDomain.Repository
+	emails map[uuid.UUID]*emailDomain.Email
+}
+
+func (r *lockingEmailRepository) Update(ctx context.Context, emailEntity *emailDomain.Email) error {
+	r.emails[emailEntity.ID] = emailEntity
+	return nil
+}
+
+func (r *lockingEmailRepository) LockForProcessing(ctx context.Context, id uuid.UUID, fn func(*emailDomain.Email, emailDomain.Repository) error) error {
+	emailEntity, ok := r.emails[id]
+	if !ok {
+		return emailDomain.ErrEmailNotFound
+	}
+	return fn(emailEntity, r)
+}
+
+// spanRecordingSender records the span active when the email is sent.
+type spanRecordingSender struct {
+	sendSpan trace.SpanContext
+}
+
+func (s *spanRecordingSender) SendEmail(ctx context.Context, emailEntity *emailDomain.Email) error {
+	s.sendSpan = trace.SpanContextFromContext(ctx)
+	return nil
+}
+
+func (s *spanRecordingSender) SendEmailDev(ctx context.Context, emailEntity *emailDomain.Email) error {
+	return s.SendEmail(ctx, emailEntity)
+}
+
+func (s *spanRecordingSender) SendEmailAuto(ctx context.Context, emailEntity *emailDomain.Email) error {
+	return s.SendEmail(ctx, emailEntity)
+}
+
+// useInMemoryTracer routes spans to an in-memory exporter for the test.
+func useInMemoryTracer(t *testing.T) *tracetest.InMemoryExporter {
+	t.Helper()
+
+	_, err := tracing.Setup(context.Background(), tracing.Config{})
+	require.NoError(t, err)
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+		provider.Shutdown(context.Background())
+	})
+
+	return exporter
+}
+
+func TestEmailConsumerHandler_Tracing(t *testing.T) {
+	t.Run("send span joins the signup trace through the queue message", func(t *testing.T) {
+		exporter := useInMemoryTracer(t)
+
+		welcome, err := emailDomain.NewWelcomeEmail(emailDomain.WelcomeEmailData{
+			UserID:    uuid.New().String(),
+			UserName:  "John Doe",
+			UserEmail: "john@example.com",
+		})
+		require.NoError(t, err)
+
+		repo := &lockingEmailRepository{emails: map[uuid.UUID]*emailDomain.Email{welcome.ID: welcome}}
+		sender := &spanRecordingSender{}
+		handler := NewEmailConsumerHandler(emailUC.NewProcessEmailQueueUseCase(repo, sender))
+
+		// Produtor: o signup publica a mensagem com o trace context do seu span
+		signupCtx, signupSpan := tracing.Start(context.Background(), "SignUpUseCase.Execute")
+		body, err := json.Marshal(emailDomain.QueueMessage{
+			EmailID:      welcome.ID,
+			Type:         welcome.Type,
+			TraceContext: tracing.Inject(signupCtx),
+		})
+		require.NoError(t, err)
+		signupSpan.End()
+
+		// Consumer: mesma extração feita pelo rabbitmq antes de chamar o handler
+		var received emailDomain.QueueMessage
+		require.NoError(t, json.Unmarshal(body, &received))
+		consumerCtx := tracing.Extract(context.Background(), received.TraceContext)
+
+		require.NoError(t, handler.HandleEmailMessage(consumerCtx, received))
+		assert.Equal(t, emailDomain.StatusSent, repo.emails[welcome.ID].Status)
+
+		spans := map[string]tracetest.SpanStub{}
+		for _, span := range exporter.GetSpans() {
+			spans[span.Name] = span
+		}
+		require.Contains(t, spans, "SignUpUseCase.Execute")
+		require.Contains(t, spans, "EmailConsumerHandler.HandleEmailMessage")
+		require.Contains(t, spans, "ProcessEmailQueueUseCase.Execute")
+
+		signup := spans["SignUpUseCase.Execute"].SpanContext
+		consume := spans["EmailConsumerHandler.HandleEmailMessage"]
+		process := spans["ProcessEmailQueueUseCase.Execute"]
+
+		assert.Equal(t, signup.TraceID(), consume.SpanContext.TraceID())
+		assert.Equal(t, signup.SpanID(), consume.Parent.SpanID())
+		assert.Equal(t, consume.SpanContext.SpanID(), process.Parent.SpanID())
+
+		// O envio SMTP acontece dentro do mesmo trace
+		assert.Equal(t, signup.TraceID(), sender.sendSpan.TraceID())
+		assert.Equal(t, process.SpanContext.SpanID(), sender.sendSpan.SpanID())
+	})
+
+	t.Run("starts a new trace for messages without trace context", func(t *testing.T) {
+		exporter := useInMemoryTracer(t)
+		repo := &lockingEmailRepository{emails: map[uuid.UUID]*emailDomain.Email{}}
+		handler := NewEmailConsumerHandler(emailUC.NewProcessEmailQueueUseCase(repo, &spanRecordingSender{}))
+
+		message := emailDomain.QueueMessage{EmailID: uuid.New(), Type: emailDomain.EmailTypeWelcome}
+		err := handler.HandleEmailMessage(tracing.Extract(context.Background(), nil), message)
+		assert.Error(t, err)
+
+		spans := exporter.GetSpans()
+		require.NotEmpty(t, spans)
+		for _, span := range spans {
+			if span.Name == "EmailConsumerHandler.HandleEmailMessage" {
+				assert.False(t, span.Parent.IsValid())
+			}
+		}
+	})
+}
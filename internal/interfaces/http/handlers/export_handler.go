@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	userUC "github.com/moura95/backend-challenge/internal/application/usecases/user"
+	"github.com/moura95/backend-challenge/internal/interfaces/http/ginx"
+	"github.com/moura95/backend-challenge/internal/interfaces/http/middlewares"
+)
+
+type ExportHandler struct {
+	exportUserDataUseCase *userUC.ExportUserDataUseCase
+}
+
+func NewExportHandler(exportUserDataUC *userUC.ExportUserDataUseCase) *ExportHandler {
+	return &ExportHandler{
+		exportUserDataUseCase: exportUserDataUC,
+	}
+}
+
+// @Summary Export account data
+// @Description Download every piece of data held about the current user: profile and emails sent to their address
+// @Tags user
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} github_com_moura95_backend-challenge_internal_application_usecases_user.UserDataExport
+// @Failure 401 {object} ginx.Response
+// @Failure 404 {object} ginx.Response
+// @Router /account/export [get]
+func (h *ExportHandler) ExportAccount(c *gin.Context) {
+	userID, exists := middlewares.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ginx.ErrorResponse("handler: export account failed: user not authenticated"))
+		return
+	}
+
+	export, err := h.exportUserDataUseCase.Execute(c.Request.Context(), userID)
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		c.JSON(statusCode, errorResponse(fmt.Sprintf("handler: export account failed: %v", err), err))
+		return
+	}
+
+	// The document itself is the download, without the usual response envelope
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="account-export-%s.json"`, userID))
+	c.JSON(http.StatusOK, export)
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/moura95/backend-challenge/internal/interfaces/http/ginx"
+)
+
+// RegisterFallbacks makes unknown routes answer 404 and known routes called
+// with the wrong method answer 405 (with Allow), both in the ginx.Response envelope.
+func RegisterFallbacks(router *gin.Engine) {
+	router.HandleMethodNotAllowed = true
+	router.NoRoute(noRoute)
+	router.NoMethod(noMethod(router))
+}
+
+func noRoute(c *gin.Context) {
+	c.JSON(http.StatusNotFound, ginx.ErrorResponseWithCode("handler: route not found", ErrorCodeRouteNotFound))
+}
+
+func noMethod(router *gin.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// As rotas são lidas na hora: todas já estão registradas quando chega uma requisição
+		if allowed := allowedMethods(router.Routes(), c.Request.URL.Path); len(allowed) > 0 {
+			c.Header("Allow", strings.Join(allowed, ", "))
+		}
+		c.JSON(http.StatusMethodNotAllowed, ginx.ErrorResponseWithCode("handler: method not allowed", ErrorCodeMethodNotAllowed))
+	}
+}
+
+// allowedMethods lists, sorted, the methods registered for a route matching path.
+func allowedMethods(routes gin.RoutesInfo, path string) []string {
+	seen := map[string]bool{}
+	for _, route := range routes {
+		if routeMatches(route.Path, path) {
+			seen[route.Method] = true
+		}
+	}
+
+	methods := make([]string, 0, len(seen))
+	for method := range seen {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	return methods
+}
+
+// routeMatches compares a gin pattern (with :param and *wildcard segments)
+// against a request path.
+func routeMatches(pattern, path string) bool {
+	patternParts := strings.Split(strings.Trim(pattern, "/"), "/")
+	pathParts := strings.Split(strings.Trim(path, "/"), "/")
+
+	for i, part := range patternParts {
+		if strings.HasPrefix(part, "*") {
+			return true
+		}
+		if i >= len(pathParts) {
+			return false
+		}
+		if !strings.HasPrefix(part, ":") && part != pathParts[i] {
+			return false
+		}
+	}
+	return len(patternParts) == len(pathParts)
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/moura95/backend-challenge/internal/interfaces/http/ginx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupFallbackRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	account := router.Group("/api/account")
+	account.GET("/me", ok)
+	account.PUT("/me", ok)
+	account.PATCH("/me", ok)
+	account.DELETE("/me", ok)
+	router.GET("/api/admin/emails/:id", ok)
+	router.GET("/swagger/*any", ok)
+
+	RegisterFallbacks(router)
+	return router
+}
+
+func decodeFallbackResponse(t *testing.T, recorder *httptest.ResponseRecorder) ginx.Response {
+	t.Helper()
+
+	assert.Contains(t, recorder.Header().Get("Content-Type"), "application/json")
+	var response ginx.Response
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	return response
+}
+
+func TestRegisterFallbacks(t *testing.T) {
+	router := setupFallbackRouter()
+
+	t.Run("wrong method on a known route returns JSON 405 with Allow", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest("POST", "/api/account/me", nil))
+
+		assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+		assert.Equal(t, "DELETE, GET, PATCH, PUT", recorder.Header().Get("Allow"))
+
+		response := decodeFallbackResponse(t, recorder)
+		assert.Equal(t, "handler: method not allowed", response.Error)
+		assert.Equal(t, ErrorCodeMethodNotAllowed, response.Code)
+	})
+
+	t.Run("Allow matches parameterized routes", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest("DELETE", "/api/admin/emails/123", nil))
+
+		assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+		assert.Equal(t, "GET", recorder.Header().Get("Allow"))
+	})
+
+	t.Run("unknown route returns JSON 404", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest("GET", "/api/unknown", nil))
+
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+		assert.Empty(t, recorder.Header().Get("Allow"))
+
+		response := decodeFallbackResponse(t, recorder)
+		assert.Equal(t, "handler: route not found", response.Error)
+		assert.Equal(t, ErrorCodeRouteNotFound, response.Code)
+	})
+
+	t.Run("registered methods are unaffected", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest("PATCH", "/api/account/me", nil))
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+	})
+}
+
+func TestRouteMatches(t *testing.T) {
+	testCases := []struct {
+		pattern string
+		path    string
+		matches bool
+	}{
+		{"/api/account/me", "/api/account/me", true},
+		{"/api/account/me", "/api/account/me/", true},
+		{"/api/account/me", "/api/account", false},
+		{"/api/account/me", "/api/account/me/extra", false},
+		{"/api/admin/emails/:id", "/api/admin/emails/abc", true},
+		{"/api/admin/emails/:id", "/api/admin/emails", false},
+		{"/swagger/*any", "/swagger/index.html", true},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.matches, routeMatches(tc.pattern, tc.path), "%s vs %s", tc.pattern, tc.path)
+	}
+}
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/moura95/backend-challenge/internal/infra/buildinfo"
+	"github.com/moura95/backend-challenge/internal/interfaces/http/ginx"
+)
+
+const (
+	dependencyUp       = "up"
+	dependencyDown     = "down"
+	backlogExceeded    = "backlogged"
+	readinessReady     = "ready"
+	readinessDegraded  = "degraded"
+	readinessNotReady  = "unavailable"
+	readinessCheckTime = 2 * time.Second
+)
+
+type DBPinger interface {
+	PingContext(ctx context.Context) error
+}
+
+type BrokerStatus interface {
+	IsConnected() bool
+}
+
+// PendingEmailCounter reports how many emails are waiting to be sent.
+type PendingEmailCounter interface {
+	CountPending(ctx context.Context) (int, error)
+}
+
+type HealthHandler struct {
+	db     DBPinger
+	broker BrokerStatus
+
+	// Opcional: acima de backlogThreshold emails pendentes a API fica degradada
+	pendingEmails    PendingEmailCounter
+	backlogThreshold int
+}
+
+type ReadinessResponse struct {
+	Status       string            `json:"status"`
+	Dependencies map[string]string `json:"dependencies"`
+	// Set when the email backlog check is configured
+	PendingEmails *int `json:"pending_emails,omitempty"`
+}
+
+func NewHealthHandler(db DBPinger, broker BrokerStatus) *HealthHandler {
+	return &HealthHandler{
+		db:     db,
+		broker: broker,
+	}
+}
+
+// WithEmailBacklog inclui no /readyz a contagem de emails pendentes e marca a
+// API como degradada quando ela passa de threshold. Threshold <= 0 desativa.
+func (h *HealthHandler) WithEmailBacklog(counter PendingEmailCounter, threshold int) *HealthHandler {
+	if threshold > 0 {
+		h.pendingEmails = counter
+		h.backlogThreshold = threshold
+	}
+	return h
+}
+
+// @Summary Liveness check
+// @Tags system
+// @Success 204 "No content"
+// @Router /healthz [get]
+func (h *HealthHandler) Healthz(c *gin.Context) {
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Build version
+// @Description Version, git commit and build time of the running binary, plus the Go runtime version
+// @Tags system
+// @Produce json
+// @Produce application/vnd.backend.v1+json
+// @Success 200 {object} ginx.Response{data=buildinfo.Info}
+// @Failure 406 {object} ginx.Response
+// @Router /version [get]
+func (h *HealthHandler) Version(c *gin.Context) {
+	negotiation, ok := negotiateVersion(c, "handler: get version failed", ginx.APIVersion1)
+	if !ok {
+		return
+	}
+
+	negotiation.Render(c, http.StatusOK, ginx.Serializers{
+		ginx.APIVersion1: func() any { return ginx.SuccessResponse(buildinfo.Get()) },
+	})
+}
+
+// @Summary Readiness check
+// @Description Checks database (required), RabbitMQ (optional: down only degrades) and, when configured, the pending email backlog (over the threshold only degrades)
+// @Tags system
+// @Produce json
+// @Success 200 {object} ginx.Response{data=handlers.ReadinessResponse}
+// @Failure 503 {object} ginx.Response{data=handlers.ReadinessResponse}
+// @Router /readyz [get]
+func (h *HealthHandler) Readyz(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), readinessCheckTime)
+	defer cancel()
+
+	response := ReadinessResponse{
+		Status:       readinessReady,
+		Dependencies: map[string]string{},
+	}
+
+	// Banco é obrigatório
+	if err := h.db.PingContext(ctx); err != nil {
+		response.Dependencies["database"] = dependencyDown
+		response.Status = readinessNotReady
+	} else {
+		response.Dependencies["database"] = dependencyUp
+	}
+
+	// RabbitMQ é opcional: a API segue funcionando sem mensageria
+	if h.broker != nil && h.broker.IsConnected() {
+		response.Dependencies["rabbitmq"] = dependencyUp
+	} else {
+		response.Dependencies["rabbitmq"] = dependencyDown
+		if response.Status == readinessReady {
+			response.Status = readinessDegraded
+		}
+	}
+
+	// Fila de emails acumulando: a API atende, mas os envios estão atrasados
+	if h.pendingEmails != nil && response.Dependencies["database"] == dependencyUp {
+		pending, err := h.pendingEmails.CountPending(ctx)
+		switch {
+		case err != nil:
+			response.Dependencies["email_backlog"] = dependencyDown
+		case pending > h.backlogThreshold:
+			response.PendingEmails = &pending
+			response.Dependencies["email_backlog"] = backlogExceeded
+		default:
+			response.PendingEmails = &pending
+			response.Dependencies["email_backlog"] = dependencyUp
+		}
+		if response.Dependencies["email_backlog"] != dependencyUp && response.Status == readinessReady {
+			response.Status = readinessDegraded
+		}
+	}
+
+	if response.Status == readinessNotReady {
+		c.JSON(http.StatusServiceUnavailable, ginx.Response{
+			Error: "handler: readiness failed: required dependency unavailable",
+			Data:  response,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, ginx.SuccessResponse(response))
+}
@@ -0,0 +1,247 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/moura95/backend-challenge/internal/interfaces/http/ginx"
+)
+
+type fakePinger struct {
+	err error
+}
+
+func (f fakePinger) PingContext(ctx context.Context) error {
+	return f.err
+}
+
+type fakeBroker struct {
+	connected bool
+}
+
+func (f fakeBroker) IsConnected() bool {
+	return f.connected
+}
+
+// fakePendingCounter reports a fixed number of pending emails.
+type fakePendingCounter struct {
+	pending int
+	err     error
+}
+
+func (f fakePendingCounter) CountPending(ctx context.Context) (int, error) {
+	return f.pending, f.err
+}
+
+func performReadiness(t *testing.T, handler *HealthHandler) (int, ReadinessResponse, ginx.Response) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/healthz", handler.Healthz)
+	router.GET("/readyz", handler.Readyz)
+
+	req := httptest.NewRequest("GET", "/readyz", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	var response ginx.Response
+	err := json.Unmarshal(recorder.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(response.Data)
+	require.NoError(t, err)
+
+	var readiness ReadinessResponse
+	err = json.Unmarshal(data, &readiness)
+	require.NoError(t, err)
+
+	return recorder.Code, readiness, response
+}
+
+func TestHealthHandler_Readyz(t *testing.T) {
+	t.Run("should be ready when all dependencies are up", func(t *testing.T) {
+		handler := NewHealthHandler(fakePinger{}, fakeBroker{connected: true})
+
+		code, readiness, _ := performReadiness(t, handler)
+
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "ready", readiness.Status)
+		assert.Equal(t, "up", readiness.Dependencies["database"])
+		assert.Equal(t, "up", readiness.Dependencies["rabbitmq"])
+	})
+
+	t.Run("should be degraded when rabbitmq is down", func(t *testing.T) {
+		handler := NewHealthHandler(fakePinger{}, fakeBroker{connected: false})
+
+		code, readiness, _ := performReadiness(t, handler)
+
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "degraded", readiness.Status)
+		assert.Equal(t, "up", readiness.Dependencies["database"])
+		assert.Equal(t, "down", readiness.Dependencies["rabbitmq"])
+	})
+
+	t.Run("should be degraded when rabbitmq is not configured", func(t *testing.T) {
+		handler := NewHealthHandler(fakePinger{}, nil)
+
+		code, readiness, _ := performReadiness(t, handler)
+
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "degraded", readiness.Status)
+	})
+
+	t.Run("should be unavailable when database is down", func(t *testing.T) {
+		handler := NewHealthHandler(fakePinger{err: errors.New("connection refused")}, fakeBroker{connected: true})
+
+		code, readiness, response := performReadiness(t, handler)
+
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, "unavailable", readiness.Status)
+		assert.Equal(t, "down", readiness.Dependencies["database"])
+		assert.Equal(t, "up", readiness.Dependencies["rabbitmq"])
+		assert.Contains(t, response.Error, "required dependency unavailable")
+	})
+}
+
+func TestHealthHandler_ReadyzEmailBacklog(t *testing.T) {
+	t.Run("should report the pending count while under the threshold", func(t *testing.T) {
+		handler := NewHealthHandler(fakePinger{}, fakeBroker{connected: true}).
+			WithEmailBacklog(fakePendingCounter{pending: 10}, 100)
+
+		code, readiness, _ := performReadiness(t, handler)
+
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "ready", readiness.Status)
+		assert.Equal(t, "up", readiness.Dependencies["email_backlog"])
+		require.NotNil(t, readiness.PendingEmails)
+		assert.Equal(t, 10, *readiness.PendingEmails)
+	})
+
+	t.Run("should be degraded when pending emails exceed the threshold", func(t *testing.T) {
+		handler := NewHealthHandler(fakePinger{}, fakeBroker{connected: true}).
+			WithEmailBacklog(fakePendingCounter{pending: 101}, 100)
+
+		code, readiness, _ := performReadiness(t, handler)
+
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "degraded", readiness.Status)
+		assert.Equal(t, "backlogged", readiness.Dependencies["email_backlog"])
+		require.NotNil(t, readiness.PendingEmails)
+		assert.Equal(t, 101, *readiness.PendingEmails)
+	})
+
+	t.Run("should be degraded when the backlog cannot be counted", func(t *testing.T) {
+		handler := NewHealthHandler(fakePinger{}, fakeBroker{connected: true}).
+			WithEmailBacklog(fakePendingCounter{err: errors.New("query timeout")}, 100)
+
+		code, readiness, _ := performReadiness(t, handler)
+
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "degraded", readiness.Status)
+		assert.Equal(t, "down", readiness.Dependencies["email_backlog"])
+		assert.Nil(t, readiness.PendingEmails)
+	})
+
+	t.Run("should skip the check when the database is down or it is disabled", func(t *testing.T) {
+		handler := NewHealthHandler(fakePinger{err: errors.New("connection refused")}, fakeBroker{connected: true}).
+			WithEmailBacklog(fakePendingCounter{pending: 500}, 100)
+
+		code, readiness, _ := performReadiness(t, handler)
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.NotContains(t, readiness.Dependencies, "email_backlog")
+
+		handler = NewHealthHandler(fakePinger{}, fakeBroker{connected: true}).
+			WithEmailBacklog(fakePendingCounter{pending: 500}, 0)
+
+		code, readiness, _ = performReadiness(t, handler)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "ready", readiness.Status)
+		assert.Nil(t, readiness.PendingEmails)
+	})
+}
+
+func TestHealthHandler_Healthz(t *testing.T) {
+	t.Run("should return no content", func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		router.GET("/healthz", NewHealthHandler(fakePinger{}, nil).Healthz)
+
+		req := httptest.NewRequest("GET", "/healthz", nil)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusNoContent, recorder.Code)
+	})
+}
+
+func TestHealthHandler_Version(t *testing.T) {
+	t.Run("should report build metadata with unknown defaults", func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		router.GET("/version", NewHealthHandler(fakePinger{}, nil).Version)
+
+		req := httptest.NewRequest("GET", "/version", nil)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+
+		require.Equal(t, http.StatusOK, recorder.Code)
+
+		var response ginx.Response
+		err := json.Unmarshal(recorder.Body.Bytes(), &response)
+		require.NoError(t, err)
+
+		data, ok := response.Data.(map[string]interface{})
+		require.True(t, ok)
+		for _, key := range []string{"version", "commit", "build_time", "go_version"} {
+			assert.Contains(t, data, key)
+			assert.NotEmpty(t, data[key])
+		}
+		assert.Equal(t, "unknown", data["version"])
+		assert.Equal(t, runtime.Version(), data["go_version"])
+	})
+
+	t.Run("should negotiate the response version from Accept", func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		router.GET("/version", NewHealthHandler(fakePinger{}, nil).Version)
+
+		get := func(accept string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest("GET", "/version", nil)
+			req.Header.Set("Accept", accept)
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, req)
+			return recorder
+		}
+
+		// Padrão: mesmo envelope e Content-Type de sempre
+		recorder := get("application/json")
+		require.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "application/json; charset=utf-8", recorder.Header().Get("Content-Type"))
+
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+		assert.Equal(t, "", body["error"])
+		assert.Contains(t, body["data"], "go_version")
+
+		pinned := get("application/vnd.backend.v1+json")
+		require.Equal(t, http.StatusOK, pinned.Code)
+		assert.Equal(t, "application/vnd.backend.v1+json", pinned.Header().Get("Content-Type"))
+		assert.JSONEq(t, recorder.Body.String(), pinned.Body.String())
+
+		unknown := get("application/vnd.backend.v9+json")
+		require.Equal(t, http.StatusNotAcceptable, unknown.Code)
+
+		var response ginx.Response
+		require.NoError(t, json.Unmarshal(unknown.Body.Bytes(), &response))
+		assert.Equal(t, ErrorCodeNotAcceptable, response.Code)
+		assert.Contains(t, response.Error, "no acceptable response version")
+	})
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	adminUC "github.com/moura95/backend-challenge/internal/application/usecases/admin"
+	"github.com/moura95/backend-challenge/internal/interfaces/http/ginx"
+)
+
+type LoginEventsHandler struct {
+	listLoginEventsUseCase *adminUC.ListLoginEventsUseCase
+}
+
+func NewLoginEventsHandler(listLoginEventsUC *adminUC.ListLoginEventsUseCase) *LoginEventsHandler {
+	return &LoginEventsHandler{
+		listLoginEventsUseCase: listLoginEventsUC,
+	}
+}
+
+// @Summary List a user's login attempts
+// @Description Audit trail of signin attempts for the user, most recent first (admin only)
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "User ID"
+// @Param limit query int false "Maximum events to return (default 50, max 500)"
+// @Success 200 {object} ginx.Response{data=[]github_com_moura95_backend-challenge_internal_domain_user.LoginEvent}
+// @Failure 400 {object} ginx.Response
+// @Failure 401 {object} ginx.Response
+// @Failure 403 {object} ginx.Response
+// @Router /admin/users/{id}/logins [get]
+func (h *LoginEventsHandler) ListLoginEvents(c *gin.Context) {
+	limit := 0 // zero uses the use case default
+	if value := c.Query("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 {
+			err = fmt.Errorf("limit must be a positive integer")
+			c.JSON(http.StatusBadRequest, errorResponse(fmt.Sprintf("handler: list login events failed: %v", err), err))
+			return
+		}
+		limit = parsed
+	}
+
+	events, err := h.listLoginEventsUseCase.Execute(c.Request.Context(), c.Param("id"), limit)
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		c.JSON(statusCode, errorResponse(fmt.Sprintf("handler: list login events failed: %v", err), err))
+		return
+	}
+
+	c.JSON(http.StatusOK, ginx.SuccessResponse(events))
+}
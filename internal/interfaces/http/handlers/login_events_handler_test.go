@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	authUC "github.com/moura95/backend-challenge/internal/application/usecases/auth"
+	userDomain "github.com/moura95/backend-challenge/internal/domain/user"
+	"github.com/moura95/backend-challenge/internal/interfaces/http/ginx"
+)
+
+func TestLoginEventsHandler_ListLoginEvents(t *testing.T) {
+	server := setupUserHandlerTest(t)
+	defer server.cleanup()
+
+	adminToken, _ := createUserAndGetToken(t, server, "Admin User", "admin@example.com", "password123")
+	promoteToAdmin(t, server, "admin@example.com")
+
+	signIn := func(email, password, forwardedFor string) int {
+		body, err := json.Marshal(authUC.SignInRequest{Email: email, Password: password})
+		require.NoError(t, err)
+
+		req := httptest.NewRequest("POST", "/api/auth/signin", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("User-Agent", "login-events-test")
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		recorder := httptest.NewRecorder()
+		server.router.ServeHTTP(recorder, req)
+		return recorder.Code
+	}
+
+	decodeEvents := func(t *testing.T, body []byte) []userDomain.LoginEvent {
+		var response ginx.Response
+		require.NoError(t, json.Unmarshal(body, &response))
+
+		data, err := json.Marshal(response.Data)
+		require.NoError(t, err)
+
+		var events []userDomain.LoginEvent
+		require.NoError(t, json.Unmarshal(data, &events))
+		return events
+	}
+
+	t.Run("should list failed and successful logins with client metadata", func(t *testing.T) {
+		_, userID := createUserAndGetToken(t, server, "Audited User", "audited@example.com", "password123")
+
+		assert.Equal(t, http.StatusUnauthorized, signIn("audited@example.com", "wrongpassword", "203.0.113.7"))
+		assert.Equal(t, http.StatusOK, signIn("audited@example.com", "password123", "198.51.100.2, 10.0.0.1"))
+
+		recorder := makeAuthenticatedRequest(t, server, "GET", "/api/admin/users/"+userID+"/logins", adminToken, nil)
+		require.Equal(t, http.StatusOK, recorder.Code)
+
+		// Signup helper login plus the two above, most recent first
+		events := decodeEvents(t, recorder.Body.Bytes())
+		require.Len(t, events, 3)
+
+		assert.True(t, events[0].Success)
+		assert.Equal(t, "198.51.100.2", events[0].IPAddress)
+		assert.Equal(t, "login-events-test", events[0].UserAgent)
+
+		assert.False(t, events[1].Success)
+		assert.Equal(t, "203.0.113.7", events[1].IPAddress)
+
+		for _, event := range events {
+			require.NotNil(t, event.UserID)
+			assert.Equal(t, userID, event.UserID.String())
+		}
+
+		recorder = makeAuthenticatedRequest(t, server, "GET", "/api/admin/users/"+userID+"/logins?limit=1", adminToken, nil)
+		require.Equal(t, http.StatusOK, recorder.Code)
+		assert.Len(t, decodeEvents(t, recorder.Body.Bytes()), 1)
+	})
+
+	t.Run("should return an empty list for a user without logins", func(t *testing.T) {
+		recorder := makeAuthenticatedRequest(t, server, "GET", "/api/admin/users/"+uuid.NewString()+"/logins", adminToken, nil)
+		require.Equal(t, http.StatusOK, recorder.Code)
+		assert.Empty(t, decodeEvents(t, recorder.Body.Bytes()))
+	})
+
+	t.Run("should reject invalid input", func(t *testing.T) {
+		recorder := makeAuthenticatedRequest(t, server, "GET", "/api/admin/users/not-a-uuid/logins", adminToken, nil)
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+		recorder = makeAuthenticatedRequest(t, server, "GET", "/api/admin/users/"+uuid.NewString()+"/logins?limit=0", adminToken, nil)
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+
+	t.Run("should forbid regular users", func(t *testing.T) {
+		token, userID := createUserAndGetToken(t, server, "Regular User", "regular@example.com", "password123")
+
+		recorder := makeAuthenticatedRequest(t, server, "GET", "/api/admin/users/"+userID+"/logins", token, nil)
+		assert.Equal(t, http.StatusForbidden, recorder.Code)
+	})
+}
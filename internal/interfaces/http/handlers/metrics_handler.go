@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/moura95/backend-challenge/internal/infra/metrics"
+	"github.com/moura95/backend-challenge/internal/interfaces/http/ginx"
+)
+
+// metricsContentType is the Prometheus text exposition format.
+const metricsContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+type MetricsHandler struct {
+	registry *metrics.Registry
+}
+
+func NewMetricsHandler(registry *metrics.Registry) *MetricsHandler {
+	return &MetricsHandler{registry: registry}
+}
+
+// @Summary Metrics
+// @Description Process metrics (e.g. email consumer latency per type) in the Prometheus text format
+// @Tags system
+// @Produce plain
+// @Success 200 {string} string "Prometheus metrics"
+// @Router /metrics [get]
+func (h *MetricsHandler) Metrics(c *gin.Context) {
+	var body bytes.Buffer
+	if err := h.registry.WriteText(&body); err != nil {
+		c.JSON(http.StatusInternalServerError, ginx.ErrorResponse("handler: metrics failed: "+err.Error()))
+		return
+	}
+
+	c.Data(http.StatusOK, metricsContentType, body.Bytes())
+}
@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/moura95/backend-challenge/internal/infra/metrics"
+)
+
+func TestMetricsHandler_Metrics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	registry := metrics.NewRegistry()
+	registry.Histogram("email_consumer_processing_seconds", "Processing time.", "type", nil).Observe("welcome", 0.2)
+
+	router := gin.New()
+	router.GET("/metrics", NewMetricsHandler(registry).Metrics)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, metricsContentType, recorder.Header().Get("Content-Type"))
+	assert.Contains(t, recorder.Body.String(), `email_consumer_processing_seconds_count{type="welcome"} 1`)
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	authUC "github.com/moura95/backend-challenge/internal/application/usecases/auth"
+	userUC "github.com/moura95/backend-challenge/internal/application/usecases/user"
+	"github.com/moura95/backend-challenge/internal/domain/user"
+	"github.com/moura95/backend-challenge/internal/infra/logging"
+	"github.com/moura95/backend-challenge/internal/infra/security/jwt"
+	"github.com/moura95/backend-challenge/internal/interfaces/http/middlewares"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// deletableUserRepository records deletions of its single user.
+type deletableUserRepository struct {
+	cookieUserRepository
+	deleted bool
+}
+
+func (r *deletableUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if id != r.user.ID {
+		return user.ErrUserNotFound
+	}
+	r.deleted = true
+	return nil
+}
+
+func TestUserHandler_DeleteProfileNoContent(t *testing.T) {
+	existing, err := user.NewUser("John Doe", "delete@example.com", "password123")
+	require.NoError(t, err)
+
+	tokenMaker, err := jwt.NewPasetoMaker("12345678901234567890123456789012")
+	require.NoError(t, err)
+
+	userRepo := &deletableUserRepository{cookieUserRepository: cookieUserRepository{user: existing}}
+	verifyTokenUC := authUC.NewVerifyTokenUseCase(userRepo, &cookieTokenRepository{revoked: map[uuid.UUID]bool{}}, tokenMaker)
+	handler := NewUserHandler(nil, nil, nil, userUC.NewDeleteUserUseCase(userRepo), nil, nil)
+
+	// Mesma cadeia de middlewares do servidor: nenhum deles deve escrever corpo
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middlewares.RequestID())
+	router.Use(middlewares.Tracing())
+	router.Use(middlewares.RequestLogger(zap.NewNop().Sugar()))
+	router.Use(middlewares.Recovery(zap.NewNop().Sugar()))
+	router.DELETE("/account/me", middlewares.AuthMiddleware(verifyTokenUC), handler.DeleteProfile)
+
+	accessToken, _, err := tokenMaker.CreateToken(existing.ID, existing.TokenVersion, time.Minute)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("DELETE", "/account/me", nil)
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set(ConfirmPasswordHeader, "password123")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusNoContent, recorder.Code)
+	assert.Empty(t, recorder.Body.String())
+	assert.Empty(t, recorder.Header().Get("Content-Type"))
+	assert.NotEmpty(t, recorder.Header().Get(logging.RequestIDHeader))
+	assert.True(t, userRepo.deleted)
+}
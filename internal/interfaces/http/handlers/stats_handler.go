@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	adminUC "github.com/moura95/backend-challenge/internal/application/usecases/admin"
+	"github.com/moura95/backend-challenge/internal/interfaces/http/ginx"
+)
+
+type StatsHandler struct {
+	getStatsUseCase *adminUC.GetStatsUseCase
+}
+
+func NewStatsHandler(getStatsUC *adminUC.GetStatsUseCase) *StatsHandler {
+	return &StatsHandler{
+		getStatsUseCase: getStatsUC,
+	}
+}
+
+// @Summary Get user and email stats
+// @Description Total users, signups in the last 24h/7d/30d and emails by status (admin only)
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} ginx.Response{data=github_com_moura95_backend-challenge_internal_application_usecases_admin.StatsResponse}
+// @Failure 401 {object} ginx.Response
+// @Failure 403 {object} ginx.Response
+// @Router /admin/stats [get]
+func (h *StatsHandler) GetStats(c *gin.Context) {
+	stats, err := h.getStatsUseCase.Execute(c.Request.Context())
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		c.JSON(statusCode, errorResponse(fmt.Sprintf("handler: get stats failed: %v", err), err))
+		return
+	}
+
+	c.JSON(http.StatusOK, ginx.SuccessResponse(stats))
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	adminUC "github.com/moura95/backend-challenge/internal/application/usecases/admin"
+	"github.com/moura95/backend-challenge/internal/interfaces/http/ginx"
+)
+
+func TestStatsHandler_GetStats(t *testing.T) {
+	server := setupUserHandlerTest(t)
+	defer server.cleanup()
+
+	adminToken, _ := createUserAndGetToken(t, server, "Admin User", "admin@example.com", "password123")
+	promoteToAdmin(t, server, "admin@example.com")
+
+	t.Run("should return aggregates for admin", func(t *testing.T) {
+		createUserAndGetToken(t, server, "Stats User", "stats@example.com", "password123")
+
+		recorder := makeAuthenticatedRequest(t, server, "GET", "/api/admin/stats", adminToken, nil)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+
+		var response ginx.Response
+		err := json.Unmarshal(recorder.Body.Bytes(), &response)
+		require.NoError(t, err)
+
+		statsData, err := json.Marshal(response.Data)
+		require.NoError(t, err)
+
+		var stats adminUC.StatsResponse
+		err = json.Unmarshal(statsData, &stats)
+		require.NoError(t, err)
+
+		// Both signups happened just now
+		assert.Equal(t, 2, stats.Users.Total)
+		assert.Equal(t, 2, stats.Users.CreatedLast24h)
+		assert.Equal(t, 2, stats.Users.CreatedLast7d)
+		assert.Equal(t, 2, stats.Users.CreatedLast30d)
+
+		// Each signup queued one welcome email
+		assert.Equal(t, 2, stats.Emails.Pending+stats.Emails.Sent+stats.Emails.Failed)
+	})
+
+	t.Run("should forbid regular users", func(t *testing.T) {
+		token, _ := createUserAndGetToken(t, server, "Regular User", "regular@example.com", "password123")
+
+		recorder := makeAuthenticatedRequest(t, server, "GET", "/api/admin/stats", token, nil)
+		assert.Equal(t, http.StatusForbidden, recorder.Code)
+	})
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	adminUC "github.com/moura95/backend-challenge/internal/application/usecases/admin"
+	"github.com/moura95/backend-challenge/internal/interfaces/http/ginx"
+)
+
+type UserBulkDeleteHandler struct {
+	bulkDeleteUsersUseCase *adminUC.BulkDeleteUsersUseCase
+}
+
+func NewUserBulkDeleteHandler(bulkDeleteUsersUC *adminUC.BulkDeleteUsersUseCase) *UserBulkDeleteHandler {
+	return &UserBulkDeleteHandler{
+		bulkDeleteUsersUseCase: bulkDeleteUsersUC,
+	}
+}
+
+// @Summary Delete users in batch
+// @Description Soft-delete up to 100 users at once in a single transaction. Every ID is validated before anything is deleted; unknown or already deleted IDs are reported as not_found (admin only)
+// @Tags admin
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body github_com_moura95_backend-challenge_internal_application_usecases_admin.BulkDeleteUsersRequest true "User IDs to delete"
+// @Success 200 {object} ginx.Response{data=github_com_moura95_backend-challenge_internal_application_usecases_admin.BulkDeleteUsersResponse}
+// @Failure 400 {object} ginx.Response
+// @Failure 401 {object} ginx.Response
+// @Failure 403 {object} ginx.Response
+// @Router /admin/users/bulk-delete [post]
+func (h *UserBulkDeleteHandler) BulkDeleteUsers(c *gin.Context) {
+	var req adminUC.BulkDeleteUsersRequest
+
+	if err := ginx.ParseJSON(c, &req); err != nil {
+		c.JSON(bindErrorResponse("handler: bulk delete users failed", err))
+		return
+	}
+
+	result, err := h.bulkDeleteUsersUseCase.Execute(c.Request.Context(), req)
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		c.JSON(statusCode, errorResponse(fmt.Sprintf("handler: bulk delete users failed: %v", err), err))
+		return
+	}
+
+	c.JSON(http.StatusOK, ginx.SuccessResponse(result))
+}
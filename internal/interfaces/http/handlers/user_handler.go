@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	userUC "github.com/moura95/backend-challenge/internal/application/usecases/user"
@@ -15,8 +17,10 @@ import (
 type UserHandler struct {
 	getUserProfileUseCase *userUC.GetUserProfileUseCase
 	updateUserUseCase     *userUC.UpdateUserUseCase
+	patchUserUseCase      *userUC.PatchUserUseCase
 	deleteUserUseCase     *userUC.DeleteUserUseCase
 	listUsersUseCase      *userUC.ListUsersUseCase
+	changePasswordUseCase *userUC.ChangePasswordUseCase
 }
 
 type UpdateUserRequest struct {
@@ -24,23 +28,48 @@ type UpdateUserRequest struct {
 	Email string `json:"email"`
 }
 
+// PatchUserRequest distinguishes omitted fields (nil, left unchanged) from
+// fields sent with a value, which are validated and applied even when empty.
+type PatchUserRequest struct {
+	Name  *string `json:"name"`
+	Email *string `json:"email"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required"`
+}
+
+// ConfirmPasswordHeader carries the current password for account deletion
+// when the client cannot send a body with DELETE
+const ConfirmPasswordHeader = "X-Confirm-Password"
+
+type DeleteProfileRequest struct {
+	Password string `json:"password"`
+}
+
 type ListUsersResponse struct {
-	Users []*userDomain.UserResponse `json:"users"`
-	Total int                        `json:"total"`
-	Page  int                        `json:"page"`
+	Users      []*userDomain.UserResponse `json:"users"`
+	Total      int                        `json:"total"`
+	Page       int                        `json:"page"`
+	NextCursor string                     `json:"next_cursor"`
 }
 
 func NewUserHandler(
 	getUserProfileUC *userUC.GetUserProfileUseCase,
 	updateUserUC *userUC.UpdateUserUseCase,
+	patchUserUC *userUC.PatchUserUseCase,
 	deleteUserUC *userUC.DeleteUserUseCase,
 	listUsersUC *userUC.ListUsersUseCase,
+	changePasswordUC *userUC.ChangePasswordUseCase,
 ) *UserHandler {
 	return &UserHandler{
 		getUserProfileUseCase: getUserProfileUC,
 		updateUserUseCase:     updateUserUC,
+		patchUserUseCase:      patchUserUC,
 		deleteUserUseCase:     deleteUserUC,
 		listUsersUseCase:      listUsersUC,
+		changePasswordUseCase: changePasswordUC,
 	}
 }
 
@@ -49,9 +78,11 @@ func NewUserHandler(
 // @Tags user
 // @Security BearerAuth
 // @Produce json
+// @Produce application/vnd.backend.v1+json
 // @Success 200 {object} ginx.Response{data=github_com_moura95_backend-challenge_internal_domain_user.UserResponse}
 // @Failure 401 {object} ginx.Response
 // @Failure 404 {object} ginx.Response
+// @Failure 406 {object} ginx.Response
 // @Router /account/me [get]
 func (h *UserHandler) GetProfile(c *gin.Context) {
 	userID, exists := middlewares.GetUserIDFromContext(c)
@@ -60,14 +91,21 @@ func (h *UserHandler) GetProfile(c *gin.Context) {
 		return
 	}
 
+	negotiation, ok := negotiateVersion(c, "handler: get profile failed", ginx.APIVersion1)
+	if !ok {
+		return
+	}
+
 	foundUser, err := h.getUserProfileUseCase.Execute(c.Request.Context(), userID)
 	if err != nil {
 		statusCode := getStatusCodeFromError(err)
-		c.JSON(statusCode, ginx.ErrorResponse(fmt.Sprintf("handler: get profile failed: %v", err)))
+		c.JSON(statusCode, errorResponse(fmt.Sprintf("handler: get profile failed: %v", err), err))
 		return
 	}
 
-	c.JSON(http.StatusOK, ginx.SuccessResponse(foundUser.ToResponse()))
+	negotiation.Render(c, http.StatusOK, ginx.Serializers{
+		ginx.APIVersion1: func() any { return ginx.SuccessResponse(foundUser.ToResponse()) },
+	})
 }
 
 // @Summary Update user profile
@@ -91,7 +129,7 @@ func (h *UserHandler) UpdateProfile(c *gin.Context) {
 
 	var req UpdateUserRequest
 	if err := ginx.ParseJSON(c, &req); err != nil {
-		c.JSON(http.StatusBadRequest, ginx.ErrorResponse("handler: update profile failed: invalid request format"))
+		c.JSON(bindErrorResponse("handler: update profile failed", err))
 		return
 	}
 
@@ -103,18 +141,101 @@ func (h *UserHandler) UpdateProfile(c *gin.Context) {
 	updatedUser, err := h.updateUserUseCase.Execute(c.Request.Context(), userID, updateReq)
 	if err != nil {
 		statusCode := getStatusCodeFromError(err)
-		c.JSON(statusCode, ginx.ErrorResponse(fmt.Sprintf("handler: update profile failed: %v", err)))
+		c.JSON(statusCode, errorResponse(fmt.Sprintf("handler: update profile failed: %v", err), err))
 		return
 	}
 
 	c.JSON(http.StatusOK, ginx.SuccessResponse(updatedUser.ToResponse()))
 }
 
+// @Summary Partially update user profile
+// @Description Update only the fields present in the body; omitted fields are left unchanged
+// @Tags user
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body handlers.PatchUserRequest true "Patch user request"
+// @Success 200 {object} ginx.Response{data=github_com_moura95_backend-challenge_internal_domain_user.UserResponse}
+// @Failure 400 {object} ginx.Response
+// @Failure 401 {object} ginx.Response
+// @Failure 409 {object} ginx.Response
+// @Router /account/me [patch]
+func (h *UserHandler) PatchProfile(c *gin.Context) {
+	userID, exists := middlewares.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ginx.ErrorResponse("handler: patch profile failed: user not authenticated"))
+		return
+	}
+
+	var req PatchUserRequest
+	if err := ginx.ParseJSON(c, &req); err != nil {
+		c.JSON(bindErrorResponse("handler: patch profile failed", err))
+		return
+	}
+
+	patchReq := userUC.PatchUserRequest{
+		Name:  req.Name,
+		Email: req.Email,
+	}
+
+	updatedUser, err := h.patchUserUseCase.Execute(c.Request.Context(), userID, patchReq)
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		c.JSON(statusCode, errorResponse(fmt.Sprintf("handler: patch profile failed: %v", err), err))
+		return
+	}
+
+	c.JSON(http.StatusOK, ginx.SuccessResponse(updatedUser.ToResponse()))
+}
+
+// @Summary Change password
+// @Description Change current user password after confirming the current one
+// @Tags user
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body handlers.ChangePasswordRequest true "Change password request"
+// @Success 200 {object} ginx.Response
+// @Failure 400 {object} ginx.Response
+// @Failure 401 {object} ginx.Response
+// @Router /account/password [put]
+func (h *UserHandler) ChangePassword(c *gin.Context) {
+	userID, exists := middlewares.GetUserIDFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ginx.ErrorResponse("handler: change password failed: user not authenticated"))
+		return
+	}
+
+	var req ChangePasswordRequest
+	if err := ginx.ParseJSON(c, &req); err != nil {
+		c.JSON(bindErrorResponse("handler: change password failed", err))
+		return
+	}
+
+	changeReq := userUC.ChangePasswordRequest{
+		CurrentPassword: req.CurrentPassword,
+		NewPassword:     req.NewPassword,
+	}
+
+	err := h.changePasswordUseCase.Execute(c.Request.Context(), userID, changeReq)
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		c.JSON(statusCode, errorResponse(fmt.Sprintf("handler: change password failed: %v", err), err))
+		return
+	}
+
+	c.JSON(http.StatusOK, ginx.SuccessResponse("password updated"))
+}
+
 // @Summary Delete user profile
-// @Description Delete current user account
+// @Description Delete current user account after confirming the current password, sent in the body or in the X-Confirm-Password header
 // @Tags user
 // @Security BearerAuth
+// @Accept json
+// @Param request body handlers.DeleteProfileRequest false "Current password"
+// @Param X-Confirm-Password header string false "Current password, instead of the body"
 // @Success 204 "No content"
+// @Failure 400 {object} ginx.Response
 // @Failure 401 {object} ginx.Response
 // @Failure 404 {object} ginx.Response
 // @Router /account/me [delete]
@@ -125,43 +246,86 @@ func (h *UserHandler) DeleteProfile(c *gin.Context) {
 		return
 	}
 
-	err := h.deleteUserUseCase.Execute(c.Request.Context(), userID)
+	password := c.GetHeader(ConfirmPasswordHeader)
+	if password == "" && c.Request.ContentLength != 0 {
+		var req DeleteProfileRequest
+		if err := ginx.ParseJSON(c, &req); err != nil {
+			c.JSON(bindErrorResponse("handler: delete profile failed", err))
+			return
+		}
+		password = req.Password
+	}
+
+	err := h.deleteUserUseCase.Execute(c.Request.Context(), userID, password)
 	if err != nil {
 		statusCode := getStatusCodeFromError(err)
-		c.JSON(statusCode, ginx.ErrorResponse(fmt.Sprintf("handler: delete profile failed: %v", err)))
+		c.JSON(statusCode, errorResponse(fmt.Sprintf("handler: delete profile failed: %v", err), err))
 		return
 	}
 
-	c.JSON(http.StatusNoContent, ginx.SuccessResponse(nil))
+	// 204 não tem corpo
+	c.Status(http.StatusNoContent)
 }
 
 // @Summary List users
-// @Description Get paginated list of users with optional search
+// @Description Get paginated list of users with optional search (admin only)
 // @Tags user
 // @Security BearerAuth
 // @Param page query int false "Page number" default(1)
-// @Param page_size query int false "Page size" default(10)
+// @Param page_size query int false "Page size, capped at MAX_PAGE_SIZE" default(10)
 // @Param search query string false "Search by name or email"
+// @Param include_deleted query bool false "Include soft-deleted users" default(false)
+// @Param sort query string false "Sort field" Enums(name, email, created_at) default(created_at)
+// @Param order query string false "Sort order" Enums(asc, desc) default(desc)
+// @Param cursor query string false "Opaque cursor from next_cursor; takes precedence over page"
+// @Param created_after query string false "Only users created at or after this instant (RFC3339)"
+// @Param created_before query string false "Only users created at or before this instant (RFC3339)"
 // @Produce json
 // @Success 200 {object} ginx.Response{data=handlers.ListUsersResponse}
+// @Header 200 {int} X-Total-Count "Total users matching the filters"
+// @Header 200 {int} X-Page "Current page"
+// @Header 200 {int} X-Page-Size "Effective page size"
+// @Header 200 {string} Link "RFC 5988 links: first, prev, next, last"
 // @Failure 400 {object} ginx.Response
 // @Failure 401 {object} ginx.Response
+// @Failure 403 {object} ginx.Response
 // @Router /users [get]
 func (h *UserHandler) ListUsers(c *gin.Context) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size")) // zero uses the configured default
 	search := c.Query("search")
+	includeDeleted, _ := strconv.ParseBool(c.DefaultQuery("include_deleted", "false"))
+	sortBy := c.Query("sort")
+	sortOrder := c.Query("order")
+	cursor := c.Query("cursor")
+
+	createdAfter, err := parseTimeQuery(c, "created_after")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ginx.ErrorResponse(fmt.Sprintf("handler: list users failed: %v", err)))
+		return
+	}
+	createdBefore, err := parseTimeQuery(c, "created_before")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ginx.ErrorResponse(fmt.Sprintf("handler: list users failed: %v", err)))
+		return
+	}
 
 	req := userUC.ListUsersRequest{
-		Page:     page,
-		PageSize: pageSize,
-		Search:   search,
+		Page:           page,
+		PageSize:       pageSize,
+		Search:         search,
+		IncludeDeleted: includeDeleted,
+		SortBy:         sortBy,
+		SortOrder:      sortOrder,
+		Cursor:         cursor,
+		CreatedAfter:   createdAfter,
+		CreatedBefore:  createdBefore,
 	}
 
 	result, err := h.listUsersUseCase.Execute(c.Request.Context(), req)
 	if err != nil {
 		statusCode := getStatusCodeFromError(err)
-		c.JSON(statusCode, ginx.ErrorResponse(fmt.Sprintf("handler: list users failed: %v", err)))
+		c.JSON(statusCode, errorResponse(fmt.Sprintf("handler: list users failed: %v", err), err))
 		return
 	}
 
@@ -172,10 +336,59 @@ func (h *UserHandler) ListUsers(c *gin.Context) {
 	}
 
 	response := ListUsersResponse{
-		Users: userResponses,
-		Total: result.Total,
-		Page:  result.Page,
+		Users:      userResponses,
+		Total:      result.Total,
+		Page:       result.Page,
+		NextCursor: result.NextCursor,
 	}
 
+	setPaginationHeaders(c, result.Total, result.Page, result.PageSize)
 	c.JSON(http.StatusOK, ginx.SuccessResponse(response))
 }
+
+// setPaginationHeaders exposes the page position as X-Total-Count, X-Page,
+// X-Page-Size and an RFC 5988 Link header. Links keep the request's other
+// query parameters and switch to page-based navigation.
+func setPaginationHeaders(c *gin.Context, total, page, pageSize int) {
+	lastPage := 1
+	if pageSize > 0 && total > 0 {
+		lastPage = (total + pageSize - 1) / pageSize
+	}
+
+	c.Header("X-Total-Count", strconv.Itoa(total))
+	c.Header("X-Page", strconv.Itoa(page))
+	c.Header("X-Page-Size", strconv.Itoa(pageSize))
+
+	pageURL := func(p int) string {
+		query := c.Request.URL.Query()
+		query.Del("cursor")
+		query.Set("page", strconv.Itoa(p))
+		query.Set("page_size", strconv.Itoa(pageSize))
+		return c.Request.URL.Path + "?" + query.Encode()
+	}
+
+	links := []string{fmt.Sprintf(`<%s>; rel="first"`, pageURL(1))}
+	if page > 1 {
+		links = append(links, fmt.Sprintf(`<%s>; rel="prev"`, pageURL(min(page-1, lastPage))))
+	}
+	if page < lastPage {
+		links = append(links, fmt.Sprintf(`<%s>; rel="next"`, pageURL(page+1)))
+	}
+	links = append(links, fmt.Sprintf(`<%s>; rel="last"`, pageURL(lastPage)))
+
+	c.Header("Link", strings.Join(links, ", "))
+}
+
+// parseTimeQuery reads an optional RFC3339 query parameter; missing returns nil.
+func parseTimeQuery(c *gin.Context, key string) (*time.Time, error) {
+	value := c.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: expected RFC3339", key)
+	}
+	return &parsed, nil
+}
@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
 	"time"
@@ -16,13 +18,18 @@ import (
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/modules/postgres"
 	"github.com/testcontainers/testcontainers-go/wait"
 
+	adminUC "github.com/moura95/backend-challenge/internal/application/usecases/admin"
 	authUC "github.com/moura95/backend-challenge/internal/application/usecases/auth"
+	emailUC "github.com/moura95/backend-challenge/internal/application/usecases/email"
 	userUC "github.com/moura95/backend-challenge/internal/application/usecases/user"
+	emailDomain "github.com/moura95/backend-challenge/internal/domain/email"
+	userDomain "github.com/moura95/backend-challenge/internal/domain/user"
 	"github.com/moura95/backend-challenge/internal/infra/repository/adapters"
 	"github.com/moura95/backend-challenge/internal/infra/security/jwt"
 	"github.com/moura95/backend-challenge/internal/interfaces/http/ginx"
@@ -77,22 +84,64 @@ func setupUserHandlerTest(t *testing.T) *userHandlerTestServer {
 
 	// Setup auth use cases
 	signUpUC := authUC.NewSignUpUseCase(repos.User, repos.Email, tokenMaker, nil)
-	signInUC := authUC.NewSignInUseCase(repos.User, tokenMaker)
-	verifyTokenUC := authUC.NewVerifyTokenUseCase(repos.User, tokenMaker)
+	signInUC := authUC.NewSignInUseCase(repos.User, tokenMaker).WithLoginAudit(repos.LoginEvent)
+	verifyTokenUC := authUC.NewVerifyTokenUseCase(repos.User, repos.Token, tokenMaker)
+	refreshTokenUC := authUC.NewRefreshTokenUseCase(repos.User, repos.Token, tokenMaker)
+	logoutUC := authUC.NewLogoutUseCase(repos.Token, tokenMaker)
+	requestPasswordResetUC := authUC.NewRequestPasswordResetUseCase(repos.User, repos.Email, repos.PasswordReset, nil, "")
+	resetPasswordUC := authUC.NewResetPasswordUseCase(repos.User, repos.PasswordReset)
+	verifyEmailUC := authUC.NewVerifyEmailUseCase(repos.User, repos.EmailVerification)
 
 	// Setup user use cases
 	getUserProfileUC := userUC.NewGetUserProfileUseCase(repos.User)
 	updateUserUC := userUC.NewUpdateUserUseCase(repos.User)
+	patchUserUC := userUC.NewPatchUserUseCase(repos.User)
 	deleteUserUC := userUC.NewDeleteUserUseCase(repos.User)
 	listUsersUC := userUC.NewListUsersUseCase(repos.User)
+	changePasswordUC := userUC.NewChangePasswordUseCase(repos.User)
+	exportUserDataUC := userUC.NewExportUserDataUseCase(repos.User, repos.Email)
+	listUserEmailsUC := userUC.NewListUserEmailsUseCase(repos.User, repos.Email)
+
+	// Setup email use cases
+	getEmailStatusUC := emailUC.NewGetEmailStatusUseCase(repos.Email)
+	previewEmailUC := emailUC.NewPreviewEmailUseCase()
+	searchEmailsUC := emailUC.NewSearchEmailsUseCase(repos.Email)
+	getStatsUC := adminUC.NewGetStatsUseCase(repos.User, repos.Email)
+	listLoginEventsUC := adminUC.NewListLoginEventsUseCase(repos.LoginEvent)
+
+	// Emails to undeliverable* fail, every other address is sent
+	emailSender := new(MockEmailService)
+	emailSender.On("SendEmailAuto", mock.Anything, mock.MatchedBy(func(e *emailDomain.Email) bool {
+		return strings.HasPrefix(e.To, "undeliverable")
+	})).Return(errors.New("smtp unavailable"))
+	emailSender.On("SendEmailAuto", mock.Anything, mock.Anything).Return(nil)
+	processEmailUC := emailUC.NewProcessEmailQueueUseCase(repos.Email, emailSender)
 
 	// Setup handlers
-	authHandler := NewAuthHandler(signUpUC, signInUC, verifyTokenUC)
-	userHandler := NewUserHandler(getUserProfileUC, updateUserUC, deleteUserUC, listUsersUC)
+	authHandler := NewAuthHandler(
+		signUpUC,
+		signInUC,
+		verifyTokenUC,
+		refreshTokenUC,
+		logoutUC,
+		requestPasswordResetUC,
+		resetPasswordUC,
+		verifyEmailUC,
+	)
+	userHandler := NewUserHandler(getUserProfileUC, updateUserUC, patchUserUC, deleteUserUC, listUsersUC, changePasswordUC)
+	emailStatusHandler := NewEmailStatusHandler(getEmailStatusUC)
+	emailPreviewHandler := NewEmailPreviewHandler(previewEmailUC)
+	emailSearchHandler := NewEmailSearchHandler(searchEmailsUC)
+	statsHandler := NewStatsHandler(getStatsUC)
+	loginEventsHandler := NewLoginEventsHandler(listLoginEventsUC)
+	emailProcessingHandler := NewEmailProcessingHandler(processEmailUC)
+	exportHandler := NewExportHandler(exportUserDataUC)
+	accountEmailsHandler := NewAccountEmailsHandler(listUserEmailsUC)
 
 	// Setup Gin router
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	RegisterFallbacks(router)
 
 	// Setup routes
 	api := router.Group("/api")
@@ -102,6 +151,7 @@ func setupUserHandlerTest(t *testing.T) *userHandlerTestServer {
 		{
 			auth.POST("/signup", authHandler.SignUp)
 			auth.POST("/signin", authHandler.SignIn)
+			auth.POST("/refresh", authHandler.RefreshToken)
 		}
 
 		// Protected routes
@@ -112,10 +162,25 @@ func setupUserHandlerTest(t *testing.T) *userHandlerTestServer {
 			{
 				account.GET("/me", userHandler.GetProfile)
 				account.PUT("/me", userHandler.UpdateProfile)
+				account.PATCH("/me", userHandler.PatchProfile)
 				account.DELETE("/me", userHandler.DeleteProfile)
+				account.PUT("/password", userHandler.ChangePassword)
+				account.GET("/export", exportHandler.ExportAccount)
+				account.GET("/emails", accountEmailsHandler.ListEmails)
 			}
 
-			protected.GET("/users", userHandler.ListUsers)
+			protected.GET("/users", middlewares.RequireRole(userDomain.RoleAdmin), userHandler.ListUsers)
+
+			admin := protected.Group("/admin")
+			admin.Use(middlewares.RequireRole(userDomain.RoleAdmin))
+			{
+				admin.GET("/emails", emailSearchHandler.SearchEmails)
+				admin.GET("/emails/preview", emailPreviewHandler.PreviewEmail)
+				admin.GET("/emails/:id", emailStatusHandler.GetEmailStatus)
+				admin.POST("/emails/process", emailProcessingHandler.ProcessPendingEmails)
+				admin.GET("/stats", statsHandler.GetStats)
+				admin.GET("/users/:id/logins", loginEventsHandler.ListLoginEvents)
+			}
 		}
 	}
 
@@ -146,6 +211,13 @@ func runUserHandlerMigrations(db *sqlx.DB) error {
 		name         VARCHAR(255) NOT NULL,
 		email        VARCHAR(100) NOT NULL UNIQUE,
 		password     TEXT NOT NULL,
+		verified_at  TIMESTAMPTZ,
+		role         VARCHAR(20) NOT NULL DEFAULT 'user',
+		deleted_at   TIMESTAMPTZ,
+		version      INTEGER NOT NULL DEFAULT 1,
+		status       VARCHAR(20) NOT NULL DEFAULT 'active',
+		token_version INTEGER NOT NULL DEFAULT 0,
+		last_login_at TIMESTAMPTZ,
 		created_at   TIMESTAMP NOT NULL DEFAULT NOW(),
 		updated_at   TIMESTAMP NOT NULL DEFAULT NOW()
 	);
@@ -163,7 +235,44 @@ func runUserHandlerMigrations(db *sqlx.DB) error {
 		error_msg    TEXT,
 		sent_at      TIMESTAMPTZ,
 		created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
-		updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		plain_body   TEXT NOT NULL DEFAULT '',
+		next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		cc_emails    TEXT[] NOT NULL DEFAULT '{}',
+		bcc_emails   TEXT[] NOT NULL DEFAULT '{}',
+		dedup_key    VARCHAR(255),
+		scheduled_at TIMESTAMPTZ
+	);
+	
+	-- Email attachments table
+	CREATE TABLE IF NOT EXISTS email_attachments (
+		id           BIGSERIAL PRIMARY KEY,
+		email_uuid   UUID NOT NULL,
+		filename     VARCHAR(255) NOT NULL,
+		content_type VARCHAR(255) NOT NULL,
+		content      BYTEA NOT NULL,
+		created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		FOREIGN KEY (email_uuid) REFERENCES emails(uuid) ON DELETE CASCADE
+	);
+	
+	-- Revoked tokens table
+	CREATE TABLE IF NOT EXISTS revoked_tokens (
+		token_uuid   UUID PRIMARY KEY,
+		user_uuid    UUID NOT NULL,
+		expires_at   TIMESTAMPTZ NOT NULL,
+		revoked_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	);
+	
+	-- Login events table
+	CREATE TABLE IF NOT EXISTS login_events (
+		id          BIGSERIAL PRIMARY KEY,
+		user_uuid   UUID,
+		email       VARCHAR(255) NOT NULL,
+		ip_address  VARCHAR(64) NOT NULL DEFAULT '',
+		user_agent  TEXT NOT NULL DEFAULT '',
+		success     BOOLEAN NOT NULL,
+		created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		FOREIGN KEY (user_uuid) REFERENCES users(uuid) ON DELETE CASCADE
 	);
 	
 	-- Indexes
@@ -249,6 +358,16 @@ func makeAuthenticatedRequest(t *testing.T, server *userHandlerTestServer, metho
 	return recorder
 }
 
+// confirmDeleteBody confirms account deletion for users created with password123
+var confirmDeleteBody = []byte(`{"password":"password123"}`)
+
+// Helper function to promote a user to admin
+func promoteToAdmin(t *testing.T, server *userHandlerTestServer, email string) {
+	promoteUC := userUC.NewPromoteUserUseCase(server.repos.User)
+	_, err := promoteUC.Execute(context.Background(), email)
+	require.NoError(t, err)
+}
+
 func TestUserHandler_GetProfile(t *testing.T) {
 	server := setupUserHandlerTest(t)
 	defer server.cleanup()
@@ -332,7 +451,7 @@ func TestUserHandler_GetProfile(t *testing.T) {
 
 		// Create a token that expires immediately
 		userUID, _ := uuid.Parse(userUUID.UserUUID)
-		expiredToken, _, err := server.tokenMaker.CreateToken(userUID, -1*time.Hour)
+		expiredToken, _, err := server.tokenMaker.CreateToken(userUID, 0, -1*time.Hour)
 		require.NoError(t, err)
 
 		// Try to access with expired token
@@ -530,6 +649,73 @@ func TestUserHandler_UpdateProfile(t *testing.T) {
 	})
 }
 
+func TestUserHandler_PatchProfile(t *testing.T) {
+	server := setupUserHandlerTest(t)
+	defer server.cleanup()
+
+	decodeUser := func(t *testing.T, body []byte) map[string]interface{} {
+		var response ginx.Response
+		require.NoError(t, json.Unmarshal(body, &response))
+		assert.Empty(t, response.Error)
+
+		responseData, err := json.Marshal(response.Data)
+		require.NoError(t, err)
+		var userResponse map[string]interface{}
+		require.NoError(t, json.Unmarshal(responseData, &userResponse))
+		return userResponse
+	}
+
+	t.Run("should change only the name and leave email untouched", func(t *testing.T) {
+		token, _ := createUserAndGetToken(t, server, "Patch Original", "patch@example.com", "password123")
+
+		recorder := makeAuthenticatedRequest(t, server, "PATCH", "/api/account/me", token, []byte(`{"name":"Patched Name"}`))
+		require.Equal(t, http.StatusOK, recorder.Code)
+
+		userResponse := decodeUser(t, recorder.Body.Bytes())
+		assert.Equal(t, "Patched Name", userResponse["name"])
+		assert.Equal(t, "patch@example.com", userResponse["email"])
+
+		var dbUser struct {
+			Name  string `db:"name"`
+			Email string `db:"email"`
+		}
+		err := server.db.Get(&dbUser, "SELECT name, email FROM users WHERE email = $1", "patch@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, "Patched Name", dbUser.Name)
+	})
+
+	t.Run("should be a no-op when no field is present", func(t *testing.T) {
+		token, _ := createUserAndGetToken(t, server, "Patch Noop", "patchnoop@example.com", "password123")
+
+		var versionBefore int
+		err := server.db.Get(&versionBefore, "SELECT version FROM users WHERE email = $1", "patchnoop@example.com")
+		require.NoError(t, err)
+
+		recorder := makeAuthenticatedRequest(t, server, "PATCH", "/api/account/me", token, []byte(`{}`))
+		require.Equal(t, http.StatusOK, recorder.Code)
+
+		userResponse := decodeUser(t, recorder.Body.Bytes())
+		assert.Equal(t, "Patch Noop", userResponse["name"])
+		assert.Equal(t, "patchnoop@example.com", userResponse["email"])
+
+		var versionAfter int
+		err = server.db.Get(&versionAfter, "SELECT version FROM users WHERE email = $1", "patchnoop@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, versionBefore, versionAfter)
+	})
+
+	t.Run("should validate a present empty name", func(t *testing.T) {
+		token, _ := createUserAndGetToken(t, server, "Patch Empty", "patchempty@example.com", "password123")
+
+		recorder := makeAuthenticatedRequest(t, server, "PATCH", "/api/account/me", token, []byte(`{"name":""}`))
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+		var response ginx.Response
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		assert.Contains(t, response.Fields, "name")
+	})
+}
+
 func TestUserHandler_DeleteProfile(t *testing.T) {
 	server := setupUserHandlerTest(t)
 	defer server.cleanup()
@@ -545,13 +731,14 @@ func TestUserHandler_DeleteProfile(t *testing.T) {
 		assert.Equal(t, 1, userCount)
 
 		// Make authenticated delete request
-		recorder := makeAuthenticatedRequest(t, server, "DELETE", "/api/account/me", token, nil)
+		recorder := makeAuthenticatedRequest(t, server, "DELETE", "/api/account/me", token, confirmDeleteBody)
 
 		// Assert HTTP response
 		assert.Equal(t, http.StatusNoContent, recorder.Code)
+		assert.Empty(t, recorder.Body.String())
 
-		// Verify user was deleted
-		err = server.db.Get(&userCount, "SELECT COUNT(*) FROM users WHERE email = $1", "delete@example.com")
+		// Verify user was soft-deleted
+		err = server.db.Get(&userCount, "SELECT COUNT(*) FROM users WHERE email = $1 AND deleted_at IS NULL", "delete@example.com")
 		require.NoError(t, err)
 		assert.Equal(t, 0, userCount)
 
@@ -564,7 +751,7 @@ func TestUserHandler_DeleteProfile(t *testing.T) {
 		token, _ := createUserAndGetToken(t, server, "Delete Again", "deleteagain@example.com", "password123")
 
 		// Delete user first time
-		recorder := makeAuthenticatedRequest(t, server, "DELETE", "/api/account/me", token, nil)
+		recorder := makeAuthenticatedRequest(t, server, "DELETE", "/api/account/me", token, confirmDeleteBody)
 		assert.Equal(t, http.StatusNoContent, recorder.Code)
 
 		// Try to delete again with same token
@@ -581,6 +768,58 @@ func TestUserHandler_DeleteProfile(t *testing.T) {
 		assert.Contains(t, response.Error, "invalid or expired token")
 	})
 
+	t.Run("should require the current password", func(t *testing.T) {
+		token, userID := createUserAndGetToken(t, server, "No Password", "nopassword@example.com", "password123")
+
+		recorder := makeAuthenticatedRequest(t, server, "DELETE", "/api/account/me", token, nil)
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+
+		recorder = makeAuthenticatedRequest(t, server, "DELETE", "/api/account/me", token, []byte(`{}`))
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+
+		// Account survives
+		var userCount int
+		err := server.db.Get(&userCount, "SELECT COUNT(*) FROM users WHERE uuid = $1 AND deleted_at IS NULL", userID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, userCount)
+	})
+
+	t.Run("should reject a wrong password", func(t *testing.T) {
+		token, userID := createUserAndGetToken(t, server, "Wrong Password", "wrongpassword@example.com", "password123")
+
+		recorder := makeAuthenticatedRequest(t, server, "DELETE", "/api/account/me", token, []byte(`{"password":"not-my-password"}`))
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+
+		var response ginx.Response
+		err := json.Unmarshal(recorder.Body.Bytes(), &response)
+		require.NoError(t, err)
+		assert.Equal(t, ErrorCodeIncorrectPassword, response.Code)
+
+		// Account survives
+		var userCount int
+		err = server.db.Get(&userCount, "SELECT COUNT(*) FROM users WHERE uuid = $1 AND deleted_at IS NULL", userID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, userCount)
+	})
+
+	t.Run("should accept the password in the confirmation header", func(t *testing.T) {
+		token, userID := createUserAndGetToken(t, server, "Header Confirm", "headerconfirm@example.com", "password123")
+
+		req := httptest.NewRequest("DELETE", "/api/account/me", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set(ConfirmPasswordHeader, "password123")
+		recorder := httptest.NewRecorder()
+		server.router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusNoContent, recorder.Code)
+		assert.Empty(t, recorder.Body.String())
+
+		var userCount int
+		err := server.db.Get(&userCount, "SELECT COUNT(*) FROM users WHERE uuid = $1 AND deleted_at IS NULL", userID)
+		require.NoError(t, err)
+		assert.Equal(t, 0, userCount)
+	})
+
 	t.Run("should not affect other users when deleting", func(t *testing.T) {
 		// Create multiple users
 		token1, _ := createUserAndGetToken(t, server, "Keep Me 1", "keep1@example.com", "password123")
@@ -588,8 +827,9 @@ func TestUserHandler_DeleteProfile(t *testing.T) {
 		token3, _ := createUserAndGetToken(t, server, "Keep Me 2", "keep2@example.com", "password123")
 
 		// Delete middle user
-		recorder := makeAuthenticatedRequest(t, server, "DELETE", "/api/account/me", token2, nil)
+		recorder := makeAuthenticatedRequest(t, server, "DELETE", "/api/account/me", token2, confirmDeleteBody)
 		assert.Equal(t, http.StatusNoContent, recorder.Code)
+		assert.Empty(t, recorder.Body.String())
 
 		// Verify other users still exist and can access their profiles
 		recorder1 := makeAuthenticatedRequest(t, server, "GET", "/api/account/me", token1, nil)
@@ -600,7 +840,7 @@ func TestUserHandler_DeleteProfile(t *testing.T) {
 
 		// Verify deleted user count
 		var deletedCount int
-		err := server.db.Get(&deletedCount, "SELECT COUNT(*) FROM users WHERE email = $1", "deleteme@example.com")
+		err := server.db.Get(&deletedCount, "SELECT COUNT(*) FROM users WHERE email = $1 AND deleted_at IS NULL", "deleteme@example.com")
 		require.NoError(t, err)
 		assert.Equal(t, 0, deletedCount)
 
@@ -612,6 +852,98 @@ func TestUserHandler_DeleteProfile(t *testing.T) {
 	})
 }
 
+func TestUserHandler_ChangePassword(t *testing.T) {
+	server := setupUserHandlerTest(t)
+	defer server.cleanup()
+
+	signIn := func(email, password string) int {
+		requestBody, err := json.Marshal(authUC.SignInRequest{Email: email, Password: password})
+		require.NoError(t, err)
+
+		req := httptest.NewRequest("POST", "/api/auth/signin", bytes.NewBuffer(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		recorder := httptest.NewRecorder()
+		server.router.ServeHTTP(recorder, req)
+		return recorder.Code
+	}
+
+	t.Run("should change password successfully", func(t *testing.T) {
+		token, _ := createUserAndGetToken(t, server, "Change Password", "changepw@example.com", "password123")
+
+		requestBody, err := json.Marshal(ChangePasswordRequest{
+			CurrentPassword: "password123",
+			NewPassword:     "newpassword456",
+		})
+		require.NoError(t, err)
+
+		recorder := makeAuthenticatedRequest(t, server, "PUT", "/api/account/password", token, requestBody)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+
+		// Old token keeps working
+		recorder = makeAuthenticatedRequest(t, server, "GET", "/api/account/me", token, nil)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+
+		// Old password no longer works, new one does
+		assert.Equal(t, http.StatusUnauthorized, signIn("changepw@example.com", "password123"))
+		assert.Equal(t, http.StatusOK, signIn("changepw@example.com", "newpassword456"))
+	})
+
+	t.Run("should fail with wrong current password", func(t *testing.T) {
+		token, _ := createUserAndGetToken(t, server, "Wrong Current", "wrongcurrent@example.com", "password123")
+
+		requestBody, err := json.Marshal(ChangePasswordRequest{
+			CurrentPassword: "notmypassword",
+			NewPassword:     "newpassword456",
+		})
+		require.NoError(t, err)
+
+		recorder := makeAuthenticatedRequest(t, server, "PUT", "/api/account/password", token, requestBody)
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+
+		var response ginx.Response
+		err = json.Unmarshal(recorder.Body.Bytes(), &response)
+		require.NoError(t, err)
+		assert.Contains(t, response.Error, "current password is incorrect")
+
+		assert.Equal(t, http.StatusOK, signIn("wrongcurrent@example.com", "password123"))
+	})
+
+	t.Run("should fail with weak new password", func(t *testing.T) {
+		token, _ := createUserAndGetToken(t, server, "Weak New", "weaknew@example.com", "password123")
+
+		requestBody, err := json.Marshal(ChangePasswordRequest{
+			CurrentPassword: "password123",
+			NewPassword:     "123",
+		})
+		require.NoError(t, err)
+
+		recorder := makeAuthenticatedRequest(t, server, "PUT", "/api/account/password", token, requestBody)
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+		var response ginx.Response
+		err = json.Unmarshal(recorder.Body.Bytes(), &response)
+		require.NoError(t, err)
+		assert.Contains(t, response.Error, "password must be at least 6 characters long")
+
+		assert.Equal(t, http.StatusOK, signIn("weaknew@example.com", "password123"))
+	})
+
+	t.Run("should fail with missing fields", func(t *testing.T) {
+		token, _ := createUserAndGetToken(t, server, "Missing Fields", "missingfields@example.com", "password123")
+
+		recorder := makeAuthenticatedRequest(t, server, "PUT", "/api/account/password", token, []byte(`{}`))
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+
+	t.Run("should fail without authentication", func(t *testing.T) {
+		req := httptest.NewRequest("PUT", "/api/account/password", nil)
+		recorder := httptest.NewRecorder()
+		server.router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	})
+}
+
 func TestUserHandler_ListUsers(t *testing.T) {
 	server := setupUserHandlerTest(t)
 	defer server.cleanup()
@@ -621,8 +953,10 @@ func TestUserHandler_ListUsers(t *testing.T) {
 		// Generate unique timestamp to avoid conflicts
 		timestamp := time.Now().UnixNano()
 
-		// Create main user to get token
-		token, _ := createUserAndGetToken(t, server, "Main User", fmt.Sprintf("main%d@example.com", timestamp), "password123")
+		// Create main user to get token (listing requires admin)
+		mainEmail := fmt.Sprintf("main%d@example.com", timestamp)
+		token, _ := createUserAndGetToken(t, server, "Main User", mainEmail, "password123")
+		promoteToAdmin(t, server, mainEmail)
 
 		// Create additional users for listing with unique emails
 		_, _ = createUserAndGetToken(t, server, "Alice Johnson", fmt.Sprintf("alice%d@example.com", timestamp), "password123")
@@ -694,6 +1028,119 @@ func TestUserHandler_ListUsers(t *testing.T) {
 		assert.Equal(t, 1, listResponse.Page)
 	})
 
+	t.Run("should sort users by name", func(t *testing.T) {
+		token := setupTestUsers()
+
+		for _, order := range []string{"asc", "desc"} {
+			recorder := makeAuthenticatedRequest(t, server, "GET", "/api/users?sort=name&order="+order+"&page_size=100", token, nil)
+			assert.Equal(t, http.StatusOK, recorder.Code)
+
+			var response ginx.Response
+			err := json.Unmarshal(recorder.Body.Bytes(), &response)
+			require.NoError(t, err)
+
+			responseData, err := json.Marshal(response.Data)
+			require.NoError(t, err)
+
+			var listResponse ListUsersResponse
+			err = json.Unmarshal(responseData, &listResponse)
+			require.NoError(t, err)
+			require.NotEmpty(t, listResponse.Users)
+
+			for i := 1; i < len(listResponse.Users); i++ {
+				prev, curr := listResponse.Users[i-1].Name, listResponse.Users[i].Name
+				if order == "asc" {
+					assert.LessOrEqual(t, prev, curr)
+				} else {
+					assert.GreaterOrEqual(t, prev, curr)
+				}
+			}
+		}
+	})
+
+	t.Run("should reject unknown sort field", func(t *testing.T) {
+		token := setupTestUsers()
+
+		recorder := makeAuthenticatedRequest(t, server, "GET", "/api/users?sort=password", token, nil)
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+		var response ginx.Response
+		err := json.Unmarshal(recorder.Body.Bytes(), &response)
+		require.NoError(t, err)
+		assert.Contains(t, response.Error, "invalid sort field")
+	})
+
+	t.Run("should return total count across pages", func(t *testing.T) {
+		// Fresh server so the user count is known
+		freshServer := setupUserHandlerTest(t)
+		defer freshServer.cleanup()
+
+		adminEmail := "totaladmin@example.com"
+		token, _ := createUserAndGetToken(t, freshServer, "Total Admin", adminEmail, "password123")
+		promoteToAdmin(t, freshServer, adminEmail)
+		for i := 0; i < 9; i++ {
+			_, _ = createUserAndGetToken(t, freshServer, fmt.Sprintf("Total User %d", i), fmt.Sprintf("total%d@example.com", i), "password123")
+		}
+
+		recorder := makeAuthenticatedRequest(t, freshServer, "GET", "/api/users?page=1&page_size=2", token, nil)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+
+		var response ginx.Response
+		err := json.Unmarshal(recorder.Body.Bytes(), &response)
+		require.NoError(t, err)
+
+		responseData, err := json.Marshal(response.Data)
+		require.NoError(t, err)
+
+		var listResponse ListUsersResponse
+		err = json.Unmarshal(responseData, &listResponse)
+		require.NoError(t, err)
+
+		assert.Len(t, listResponse.Users, 2)
+		assert.Equal(t, 10, listResponse.Total)
+	})
+
+	t.Run("should paginate with cursor", func(t *testing.T) {
+		token := setupTestUsers()
+
+		decodeList := func(recorder *httptest.ResponseRecorder) ListUsersResponse {
+			var response ginx.Response
+			err := json.Unmarshal(recorder.Body.Bytes(), &response)
+			require.NoError(t, err)
+
+			responseData, err := json.Marshal(response.Data)
+			require.NoError(t, err)
+
+			var listResponse ListUsersResponse
+			err = json.Unmarshal(responseData, &listResponse)
+			require.NoError(t, err)
+			return listResponse
+		}
+
+		recorder := makeAuthenticatedRequest(t, server, "GET", "/api/users?page_size=2", token, nil)
+		require.Equal(t, http.StatusOK, recorder.Code)
+		firstPage := decodeList(recorder)
+		require.Len(t, firstPage.Users, 2)
+		require.NotEmpty(t, firstPage.NextCursor)
+
+		// cursor takes precedence over page
+		recorder = makeAuthenticatedRequest(t, server, "GET", "/api/users?page=99&page_size=2&cursor="+firstPage.NextCursor, token, nil)
+		require.Equal(t, http.StatusOK, recorder.Code)
+		secondPage := decodeList(recorder)
+		require.NotEmpty(t, secondPage.Users)
+		for _, u := range secondPage.Users {
+			assert.NotEqual(t, firstPage.Users[0].ID, u.ID)
+			assert.NotEqual(t, firstPage.Users[1].ID, u.ID)
+		}
+	})
+
+	t.Run("should reject invalid cursor", func(t *testing.T) {
+		token := setupTestUsers()
+
+		recorder := makeAuthenticatedRequest(t, server, "GET", "/api/users?cursor=garbage!", token, nil)
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+
 	t.Run("should search users by name", func(t *testing.T) {
 		token := setupTestUsers()
 
@@ -802,6 +1249,52 @@ func TestUserHandler_ListUsers(t *testing.T) {
 	})
 }
 
+func TestUserHandler_ListUsers_RequiresAdmin(t *testing.T) {
+	server := setupUserHandlerTest(t)
+	defer server.cleanup()
+
+	t.Run("should forbid listing for regular user", func(t *testing.T) {
+		token, _ := createUserAndGetToken(t, server, "Regular User", "regular@example.com", "password123")
+
+		recorder := makeAuthenticatedRequest(t, server, "GET", "/api/users", token, nil)
+		assert.Equal(t, http.StatusForbidden, recorder.Code)
+
+		var response ginx.Response
+		err := json.Unmarshal(recorder.Body.Bytes(), &response)
+		require.NoError(t, err)
+		assert.Contains(t, response.Error, "insufficient permissions")
+	})
+
+	t.Run("should allow listing for admin", func(t *testing.T) {
+		token, _ := createUserAndGetToken(t, server, "Admin User", "admin@example.com", "password123")
+		promoteToAdmin(t, server, "admin@example.com")
+
+		recorder := makeAuthenticatedRequest(t, server, "GET", "/api/users", token, nil)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+
+		var response ginx.Response
+		err := json.Unmarshal(recorder.Body.Bytes(), &response)
+		require.NoError(t, err)
+
+		listData, err := json.Marshal(response.Data)
+		require.NoError(t, err)
+
+		var userList ListUsersResponse
+		err = json.Unmarshal(listData, &userList)
+		require.NoError(t, err)
+
+		assert.GreaterOrEqual(t, len(userList.Users), 2)
+		for _, u := range userList.Users {
+			if u.Email == "admin@example.com" {
+				assert.Equal(t, "admin", u.Role)
+			}
+			if u.Email == "regular@example.com" {
+				assert.Equal(t, "user", u.Role)
+			}
+		}
+	})
+}
+
 func TestUserHandler_Integration_CompleteFlow(t *testing.T) {
 	server := setupUserHandlerTest(t)
 	defer server.cleanup()
@@ -878,7 +1371,8 @@ func TestUserHandler_Integration_CompleteFlow(t *testing.T) {
 		// 5. Create another user to test listing
 		_, _ = createUserAndGetToken(t, server, "Another User", "another@example.com", "password123")
 
-		// 6. List users
+		// 6. List users (requires admin)
+		promoteToAdmin(t, server, "updated.integration@example.com")
 		recorder = makeAuthenticatedRequest(t, server, "GET", "/api/users", token, nil)
 		assert.Equal(t, http.StatusOK, recorder.Code)
 
@@ -935,8 +1429,9 @@ func TestUserHandler_Integration_CompleteFlow(t *testing.T) {
 		assert.True(t, foundInSearch, "Should find updated user in search")
 
 		// 8. Finally, delete the user
-		recorder = makeAuthenticatedRequest(t, server, "DELETE", "/api/account/me", token, nil)
+		recorder = makeAuthenticatedRequest(t, server, "DELETE", "/api/account/me", token, confirmDeleteBody)
 		assert.Equal(t, http.StatusNoContent, recorder.Code)
+		assert.Empty(t, recorder.Body.String())
 
 		// 9. Verify user was deleted (token should no longer work)
 		recorder = makeAuthenticatedRequest(t, server, "GET", "/api/account/me", token, nil)
@@ -990,10 +1485,10 @@ func TestUserHandler_ErrorHandling(t *testing.T) {
 			description  string
 		}{
 			{"GET", "/api/account/me", http.StatusOK, "GET profile should work"},
-			{"POST", "/api/account/me", http.StatusNotFound, "POST profile should not be allowed"},
+			{"POST", "/api/account/me", http.StatusMethodNotAllowed, "POST profile should not be allowed"},
 			{"PUT", "/api/account/me", http.StatusBadRequest, "PUT profile without body should fail"},
 			{"DELETE", "/api/account/me", http.StatusNoContent, "DELETE profile should work"},
-			{"PATCH", "/api/account/me", http.StatusNotFound, "PATCH profile should not be allowed"},
+			{"PATCH", "/api/account/me", http.StatusBadRequest, "PATCH profile without body should fail"},
 		}
 
 		for _, tc := range testCases {
@@ -1001,7 +1496,7 @@ func TestUserHandler_ErrorHandling(t *testing.T) {
 			if tc.method == "DELETE" {
 				// Create a fresh user for delete test
 				deleteToken, _ := createUserAndGetToken(t, server, "Delete Test", "deletetest@example.com", "password123")
-				recorder := makeAuthenticatedRequest(t, server, tc.method, tc.path, deleteToken, nil)
+				recorder := makeAuthenticatedRequest(t, server, tc.method, tc.path, deleteToken, confirmDeleteBody)
 				assert.Equal(t, tc.expectedCode, recorder.Code, tc.description)
 			} else if tc.method != "DELETE" {
 				recorder := makeAuthenticatedRequest(t, server, tc.method, tc.path, token, nil)
@@ -1105,7 +1600,8 @@ func TestUserHandler_Security(t *testing.T) {
 		assert.NotContains(t, responseBody, "password123")
 		assert.NotContains(t, responseBody, "$2a$") // bcrypt prefix
 
-		// List users
+		// List users (requires admin)
+		promoteToAdmin(t, server, "password@example.com")
 		recorder = makeAuthenticatedRequest(t, server, "GET", "/api/users", token, nil)
 		assert.Equal(t, http.StatusOK, recorder.Code)
 
@@ -1115,3 +1611,118 @@ func TestUserHandler_Security(t *testing.T) {
 		assert.NotContains(t, responseBody, "$2a$") // bcrypt prefix
 	})
 }
+
+func TestUserHandler_ListUsers_CreatedRange(t *testing.T) {
+	server := setupUserHandlerTest(t)
+	defer server.cleanup()
+
+	token, _ := createUserAndGetToken(t, server, "Range Admin", "rangeadmin@example.com", "password123")
+	promoteToAdmin(t, server, "rangeadmin@example.com")
+
+	t.Run("should filter by created range", func(t *testing.T) {
+		after := url.QueryEscape(time.Now().Add(-time.Hour).Format(time.RFC3339))
+		before := url.QueryEscape(time.Now().Add(time.Hour).Format(time.RFC3339))
+
+		recorder := makeAuthenticatedRequest(t, server, "GET", "/api/users?created_after="+after+"&created_before="+before, token, nil)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+	})
+
+	t.Run("should return 400 for malformed date", func(t *testing.T) {
+		recorder := makeAuthenticatedRequest(t, server, "GET", "/api/users?created_after=yesterday", token, nil)
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+		var response ginx.Response
+		err := json.Unmarshal(recorder.Body.Bytes(), &response)
+		require.NoError(t, err)
+		assert.Contains(t, response.Error, "invalid created_after")
+	})
+
+	t.Run("should return 400 when created_after is after created_before", func(t *testing.T) {
+		recorder := makeAuthenticatedRequest(t, server, "GET",
+			"/api/users?created_after=2024-02-01T00:00:00Z&created_before=2024-01-01T00:00:00Z", token, nil)
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+}
+
+func TestUserHandler_ListUsers_PaginationHeaders(t *testing.T) {
+	server := setupUserHandlerTest(t)
+	defer server.cleanup()
+
+	token, _ := createUserAndGetToken(t, server, "Paging Admin", "pagingadmin@example.com", "password123")
+	promoteToAdmin(t, server, "pagingadmin@example.com")
+
+	// Three users matching the search: two pages of two
+	for _, name := range []string{"Paged One", "Paged Two", "Paged Three"} {
+		email := strings.ToLower(strings.ReplaceAll(name, " ", ".")) + "@paging.test"
+		_, _ = createUserAndGetToken(t, server, name, email, "password123")
+	}
+
+	t.Run("should link to next page when more pages exist", func(t *testing.T) {
+		recorder := makeAuthenticatedRequest(t, server, "GET", "/api/users?search=paging.test&page=1&page_size=2", token, nil)
+		require.Equal(t, http.StatusOK, recorder.Code)
+
+		assert.Equal(t, "3", recorder.Header().Get("X-Total-Count"))
+		assert.Equal(t, "1", recorder.Header().Get("X-Page"))
+		assert.Equal(t, "2", recorder.Header().Get("X-Page-Size"))
+
+		link := recorder.Header().Get("Link")
+		assert.Contains(t, link, `</api/users?page=2&page_size=2&search=paging.test>; rel="next"`)
+		assert.Contains(t, link, `</api/users?page=1&page_size=2&search=paging.test>; rel="first"`)
+		assert.Contains(t, link, `</api/users?page=2&page_size=2&search=paging.test>; rel="last"`)
+		assert.NotContains(t, link, `rel="prev"`)
+
+		// JSON body is unchanged
+		var response ginx.Response
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		data := response.Data.(map[string]interface{})
+		assert.Equal(t, float64(3), data["total"])
+	})
+
+	t.Run("should omit next on the last page", func(t *testing.T) {
+		recorder := makeAuthenticatedRequest(t, server, "GET", "/api/users?search=paging.test&page=2&page_size=2", token, nil)
+		require.Equal(t, http.StatusOK, recorder.Code)
+
+		link := recorder.Header().Get("Link")
+		assert.NotContains(t, link, `rel="next"`)
+		assert.Contains(t, link, `</api/users?page=1&page_size=2&search=paging.test>; rel="prev"`)
+		assert.Contains(t, link, `</api/users?page=2&page_size=2&search=paging.test>; rel="last"`)
+	})
+}
+
+func TestUserHandler_ExportAccount(t *testing.T) {
+	server := setupUserHandlerTest(t)
+	defer server.cleanup()
+
+	t.Run("should export profile and welcome email as attachment", func(t *testing.T) {
+		token, userID := createUserAndGetToken(t, server, "Export User", "export@example.com", "password123")
+
+		recorder := makeAuthenticatedRequest(t, server, "GET", "/api/account/export", token, nil)
+		require.Equal(t, http.StatusOK, recorder.Code)
+
+		assert.Contains(t, recorder.Header().Get("Content-Disposition"), "attachment")
+		assert.Contains(t, recorder.Header().Get("Content-Disposition"), ".json")
+		assert.NotContains(t, recorder.Body.String(), "password")
+
+		var export struct {
+			Profile map[string]interface{}   `json:"profile"`
+			Emails  []map[string]interface{} `json:"emails"`
+		}
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &export))
+
+		assert.Equal(t, userID, export.Profile["id"])
+		assert.Equal(t, "Export User", export.Profile["name"])
+		assert.Equal(t, "export@example.com", export.Profile["email"])
+
+		require.Len(t, export.Emails, 1)
+		assert.Equal(t, "welcome", export.Emails[0]["type"])
+		assert.Equal(t, "export@example.com", export.Emails[0]["to"])
+	})
+
+	t.Run("should require authentication", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/account/export", nil)
+		recorder := httptest.NewRecorder()
+		server.router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	})
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	adminUC "github.com/moura95/backend-challenge/internal/application/usecases/admin"
+	"github.com/moura95/backend-challenge/internal/interfaces/http/ginx"
+)
+
+type UserImportHandler struct {
+	importUsersUseCase *adminUC.ImportUsersUseCase
+}
+
+func NewUserImportHandler(importUsersUC *adminUC.ImportUsersUseCase) *UserImportHandler {
+	return &UserImportHandler{
+		importUsersUseCase: importUsersUC,
+	}
+}
+
+// @Summary Import users in batch
+// @Description Create up to 100 users at once; each gets a random temporary password and a password setup email. Rows fail independently (admin only)
+// @Tags admin
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body []github_com_moura95_backend-challenge_internal_application_usecases_admin.ImportUserRecord true "Users to create"
+// @Success 200 {object} ginx.Response{data=github_com_moura95_backend-challenge_internal_application_usecases_admin.ImportUsersResponse}
+// @Failure 400 {object} ginx.Response
+// @Failure 401 {object} ginx.Response
+// @Failure 403 {object} ginx.Response
+// @Router /admin/users/import [post]
+func (h *UserImportHandler) ImportUsers(c *gin.Context) {
+	var records []adminUC.ImportUserRecord
+
+	if err := ginx.ParseJSON(c, &records); err != nil {
+		c.JSON(bindErrorResponse("handler: import users failed", err))
+		return
+	}
+
+	result, err := h.importUsersUseCase.Execute(c.Request.Context(), records)
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		c.JSON(statusCode, errorResponse(fmt.Sprintf("handler: import users failed: %v", err), err))
+		return
+	}
+
+	c.JSON(http.StatusOK, ginx.SuccessResponse(result))
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	adminUC "github.com/moura95/backend-challenge/internal/application/usecases/admin"
+	"github.com/moura95/backend-challenge/internal/interfaces/http/ginx"
+)
+
+type UserStatusHandler struct {
+	suspendUserUseCase    *adminUC.SuspendUserUseCase
+	reactivateUserUseCase *adminUC.ReactivateUserUseCase
+}
+
+func NewUserStatusHandler(suspendUserUC *adminUC.SuspendUserUseCase, reactivateUserUC *adminUC.ReactivateUserUseCase) *UserStatusHandler {
+	return &UserStatusHandler{
+		suspendUserUseCase:    suspendUserUC,
+		reactivateUserUseCase: reactivateUserUC,
+	}
+}
+
+// @Summary Suspend a user
+// @Description Block signin and existing tokens for the account without deleting it (admin only)
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} ginx.Response{data=github_com_moura95_backend-challenge_internal_domain_user.UserResponse}
+// @Failure 400 {object} ginx.Response
+// @Failure 401 {object} ginx.Response
+// @Failure 403 {object} ginx.Response
+// @Router /admin/users/{id}/suspend [post]
+func (h *UserStatusHandler) SuspendUser(c *gin.Context) {
+	suspended, err := h.suspendUserUseCase.Execute(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		c.JSON(statusCode, errorResponse(fmt.Sprintf("handler: suspend user failed: %v", err), err))
+		return
+	}
+
+	c.JSON(http.StatusOK, ginx.SuccessResponse(suspended.ToResponse()))
+}
+
+// @Summary Reactivate a user
+// @Description Restore access to a suspended account (admin only)
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} ginx.Response{data=github_com_moura95_backend-challenge_internal_domain_user.UserResponse}
+// @Failure 400 {object} ginx.Response
+// @Failure 401 {object} ginx.Response
+// @Failure 403 {object} ginx.Response
+// @Router /admin/users/{id}/reactivate [post]
+func (h *UserStatusHandler) ReactivateUser(c *gin.Context) {
+	reactivated, err := h.reactivateUserUseCase.Execute(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		statusCode := getStatusCodeFromError(err)
+		c.JSON(statusCode, errorResponse(fmt.Sprintf("handler: reactivate user failed: %v", err), err))
+		return
+	}
+
+	c.JSON(http.StatusOK, ginx.SuccessResponse(reactivated.ToResponse()))
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	authUC "github.com/moura95/backend-challenge/internal/application/usecases/auth"
+	userUC "github.com/moura95/backend-challenge/internal/application/usecases/user"
+	"github.com/moura95/backend-challenge/internal/domain/user"
+	"github.com/moura95/backend-challenge/internal/infra/security/jwt"
+	"github.com/moura95/backend-challenge/internal/interfaces/http/middlewares"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// raceUserRepository enforces unique emails like the users index and holds
+// every EmailExists caller until all racers have checked, so each one sees
+// the email as free.
+type raceUserRepository struct {
+	user.Repository
+	mu      sync.Mutex
+	users   map[uuid.UUID]*user.User
+	checked sync.WaitGroup
+}
+
+func (r *raceUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*user.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	found, ok := r.users[id]
+	if !ok {
+		return nil, user.ErrUserNotFound
+	}
+	stored := *found
+	return &stored, nil
+}
+
+func (r *raceUserRepository) EmailExists(ctx context.Context, address string) (bool, error) {
+	r.mu.Lock()
+	exists := false
+	for _, u := range r.users {
+		exists = exists || u.Email == address
+	}
+	r.mu.Unlock()
+
+	r.checked.Done()
+	r.checked.Wait()
+	return exists, nil
+}
+
+func (r *raceUserRepository) Update(ctx context.Context, domainUser *user.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, u := range r.users {
+		if id != domainUser.ID && u.Email == domainUser.Email {
+			return fmt.Errorf("repository: update user failed: %w", user.ErrEmailExists)
+		}
+	}
+	stored := *domainUser
+	r.users[domainUser.ID] = &stored
+	return nil
+}
+
+func TestUserHandler_UpdateProfileConcurrentEmail(t *testing.T) {
+	alice, err := user.NewUser("Alice Smith", "alice@example.com", "password123")
+	require.NoError(t, err)
+	bob, err := user.NewUser("Bob Jones", "bob@example.com", "password123")
+	require.NoError(t, err)
+
+	tokenMaker, err := jwt.NewPasetoMaker("12345678901234567890123456789012")
+	require.NoError(t, err)
+
+	userRepo := &raceUserRepository{users: map[uuid.UUID]*user.User{alice.ID: alice, bob.ID: bob}}
+	userRepo.checked.Add(2)
+
+	// Sem banco, o runner apenas repassa o repositório
+	updateUC := userUC.NewUpdateUserUseCase(userRepo).
+		WithTransaction(func(ctx context.Context, fn func(user.Repository) error) error {
+			return fn(userRepo)
+		})
+	verifyTokenUC := authUC.NewVerifyTokenUseCase(userRepo, &cookieTokenRepository{revoked: map[uuid.UUID]bool{}}, tokenMaker)
+	handler := NewUserHandler(nil, updateUC, nil, nil, nil, nil)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.PUT("/account/me", middlewares.AuthMiddleware(verifyTokenUC), handler.UpdateProfile)
+
+	codes := make([]int, 2)
+	var wg sync.WaitGroup
+	for i, racer := range []*user.User{alice, bob} {
+		accessToken, _, err := tokenMaker.CreateToken(racer.ID, racer.TokenVersion, time.Minute)
+		require.NoError(t, err)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			req := httptest.NewRequest("PUT", "/account/me", bytes.NewBufferString(`{"email":"taken@example.com"}`))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer "+accessToken)
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, req)
+			codes[i] = recorder.Code
+		}()
+	}
+	wg.Wait()
+
+	assert.ElementsMatch(t, []int{http.StatusOK, http.StatusConflict}, codes)
+
+	claimed := 0
+	for _, u := range userRepo.users {
+		if u.Email == "taken@example.com" {
+			claimed++
+		}
+	}
+	assert.Equal(t, 1, claimed)
+}
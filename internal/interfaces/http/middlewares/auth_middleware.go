@@ -6,6 +6,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	authUC "github.com/moura95/backend-challenge/internal/application/usecases/auth"
+	"github.com/moura95/backend-challenge/internal/infra/security/jwt"
 	"github.com/moura95/backend-challenge/internal/interfaces/http/ginx"
 )
 
@@ -13,35 +14,24 @@ const (
 	authorizationHeaderKey  = "authorization"
 	authorizationTypeBearer = "bearer"
 	userIDKey               = "user_id"
+	accessTokenKey          = "access_token"
+	userRoleKey             = "user_role"
+	tokenPayloadKey         = "token_payload"
 )
 
+// AccessTokenCookieName is the HttpOnly cookie set by signin in cookie mode.
+const AccessTokenCookieName = "access_token"
+
 func AuthMiddleware(verifyTokenUseCase *authUC.VerifyTokenUseCase) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		authorizationHeader := c.GetHeader(authorizationHeaderKey)
-
-		if len(authorizationHeader) == 0 {
-			c.JSON(http.StatusUnauthorized, ginx.ErrorResponse("middleware: authorization header not provided"))
-			c.Abort()
-			return
-		}
-
-		fields := strings.Fields(authorizationHeader)
-		if len(fields) < 2 {
-			c.JSON(http.StatusUnauthorized, ginx.ErrorResponse("middleware: invalid authorization header format"))
+		accessToken, errMessage := accessTokenFromRequest(c)
+		if errMessage != "" {
+			c.JSON(http.StatusUnauthorized, ginx.ErrorResponse(errMessage))
 			c.Abort()
 			return
 		}
 
-		authorizationType := strings.ToLower(fields[0])
-		if authorizationType != authorizationTypeBearer {
-			c.JSON(http.StatusUnauthorized, ginx.ErrorResponse("middleware: unsupported authorization type"))
-			c.Abort()
-			return
-		}
-
-		accessToken := fields[1]
-
-		user, err := verifyTokenUseCase.Execute(c.Request.Context(), accessToken)
+		user, payload, err := verifyTokenUseCase.ExecuteWithPayload(c.Request.Context(), accessToken)
 		if err != nil {
 			c.JSON(http.StatusUnauthorized, ginx.ErrorResponse("middleware: invalid or expired token"))
 			c.Abort()
@@ -49,10 +39,38 @@ func AuthMiddleware(verifyTokenUseCase *authUC.VerifyTokenUseCase) gin.HandlerFu
 		}
 
 		c.Set(userIDKey, user.ID.String())
+		c.Set(accessTokenKey, accessToken)
+		c.Set(userRoleKey, string(user.Role))
+		c.Set(tokenPayloadKey, payload)
 		c.Next()
 	}
 }
 
+// accessTokenFromRequest lê o header Authorization; sem ele, usa o cookie
+// definido pelo signin em modo cookie. O header tem precedência.
+func accessTokenFromRequest(c *gin.Context) (string, string) {
+	authorizationHeader := c.GetHeader(authorizationHeaderKey)
+
+	if len(authorizationHeader) == 0 {
+		if cookie, err := c.Cookie(AccessTokenCookieName); err == nil && cookie != "" {
+			return cookie, ""
+		}
+		return "", "middleware: authorization header not provided"
+	}
+
+	fields := strings.Fields(authorizationHeader)
+	if len(fields) < 2 {
+		return "", "middleware: invalid authorization header format"
+	}
+
+	authorizationType := strings.ToLower(fields[0])
+	if authorizationType != authorizationTypeBearer {
+		return "", "middleware: unsupported authorization type"
+	}
+
+	return fields[1], ""
+}
+
 func GetUserIDFromContext(c *gin.Context) (string, bool) {
 	userID, exists := c.Get(userIDKey)
 	if !exists {
@@ -66,3 +84,46 @@ func GetUserIDFromContext(c *gin.Context) (string, bool) {
 
 	return userIDStr, true
 }
+
+func GetAccessTokenFromContext(c *gin.Context) (string, bool) {
+	accessToken, exists := c.Get(accessTokenKey)
+	if !exists {
+		return "", false
+	}
+
+	accessTokenStr, ok := accessToken.(string)
+	if !ok {
+		return "", false
+	}
+
+	return accessTokenStr, true
+}
+
+func GetUserRoleFromContext(c *gin.Context) (string, bool) {
+	role, exists := c.Get(userRoleKey)
+	if !exists {
+		return "", false
+	}
+
+	roleStr, ok := role.(string)
+	if !ok {
+		return "", false
+	}
+
+	return roleStr, true
+}
+
+// GetTokenPayloadFromContext returns the claims of the token verified by AuthMiddleware.
+func GetTokenPayloadFromContext(c *gin.Context) (*jwt.Payload, bool) {
+	payload, exists := c.Get(tokenPayloadKey)
+	if !exists {
+		return nil, false
+	}
+
+	tokenPayload, ok := payload.(*jwt.Payload)
+	if !ok || tokenPayload == nil {
+		return nil, false
+	}
+
+	return tokenPayload, true
+}
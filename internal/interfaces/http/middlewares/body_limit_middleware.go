@@ -0,0 +1,27 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/moura95/backend-challenge/internal/interfaces/http/ginx"
+)
+
+// BodyLimit limita o corpo da requisição a maxBytes. Um Content-Length acima
+// do limite é recusado com 413 antes de ler; nos demais casos o corpo é
+// envolvido com http.MaxBytesReader e ginx.ParseJSON devolve ErrBodyTooLarge.
+func BodyLimit(maxBytes int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.ContentLength > maxBytes {
+			c.JSON(http.StatusRequestEntityTooLarge, ginx.ErrorResponse("middleware: request body too large"))
+			c.Abort()
+			return
+		}
+
+		if c.Request.Body != nil {
+			c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes)
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,82 @@
+package middlewares
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/moura95/backend-challenge/internal/interfaces/http/ginx"
+)
+
+func setupBodyLimitRouter(maxBytes int64) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(BodyLimit(maxBytes))
+	router.POST("/echo", func(c *gin.Context) {
+		var body map[string]string
+		if err := ginx.ParseJSON(c, &body); err != nil {
+			if errors.Is(err, ginx.ErrBodyTooLarge) {
+				c.Status(http.StatusRequestEntityTooLarge)
+				return
+			}
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+
+	return router
+}
+
+func TestBodyLimit(t *testing.T) {
+	router := setupBodyLimitRouter(64)
+	oversized := `{"name":"` + strings.Repeat("a", 128) + `"}`
+
+	t.Run("should accept body within the limit", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/echo", strings.NewReader(`{"name":"ok"}`))
+		req.Header.Set("Content-Type", "application/json")
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+	})
+
+	t.Run("should reject declared content length over the limit", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/echo", strings.NewReader(oversized))
+		req.Header.Set("Content-Type", "application/json")
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "request body too large")
+	})
+
+	t.Run("should surface ErrBodyTooLarge when length is unknown", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/echo", strings.NewReader(oversized))
+		req.Header.Set("Content-Type", "application/json")
+		req.ContentLength = -1
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+	})
+
+	t.Run("should keep malformed json as a parse error", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/echo", strings.NewReader(`{not json`))
+		req.Header.Set("Content-Type", "application/json")
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+}
@@ -0,0 +1,38 @@
+package middlewares
+
+import (
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/moura95/backend-challenge/internal/interfaces/http/ginx"
+)
+
+// RequireJSON recusa com 415 requisições de escrita (POST, PUT, PATCH e
+// DELETE) cujo Content-Type não seja application/json, com ou sem charset.
+// Sem Content-Type a requisição segue, para não quebrar clientes antigos.
+func RequireJSON() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		default:
+			c.Next()
+			return
+		}
+
+		contentType := c.GetHeader("Content-Type")
+		if contentType == "" {
+			c.Next()
+			return
+		}
+
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil || mediaType != gin.MIMEJSON {
+			c.JSON(http.StatusUnsupportedMediaType, ginx.ErrorResponse("middleware: unsupported content type, expected application/json"))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,56 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupContentTypeRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(RequireJSON())
+	router.POST("/echo", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/echo", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	return router
+}
+
+func TestRequireJSON(t *testing.T) {
+	router := setupContentTypeRouter()
+
+	testCases := []struct {
+		name           string
+		method         string
+		contentType    string
+		expectedStatus int
+	}{
+		{"json", "POST", "application/json", http.StatusOK},
+		{"json with charset", "POST", "application/json; charset=utf-8", http.StatusOK},
+		{"json in upper case", "POST", "Application/JSON", http.StatusOK},
+		{"missing content type", "POST", "", http.StatusOK},
+		{"text plain", "POST", "text/plain", http.StatusUnsupportedMediaType},
+		{"form", "POST", "application/x-www-form-urlencoded", http.StatusUnsupportedMediaType},
+		{"malformed content type", "POST", "application/json; charset", http.StatusUnsupportedMediaType},
+		{"read only method", "GET", "text/plain", http.StatusOK},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, "/echo", strings.NewReader(`{"name":"ok"}`))
+			if tc.contentType != "" {
+				req.Header.Set("Content-Type", tc.contentType)
+			}
+			recorder := httptest.NewRecorder()
+
+			router.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expectedStatus, recorder.Code)
+		})
+	}
+}
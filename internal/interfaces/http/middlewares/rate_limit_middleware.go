@@ -0,0 +1,32 @@
+package middlewares
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/moura95/backend-challenge/internal/infra/security/ratelimit"
+	"github.com/moura95/backend-challenge/internal/interfaces/http/ginx"
+)
+
+// RateLimit recusa com 429 as requisições de um IP que esgotou seu saldo no
+// limiter, informando em Retry-After (segundos) quando tentar de novo. O IP
+// vem de ginx.ClientIP, que só considera X-Forwarded-For de proxies confiáveis.
+func RateLimit(limiter ratelimit.RateLimiter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		allowed, retryAfter := limiter.Allow(ginx.ClientIP(c))
+		if !allowed {
+			seconds := int(math.Ceil(retryAfter.Seconds()))
+			if seconds < 1 {
+				seconds = 1
+			}
+			c.Header("Retry-After", strconv.Itoa(seconds))
+			c.JSON(http.StatusTooManyRequests, ginx.ErrorResponse("middleware: too many requests"))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,89 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/moura95/backend-challenge/internal/infra/security/ratelimit"
+)
+
+type rateLimitClock struct {
+	current time.Time
+}
+
+func (c *rateLimitClock) Now() time.Time {
+	return c.current
+}
+
+func setupRateLimitRouter(t *testing.T, rate float64, burst int, trustedProxies []string) (*gin.Engine, *rateLimitClock) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	clock := &rateLimitClock{current: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	limiter := ratelimit.NewTokenBucketLimiter(rate, burst).WithClock(clock.Now)
+
+	router := gin.New()
+	require.NoError(t, router.SetTrustedProxies(trustedProxies))
+	router.Use(RateLimit(limiter))
+	router.POST("/auth/signin", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	return router, clock
+}
+
+func sendFrom(router *gin.Engine, remoteAddr, forwardedFor string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", "/auth/signin", nil)
+	req.RemoteAddr = remoteAddr
+	if forwardedFor != "" {
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestRateLimit(t *testing.T) {
+	t.Run("should return 429 past the burst and recover after refill", func(t *testing.T) {
+		router, clock := setupRateLimitRouter(t, 0.5, 3, nil)
+
+		for i := 0; i < 3; i++ {
+			recorder := sendFrom(router, "203.0.113.7:4000", "")
+			require.Equal(t, http.StatusOK, recorder.Code, "request %d", i+1)
+		}
+
+		recorder := sendFrom(router, "203.0.113.7:4000", "")
+		assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+		assert.Equal(t, "2", recorder.Header().Get("Retry-After"))
+		assert.Contains(t, recorder.Body.String(), "too many requests")
+
+		// Outro IP tem seu próprio saldo
+		assert.Equal(t, http.StatusOK, sendFrom(router, "203.0.113.8:4000", "").Code)
+
+		clock.current = clock.current.Add(2 * time.Second)
+		assert.Equal(t, http.StatusOK, sendFrom(router, "203.0.113.7:4000", "").Code)
+		assert.Equal(t, http.StatusTooManyRequests, sendFrom(router, "203.0.113.7:4000", "").Code)
+	})
+
+	t.Run("should key by X-Forwarded-For from a trusted proxy", func(t *testing.T) {
+		router, _ := setupRateLimitRouter(t, 1, 1, []string{"10.0.0.0/8"})
+
+		assert.Equal(t, http.StatusOK, sendFrom(router, "10.0.0.1:4000", "198.51.100.1").Code)
+		assert.Equal(t, http.StatusTooManyRequests, sendFrom(router, "10.0.0.2:4000", "198.51.100.1").Code)
+		assert.Equal(t, http.StatusOK, sendFrom(router, "10.0.0.1:4000", "198.51.100.2").Code)
+	})
+
+	t.Run("should ignore X-Forwarded-For from an untrusted client", func(t *testing.T) {
+		router, _ := setupRateLimitRouter(t, 1, 1, nil)
+
+		assert.Equal(t, http.StatusOK, sendFrom(router, "203.0.113.7:4000", "198.51.100.1").Code)
+		// Trocar o header não dá um novo saldo
+		assert.Equal(t, http.StatusTooManyRequests, sendFrom(router, "203.0.113.7:4000", "198.51.100.2").Code)
+	})
+}
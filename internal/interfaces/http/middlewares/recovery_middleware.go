@@ -0,0 +1,50 @@
+package middlewares
+
+import (
+	"errors"
+	"net/http"
+	"runtime/debug"
+	"syscall"
+
+	"github.com/gin-gonic/gin"
+	"github.com/moura95/backend-challenge/internal/infra/logging"
+	"github.com/moura95/backend-challenge/internal/interfaces/http/ginx"
+	"go.uber.org/zap"
+)
+
+// Recovery substitui o gin.Recovery: registra o panic (com stack e request_id)
+// no logger e responde 500 no envelope ginx.Response, sem expor detalhes ao cliente.
+func Recovery(log *zap.SugaredLogger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+
+			logger := logging.FromContext(c.Request.Context(), log)
+
+			// Cliente desconectou: não há para quem responder
+			if err, ok := recovered.(error); ok && (errors.Is(err, syscall.EPIPE) || errors.Is(err, syscall.ECONNRESET)) {
+				logger.Warnw("connection closed by client", "method", c.Request.Method, "path", c.Request.URL.Path, "error", err)
+				c.Abort()
+				return
+			}
+
+			logger.Errorw("panic recovered",
+				"method", c.Request.Method,
+				"path", c.Request.URL.Path,
+				"panic", recovered,
+				"stack", string(debug.Stack()),
+			)
+
+			if c.Writer.Written() {
+				c.Abort()
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ginx.ErrorResponse("internal server error"))
+		}()
+
+		c.Next()
+	}
+}
@@ -0,0 +1,68 @@
+package middlewares
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/moura95/backend-challenge/internal/infra/logging"
+	"github.com/moura95/backend-challenge/internal/interfaces/http/ginx"
+)
+
+func TestRecovery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	core, logs := observer.New(zapcore.InfoLevel)
+
+	router := gin.New()
+	router.Use(RequestID(), Recovery(zap.New(core).Sugar()))
+	router.GET("/panic", func(c *gin.Context) {
+		panic("database password is hunter2")
+	})
+	router.GET("/ping", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	t.Run("should return JSON envelope with 500 and log the panic", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/panic", nil)
+		req.Header.Set(logging.RequestIDHeader, "panic-id-789")
+		recorder := httptest.NewRecorder()
+
+		require.NotPanics(t, func() { router.ServeHTTP(recorder, req) })
+
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		assert.Contains(t, recorder.Header().Get("Content-Type"), "application/json")
+
+		var response ginx.Response
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		assert.Equal(t, "internal server error", response.Error)
+		assert.NotContains(t, recorder.Body.String(), "hunter2")
+		assert.NotContains(t, recorder.Body.String(), "goroutine")
+
+		entries := logs.FilterMessage("panic recovered").All()
+		logs.TakeAll()
+		require.Len(t, entries, 1)
+		assert.Equal(t, zapcore.ErrorLevel, entries[0].Level)
+		fields := entries[0].ContextMap()
+		assert.Equal(t, "panic-id-789", fields["request_id"])
+		assert.Equal(t, "/panic", fields["path"])
+		assert.Equal(t, "database password is hunter2", fields["panic"])
+		assert.NotEmpty(t, fields["stack"])
+	})
+
+	t.Run("should not interfere with normal requests", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, httptest.NewRequest("GET", "/ping", nil))
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Empty(t, logs.FilterMessage("panic recovered").All())
+	})
+}
@@ -0,0 +1,64 @@
+package middlewares
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/moura95/backend-challenge/internal/infra/logging"
+	"github.com/moura95/backend-challenge/internal/interfaces/http/ginx"
+	"go.uber.org/zap"
+)
+
+const (
+	requestIDKey       = "request_id"
+	maxRequestIDLength = 128
+)
+
+// RequestID reaproveita o X-Request-ID recebido (ou gera um novo), devolve no
+// response e guarda no contexto da requisição para os use cases.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(logging.RequestIDHeader)
+		if requestID == "" || len(requestID) > maxRequestIDLength {
+			requestID = uuid.New().String()
+		}
+
+		c.Set(requestIDKey, requestID)
+		c.Request = c.Request.WithContext(logging.WithRequestID(c.Request.Context(), requestID))
+		c.Header(logging.RequestIDHeader, requestID)
+
+		c.Next()
+	}
+}
+
+// RequestLogger registra cada requisição com o request_id. Deve vir depois do RequestID.
+func RequestLogger(log *zap.SugaredLogger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		logging.FromContext(c.Request.Context(), log).Infow("http request",
+			"method", c.Request.Method,
+			"path", c.Request.URL.Path,
+			"status", c.Writer.Status(),
+			"latency", time.Since(start),
+			"client_ip", ginx.ClientIP(c),
+		)
+	}
+}
+
+func GetRequestIDFromContext(c *gin.Context) (string, bool) {
+	requestID, exists := c.Get(requestIDKey)
+	if !exists {
+		return "", false
+	}
+
+	requestIDStr, ok := requestID.(string)
+	if !ok {
+		return "", false
+	}
+
+	return requestIDStr, true
+}
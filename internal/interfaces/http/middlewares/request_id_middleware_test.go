@@ -0,0 +1,92 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/moura95/backend-challenge/internal/infra/logging"
+)
+
+func setupRequestIDRouter(log *zap.SugaredLogger) (*gin.Engine, *string) {
+	gin.SetMode(gin.TestMode)
+
+	var seen string
+	router := gin.New()
+	router.Use(RequestID(), RequestLogger(log))
+	router.GET("/ping", func(c *gin.Context) {
+		seen = logging.RequestIDFromContext(c.Request.Context())
+		c.Status(http.StatusOK)
+	})
+
+	return router, &seen
+}
+
+func TestRequestID(t *testing.T) {
+	router, seen := setupRequestIDRouter(zap.NewNop().Sugar())
+
+	t.Run("should generate request ID when missing", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/ping", nil)
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, req)
+
+		requestID := recorder.Header().Get(logging.RequestIDHeader)
+		require.NotEmpty(t, requestID)
+		_, err := uuid.Parse(requestID)
+		assert.NoError(t, err)
+		assert.Equal(t, requestID, *seen)
+	})
+
+	t.Run("should propagate incoming request ID", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/ping", nil)
+		req.Header.Set(logging.RequestIDHeader, "client-id-123")
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, "client-id-123", recorder.Header().Get(logging.RequestIDHeader))
+		assert.Equal(t, "client-id-123", *seen)
+	})
+
+	t.Run("should replace oversized request ID", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/ping", nil)
+		req.Header.Set(logging.RequestIDHeader, strings.Repeat("a", maxRequestIDLength+1))
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, req)
+
+		requestID := recorder.Header().Get(logging.RequestIDHeader)
+		assert.Len(t, requestID, 36)
+		assert.Equal(t, requestID, *seen)
+	})
+}
+
+func TestRequestLogger(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	router, _ := setupRequestIDRouter(zap.New(core).Sugar())
+
+	t.Run("should log request with request ID", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/ping", nil)
+		req.Header.Set(logging.RequestIDHeader, "log-id-456")
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, req)
+
+		entries := logs.TakeAll()
+		require.Len(t, entries, 1)
+		fields := entries[0].ContextMap()
+		assert.Equal(t, "log-id-456", fields["request_id"])
+		assert.Equal(t, "/ping", fields["path"])
+		assert.Equal(t, int64(http.StatusOK), fields["status"])
+	})
+}
@@ -0,0 +1,29 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/moura95/backend-challenge/internal/domain/user"
+	"github.com/moura95/backend-challenge/internal/interfaces/http/ginx"
+)
+
+// RequireRole deve ser usado depois do AuthMiddleware, que carrega o papel do usuário no contexto
+func RequireRole(role user.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userRole, exists := GetUserRoleFromContext(c)
+		if !exists {
+			c.JSON(http.StatusUnauthorized, ginx.ErrorResponse("middleware: user not authenticated"))
+			c.Abort()
+			return
+		}
+
+		if user.Role(userRole) != role {
+			c.JSON(http.StatusForbidden, ginx.ErrorResponse("middleware: insufficient permissions"))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,44 @@
+#!/bin/sh
+# Remove as filas de email declaradas antes da dead-letter queue (apenas com
+# x-message-ttl) para que a aplicação as redeclare com x-dead-letter-exchange
+# e x-dead-letter-routing-key no próximo start. O RabbitMQ recusa redeclarar
+# uma fila durável com argumentos diferentes (PRECONDITION_FAILED).
+#
+# Rode com a aplicação parada. Mensagens ainda na fila são perdidas, mas os
+# emails continuam gravados como pending no banco e são reenviados pelo
+# processamento periódico. Por padrão a fila só é removida se estiver vazia;
+# FORCE=1 remove mesmo com mensagens.
+#
+# Uso: scripts/rabbitmq-migrate-email-queues.sh [fila...]
+# (sem argumentos: $EMAIL_QUEUE ou email_notifications, e $PASSWORD_RESET_QUEUE se definida)
+set -eu
+
+API=${RABBITMQ_MANAGEMENT_URL:-http://localhost:15672}/api
+CREDENTIALS=${RABBITMQ_USER:-rabbitmq}:${RABBITMQ_PASS:-rabbitmq}
+VHOST=${RABBITMQ_VHOST:-%2F}
+FORCE=${FORCE:-0}
+
+if [ $# -eq 0 ]; then
+	set -- "${EMAIL_QUEUE:-email_notifications}" ${PASSWORD_RESET_QUEUE:-}
+fi
+
+for queue in "$@"; do
+	if ! info=$(curl -fsS -u "$CREDENTIALS" "$API/queues/$VHOST/$queue" 2>/dev/null); then
+		echo "$queue: not found, nothing to migrate"
+		continue
+	fi
+
+	if echo "$info" | grep -q '"x-dead-letter-exchange"'; then
+		echo "$queue: already has a dead-letter exchange"
+		continue
+	fi
+
+	messages=$(echo "$info" | grep -o '"messages":[0-9]*' | head -n 1 | cut -d: -f2)
+	if [ "${messages:-0}" -gt 0 ] && [ "$FORCE" != "1" ]; then
+		echo "$queue: $messages message(s) waiting; drain it or rerun with FORCE=1" >&2
+		exit 1
+	fi
+
+	curl -fsS -u "$CREDENTIALS" -X DELETE "$API/queues/$VHOST/$queue"
+	echo "$queue: deleted, it is redeclared with the dead-letter arguments on the next start"
+done